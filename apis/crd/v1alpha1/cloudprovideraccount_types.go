@@ -18,6 +18,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// CloudProviderAccountAllRegions in the regions of a cloud provider account selects all regions enabled for the account.
+const CloudProviderAccountAllRegions = "*"
+
+// CloudProviderAccountCredentialMode is the source of credentials of a cloud provider account.
+type CloudProviderAccountCredentialMode string
+
+const (
+	// CredentialModeSecret uses the credentials in the secret of the account.
+	CredentialModeSecret CloudProviderAccountCredentialMode = "Secret"
+	// CredentialModeWorkloadIdentity uses the workload identity of the Nephe controller Pod, i.e. the AWS web identity
+	// token of IRSA, EKS Pod Identity, or the Azure workload identity federated token.
+	CredentialModeWorkloadIdentity CloudProviderAccountCredentialMode = "WorkloadIdentity"
+	// CredentialModeManagedIdentity uses the Azure managed identity of the Node.
+	CredentialModeManagedIdentity CloudProviderAccountCredentialMode = "ManagedIdentity"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -32,6 +48,18 @@ type CloudProviderAccountSpec struct {
 	AWSConfig *CloudProviderAccountAWSConfig `json:"awsConfig,omitempty"`
 	// Cloud provider account config.
 	AzureConfig *CloudProviderAccountAzureConfig `json:"azureConfig,omitempty"`
+	// Cloud provider account config.
+	GCPConfig *CloudProviderAccountGCPConfig `json:"gcpConfig,omitempty"`
+	// Cloud provider account config of an OpenStack project.
+	OpenStackConfig *CloudProviderAccountOpenStackConfig `json:"openstackConfig,omitempty"`
+	// Cloud provider account config of an out-of-tree cloud provider plugin.
+	PluginConfig *CloudProviderAccountPluginConfig `json:"pluginConfig,omitempty"`
+	// Cloud provider account config of an in-memory simulated cloud, for development and demos.
+	SimulatedConfig *CloudProviderAccountSimulatedConfig `json:"simulatedConfig,omitempty"`
+	// Cloud provider account config of statically declared hosts, e.g. bare-metal hosts.
+	StaticConfig *CloudProviderAccountStaticConfig `json:"staticConfig,omitempty"`
+	// Cloud provider account config of an on-prem vSphere vCenter.
+	VSphereConfig *CloudProviderAccountVSphereConfig `json:"vsphereConfig,omitempty"`
 }
 
 type CloudProviderAccountAWSConfig struct {
@@ -39,13 +67,184 @@ type CloudProviderAccountAWSConfig struct {
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
 	// Cloud provider account region.
 	Region string `json:"region,omitempty"`
+	// Regions of the cloud provider account in addition to Region. All regions enabled for the account are used if
+	// it contains "*".
+	Regions []string `json:"regions,omitempty"`
 	// Endpoint URL that overrides the default AWS generated endpoint.
 	Endpoint string `json:"endpoint,omitempty"`
+	// Mode of credentials of the account. The secret is optional with WorkloadIdentity, and may specify a role to
+	// assume. Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;WorkloadIdentity
+	CredentialMode CloudProviderAccountCredentialMode `json:"credentialMode,omitempty"`
+	// Source of credentials other than a k8s secret. Credentials of the source override those of the secret.
+	CredentialSource *CloudProviderAccountCredentialSource `json:"credentialSource,omitempty"`
+	// Organization discovers the member accounts of the AWS Organization managed by the account credentials,
+	// and imports the member accounts instead of the account itself.
+	Organization *CloudProviderAccountAWSOrganizationConfig `json:"organization,omitempty"`
+}
+
+// CloudProviderAccountAWSOrganizationConfig specifies the member accounts of an AWS Organization to import.
+type CloudProviderAccountAWSOrganizationConfig struct {
+	// Name of the IAM role assumed in each member account.
+	RoleName string `json:"roleName"`
+	// External ID used to assume the role in member accounts.
+	ExternalID string `json:"externalID,omitempty"`
+	// IDs of member accounts to import. All active member accounts are imported if empty.
+	AllowedAccountIDs []string `json:"allowedAccountIDs,omitempty"`
+	// IDs of member accounts not to import.
+	DeniedAccountIDs []string `json:"deniedAccountIDs,omitempty"`
 }
 
 type CloudProviderAccountAzureConfig struct {
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
 	Region    string           `json:"region,omitempty"`
+	// Regions of the cloud provider account in addition to Region. All regions of virtual networks of the
+	// subscriptions are used if it contains "*".
+	Regions []string `json:"regions,omitempty"`
+	// IDs of subscriptions of the cloud provider account in addition to the subscription of the secret.
+	SubscriptionIDs []string `json:"subscriptionIDs,omitempty"`
+	// ID of a management group, all subscriptions under which are included in the cloud provider account.
+	ManagementGroupID string `json:"managementGroupID,omitempty"`
+	// Mode of credentials of the account. The secret is optional with WorkloadIdentity and ManagedIdentity, in which
+	// case the subscription is the first of SubscriptionIDs. Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;WorkloadIdentity;ManagedIdentity
+	CredentialMode CloudProviderAccountCredentialMode `json:"credentialMode,omitempty"`
+	// Source of credentials other than a k8s secret. Credentials of the source override those of the secret, which
+	// may hold the subscription and tenant of a client secret of the Vault Azure secrets engine.
+	CredentialSource *CloudProviderAccountCredentialSource `json:"credentialSource,omitempty"`
+}
+
+// CloudProviderAccountCredentialSource is a source of cloud provider credentials, in the format of the k8s secret of
+// the cloud provider. Exactly one of File and Vault must be specified. Credentials are retrieved again before they
+// expire, and files and Vault KV secrets are reloaded periodically.
+type CloudProviderAccountCredentialSource struct {
+	// File with the credentials, e.g. a projected volume mounted into nephe-controller.
+	File *FileCredentialSource `json:"file,omitempty"`
+	// Vault secret with the credentials.
+	Vault *VaultCredentialSource `json:"vault,omitempty"`
+}
+
+// FileCredentialSource is a file with cloud provider credentials.
+type FileCredentialSource struct {
+	// Absolute path of the file in nephe-controller, in the directory of the namespace of the account under the
+	// credential file root of nephe-controller configuration.
+	Path string `json:"path"`
+}
+
+// VaultSecretEngine is the secrets engine of a HashiCorp Vault secret.
+type VaultSecretEngine string
+
+const (
+	// VaultSecretEngineKV reads credentials in the format of the k8s secret from a KV version 1 or 2 secret.
+	VaultSecretEngineKV VaultSecretEngine = "KV"
+	// VaultSecretEngineAWS generates access keys using the AWS secrets engine.
+	VaultSecretEngineAWS VaultSecretEngine = "AWS"
+	// VaultSecretEngineAzure generates a client secret using the Azure secrets engine.
+	VaultSecretEngineAzure VaultSecretEngine = "Azure"
+)
+
+// VaultCredentialSource is a HashiCorp Vault secret with cloud provider credentials.
+type VaultCredentialSource struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200, which must be an https address allowed by
+	// nephe-controller configuration.
+	Address string `json:"address"`
+	// Namespace of the secret in Vault Enterprise.
+	Namespace string `json:"namespace,omitempty"`
+	// Path of the secret, e.g. secret/data/nephe for a KV version 2 secret, or aws/creds/nephe.
+	Path string `json:"path"`
+	// Engine of the secret. Defaults to KV.
+	// +kubebuilder:validation:Enum=KV;AWS;Azure
+	Engine VaultSecretEngine `json:"engine,omitempty"`
+	// Role of the Kubernetes auth method, used to log in to Vault with the service account token of nephe-controller
+	// projected with the audience of Vault.
+	Role string `json:"role,omitempty"`
+	// Mount path of the Kubernetes auth method. Defaults to kubernetes.
+	AuthMountPath string `json:"authMountPath,omitempty"`
+	// Reference to k8s secret which has a Vault token, used instead of the Kubernetes auth method.
+	TokenSecretRef *SecretReference `json:"tokenSecretRef,omitempty"`
+}
+
+type CloudProviderAccountGCPConfig struct {
+	// Reference to k8s secret which has cloud provider credentials.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	// Cloud provider account region.
+	Region string `json:"region,omitempty"`
+}
+
+type CloudProviderAccountOpenStackConfig struct {
+	// Reference to k8s secret which has OpenStack credentials.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	// AuthURL is the URL of the OpenStack identity (keystone) service, e.g. https://keystone.example.com:5000/v3.
+	AuthURL string `json:"authURL,omitempty"`
+	// Cloud provider account region.
+	Region string `json:"region,omitempty"`
+}
+
+type CloudProviderAccountPluginConfig struct {
+	// Provider is the cloud provider type of a cloud provider plugin configured in nephe-controller configuration.
+	Provider string `json:"provider"`
+	// Reference to k8s secret which has cloud provider credentials.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	// Cloud provider account region.
+	Region string `json:"region,omitempty"`
+	// Parameters are provider specific account parameters, passed to the cloud provider plugin as is.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+type CloudProviderAccountSimulatedConfig struct {
+	// Reference to k8s configmap which has the simulated cloud inventory.
+	ConfigMapRef *ConfigMapReference `json:"configMapRef,omitempty"`
+	// Cloud provider account region.
+	Region string `json:"region,omitempty"`
+	// LatencyInMilliseconds is the latency injected into each simulated cloud API call.
+	LatencyInMilliseconds uint `json:"latencyInMilliseconds,omitempty"`
+	// FailurePercentage is the percentage of simulated cloud API calls failing at random.
+	// +kubebuilder:validation:Maximum=100
+	FailurePercentage uint `json:"failurePercentage,omitempty"`
+	// FailedOperations are the simulated cloud API calls always failing, e.g. CreateSecurityGroup.
+	FailedOperations []string `json:"failedOperations,omitempty"`
+}
+
+type CloudProviderAccountStaticConfig struct {
+	// Region reported for the hosts, e.g. the name of the data center.
+	Region string `json:"region,omitempty"`
+	// Hosts declared in the account.
+	Hosts []StaticHost `json:"hosts,omitempty"`
+}
+
+// StaticHost is a host not known to any cloud API, e.g. a bare-metal host.
+type StaticHost struct {
+	// ID uniquely identifies the host in the account.
+	ID string `json:"id"`
+	// Name of the host. ID is used if not specified.
+	Name string `json:"name,omitempty"`
+	// Network the host is attached to, reported as the VPC of the host.
+	Network string `json:"network"`
+	// Tags of the host.
+	Tags map[string]string `json:"tags,omitempty"`
+	// NetworkInterfaces of the host.
+	NetworkInterfaces []StaticHostNetworkInterface `json:"networkInterfaces,omitempty"`
+}
+
+// StaticHostNetworkInterface is a network interface of a static host.
+type StaticHostNetworkInterface struct {
+	// Name of the network interface, unique within the host.
+	Name string `json:"name"`
+	// MAC address of the network interface.
+	MAC string `json:"mac,omitempty"`
+	// IPs of the network interface.
+	IPs []string `json:"ips,omitempty"`
+}
+
+type CloudProviderAccountVSphereConfig struct {
+	// Reference to k8s secret which has vCenter credentials.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	// Endpoint is the URL of the vCenter server, e.g. https://vcenter.example.com/sdk.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Datacenter limits the account to the named datacenter. All datacenters are used if not specified.
+	Datacenter string `json:"datacenter,omitempty"`
+	// Insecure skips verification of the vCenter server certificate.
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 // SecretReference is a reference to a k8s secret resource in an arbitrary namespace.
@@ -58,6 +257,16 @@ type SecretReference struct {
 	Key string `json:"key"`
 }
 
+// ConfigMapReference is a reference to a k8s configmap resource in an arbitrary namespace.
+type ConfigMapReference struct {
+	// Name of the configmap.
+	Name string `json:"name"`
+	// Namespace of the configmap.
+	Namespace string `json:"namespace"`
+	// Key to select in the configmap.
+	Key string `json:"key"`
+}
+
 // AwsAccountCredential is the format of k8s secret for aws provider account.
 type AwsAccountCredential struct {
 	AccessKeyID     string `json:"accessKeyId,omitempty"`
@@ -75,12 +284,57 @@ type AzureAccountCredential struct {
 	ClientKey      string `json:"clientKey,omitempty"`
 }
 
+// GcpAccountCredential is the format of k8s secret for gcp provider account.
+type GcpAccountCredential struct {
+	ProjectID string `json:"projectId,omitempty"`
+	// ServiceAccountKey is the JSON key of a GCP service account.
+	ServiceAccountKey string `json:"serviceAccountKey,omitempty"`
+}
+
+// OpenStackAccountCredential is the format of k8s secret for openstack provider account.
+type OpenStackAccountCredential struct {
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	DomainName string `json:"domainName,omitempty"`
+	ProjectID  string `json:"projectId,omitempty"`
+}
+
+// VSphereAccountCredential is the format of k8s secret for vsphere provider account.
+type VSphereAccountCredential struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
 // CloudProviderAccountStatus defines the observed state of CloudProviderAccount.
 type CloudProviderAccountStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// Error is current error, if any, of the CloudProviderAccount.
 	Error string `json:"error,omitempty"`
+	// Capabilities are the network policy features supported by the cloud provider of the CloudProviderAccount.
+	Capabilities CloudCapabilities `json:"capabilities,omitempty"`
+}
+
+// CloudCapabilities specifies the network policy features a cloud provider can realize on its VMs.
+type CloudCapabilities struct {
+	// SecurityGroups is true if network policies are realized in cloud security groups, and false if they
+	// can only be enforced by agents.
+	SecurityGroups bool `json:"securityGroups,omitempty"`
+	// DenyRules is true if rules with Drop or Reject action are supported.
+	DenyRules bool `json:"denyRules,omitempty"`
+	// RuleOrdering is true if allow and deny rules are evaluated in the order of their priorities, and false if
+	// deny rules take precedence over all allow rules.
+	RuleOrdering bool `json:"ruleOrdering,omitempty"`
+	// IPv6 is true if IPv6 CIDRs are supported.
+	IPv6 bool `json:"ipv6,omitempty"`
+	// PortRanges is true if port ranges are supported.
+	PortRanges bool `json:"portRanges,omitempty"`
+	// ICMPTypeCode is true if ICMP type and code are supported.
+	ICMPTypeCode bool `json:"icmpTypeCode,omitempty"`
+	// SCTP is true if SCTP protocol is supported.
+	SCTP bool `json:"sctp,omitempty"`
+	// MaxRules is the maximum number of inbound or outbound rules of a cloud security group, 0 if unlimited.
+	MaxRules int `json:"maxRules,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -53,6 +53,21 @@ func (in *AzureAccountCredential) DeepCopy() *AzureAccountCredential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudCapabilities) DeepCopyInto(out *CloudCapabilities) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudCapabilities.
+func (in *CloudCapabilities) DeepCopy() *CloudCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudEntitySelector) DeepCopyInto(out *CloudEntitySelector) {
 	*out = *in
@@ -168,6 +183,21 @@ func (in *CloudProviderAccountAWSConfig) DeepCopyInto(out *CloudProviderAccountA
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialSource != nil {
+		in, out := &in.CredentialSource, &out.CredentialSource
+		*out = new(CloudProviderAccountCredentialSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Organization != nil {
+		in, out := &in.Organization, &out.Organization
+		*out = new(CloudProviderAccountAWSOrganizationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountAWSConfig.
@@ -180,6 +210,31 @@ func (in *CloudProviderAccountAWSConfig) DeepCopy() *CloudProviderAccountAWSConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountAWSOrganizationConfig) DeepCopyInto(out *CloudProviderAccountAWSOrganizationConfig) {
+	*out = *in
+	if in.AllowedAccountIDs != nil {
+		in, out := &in.AllowedAccountIDs, &out.AllowedAccountIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedAccountIDs != nil {
+		in, out := &in.DeniedAccountIDs, &out.DeniedAccountIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountAWSOrganizationConfig.
+func (in *CloudProviderAccountAWSOrganizationConfig) DeepCopy() *CloudProviderAccountAWSOrganizationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountAWSOrganizationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderAccountAzureConfig) DeepCopyInto(out *CloudProviderAccountAzureConfig) {
 	*out = *in
@@ -188,6 +243,21 @@ func (in *CloudProviderAccountAzureConfig) DeepCopyInto(out *CloudProviderAccoun
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubscriptionIDs != nil {
+		in, out := &in.SubscriptionIDs, &out.SubscriptionIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialSource != nil {
+		in, out := &in.CredentialSource, &out.CredentialSource
+		*out = new(CloudProviderAccountCredentialSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountAzureConfig.
@@ -200,6 +270,51 @@ func (in *CloudProviderAccountAzureConfig) DeepCopy() *CloudProviderAccountAzure
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountCredentialSource) DeepCopyInto(out *CloudProviderAccountCredentialSource) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileCredentialSource)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCredentialSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountCredentialSource.
+func (in *CloudProviderAccountCredentialSource) DeepCopy() *CloudProviderAccountCredentialSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountCredentialSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountGCPConfig) DeepCopyInto(out *CloudProviderAccountGCPConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountGCPConfig.
+func (in *CloudProviderAccountGCPConfig) DeepCopy() *CloudProviderAccountGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderAccountList) DeepCopyInto(out *CloudProviderAccountList) {
 	*out = *in
@@ -232,6 +347,78 @@ func (in *CloudProviderAccountList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountOpenStackConfig) DeepCopyInto(out *CloudProviderAccountOpenStackConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountOpenStackConfig.
+func (in *CloudProviderAccountOpenStackConfig) DeepCopy() *CloudProviderAccountOpenStackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountOpenStackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountPluginConfig) DeepCopyInto(out *CloudProviderAccountPluginConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountPluginConfig.
+func (in *CloudProviderAccountPluginConfig) DeepCopy() *CloudProviderAccountPluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountPluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountSimulatedConfig) DeepCopyInto(out *CloudProviderAccountSimulatedConfig) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.FailedOperations != nil {
+		in, out := &in.FailedOperations, &out.FailedOperations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountSimulatedConfig.
+func (in *CloudProviderAccountSimulatedConfig) DeepCopy() *CloudProviderAccountSimulatedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountSimulatedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderAccountSpec) DeepCopyInto(out *CloudProviderAccountSpec) {
 	*out = *in
@@ -250,6 +437,36 @@ func (in *CloudProviderAccountSpec) DeepCopyInto(out *CloudProviderAccountSpec)
 		*out = new(CloudProviderAccountAzureConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCPConfig != nil {
+		in, out := &in.GCPConfig, &out.GCPConfig
+		*out = new(CloudProviderAccountGCPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStackConfig != nil {
+		in, out := &in.OpenStackConfig, &out.OpenStackConfig
+		*out = new(CloudProviderAccountOpenStackConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginConfig != nil {
+		in, out := &in.PluginConfig, &out.PluginConfig
+		*out = new(CloudProviderAccountPluginConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SimulatedConfig != nil {
+		in, out := &in.SimulatedConfig, &out.SimulatedConfig
+		*out = new(CloudProviderAccountSimulatedConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticConfig != nil {
+		in, out := &in.StaticConfig, &out.StaticConfig
+		*out = new(CloudProviderAccountStaticConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphereConfig != nil {
+		in, out := &in.VSphereConfig, &out.VSphereConfig
+		*out = new(CloudProviderAccountVSphereConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountSpec.
@@ -262,9 +479,32 @@ func (in *CloudProviderAccountSpec) DeepCopy() *CloudProviderAccountSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountStaticConfig) DeepCopyInto(out *CloudProviderAccountStaticConfig) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]StaticHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountStaticConfig.
+func (in *CloudProviderAccountStaticConfig) DeepCopy() *CloudProviderAccountStaticConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountStaticConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderAccountStatus) DeepCopyInto(out *CloudProviderAccountStatus) {
 	*out = *in
+	out.Capabilities = in.Capabilities
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountStatus.
@@ -277,6 +517,41 @@ func (in *CloudProviderAccountStatus) DeepCopy() *CloudProviderAccountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderAccountVSphereConfig) DeepCopyInto(out *CloudProviderAccountVSphereConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderAccountVSphereConfig.
+func (in *CloudProviderAccountVSphereConfig) DeepCopy() *CloudProviderAccountVSphereConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderAccountVSphereConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EntityMatch) DeepCopyInto(out *EntityMatch) {
 	*out = *in
@@ -292,6 +567,51 @@ func (in *EntityMatch) DeepCopy() *EntityMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCredentialSource) DeepCopyInto(out *FileCredentialSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCredentialSource.
+func (in *FileCredentialSource) DeepCopy() *FileCredentialSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCredentialSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpAccountCredential) DeepCopyInto(out *GcpAccountCredential) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpAccountCredential.
+func (in *GcpAccountCredential) DeepCopy() *GcpAccountCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpAccountCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackAccountCredential) DeepCopyInto(out *OpenStackAccountCredential) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackAccountCredential.
+func (in *OpenStackAccountCredential) DeepCopy() *OpenStackAccountCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackAccountCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -307,6 +627,90 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticHost) DeepCopyInto(out *StaticHost) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]StaticHostNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticHost.
+func (in *StaticHost) DeepCopy() *StaticHost {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticHostNetworkInterface) DeepCopyInto(out *StaticHostNetworkInterface) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticHostNetworkInterface.
+func (in *StaticHostNetworkInterface) DeepCopy() *StaticHostNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticHostNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereAccountCredential) DeepCopyInto(out *VSphereAccountCredential) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereAccountCredential.
+func (in *VSphereAccountCredential) DeepCopy() *VSphereAccountCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereAccountCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialSource) DeepCopyInto(out *VaultCredentialSource) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialSource.
+func (in *VaultCredentialSource) DeepCopy() *VaultCredentialSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSelector) DeepCopyInto(out *VirtualMachineSelector) {
 	*out = *in
@@ -0,0 +1,2568 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: apis/plugin/v1alpha1/cloudprovider.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Empty is the request or response of methods without parameters or results.
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{0}
+}
+
+// NamespacedName is the namespace and name of a Kubernetes object.
+type NamespacedName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *NamespacedName) Reset() {
+	*x = NamespacedName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamespacedName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespacedName) ProtoMessage() {}
+
+func (x *NamespacedName) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespacedName.ProtoReflect.Descriptor instead.
+func (*NamespacedName) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NamespacedName) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespacedName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ProviderTypeResponse is the response of ProviderType.
+type ProviderTypeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProviderType string `protobuf:"bytes,1,opt,name=provider_type,json=providerType,proto3" json:"provider_type,omitempty"`
+}
+
+func (x *ProviderTypeResponse) Reset() {
+	*x = ProviderTypeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderTypeResponse) ProtoMessage() {}
+
+func (x *ProviderTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderTypeResponse.ProtoReflect.Descriptor instead.
+func (*ProviderTypeResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProviderTypeResponse) GetProviderType() string {
+	if x != nil {
+		return x.ProviderType
+	}
+	return ""
+}
+
+// Capabilities are the network policy features a plugin can realize, as CloudCapabilities of CloudProviderAccount
+// status.
+type Capabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// security_groups is true if network policies are realized in cloud security groups, and false if they can only
+	// be enforced by agents.
+	SecurityGroups bool `protobuf:"varint,1,opt,name=security_groups,json=securityGroups,proto3" json:"security_groups,omitempty"`
+	// deny_rules is true if rules with Drop or Reject action are supported.
+	DenyRules bool `protobuf:"varint,2,opt,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`
+	// rule_ordering is true if allow and deny rules are evaluated in the order of their priorities, and false if deny
+	// rules take precedence over all allow rules.
+	RuleOrdering bool `protobuf:"varint,3,opt,name=rule_ordering,json=ruleOrdering,proto3" json:"rule_ordering,omitempty"`
+	// ipv6 is true if IPv6 CIDRs are supported.
+	Ipv6 bool `protobuf:"varint,4,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	// port_ranges is true if port ranges are supported.
+	PortRanges bool `protobuf:"varint,5,opt,name=port_ranges,json=portRanges,proto3" json:"port_ranges,omitempty"`
+	// icmp_type_code is true if ICMP type and code are supported.
+	IcmpTypeCode bool `protobuf:"varint,6,opt,name=icmp_type_code,json=icmpTypeCode,proto3" json:"icmp_type_code,omitempty"`
+	// sctp is true if SCTP protocol is supported.
+	Sctp bool `protobuf:"varint,7,opt,name=sctp,proto3" json:"sctp,omitempty"`
+	// max_rules is the maximum number of inbound or outbound rules of a cloud security group, 0 if unlimited.
+	MaxRules int32 `protobuf:"varint,8,opt,name=max_rules,json=maxRules,proto3" json:"max_rules,omitempty"`
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Capabilities) GetSecurityGroups() bool {
+	if x != nil {
+		return x.SecurityGroups
+	}
+	return false
+}
+
+func (x *Capabilities) GetDenyRules() bool {
+	if x != nil {
+		return x.DenyRules
+	}
+	return false
+}
+
+func (x *Capabilities) GetRuleOrdering() bool {
+	if x != nil {
+		return x.RuleOrdering
+	}
+	return false
+}
+
+func (x *Capabilities) GetIpv6() bool {
+	if x != nil {
+		return x.Ipv6
+	}
+	return false
+}
+
+func (x *Capabilities) GetPortRanges() bool {
+	if x != nil {
+		return x.PortRanges
+	}
+	return false
+}
+
+func (x *Capabilities) GetIcmpTypeCode() bool {
+	if x != nil {
+		return x.IcmpTypeCode
+	}
+	return false
+}
+
+func (x *Capabilities) GetSctp() bool {
+	if x != nil {
+		return x.Sctp
+	}
+	return false
+}
+
+func (x *Capabilities) GetMaxRules() int32 {
+	if x != nil {
+		return x.MaxRules
+	}
+	return 0
+}
+
+// CapabilitiesResponse is the response of GetCapabilities.
+type CapabilitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capabilities *Capabilities `protobuf:"bytes,1,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+}
+
+func (x *CapabilitiesResponse) Reset() {
+	*x = CapabilitiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesResponse) ProtoMessage() {}
+
+func (x *CapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CapabilitiesResponse) GetCapabilities() *Capabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// AddProviderAccountRequest is the request of AddProviderAccount.
+type AddProviderAccountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// account is the JSON encoded CloudProviderAccount, of API version crd.cloud.antrea.io/v1alpha1.
+	Account []byte `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// credentials are the content of the Secret key referred by the account, as plugins have no access to the
+	// Kubernetes API.
+	Credentials []byte `protobuf:"bytes,2,opt,name=credentials,proto3" json:"credentials,omitempty"`
+}
+
+func (x *AddProviderAccountRequest) Reset() {
+	*x = AddProviderAccountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddProviderAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProviderAccountRequest) ProtoMessage() {}
+
+func (x *AddProviderAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProviderAccountRequest.ProtoReflect.Descriptor instead.
+func (*AddProviderAccountRequest) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddProviderAccountRequest) GetAccount() []byte {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *AddProviderAccountRequest) GetCredentials() []byte {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+// AccountRequest is the request of methods operating on an account.
+type AccountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account *NamespacedName `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+}
+
+func (x *AccountRequest) Reset() {
+	*x = AccountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountRequest) ProtoMessage() {}
+
+func (x *AccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountRequest.ProtoReflect.Descriptor instead.
+func (*AccountRequest) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AccountRequest) GetAccount() *NamespacedName {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+// AccountResourceSelectorRequest is the request of AddAccountResourceSelector and RemoveAccountResourcesSelector.
+type AccountResourceSelectorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account *NamespacedName `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// selector is the JSON encoded CloudEntitySelector added to the account, of API version
+	// crd.cloud.antrea.io/v1alpha1.
+	Selector []byte `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// selector_name is the name of the CloudEntitySelector removed from the account.
+	SelectorName string `protobuf:"bytes,3,opt,name=selector_name,json=selectorName,proto3" json:"selector_name,omitempty"`
+}
+
+func (x *AccountResourceSelectorRequest) Reset() {
+	*x = AccountResourceSelectorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountResourceSelectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountResourceSelectorRequest) ProtoMessage() {}
+
+func (x *AccountResourceSelectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountResourceSelectorRequest.ProtoReflect.Descriptor instead.
+func (*AccountResourceSelectorRequest) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AccountResourceSelectorRequest) GetAccount() *NamespacedName {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *AccountResourceSelectorRequest) GetSelector() []byte {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *AccountResourceSelectorRequest) GetSelectorName() string {
+	if x != nil {
+		return x.SelectorName
+	}
+	return ""
+}
+
+// AccountStatusResponse is the response of GetAccountStatus.
+type AccountStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// status is the JSON encoded CloudProviderAccountStatus, of API version crd.cloud.antrea.io/v1alpha1.
+	Status []byte `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *AccountStatusResponse) Reset() {
+	*x = AccountStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStatusResponse) ProtoMessage() {}
+
+func (x *AccountStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStatusResponse.ProtoReflect.Descriptor instead.
+func (*AccountStatusResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AccountStatusResponse) GetStatus() []byte {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// VpcInventoryResponse is the response of GetVpcInventory.
+type VpcInventoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// vpcs are the JSON encoded Vpcs of API version runtime.cloud.antrea.io/v1alpha1, by VPC ID.
+	Vpcs map[string][]byte `protobuf:"bytes,1,rep,name=vpcs,proto3" json:"vpcs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VpcInventoryResponse) Reset() {
+	*x = VpcInventoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VpcInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VpcInventoryResponse) ProtoMessage() {}
+
+func (x *VpcInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VpcInventoryResponse.ProtoReflect.Descriptor instead.
+func (*VpcInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VpcInventoryResponse) GetVpcs() map[string][]byte {
+	if x != nil {
+		return x.Vpcs
+	}
+	return nil
+}
+
+// InstancesResponse is the response of InstancesGivenProviderAccount.
+type InstancesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// virtual_machines are the JSON encoded VirtualMachines of API version runtime.cloud.antrea.io/v1alpha1, by
+	// VM ID.
+	VirtualMachines map[string][]byte `protobuf:"bytes,1,rep,name=virtual_machines,json=virtualMachines,proto3" json:"virtual_machines,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *InstancesResponse) Reset() {
+	*x = InstancesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstancesResponse) ProtoMessage() {}
+
+func (x *InstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstancesResponse.ProtoReflect.Descriptor instead.
+func (*InstancesResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InstancesResponse) GetVirtualMachines() map[string][]byte {
+	if x != nil {
+		return x.VirtualMachines
+	}
+	return nil
+}
+
+// CloudResourceID is the name and VPC of a cloud resource.
+type CloudResourceID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Vpc  string `protobuf:"bytes,2,opt,name=vpc,proto3" json:"vpc,omitempty"`
+}
+
+func (x *CloudResourceID) Reset() {
+	*x = CloudResourceID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudResourceID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudResourceID) ProtoMessage() {}
+
+func (x *CloudResourceID) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudResourceID.ProtoReflect.Descriptor instead.
+func (*CloudResourceID) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CloudResourceID) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CloudResourceID) GetVpc() string {
+	if x != nil {
+		return x.Vpc
+	}
+	return ""
+}
+
+// CloudResource uniquely identifies a cloud resource.
+type CloudResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type is the type of the resource, VirtualMachine or NetworkInterface.
+	Type string           `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id   *CloudResourceID `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// account_id is the namespaced name of the account of the resource.
+	AccountId     string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CloudProvider string `protobuf:"bytes,4,opt,name=cloud_provider,json=cloudProvider,proto3" json:"cloud_provider,omitempty"`
+}
+
+func (x *CloudResource) Reset() {
+	*x = CloudResource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudResource) ProtoMessage() {}
+
+func (x *CloudResource) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudResource.ProtoReflect.Descriptor instead.
+func (*CloudResource) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CloudResource) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CloudResource) GetId() *CloudResourceID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CloudResource) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CloudResource) GetCloudProvider() string {
+	if x != nil {
+		return x.CloudProvider
+	}
+	return ""
+}
+
+// SecurityGroupRequest is the request of CreateSecurityGroup and DeleteSecurityGroup.
+type SecurityGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SecurityGroup  *CloudResource `protobuf:"bytes,1,opt,name=security_group,json=securityGroup,proto3" json:"security_group,omitempty"`
+	MembershipOnly bool           `protobuf:"varint,2,opt,name=membership_only,json=membershipOnly,proto3" json:"membership_only,omitempty"`
+}
+
+func (x *SecurityGroupRequest) Reset() {
+	*x = SecurityGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecurityGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityGroupRequest) ProtoMessage() {}
+
+func (x *SecurityGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityGroupRequest.ProtoReflect.Descriptor instead.
+func (*SecurityGroupRequest) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SecurityGroupRequest) GetSecurityGroup() *CloudResource {
+	if x != nil {
+		return x.SecurityGroup
+	}
+	return nil
+}
+
+func (x *SecurityGroupRequest) GetMembershipOnly() bool {
+	if x != nil {
+		return x.MembershipOnly
+	}
+	return false
+}
+
+// CreateSecurityGroupResponse is the response of CreateSecurityGroup.
+type CreateSecurityGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CloudSecurityGroupId *string `protobuf:"bytes,1,opt,name=cloud_security_group_id,json=cloudSecurityGroupId,proto3,oneof" json:"cloud_security_group_id,omitempty"`
+}
+
+func (x *CreateSecurityGroupResponse) Reset() {
+	*x = CreateSecurityGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSecurityGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecurityGroupResponse) ProtoMessage() {}
+
+func (x *CreateSecurityGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecurityGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateSecurityGroupResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateSecurityGroupResponse) GetCloudSecurityGroupId() string {
+	if x != nil && x.CloudSecurityGroupId != nil {
+		return *x.CloudSecurityGroupId
+	}
+	return ""
+}
+
+// RulePriority is the order in which a rule is evaluated, by the priority of the tier, then by the priority of the
+// network policy within the tier, then by the priority of the rule within the network policy. A lower value takes
+// precedence.
+type RulePriority struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tier   int32   `protobuf:"varint,1,opt,name=tier,proto3" json:"tier,omitempty"`
+	Policy float64 `protobuf:"fixed64,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Rule   int32   `protobuf:"varint,3,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *RulePriority) Reset() {
+	*x = RulePriority{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RulePriority) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePriority) ProtoMessage() {}
+
+func (x *RulePriority) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePriority.ProtoReflect.Descriptor instead.
+func (*RulePriority) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RulePriority) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+func (x *RulePriority) GetPolicy() float64 {
+	if x != nil {
+		return x.Policy
+	}
+	return 0
+}
+
+func (x *RulePriority) GetRule() int32 {
+	if x != nil {
+		return x.Rule
+	}
+	return 0
+}
+
+// IngressRule is an ingress rule of a security group. Unset ports and protocol match any.
+type IngressRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromPort *int32 `protobuf:"varint,1,opt,name=from_port,json=fromPort,proto3,oneof" json:"from_port,omitempty"`
+	// from_src_ip are the source CIDRs.
+	FromSrcIp          []string           `protobuf:"bytes,2,rep,name=from_src_ip,json=fromSrcIp,proto3" json:"from_src_ip,omitempty"`
+	FromSecurityGroups []*CloudResourceID `protobuf:"bytes,3,rep,name=from_security_groups,json=fromSecurityGroups,proto3" json:"from_security_groups,omitempty"`
+	Protocol           *int32             `protobuf:"varint,4,opt,name=protocol,proto3,oneof" json:"protocol,omitempty"`
+	FromEndPort        *int32             `protobuf:"varint,5,opt,name=from_end_port,json=fromEndPort,proto3,oneof" json:"from_end_port,omitempty"`
+	IcmpType           *int32             `protobuf:"varint,6,opt,name=icmp_type,json=icmpType,proto3,oneof" json:"icmp_type,omitempty"`
+	IcmpCode           *int32             `protobuf:"varint,7,opt,name=icmp_code,json=icmpCode,proto3,oneof" json:"icmp_code,omitempty"`
+	// action is Allow or Deny, and Allow if empty.
+	Action string `protobuf:"bytes,8,opt,name=action,proto3" json:"action,omitempty"`
+	// priority is only set for clouds ordering rules by priority.
+	Priority *RulePriority `protobuf:"bytes,9,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *IngressRule) Reset() {
+	*x = IngressRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngressRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngressRule) ProtoMessage() {}
+
+func (x *IngressRule) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngressRule.ProtoReflect.Descriptor instead.
+func (*IngressRule) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IngressRule) GetFromPort() int32 {
+	if x != nil && x.FromPort != nil {
+		return *x.FromPort
+	}
+	return 0
+}
+
+func (x *IngressRule) GetFromSrcIp() []string {
+	if x != nil {
+		return x.FromSrcIp
+	}
+	return nil
+}
+
+func (x *IngressRule) GetFromSecurityGroups() []*CloudResourceID {
+	if x != nil {
+		return x.FromSecurityGroups
+	}
+	return nil
+}
+
+func (x *IngressRule) GetProtocol() int32 {
+	if x != nil && x.Protocol != nil {
+		return *x.Protocol
+	}
+	return 0
+}
+
+func (x *IngressRule) GetFromEndPort() int32 {
+	if x != nil && x.FromEndPort != nil {
+		return *x.FromEndPort
+	}
+	return 0
+}
+
+func (x *IngressRule) GetIcmpType() int32 {
+	if x != nil && x.IcmpType != nil {
+		return *x.IcmpType
+	}
+	return 0
+}
+
+func (x *IngressRule) GetIcmpCode() int32 {
+	if x != nil && x.IcmpCode != nil {
+		return *x.IcmpCode
+	}
+	return 0
+}
+
+func (x *IngressRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *IngressRule) GetPriority() *RulePriority {
+	if x != nil {
+		return x.Priority
+	}
+	return nil
+}
+
+// EgressRule is an egress rule of a security group. Unset ports and protocol match any.
+type EgressRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ToPort *int32 `protobuf:"varint,1,opt,name=to_port,json=toPort,proto3,oneof" json:"to_port,omitempty"`
+	// to_dst_ip are the destination CIDRs.
+	ToDstIp          []string           `protobuf:"bytes,2,rep,name=to_dst_ip,json=toDstIp,proto3" json:"to_dst_ip,omitempty"`
+	ToSecurityGroups []*CloudResourceID `protobuf:"bytes,3,rep,name=to_security_groups,json=toSecurityGroups,proto3" json:"to_security_groups,omitempty"`
+	Protocol         *int32             `protobuf:"varint,4,opt,name=protocol,proto3,oneof" json:"protocol,omitempty"`
+	ToEndPort        *int32             `protobuf:"varint,5,opt,name=to_end_port,json=toEndPort,proto3,oneof" json:"to_end_port,omitempty"`
+	IcmpType         *int32             `protobuf:"varint,6,opt,name=icmp_type,json=icmpType,proto3,oneof" json:"icmp_type,omitempty"`
+	IcmpCode         *int32             `protobuf:"varint,7,opt,name=icmp_code,json=icmpCode,proto3,oneof" json:"icmp_code,omitempty"`
+	// action is Allow or Deny, and Allow if empty.
+	Action string `protobuf:"bytes,8,opt,name=action,proto3" json:"action,omitempty"`
+	// priority is only set for clouds ordering rules by priority.
+	Priority *RulePriority `protobuf:"bytes,9,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *EgressRule) Reset() {
+	*x = EgressRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EgressRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressRule) ProtoMessage() {}
+
+func (x *EgressRule) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EgressRule.ProtoReflect.Descriptor instead.
+func (*EgressRule) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EgressRule) GetToPort() int32 {
+	if x != nil && x.ToPort != nil {
+		return *x.ToPort
+	}
+	return 0
+}
+
+func (x *EgressRule) GetToDstIp() []string {
+	if x != nil {
+		return x.ToDstIp
+	}
+	return nil
+}
+
+func (x *EgressRule) GetToSecurityGroups() []*CloudResourceID {
+	if x != nil {
+		return x.ToSecurityGroups
+	}
+	return nil
+}
+
+func (x *EgressRule) GetProtocol() int32 {
+	if x != nil && x.Protocol != nil {
+		return *x.Protocol
+	}
+	return 0
+}
+
+func (x *EgressRule) GetToEndPort() int32 {
+	if x != nil && x.ToEndPort != nil {
+		return *x.ToEndPort
+	}
+	return 0
+}
+
+func (x *EgressRule) GetIcmpType() int32 {
+	if x != nil && x.IcmpType != nil {
+		return *x.IcmpType
+	}
+	return 0
+}
+
+func (x *EgressRule) GetIcmpCode() int32 {
+	if x != nil && x.IcmpCode != nil {
+		return *x.IcmpCode
+	}
+	return 0
+}
+
+func (x *EgressRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *EgressRule) GetPriority() *RulePriority {
+	if x != nil {
+		return x.Priority
+	}
+	return nil
+}
+
+// CloudRule is a rule of an appliedTo group.
+type CloudRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash          string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	NetworkPolicy string `protobuf:"bytes,2,opt,name=network_policy,json=networkPolicy,proto3" json:"network_policy,omitempty"`
+	AppliedToGrp  string `protobuf:"bytes,3,opt,name=applied_to_grp,json=appliedToGrp,proto3" json:"applied_to_grp,omitempty"`
+	// Types that are assignable to Rule:
+	//	*CloudRule_Ingress
+	//	*CloudRule_Egress
+	Rule isCloudRule_Rule `protobuf_oneof:"rule"`
+	// shard selects the security group realizing the rule, 0 for the appliedTo security group itself.
+	Shard int32 `protobuf:"varint,6,opt,name=shard,proto3" json:"shard,omitempty"`
+}
+
+func (x *CloudRule) Reset() {
+	*x = CloudRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudRule) ProtoMessage() {}
+
+func (x *CloudRule) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudRule.ProtoReflect.Descriptor instead.
+func (*CloudRule) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CloudRule) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *CloudRule) GetNetworkPolicy() string {
+	if x != nil {
+		return x.NetworkPolicy
+	}
+	return ""
+}
+
+func (x *CloudRule) GetAppliedToGrp() string {
+	if x != nil {
+		return x.AppliedToGrp
+	}
+	return ""
+}
+
+func (m *CloudRule) GetRule() isCloudRule_Rule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+func (x *CloudRule) GetIngress() *IngressRule {
+	if x, ok := x.GetRule().(*CloudRule_Ingress); ok {
+		return x.Ingress
+	}
+	return nil
+}
+
+func (x *CloudRule) GetEgress() *EgressRule {
+	if x, ok := x.GetRule().(*CloudRule_Egress); ok {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *CloudRule) GetShard() int32 {
+	if x != nil {
+		return x.Shard
+	}
+	return 0
+}
+
+type isCloudRule_Rule interface {
+	isCloudRule_Rule()
+}
+
+type CloudRule_Ingress struct {
+	Ingress *IngressRule `protobuf:"bytes,4,opt,name=ingress,proto3,oneof"`
+}
+
+type CloudRule_Egress struct {
+	Egress *EgressRule `protobuf:"bytes,5,opt,name=egress,proto3,oneof"`
+}
+
+func (*CloudRule_Ingress) isCloudRule_Rule() {}
+
+func (*CloudRule_Egress) isCloudRule_Rule() {}
+
+// UpdateSecurityGroupRulesRequest is the request of UpdateSecurityGroupRules.
+type UpdateSecurityGroupRulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppliedToGroup *CloudResource `protobuf:"bytes,1,opt,name=applied_to_group,json=appliedToGroup,proto3" json:"applied_to_group,omitempty"`
+	AddRules       []*CloudRule   `protobuf:"bytes,2,rep,name=add_rules,json=addRules,proto3" json:"add_rules,omitempty"`
+	RmRules        []*CloudRule   `protobuf:"bytes,3,rep,name=rm_rules,json=rmRules,proto3" json:"rm_rules,omitempty"`
+	AllRules       []*CloudRule   `protobuf:"bytes,4,rep,name=all_rules,json=allRules,proto3" json:"all_rules,omitempty"`
+}
+
+func (x *UpdateSecurityGroupRulesRequest) Reset() {
+	*x = UpdateSecurityGroupRulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSecurityGroupRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSecurityGroupRulesRequest) ProtoMessage() {}
+
+func (x *UpdateSecurityGroupRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSecurityGroupRulesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSecurityGroupRulesRequest) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateSecurityGroupRulesRequest) GetAppliedToGroup() *CloudResource {
+	if x != nil {
+		return x.AppliedToGroup
+	}
+	return nil
+}
+
+func (x *UpdateSecurityGroupRulesRequest) GetAddRules() []*CloudRule {
+	if x != nil {
+		return x.AddRules
+	}
+	return nil
+}
+
+func (x *UpdateSecurityGroupRulesRequest) GetRmRules() []*CloudRule {
+	if x != nil {
+		return x.RmRules
+	}
+	return nil
+}
+
+func (x *UpdateSecurityGroupRulesRequest) GetAllRules() []*CloudRule {
+	if x != nil {
+		return x.AllRules
+	}
+	return nil
+}
+
+// UpdateSecurityGroupMembersRequest is the request of UpdateSecurityGroupMembers.
+type UpdateSecurityGroupMembersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SecurityGroup  *CloudResource   `protobuf:"bytes,1,opt,name=security_group,json=securityGroup,proto3" json:"security_group,omitempty"`
+	Members        []*CloudResource `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	MembershipOnly bool             `protobuf:"varint,3,opt,name=membership_only,json=membershipOnly,proto3" json:"membership_only,omitempty"`
+}
+
+func (x *UpdateSecurityGroupMembersRequest) Reset() {
+	*x = UpdateSecurityGroupMembersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSecurityGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSecurityGroupMembersRequest) ProtoMessage() {}
+
+func (x *UpdateSecurityGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSecurityGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSecurityGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateSecurityGroupMembersRequest) GetSecurityGroup() *CloudResource {
+	if x != nil {
+		return x.SecurityGroup
+	}
+	return nil
+}
+
+func (x *UpdateSecurityGroupMembersRequest) GetMembers() []*CloudResource {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *UpdateSecurityGroupMembersRequest) GetMembershipOnly() bool {
+	if x != nil {
+		return x.MembershipOnly
+	}
+	return false
+}
+
+// SynchronizationContent is the content of a security group in cloud.
+type SynchronizationContent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource                   *CloudResource   `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	MembershipOnly             bool             `protobuf:"varint,2,opt,name=membership_only,json=membershipOnly,proto3" json:"membership_only,omitempty"`
+	Members                    []*CloudResource `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	MembersWithOtherSgAttached []*CloudResource `protobuf:"bytes,4,rep,name=members_with_other_sg_attached,json=membersWithOtherSgAttached,proto3" json:"members_with_other_sg_attached,omitempty"`
+	IngressRules               []*IngressRule   `protobuf:"bytes,5,rep,name=ingress_rules,json=ingressRules,proto3" json:"ingress_rules,omitempty"`
+	EgressRules                []*EgressRule    `protobuf:"bytes,6,rep,name=egress_rules,json=egressRules,proto3" json:"egress_rules,omitempty"`
+	// shard is the rule shard of the appliedTo security group realized in this security group.
+	Shard int32 `protobuf:"varint,7,opt,name=shard,proto3" json:"shard,omitempty"`
+}
+
+func (x *SynchronizationContent) Reset() {
+	*x = SynchronizationContent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SynchronizationContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynchronizationContent) ProtoMessage() {}
+
+func (x *SynchronizationContent) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynchronizationContent.ProtoReflect.Descriptor instead.
+func (*SynchronizationContent) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SynchronizationContent) GetResource() *CloudResource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *SynchronizationContent) GetMembershipOnly() bool {
+	if x != nil {
+		return x.MembershipOnly
+	}
+	return false
+}
+
+func (x *SynchronizationContent) GetMembers() []*CloudResource {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *SynchronizationContent) GetMembersWithOtherSgAttached() []*CloudResource {
+	if x != nil {
+		return x.MembersWithOtherSgAttached
+	}
+	return nil
+}
+
+func (x *SynchronizationContent) GetIngressRules() []*IngressRule {
+	if x != nil {
+		return x.IngressRules
+	}
+	return nil
+}
+
+func (x *SynchronizationContent) GetEgressRules() []*EgressRule {
+	if x != nil {
+		return x.EgressRules
+	}
+	return nil
+}
+
+func (x *SynchronizationContent) GetShard() int32 {
+	if x != nil {
+		return x.Shard
+	}
+	return 0
+}
+
+// EnforcedSecurityResponse is the response of GetEnforcedSecurity.
+type EnforcedSecurityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Content []*SynchronizationContent `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *EnforcedSecurityResponse) Reset() {
+	*x = EnforcedSecurityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnforcedSecurityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnforcedSecurityResponse) ProtoMessage() {}
+
+func (x *EnforcedSecurityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnforcedSecurityResponse.ProtoReflect.Descriptor instead.
+func (*EnforcedSecurityResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EnforcedSecurityResponse) GetContent() []*SynchronizationContent {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// SecurityGroupLimits are the limits of a cloud on security groups, a zero value means no limit.
+type SecurityGroupLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max_rules is the maximum number of inbound rules, and of outbound rules, of a security group.
+	MaxRules int32 `protobuf:"varint,1,opt,name=max_rules,json=maxRules,proto3" json:"max_rules,omitempty"`
+	// shared_directions is true if max_rules applies to inbound and outbound rules together.
+	SharedDirections bool `protobuf:"varint,2,opt,name=shared_directions,json=sharedDirections,proto3" json:"shared_directions,omitempty"`
+	// max_security_groups_per_interface is the maximum number of security groups attached to a network interface.
+	MaxSecurityGroupsPerInterface int32 `protobuf:"varint,3,opt,name=max_security_groups_per_interface,json=maxSecurityGroupsPerInterface,proto3" json:"max_security_groups_per_interface,omitempty"`
+	// rule_per_peer is true if each CIDR and each security group a CloudRule refers to is a rule in cloud.
+	RulePerPeer bool `protobuf:"varint,4,opt,name=rule_per_peer,json=rulePerPeer,proto3" json:"rule_per_peer,omitempty"`
+	// exclude_deny_rules is true if deny rules are not realized in security groups.
+	ExcludeDenyRules bool `protobuf:"varint,5,opt,name=exclude_deny_rules,json=excludeDenyRules,proto3" json:"exclude_deny_rules,omitempty"`
+}
+
+func (x *SecurityGroupLimits) Reset() {
+	*x = SecurityGroupLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecurityGroupLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityGroupLimits) ProtoMessage() {}
+
+func (x *SecurityGroupLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityGroupLimits.ProtoReflect.Descriptor instead.
+func (*SecurityGroupLimits) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SecurityGroupLimits) GetMaxRules() int32 {
+	if x != nil {
+		return x.MaxRules
+	}
+	return 0
+}
+
+func (x *SecurityGroupLimits) GetSharedDirections() bool {
+	if x != nil {
+		return x.SharedDirections
+	}
+	return false
+}
+
+func (x *SecurityGroupLimits) GetMaxSecurityGroupsPerInterface() int32 {
+	if x != nil {
+		return x.MaxSecurityGroupsPerInterface
+	}
+	return 0
+}
+
+func (x *SecurityGroupLimits) GetRulePerPeer() bool {
+	if x != nil {
+		return x.RulePerPeer
+	}
+	return false
+}
+
+func (x *SecurityGroupLimits) GetExcludeDenyRules() bool {
+	if x != nil {
+		return x.ExcludeDenyRules
+	}
+	return false
+}
+
+// SecurityGroupLimitsResponse is the response of GetSecurityGroupLimits.
+type SecurityGroupLimitsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limits *SecurityGroupLimits `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (x *SecurityGroupLimitsResponse) Reset() {
+	*x = SecurityGroupLimitsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecurityGroupLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityGroupLimitsResponse) ProtoMessage() {}
+
+func (x *SecurityGroupLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityGroupLimitsResponse.ProtoReflect.Descriptor instead.
+func (*SecurityGroupLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SecurityGroupLimitsResponse) GetLimits() *SecurityGroupLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+var File_apis_plugin_v1alpha1_cloudprovider_proto protoreflect.FileDescriptor
+
+var file_apis_plugin_v1alpha1_cloudprovider_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x6e, 0x65, 0x70, 0x68,
+	0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x42, 0x0a, 0x0e, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b,
+	0x0a, 0x14, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x22, 0x87, 0x02, 0x0a, 0x0c,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x52,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x75, 0x6c,
+	0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x70, 0x76,
+	0x36, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x70, 0x76, 0x36, 0x12, 0x1f, 0x0a,
+	0x0b, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x24,
+	0x0a, 0x0e, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x63, 0x6d, 0x70, 0x54, 0x79, 0x70, 0x65,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x63, 0x74, 0x70, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x73, 0x63, 0x74, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x5f, 0x0a, 0x14, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a,
+	0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x19, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x22,
+	0x51, 0x0a, 0x0e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3f, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x1e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x07, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x15, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x14, 0x56, 0x70, 0x63,
+	0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x04, 0x76, 0x70, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x70, 0x63, 0x49, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x70, 0x63,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x76, 0x70, 0x63, 0x73, 0x1a, 0x37, 0x0a, 0x09,
+	0x56, 0x70, 0x63, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc1, 0x01, 0x0a, 0x11, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x10, 0x76,
+	0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x73, 0x1a, 0x42, 0x0a, 0x14, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x37, 0x0a, 0x0f, 0x43, 0x6c, 0x6f,
+	0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x76, 0x70, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76,
+	0x70, 0x63, 0x22, 0xa1, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f,
+	0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x22, 0x8c, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x4b, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0d, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x27, 0x0a, 0x0f,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x75, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x17, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x14, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x53, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x88, 0x01, 0x01,
+	0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x22, 0x4e, 0x0a, 0x0c,
+	0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x69, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0xd9, 0x03, 0x0a,
+	0x0b, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f, 0x72, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1e,
+	0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x72, 0x63, 0x49, 0x70, 0x12, 0x58,
+	0x0a, 0x14, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x49, 0x44, 0x52, 0x12, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0d, 0x66, 0x72, 0x6f,
+	0x6d, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x02, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x6e, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x08, 0x69, 0x63, 0x6d, 0x70, 0x54, 0x79, 0x70,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x04, 0x52, 0x08, 0x69, 0x63, 0x6d, 0x70, 0x43,
+	0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f,
+	0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x42,
+	0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69,
+	0x63, 0x6d, 0x70, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0xc4, 0x03, 0x0a, 0x0a, 0x45, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06, 0x74, 0x6f, 0x50, 0x6f,
+	0x72, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x64, 0x73, 0x74, 0x5f,
+	0x69, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x44, 0x73, 0x74, 0x49,
+	0x70, 0x12, 0x54, 0x0a, 0x12, 0x74, 0x6f, 0x5f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x49, 0x44, 0x52, 0x10, 0x74, 0x6f, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
+	0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x65,
+	0x6e, 0x64, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52,
+	0x09, 0x74, 0x6f, 0x45, 0x6e, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a,
+	0x09, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x03, 0x52, 0x08, 0x69, 0x63, 0x6d, 0x70, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x20, 0x0a, 0x09, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x48, 0x04, 0x52, 0x08, 0x69, 0x63, 0x6d, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x65,
+	0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x74,
+	0x6f, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x6f, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x70,
+	0x6f, 0x72, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22,
+	0x87, 0x02, 0x0a, 0x09, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x5f, 0x67, 0x72, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x6f, 0x47, 0x72, 0x70, 0x12, 0x3e,
+	0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x75, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3b,
+	0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x75, 0x6c,
+	0x65, 0x48, 0x00, 0x52, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x42, 0x06, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0xac, 0x02, 0x0a, 0x1f, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a,
+	0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x5f, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0e, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x6f, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x3d, 0x0a,
+	0x09, 0x61, 0x64, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x08, 0x61, 0x64, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x08,
+	0x72, 0x6d, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x07, 0x72, 0x6d, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x09, 0x61, 0x6c, 0x6c,
+	0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x08,
+	0x61, 0x6c, 0x6c, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x21, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b,
+	0x0a, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0d, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x3e, 0x0a, 0x07, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x4f, 0x6e, 0x6c, 0x79, 0x22, 0xd2, 0x03, 0x0a, 0x16, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
+	0x40, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65,
+	0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x68, 0x0a, 0x1e, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6f, 0x74, 0x68, 0x65, 0x72,
+	0x5f, 0x73, 0x67, 0x5f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x1a, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x53, 0x67, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x68, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x0d, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x65,
+	0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x52,
+	0x0c, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x44, 0x0a,
+	0x0c, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0b, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x64, 0x22, 0x63, 0x0a, 0x18, 0x45, 0x6e, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x64, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xfb,
+	0x01, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x48, 0x0a, 0x21, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1d, 0x6d, 0x61, 0x78,
+	0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x50, 0x65,
+	0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x72, 0x75,
+	0x6c, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x72, 0x75, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x50, 0x65, 0x65, 0x72, 0x12, 0x2c,
+	0x0a, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x61, 0x0a, 0x1b,
+	0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6e, 0x65,
+	0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x32,
+	0x85, 0x0e, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x12, 0x59, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x2b, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2b, 0x2e,
+	0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x12, 0x41, 0x64,
+	0x64, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x30, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x5c, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x2e, 0x6e, 0x65, 0x70, 0x68,
+	0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x71,
+	0x0a, 0x1a, 0x41, 0x64, 0x64, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x35, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x75, 0x0a, 0x1e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x35, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x65, 0x70,
+	0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x67, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x56, 0x0a, 0x0f, 0x44, 0x6f, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x25, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x65,
+	0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x5f, 0x0a, 0x18, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x6c,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x25, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x65, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x56, 0x70, 0x63, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x2e,
+	0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x70, 0x63,
+	0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x70, 0x0a, 0x1d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x47, 0x69,
+	0x76, 0x65, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x25, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6e, 0x65, 0x70, 0x68,
+	0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63,
+	0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2b, 0x2e, 0x6e, 0x65, 0x70,
+	0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a, 0x18, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x36, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x74, 0x0a,
+	0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x38, 0x2e, 0x6e, 0x65,
+	0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x60, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63,
+	0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2b, 0x2e, 0x6e, 0x65, 0x70,
+	0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x64, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x64, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x2e, 0x6e,
+	0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2f, 0x2e, 0x6e, 0x65, 0x70,
+	0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x45, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x53, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x32, 0x2e, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x75,
+	0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x26, 0x5a, 0x24, 0x61, 0x6e, 0x74, 0x72, 0x65,
+	0x61, 0x2e, 0x69, 0x6f, 0x2f, 0x6e, 0x65, 0x70, 0x68, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescOnce sync.Once
+	file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescData = file_apis_plugin_v1alpha1_cloudprovider_proto_rawDesc
+)
+
+func file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescGZIP() []byte {
+	file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescOnce.Do(func() {
+		file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescData = protoimpl.X.CompressGZIP(file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescData)
+	})
+	return file_apis_plugin_v1alpha1_cloudprovider_proto_rawDescData
+}
+
+var file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_apis_plugin_v1alpha1_cloudprovider_proto_goTypes = []interface{}{
+	(*Empty)(nil),                             // 0: nephe.plugin.v1alpha1.Empty
+	(*NamespacedName)(nil),                    // 1: nephe.plugin.v1alpha1.NamespacedName
+	(*ProviderTypeResponse)(nil),              // 2: nephe.plugin.v1alpha1.ProviderTypeResponse
+	(*Capabilities)(nil),                      // 3: nephe.plugin.v1alpha1.Capabilities
+	(*CapabilitiesResponse)(nil),              // 4: nephe.plugin.v1alpha1.CapabilitiesResponse
+	(*AddProviderAccountRequest)(nil),         // 5: nephe.plugin.v1alpha1.AddProviderAccountRequest
+	(*AccountRequest)(nil),                    // 6: nephe.plugin.v1alpha1.AccountRequest
+	(*AccountResourceSelectorRequest)(nil),    // 7: nephe.plugin.v1alpha1.AccountResourceSelectorRequest
+	(*AccountStatusResponse)(nil),             // 8: nephe.plugin.v1alpha1.AccountStatusResponse
+	(*VpcInventoryResponse)(nil),              // 9: nephe.plugin.v1alpha1.VpcInventoryResponse
+	(*InstancesResponse)(nil),                 // 10: nephe.plugin.v1alpha1.InstancesResponse
+	(*CloudResourceID)(nil),                   // 11: nephe.plugin.v1alpha1.CloudResourceID
+	(*CloudResource)(nil),                     // 12: nephe.plugin.v1alpha1.CloudResource
+	(*SecurityGroupRequest)(nil),              // 13: nephe.plugin.v1alpha1.SecurityGroupRequest
+	(*CreateSecurityGroupResponse)(nil),       // 14: nephe.plugin.v1alpha1.CreateSecurityGroupResponse
+	(*RulePriority)(nil),                      // 15: nephe.plugin.v1alpha1.RulePriority
+	(*IngressRule)(nil),                       // 16: nephe.plugin.v1alpha1.IngressRule
+	(*EgressRule)(nil),                        // 17: nephe.plugin.v1alpha1.EgressRule
+	(*CloudRule)(nil),                         // 18: nephe.plugin.v1alpha1.CloudRule
+	(*UpdateSecurityGroupRulesRequest)(nil),   // 19: nephe.plugin.v1alpha1.UpdateSecurityGroupRulesRequest
+	(*UpdateSecurityGroupMembersRequest)(nil), // 20: nephe.plugin.v1alpha1.UpdateSecurityGroupMembersRequest
+	(*SynchronizationContent)(nil),            // 21: nephe.plugin.v1alpha1.SynchronizationContent
+	(*EnforcedSecurityResponse)(nil),          // 22: nephe.plugin.v1alpha1.EnforcedSecurityResponse
+	(*SecurityGroupLimits)(nil),               // 23: nephe.plugin.v1alpha1.SecurityGroupLimits
+	(*SecurityGroupLimitsResponse)(nil),       // 24: nephe.plugin.v1alpha1.SecurityGroupLimitsResponse
+	nil,                                       // 25: nephe.plugin.v1alpha1.VpcInventoryResponse.VpcsEntry
+	nil,                                       // 26: nephe.plugin.v1alpha1.InstancesResponse.VirtualMachinesEntry
+}
+var file_apis_plugin_v1alpha1_cloudprovider_proto_depIdxs = []int32{
+	3,  // 0: nephe.plugin.v1alpha1.CapabilitiesResponse.capabilities:type_name -> nephe.plugin.v1alpha1.Capabilities
+	1,  // 1: nephe.plugin.v1alpha1.AccountRequest.account:type_name -> nephe.plugin.v1alpha1.NamespacedName
+	1,  // 2: nephe.plugin.v1alpha1.AccountResourceSelectorRequest.account:type_name -> nephe.plugin.v1alpha1.NamespacedName
+	25, // 3: nephe.plugin.v1alpha1.VpcInventoryResponse.vpcs:type_name -> nephe.plugin.v1alpha1.VpcInventoryResponse.VpcsEntry
+	26, // 4: nephe.plugin.v1alpha1.InstancesResponse.virtual_machines:type_name -> nephe.plugin.v1alpha1.InstancesResponse.VirtualMachinesEntry
+	11, // 5: nephe.plugin.v1alpha1.CloudResource.id:type_name -> nephe.plugin.v1alpha1.CloudResourceID
+	12, // 6: nephe.plugin.v1alpha1.SecurityGroupRequest.security_group:type_name -> nephe.plugin.v1alpha1.CloudResource
+	11, // 7: nephe.plugin.v1alpha1.IngressRule.from_security_groups:type_name -> nephe.plugin.v1alpha1.CloudResourceID
+	15, // 8: nephe.plugin.v1alpha1.IngressRule.priority:type_name -> nephe.plugin.v1alpha1.RulePriority
+	11, // 9: nephe.plugin.v1alpha1.EgressRule.to_security_groups:type_name -> nephe.plugin.v1alpha1.CloudResourceID
+	15, // 10: nephe.plugin.v1alpha1.EgressRule.priority:type_name -> nephe.plugin.v1alpha1.RulePriority
+	16, // 11: nephe.plugin.v1alpha1.CloudRule.ingress:type_name -> nephe.plugin.v1alpha1.IngressRule
+	17, // 12: nephe.plugin.v1alpha1.CloudRule.egress:type_name -> nephe.plugin.v1alpha1.EgressRule
+	12, // 13: nephe.plugin.v1alpha1.UpdateSecurityGroupRulesRequest.applied_to_group:type_name -> nephe.plugin.v1alpha1.CloudResource
+	18, // 14: nephe.plugin.v1alpha1.UpdateSecurityGroupRulesRequest.add_rules:type_name -> nephe.plugin.v1alpha1.CloudRule
+	18, // 15: nephe.plugin.v1alpha1.UpdateSecurityGroupRulesRequest.rm_rules:type_name -> nephe.plugin.v1alpha1.CloudRule
+	18, // 16: nephe.plugin.v1alpha1.UpdateSecurityGroupRulesRequest.all_rules:type_name -> nephe.plugin.v1alpha1.CloudRule
+	12, // 17: nephe.plugin.v1alpha1.UpdateSecurityGroupMembersRequest.security_group:type_name -> nephe.plugin.v1alpha1.CloudResource
+	12, // 18: nephe.plugin.v1alpha1.UpdateSecurityGroupMembersRequest.members:type_name -> nephe.plugin.v1alpha1.CloudResource
+	12, // 19: nephe.plugin.v1alpha1.SynchronizationContent.resource:type_name -> nephe.plugin.v1alpha1.CloudResource
+	12, // 20: nephe.plugin.v1alpha1.SynchronizationContent.members:type_name -> nephe.plugin.v1alpha1.CloudResource
+	12, // 21: nephe.plugin.v1alpha1.SynchronizationContent.members_with_other_sg_attached:type_name -> nephe.plugin.v1alpha1.CloudResource
+	16, // 22: nephe.plugin.v1alpha1.SynchronizationContent.ingress_rules:type_name -> nephe.plugin.v1alpha1.IngressRule
+	17, // 23: nephe.plugin.v1alpha1.SynchronizationContent.egress_rules:type_name -> nephe.plugin.v1alpha1.EgressRule
+	21, // 24: nephe.plugin.v1alpha1.EnforcedSecurityResponse.content:type_name -> nephe.plugin.v1alpha1.SynchronizationContent
+	23, // 25: nephe.plugin.v1alpha1.SecurityGroupLimitsResponse.limits:type_name -> nephe.plugin.v1alpha1.SecurityGroupLimits
+	0,  // 26: nephe.plugin.v1alpha1.CloudProvider.ProviderType:input_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 27: nephe.plugin.v1alpha1.CloudProvider.GetCapabilities:input_type -> nephe.plugin.v1alpha1.Empty
+	5,  // 28: nephe.plugin.v1alpha1.CloudProvider.AddProviderAccount:input_type -> nephe.plugin.v1alpha1.AddProviderAccountRequest
+	6,  // 29: nephe.plugin.v1alpha1.CloudProvider.RemoveProviderAccount:input_type -> nephe.plugin.v1alpha1.AccountRequest
+	7,  // 30: nephe.plugin.v1alpha1.CloudProvider.AddAccountResourceSelector:input_type -> nephe.plugin.v1alpha1.AccountResourceSelectorRequest
+	7,  // 31: nephe.plugin.v1alpha1.CloudProvider.RemoveAccountResourcesSelector:input_type -> nephe.plugin.v1alpha1.AccountResourceSelectorRequest
+	6,  // 32: nephe.plugin.v1alpha1.CloudProvider.GetAccountStatus:input_type -> nephe.plugin.v1alpha1.AccountRequest
+	6,  // 33: nephe.plugin.v1alpha1.CloudProvider.DoInventoryPoll:input_type -> nephe.plugin.v1alpha1.AccountRequest
+	6,  // 34: nephe.plugin.v1alpha1.CloudProvider.DeleteInventoryPollCache:input_type -> nephe.plugin.v1alpha1.AccountRequest
+	6,  // 35: nephe.plugin.v1alpha1.CloudProvider.GetVpcInventory:input_type -> nephe.plugin.v1alpha1.AccountRequest
+	6,  // 36: nephe.plugin.v1alpha1.CloudProvider.InstancesGivenProviderAccount:input_type -> nephe.plugin.v1alpha1.AccountRequest
+	13, // 37: nephe.plugin.v1alpha1.CloudProvider.CreateSecurityGroup:input_type -> nephe.plugin.v1alpha1.SecurityGroupRequest
+	19, // 38: nephe.plugin.v1alpha1.CloudProvider.UpdateSecurityGroupRules:input_type -> nephe.plugin.v1alpha1.UpdateSecurityGroupRulesRequest
+	20, // 39: nephe.plugin.v1alpha1.CloudProvider.UpdateSecurityGroupMembers:input_type -> nephe.plugin.v1alpha1.UpdateSecurityGroupMembersRequest
+	13, // 40: nephe.plugin.v1alpha1.CloudProvider.DeleteSecurityGroup:input_type -> nephe.plugin.v1alpha1.SecurityGroupRequest
+	0,  // 41: nephe.plugin.v1alpha1.CloudProvider.GetEnforcedSecurity:input_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 42: nephe.plugin.v1alpha1.CloudProvider.GetSecurityGroupLimits:input_type -> nephe.plugin.v1alpha1.Empty
+	2,  // 43: nephe.plugin.v1alpha1.CloudProvider.ProviderType:output_type -> nephe.plugin.v1alpha1.ProviderTypeResponse
+	4,  // 44: nephe.plugin.v1alpha1.CloudProvider.GetCapabilities:output_type -> nephe.plugin.v1alpha1.CapabilitiesResponse
+	0,  // 45: nephe.plugin.v1alpha1.CloudProvider.AddProviderAccount:output_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 46: nephe.plugin.v1alpha1.CloudProvider.RemoveProviderAccount:output_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 47: nephe.plugin.v1alpha1.CloudProvider.AddAccountResourceSelector:output_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 48: nephe.plugin.v1alpha1.CloudProvider.RemoveAccountResourcesSelector:output_type -> nephe.plugin.v1alpha1.Empty
+	8,  // 49: nephe.plugin.v1alpha1.CloudProvider.GetAccountStatus:output_type -> nephe.plugin.v1alpha1.AccountStatusResponse
+	0,  // 50: nephe.plugin.v1alpha1.CloudProvider.DoInventoryPoll:output_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 51: nephe.plugin.v1alpha1.CloudProvider.DeleteInventoryPollCache:output_type -> nephe.plugin.v1alpha1.Empty
+	9,  // 52: nephe.plugin.v1alpha1.CloudProvider.GetVpcInventory:output_type -> nephe.plugin.v1alpha1.VpcInventoryResponse
+	10, // 53: nephe.plugin.v1alpha1.CloudProvider.InstancesGivenProviderAccount:output_type -> nephe.plugin.v1alpha1.InstancesResponse
+	14, // 54: nephe.plugin.v1alpha1.CloudProvider.CreateSecurityGroup:output_type -> nephe.plugin.v1alpha1.CreateSecurityGroupResponse
+	0,  // 55: nephe.plugin.v1alpha1.CloudProvider.UpdateSecurityGroupRules:output_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 56: nephe.plugin.v1alpha1.CloudProvider.UpdateSecurityGroupMembers:output_type -> nephe.plugin.v1alpha1.Empty
+	0,  // 57: nephe.plugin.v1alpha1.CloudProvider.DeleteSecurityGroup:output_type -> nephe.plugin.v1alpha1.Empty
+	22, // 58: nephe.plugin.v1alpha1.CloudProvider.GetEnforcedSecurity:output_type -> nephe.plugin.v1alpha1.EnforcedSecurityResponse
+	24, // 59: nephe.plugin.v1alpha1.CloudProvider.GetSecurityGroupLimits:output_type -> nephe.plugin.v1alpha1.SecurityGroupLimitsResponse
+	43, // [43:60] is the sub-list for method output_type
+	26, // [26:43] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_apis_plugin_v1alpha1_cloudprovider_proto_init() }
+func file_apis_plugin_v1alpha1_cloudprovider_proto_init() {
+	if File_apis_plugin_v1alpha1_cloudprovider_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamespacedName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderTypeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CapabilitiesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddProviderAccountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountResourceSelectorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VpcInventoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstancesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudResourceID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudResource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecurityGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSecurityGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RulePriority); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSecurityGroupRulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSecurityGroupMembersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SynchronizationContent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnforcedSecurityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecurityGroupLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecurityGroupLimitsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[17].OneofWrappers = []interface{}{}
+	file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes[18].OneofWrappers = []interface{}{
+		(*CloudRule_Ingress)(nil),
+		(*CloudRule_Egress)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_apis_plugin_v1alpha1_cloudprovider_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apis_plugin_v1alpha1_cloudprovider_proto_goTypes,
+		DependencyIndexes: file_apis_plugin_v1alpha1_cloudprovider_proto_depIdxs,
+		MessageInfos:      file_apis_plugin_v1alpha1_cloudprovider_proto_msgTypes,
+	}.Build()
+	File_apis_plugin_v1alpha1_cloudprovider_proto = out.File
+	file_apis_plugin_v1alpha1_cloudprovider_proto_rawDesc = nil
+	file_apis_plugin_v1alpha1_cloudprovider_proto_goTypes = nil
+	file_apis_plugin_v1alpha1_cloudprovider_proto_depIdxs = nil
+}
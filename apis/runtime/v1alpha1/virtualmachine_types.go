@@ -36,6 +36,16 @@ const (
 	AzureCloudProvider CloudProvider = "Azure"
 	// AWSCloudProvider specifies AWS.
 	AWSCloudProvider CloudProvider = "AWS"
+	// GCPCloudProvider specifies GCP.
+	GCPCloudProvider CloudProvider = "GCP"
+	// OpenStackCloudProvider specifies OpenStack.
+	OpenStackCloudProvider CloudProvider = "OpenStack"
+	// SimulatedCloudProvider specifies the in-memory simulated cloud.
+	SimulatedCloudProvider CloudProvider = "Simulated"
+	// StaticCloudProvider specifies statically declared hosts, e.g. bare-metal hosts.
+	StaticCloudProvider CloudProvider = "Static"
+	// VSphereCloudProvider specifies on-prem vSphere.
+	VSphereCloudProvider CloudProvider = "VSphere"
 )
 
 const (
@@ -26,6 +26,8 @@ type VpcStatus struct {
 	Tags     map[string]string `json:"tags,omitempty"`
 	Cidrs    []string          `json:"cidrs,omitempty"`
 	Managed  bool              `json:"managed,omitempty"`
+	// Peers are the IDs of VPCs peered with this VPC.
+	Peers []string `json:"peers,omitempty"`
 }
 
 // +kubebuilder:object:root=true
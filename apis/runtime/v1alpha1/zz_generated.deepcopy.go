@@ -321,6 +321,11 @@ func (in *VpcStatus) DeepCopyInto(out *VpcStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcStatus.
@@ -0,0 +1,169 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// cloud-plugin-skeleton is a reference out-of-tree cloud provider plugin. It serves the CloudProvider gRPC
+// service with a Provider keeping accounts in memory, and is meant to be copied as the starting point of a new
+// cloud provider plugin.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/plugin"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+	"antrea.io/nephe/pkg/logging"
+)
+
+var setupLog = logging.GetLogger("setup")
+
+// skeletonProvider implements plugin.Provider. Replace the TODOs with calls to the cloud API.
+type skeletonProvider struct {
+	providerType cloudcommon.ProviderType
+	mutex        sync.Mutex
+	accounts     map[types.NamespacedName]*crdv1alpha1.CloudProviderAccount
+}
+
+func newSkeletonProvider(providerType string) *skeletonProvider {
+	return &skeletonProvider{
+		providerType: cloudcommon.ProviderType(providerType),
+		accounts:     make(map[types.NamespacedName]*crdv1alpha1.CloudProviderAccount),
+	}
+}
+
+func (p *skeletonProvider) ProviderType() cloudcommon.ProviderType {
+	return p.providerType
+}
+
+func (p *skeletonProvider) GetCapabilities() crdv1alpha1.CloudCapabilities {
+	return crdv1alpha1.CloudCapabilities{}
+}
+
+func (p *skeletonProvider) AddProviderAccount(account *crdv1alpha1.CloudProviderAccount, _ []byte) error {
+	// TODO: parse credentials and create cloud API clients of the account.
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.accounts[types.NamespacedName{Namespace: account.Namespace, Name: account.Name}] = account
+	return nil
+}
+
+func (p *skeletonProvider) RemoveProviderAccount(namespacedName *types.NamespacedName) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.accounts, *namespacedName)
+}
+
+func (p *skeletonProvider) AddAccountResourceSelector(accNamespacedName *types.NamespacedName,
+	_ *crdv1alpha1.CloudEntitySelector) error {
+	// TODO: store the selector and filter inventory with it.
+	return p.checkAccount(accNamespacedName)
+}
+
+func (p *skeletonProvider) RemoveAccountResourcesSelector(_ *types.NamespacedName, _ string) {}
+
+func (p *skeletonProvider) GetAccountStatus(accNamespacedName *types.NamespacedName) (*crdv1alpha1.CloudProviderAccountStatus,
+	error) {
+	if err := p.checkAccount(accNamespacedName); err != nil {
+		return nil, err
+	}
+	return &crdv1alpha1.CloudProviderAccountStatus{}, nil
+}
+
+func (p *skeletonProvider) DoInventoryPoll(accountNamespacedName *types.NamespacedName) error {
+	// TODO: fetch VPCs and VMs from the cloud.
+	return p.checkAccount(accountNamespacedName)
+}
+
+func (p *skeletonProvider) DeleteInventoryPollCache(accountNamespacedName *types.NamespacedName) error {
+	return p.checkAccount(accountNamespacedName)
+}
+
+func (p *skeletonProvider) GetVpcInventory(accountNamespacedName *types.NamespacedName) (map[string]*runtimev1alpha1.Vpc,
+	error) {
+	return map[string]*runtimev1alpha1.Vpc{}, p.checkAccount(accountNamespacedName)
+}
+
+func (p *skeletonProvider) InstancesGivenProviderAccount(namespacedName *types.NamespacedName) (
+	map[string]*runtimev1alpha1.VirtualMachine, error) {
+	return map[string]*runtimev1alpha1.VirtualMachine{}, p.checkAccount(namespacedName)
+}
+
+func (p *skeletonProvider) CreateSecurityGroup(_ *securitygroup.CloudResource, _ bool) (*string, error) {
+	return nil, fmt.Errorf("CreateSecurityGroup is not implemented")
+}
+
+func (p *skeletonProvider) UpdateSecurityGroupRules(_ *securitygroup.CloudResource, _, _, _ []*securitygroup.CloudRule) error {
+	return fmt.Errorf("UpdateSecurityGroupRules is not implemented")
+}
+
+func (p *skeletonProvider) UpdateSecurityGroupMembers(_ *securitygroup.CloudResource, _ []*securitygroup.CloudResource,
+	_ bool) error {
+	return fmt.Errorf("UpdateSecurityGroupMembers is not implemented")
+}
+
+func (p *skeletonProvider) DeleteSecurityGroup(_ *securitygroup.CloudResource, _ bool) error {
+	return fmt.Errorf("DeleteSecurityGroup is not implemented")
+}
+
+func (p *skeletonProvider) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	return nil
+}
+
+func (p *skeletonProvider) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{}
+}
+
+func (p *skeletonProvider) checkAccount(namespacedName *types.NamespacedName) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.accounts[*namespacedName]; !ok {
+		return fmt.Errorf("unable to find cloud account: %v", *namespacedName)
+	}
+	return nil
+}
+
+func main() {
+	var address string
+	var providerType string
+	tlsConfig := &plugin.ServerTLSConfig{}
+	flag.StringVar(&address, "address", "unix:///var/run/nephe/plugin.sock",
+		"The address the plugin gRPC server binds to, a unix domain socket, or a TCP address served with mutual TLS.")
+	flag.StringVar(&providerType, "provider-type", "Skeleton", "The cloud provider type served by the plugin.")
+	flag.StringVar(&tlsConfig.CertFile, "tls-cert-file", "", "The certificate of the plugin, required on a TCP address.")
+	flag.StringVar(&tlsConfig.KeyFile, "tls-key-file", "", "The private key of the plugin certificate.")
+	flag.StringVar(&tlsConfig.ClientCAFile, "client-ca-file", "", "The CA bundle verifying the certificate of nephe-controller.")
+	flag.Parse()
+	if len(tlsConfig.CertFile) == 0 {
+		tlsConfig = nil
+	}
+
+	server, listener, err := plugin.NewServer(address, tlsConfig, newSkeletonProvider(providerType))
+	if err != nil {
+		setupLog.Error(err, "unable to listen", "address", address)
+		os.Exit(1)
+	}
+
+	setupLog.Info("Serving cloud provider plugin", "provider", providerType, "address", address)
+	if err := server.Serve(listener); err != nil {
+		setupLog.Error(err, "problem running plugin server")
+		os.Exit(1)
+	}
+}
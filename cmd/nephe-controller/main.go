@@ -30,9 +30,13 @@ import (
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/apiserver"
 	nephewebhook "antrea.io/nephe/pkg/apiserver/webhook"
+	cloudprovider "antrea.io/nephe/pkg/cloud-provider"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/aws"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
 	controllers "antrea.io/nephe/pkg/controllers/cloud"
 	"antrea.io/nephe/pkg/controllers/inventory"
+	"antrea.io/nephe/pkg/converter/source"
 	"antrea.io/nephe/pkg/logging"
 	// +kubebuilder:scaffold:imports
 )
@@ -78,6 +82,16 @@ func main() {
 
 	setupLog.Info("Nephe ConfigMap", "ControllerConfig", opts.config)
 	securitygroup.SetCloudResourcePrefix(opts.config.CloudResourcePrefix)
+	source.SetNamedPortTagPrefix(opts.config.NamedPortTagPrefix)
+	if err := credentialsource.SetConfig(&opts.config.CredentialSource); err != nil {
+		setupLog.Error(err, "invalid credential source configuration")
+		os.Exit(1)
+	}
+	aws.SetQuotas(&opts.config.AWSQuotas)
+	if err := cloudprovider.RegisterCloudProviderPlugins(opts.config.CloudProviderPlugins); err != nil {
+		setupLog.Error(err, "unable to register cloud provider plugins")
+		os.Exit(1)
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
@@ -132,11 +146,15 @@ func main() {
 	}
 
 	npController := &controllers.NetworkPolicyReconciler{
-		Client:            mgr.GetClient(),
-		Log:               logging.GetLogger("controllers").WithName("NetworkPolicy"),
-		Scheme:            mgr.GetScheme(),
-		CloudSyncInterval: opts.config.CloudSyncInterval,
-		Inventory:         cloudInventory,
+		Client:                mgr.GetClient(),
+		Log:                   logging.GetLogger("controllers").WithName("NetworkPolicy"),
+		Scheme:                mgr.GetScheme(),
+		CloudSyncInterval:     opts.config.CloudSyncInterval,
+		PodAddressTranslation: opts.config.PodAddressTranslation,
+		CrossVpcAddressType:   runtimev1alpha1.AddressType(opts.config.CrossVpcAddressType),
+		FQDNResolver:          opts.config.FQDNResolver,
+		FQDNRefreshInterval:   opts.config.FQDNRefreshInterval,
+		Inventory:             cloudInventory,
 	}
 
 	if err = npController.SetupWithManager(mgr); err != nil {
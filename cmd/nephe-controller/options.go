@@ -16,11 +16,15 @@ package main
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/config"
 )
 
@@ -75,6 +79,97 @@ func (o *Options) validate() error {
 		return fmt.Errorf("invalid CloudSyncInterval %v, CloudSyncInterval should be >= %v seconds",
 			o.config.CloudSyncInterval, config.MinimumCloudSyncInterval)
 	}
+
+	switch o.config.PodAddressTranslation {
+	case "", config.PodAddressTranslationPodIP, config.PodAddressTranslationNodeIP:
+	default:
+		return fmt.Errorf("invalid PodAddressTranslation %v, PodAddressTranslation should be %v or %v",
+			o.config.PodAddressTranslation, config.PodAddressTranslationPodIP, config.PodAddressTranslationNodeIP)
+	}
+
+	switch o.config.CrossVpcAddressType {
+	case "", string(runtimev1alpha1.AddressTypeInternalIP), string(runtimev1alpha1.AddressTypeExternalIP):
+	default:
+		return fmt.Errorf("invalid CrossVpcAddressType %v, CrossVpcAddressType should be %v or %v",
+			o.config.CrossVpcAddressType, runtimev1alpha1.AddressTypeInternalIP, runtimev1alpha1.AddressTypeExternalIP)
+	}
+
+	if o.config.FQDNRefreshInterval < 0 {
+		return fmt.Errorf("invalid FQDNRefreshInterval %v, FQDNRefreshInterval should be >= 0 seconds",
+			o.config.FQDNRefreshInterval)
+	}
+
+	if err := validateAWSQuotas(&o.config.AWSQuotas); err != nil {
+		return err
+	}
+
+	if len(o.config.CredentialSource.FileRoot) != 0 && !filepath.IsAbs(o.config.CredentialSource.FileRoot) {
+		return fmt.Errorf("invalid CredentialSource, fileRoot %v should be an absolute path", o.config.CredentialSource.FileRoot)
+	}
+	for _, address := range o.config.CredentialSource.VaultAddresses {
+		u, err := url.Parse(address)
+		if err != nil || u.Scheme != "https" || len(u.Host) == 0 || len(strings.Trim(u.Path, "/")) != 0 {
+			return fmt.Errorf("invalid CredentialSource, vault address %v should be an https URL without path", address)
+		}
+	}
+
+	providerTypes := map[string]struct{}{
+		string(runtimev1alpha1.AWSCloudProvider):       {},
+		string(runtimev1alpha1.AzureCloudProvider):     {},
+		string(runtimev1alpha1.GCPCloudProvider):       {},
+		string(runtimev1alpha1.OpenStackCloudProvider): {},
+		string(runtimev1alpha1.SimulatedCloudProvider): {},
+		string(runtimev1alpha1.StaticCloudProvider):    {},
+		string(runtimev1alpha1.VSphereCloudProvider):   {},
+	}
+	for _, plugin := range o.config.CloudProviderPlugins {
+		if len(plugin.ProviderType) == 0 || len(plugin.Address) == 0 {
+			return fmt.Errorf("invalid CloudProviderPlugins, providerType and address are required")
+		}
+		if _, found := providerTypes[plugin.ProviderType]; found {
+			return fmt.Errorf("invalid CloudProviderPlugins, providerType %v is already registered", plugin.ProviderType)
+		}
+		if plugin.TLS == nil && !strings.HasPrefix(plugin.Address, "unix:") {
+			return fmt.Errorf("invalid CloudProviderPlugins, address %v of providerType %v should be a unix domain socket "+
+				"unless tls is configured", plugin.Address, plugin.ProviderType)
+		}
+		if plugin.TLS != nil && (len(plugin.TLS.CAFile) == 0 || len(plugin.TLS.CertFile) == 0 || len(plugin.TLS.KeyFile) == 0) {
+			return fmt.Errorf("invalid CloudProviderPlugins, tls of providerType %v requires caFile, certFile and keyFile",
+				plugin.ProviderType)
+		}
+		if plugin.TimeoutInSeconds < 0 {
+			return fmt.Errorf("invalid CloudProviderPlugins, timeoutInSeconds %v of providerType %v should be >= 0",
+				plugin.TimeoutInSeconds, plugin.ProviderType)
+		}
+		providerTypes[plugin.ProviderType] = struct{}{}
+	}
+	return nil
+}
+
+// validateAWSQuotas checks if the aws quotas are within the limits aws quotas can be raised to.
+func validateAWSQuotas(quotas *config.AWSQuotaConfig) error {
+	if quotas.SecurityGroupRules < 0 || quotas.SecurityGroupsPerInterface < 0 || quotas.NetworkACLEntries < 0 {
+		return fmt.Errorf("invalid AWSQuotas, quotas should be >= 0")
+	}
+	if quotas.SecurityGroupsPerInterface > config.MaxAWSSecurityGroupsPerInterface {
+		return fmt.Errorf("invalid AWSQuotas, securityGroupsPerInterface %v should be <= %v",
+			quotas.SecurityGroupsPerInterface, config.MaxAWSSecurityGroupsPerInterface)
+	}
+	rules, groups := quotas.SecurityGroupRules, quotas.SecurityGroupsPerInterface
+	if rules == 0 {
+		rules = config.DefaultAWSSecurityGroupRules
+	}
+	if groups == 0 {
+		groups = config.DefaultAWSSecurityGroupsPerInterface
+	}
+	if rules*groups > config.MaxAWSSecurityGroupRulesPerInterface {
+		return fmt.Errorf("invalid AWSQuotas, securityGroupRules %v times securityGroupsPerInterface %v should be <= %v",
+			rules, groups, config.MaxAWSSecurityGroupRulesPerInterface)
+	}
+	if quotas.NetworkACLEntries > config.MaxAWSNetworkACLEntries {
+		return fmt.Errorf("invalid AWSQuotas, networkACLEntries %v should be <= %v", quotas.NetworkACLEntries,
+			config.MaxAWSNetworkACLEntries)
+	}
 	return nil
 }
 
@@ -86,4 +181,30 @@ func (o *Options) setDefaults() {
 	if o.config.CloudSyncInterval == 0 {
 		o.config.CloudSyncInterval = config.DefaultCloudSyncInterval
 	}
+	if len(o.config.NamedPortTagPrefix) == 0 {
+		o.config.NamedPortTagPrefix = config.DefaultNamedPortTagPrefix
+	}
+	if len(o.config.PodAddressTranslation) == 0 {
+		o.config.PodAddressTranslation = config.DefaultPodAddressTranslation
+	}
+	if len(o.config.CrossVpcAddressType) == 0 {
+		o.config.CrossVpcAddressType = config.DefaultCrossVpcAddressType
+	}
+	if o.config.FQDNRefreshInterval == 0 {
+		o.config.FQDNRefreshInterval = config.DefaultFQDNRefreshInterval
+	}
+	if o.config.AWSQuotas.SecurityGroupRules == 0 {
+		o.config.AWSQuotas.SecurityGroupRules = config.DefaultAWSSecurityGroupRules
+	}
+	if o.config.AWSQuotas.SecurityGroupsPerInterface == 0 {
+		o.config.AWSQuotas.SecurityGroupsPerInterface = config.DefaultAWSSecurityGroupsPerInterface
+	}
+	if o.config.AWSQuotas.NetworkACLEntries == 0 {
+		o.config.AWSQuotas.NetworkACLEntries = config.DefaultAWSNetworkACLEntries
+	}
+	for i := range o.config.CloudProviderPlugins {
+		if o.config.CloudProviderPlugins[i].TimeoutInSeconds == 0 {
+			o.config.CloudProviderPlugins[i].TimeoutInSeconds = config.DefaultPluginTimeout
+		}
+	}
 }
@@ -42,11 +42,137 @@ func TestOptions(t *testing.T) {
 				CloudSyncInterval:   30,
 			},
 			expectedErr: "invalid CloudSyncInterval",
+		}, {
+			name: "Invalid PodAddressTranslation",
+			config: &config.ControllerConfig{
+				PodAddressTranslation: "EgressIP",
+			},
+			expectedErr: "invalid PodAddressTranslation",
+		}, {
+			name: "Invalid CrossVpcAddressType",
+			config: &config.ControllerConfig{
+				CrossVpcAddressType: "Hostname",
+			},
+			expectedErr: "invalid CrossVpcAddressType",
+		}, {
+			name: "Invalid FQDNRefreshInterval",
+			config: &config.ControllerConfig{
+				FQDNRefreshInterval: -1,
+			},
+			expectedErr: "invalid FQDNRefreshInterval",
 		}, {
 			name:        "Empty config",
 			config:      &config.ControllerConfig{},
 			expectedErr: "",
 		},
+		{
+			name: "Relative credential file root",
+			config: &config.ControllerConfig{
+				CredentialSource: config.CredentialSourceConfig{FileRoot: "credentials"},
+			},
+			expectedErr: "fileRoot credentials should be an absolute path",
+		},
+		{
+			name: "Vault address without TLS",
+			config: &config.ControllerConfig{
+				CredentialSource: config.CredentialSourceConfig{VaultAddresses: []string{"http://vault.example.com:8200"}},
+			},
+			expectedErr: "should be an https URL",
+		},
+		{
+			name: "Valid credential source",
+			config: &config.ControllerConfig{
+				CredentialSource: config.CredentialSourceConfig{
+					FileRoot:       "/etc/nephe/credentials",
+					VaultAddresses: []string{"https://vault.example.com:8200"},
+				},
+			},
+			expectedErr: "",
+		},
+		{
+			name: "AWS security groups per interface above aws limit",
+			config: &config.ControllerConfig{
+				AWSQuotas: config.AWSQuotaConfig{SecurityGroupsPerInterface: 17},
+			},
+			expectedErr: "securityGroupsPerInterface 17 should be <= 16",
+		},
+		{
+			name: "AWS security group rules per interface above aws limit",
+			config: &config.ControllerConfig{
+				AWSQuotas: config.AWSQuotaConfig{SecurityGroupRules: 250},
+			},
+			expectedErr: "securityGroupRules 250 times securityGroupsPerInterface 5 should be <= 1000",
+		},
+		{
+			name: "AWS network acl entries above aws limit",
+			config: &config.ControllerConfig{
+				AWSQuotas: config.AWSQuotaConfig{NetworkACLEntries: 41},
+			},
+			expectedErr: "networkACLEntries 41 should be <= 40",
+		},
+		{
+			name: "Valid AWS quotas",
+			config: &config.ControllerConfig{
+				AWSQuotas: config.AWSQuotaConfig{SecurityGroupRules: 200, SecurityGroupsPerInterface: 5, NetworkACLEntries: 40},
+			},
+			expectedErr: "",
+		},
+		{
+			name: "Cloud provider plugin without address",
+			config: &config.ControllerConfig{
+				CloudProviderPlugins: []config.CloudProviderPluginConfig{{ProviderType: "Oracle"}},
+			},
+			expectedErr: "providerType and address are required",
+		},
+		{
+			name: "Cloud provider plugin with builtin provider type",
+			config: &config.ControllerConfig{
+				CloudProviderPlugins: []config.CloudProviderPluginConfig{{ProviderType: "AWS", Address: "localhost:50051"}},
+			},
+			expectedErr: "providerType AWS is already registered",
+		},
+		{
+			name: "Cloud provider plugin on TCP without TLS",
+			config: &config.ControllerConfig{
+				CloudProviderPlugins: []config.CloudProviderPluginConfig{{ProviderType: "Oracle", Address: "localhost:50051"}},
+			},
+			expectedErr: "should be a unix domain socket unless tls is configured",
+		},
+		{
+			name: "Cloud provider plugin with incomplete TLS",
+			config: &config.ControllerConfig{
+				CloudProviderPlugins: []config.CloudProviderPluginConfig{{
+					ProviderType: "Oracle",
+					Address:      "localhost:50051",
+					TLS:          &config.CloudProviderPluginTLSConfig{CAFile: "/etc/nephe/plugin/ca.crt"},
+				}},
+			},
+			expectedErr: "requires caFile, certFile and keyFile",
+		},
+		{
+			name: "Valid cloud provider plugin",
+			config: &config.ControllerConfig{
+				CloudProviderPlugins: []config.CloudProviderPluginConfig{
+					{ProviderType: "Oracle", Address: "unix:///var/run/nephe/oracle.sock"},
+				},
+			},
+			expectedErr: "",
+		},
+		{
+			name: "Valid cloud provider plugin with TLS",
+			config: &config.ControllerConfig{
+				CloudProviderPlugins: []config.CloudProviderPluginConfig{{
+					ProviderType: "Oracle",
+					Address:      "oracle-plugin:50051",
+					TLS: &config.CloudProviderPluginTLSConfig{
+						CAFile:   "/etc/nephe/plugin/ca.crt",
+						CertFile: "/etc/nephe/plugin/tls.crt",
+						KeyFile:  "/etc/nephe/plugin/tls.key",
+					},
+				}},
+			},
+			expectedErr: "",
+		},
 		{
 			name: "Valid input",
 			config: &config.ControllerConfig{
@@ -55,6 +181,13 @@ func TestOptions(t *testing.T) {
 			},
 			expectedErr: "",
 		},
+		{
+			name: "Valid PodAddressTranslation",
+			config: &config.ControllerConfig{
+				PodAddressTranslation: config.PodAddressTranslationNodeIP,
+			},
+			expectedErr: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
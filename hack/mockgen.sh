@@ -12,6 +12,10 @@ MOCKGEN_TARGETS=(
   "aws pkg/cloud-provider/cloudapi/aws/aws_services"
   "azure pkg/cloud-provider/cloudapi/azure/azure_api_wrappers"
   "azure pkg/cloud-provider/cloudapi/azure/azure_services"
+  "gcp pkg/cloud-provider/cloudapi/gcp/gcp_api_wrappers"
+  "gcp pkg/cloud-provider/cloudapi/gcp/gcp_services"
+  "openstack pkg/cloud-provider/cloudapi/openstack/openstack_api_wrappers"
+  "openstack pkg/cloud-provider/cloudapi/openstack/openstack_services"
 )
 for target in "${MOCKGEN_TARGETS[@]}"; do
   read -r package name <<<"${target}"
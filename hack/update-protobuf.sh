@@ -0,0 +1,30 @@
+#!/usr/bin/env bash
+
+# Copyright 2023 Antrea Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# Generate Go messages and gRPC stubs of the out-of-tree cloud provider plugin API.
+PROTO_FILES=(
+  "apis/plugin/v1alpha1/cloudprovider.proto"
+)
+for file in "${PROTO_FILES[@]}"; do
+  protoc \
+    --go_out=. --go_opt=paths=source_relative \
+    --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+    "${file}"
+done
@@ -52,6 +52,7 @@ var (
 	errorMsgSameAccountUsage     = "account in a namespace can be owner of only one CloudEntitySelector"
 	errorMsgOwnerAccountNotFound = "failed to find owner account"
 	errorMsgInvalidCloudType     = "invalid cloud provider type"
+	errorMsgAgentedRequired      = "agented flag must be set to true in every vmSelector of a VSphere or Static account"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -294,6 +295,18 @@ func (v *CESValidator) validateMatchSections(selector *v1alpha1.CloudEntitySelec
 		return err
 	}
 
+	// In VSphere and Static, networks do not provide security groups, so only agented VMs are supported.
+	if cloudProviderType == runtimev1alpha1.VSphereCloudProvider || cloudProviderType == runtimev1alpha1.StaticCloudProvider {
+		if len(selector.Spec.VMSelector) == 0 {
+			return fmt.Errorf(errorMsgAgentedRequired)
+		}
+		for _, m := range selector.Spec.VMSelector {
+			if !m.Agented {
+				return fmt.Errorf(errorMsgAgentedRequired)
+			}
+		}
+	}
+
 	// In Azure, Vpc Name is not supported in vpcMatch.
 	// In AWS, Vpc name(in vpcMatch section) with either vm id or vm name(in vmMatch section) is not supported.
 	if cloudProviderType == runtimev1alpha1.AzureCloudProvider {
@@ -328,6 +328,93 @@ var _ = Describe("CloudEntitySelectorWebhook", func() {
 			Expect(response.String()).Should(ContainSubstring(errorMsgUnsupportedAgented))
 		})
 
+		It("Validate vmSelector without Agented = true in VSphere", func() {
+			account.Spec.AWSConfig = nil
+			account.Spec.VSphereConfig = &v1alpha1.CloudProviderAccountVSphereConfig{
+				Endpoint: "https://vcenter.example.com/sdk",
+				SecretRef: &v1alpha1.SecretReference{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+					Key:       credentials,
+				},
+			}
+			err = fakeClient.Create(context.Background(), account)
+			Expect(err).Should(BeNil())
+
+			encodedSelector, _ = json.Marshal(selector)
+			selectorReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudEntitySelector",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudEntitySelectors",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedSelector,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), selectorReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.String()).Should(ContainSubstring(errorMsgAgentedRequired))
+
+			selector.Spec.VMSelector[0].Agented = true
+			encodedSelector, _ = json.Marshal(selector)
+			selectorReq.Object.Raw = encodedSelector
+			response = validator.Handle(context.Background(), selectorReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+
+		It("Validate vmSelector without Agented = true in Static", func() {
+			account.Spec.AWSConfig = nil
+			account.Spec.StaticConfig = &v1alpha1.CloudProviderAccountStaticConfig{
+				Region: "dc01",
+				Hosts: []v1alpha1.StaticHost{
+					{ID: "host01", Network: "rack01"},
+				},
+			}
+			err = fakeClient.Create(context.Background(), account)
+			Expect(err).Should(BeNil())
+
+			encodedSelector, _ = json.Marshal(selector)
+			selectorReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudEntitySelector",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudEntitySelectors",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedSelector,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), selectorReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.String()).Should(ContainSubstring(errorMsgAgentedRequired))
+		})
+
 		It("Validate vpcMatch matchName in Azure", func() {
 			account = &v1alpha1.CloudProviderAccount{
 				ObjectMeta: metav1.ObjectMeta{
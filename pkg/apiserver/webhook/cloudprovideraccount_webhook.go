@@ -19,7 +19,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/endpoints"
@@ -28,11 +30,16 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudprovider "antrea.io/nephe/pkg/cloud-provider"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+	cloudutils "antrea.io/nephe/pkg/cloud-provider/utils"
 	"antrea.io/nephe/pkg/controllers/cloud"
 	"antrea.io/nephe/pkg/controllers/utils"
 )
@@ -49,8 +56,35 @@ var (
 	errorMsgMissingClientDetails = "client id and client key cannot be blank or empty"
 	errorMsgMissingTenantID      = "tenant id cannot be blank or empty"
 	errorMsgMissingSubscritionID = "subscription id cannot be blank or empty"
+	errorMsgMissingProjectID     = "project id cannot be blank or empty"
+	errorMsgInvalidServiceKey    = "service account key must be a valid service account JSON key"
 	errorMsgInvalidRequest       = "invalid admission webhook request"
 	errorMsgDecodeFail           = "unable to decode the secret"
+	errorMsgMissingProvider      = "plugin provider cannot be blank or empty"
+	errorMsgInvalidProvider      = "plugin provider must not be a built-in cloud provider"
+	errorMsgMultipleProviders    = "pluginConfig cannot be specified along with awsConfig, azureConfig, gcpConfig, " +
+		"openstackConfig, simulatedConfig, staticConfig or vsphereConfig"
+	errorMsgConfigMapNotFound   = "unable to get configmap"
+	errorMsgMissingConfigMapKey = "configmap does not have the key"
+	errorMsgMissingConfigMapRef = "configMapRef cannot be empty"
+	errorMsgInvalidEndpoint     = "endpoint must be a valid vCenter URL"
+	errorMsgMissingUserPassword = "username and password cannot be blank or empty"
+	errorMsgInvalidAuthURL      = "authURL must be a valid keystone URL"
+	errorMsgMissingHostID       = "host id cannot be blank or empty"
+	errorMsgInvalidHostID       = "host id must be a valid DNS subdomain name"
+	errorMsgDuplicateHostID     = "host id must be unique in the account"
+	errorMsgMissingHostNetwork  = "host network cannot be blank or empty"
+	errorMsgDuplicateNicName    = "network interface name must be unique in the host"
+	errorMsgInvalidHostIP       = "host ip must be a valid IP address"
+	errorMsgMissingRoleName     = "organization role name cannot be blank or empty"
+	errorMsgMissingSecretRef    = "secretRef cannot be empty with Secret credential mode"
+	errorMsgInvalidAccountID    = "organization account id must be a 12 digit AWS account id"
+	errorMsgInvalidCredSource   = "exactly one of file and vault must be specified in credentialSource"
+	errorMsgInvalidCredFile     = "invalid credential file path"
+	errorMsgInvalidVaultAddress = "invalid vault address"
+	errorMsgMissingVaultPath    = "vault secret path cannot be blank or empty"
+	errorMsgMissingVaultAuth    = "either vault role or token secret must be specified"
+	errorMsgInvalidVaultEngine  = "vault secrets engine is not supported by the cloud provider"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -144,6 +178,12 @@ func (v *CPAValidator) validateCreate(req admission.Request) admission.Response
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	if cpa.Spec.PluginConfig != nil {
+		if err := v.validatePluginAccount(cpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
 	cloudProviderType, err := utils.GetAccountProviderType(cpa)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
@@ -158,6 +198,26 @@ func (v *CPAValidator) validateCreate(req admission.Request) admission.Response
 		if err := v.validateAzureAccount(cpa); err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
+	case runtimev1alpha1.GCPCloudProvider:
+		if err := v.validateGCPAccount(cpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.OpenStackCloudProvider:
+		if err := v.validateOpenStackAccount(cpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.SimulatedCloudProvider:
+		if err := v.validateSimulatedAccount(cpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.StaticCloudProvider:
+		if err := v.validateStaticAccount(cpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.VSphereCloudProvider:
+		if err := v.validateVSphereAccount(cpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
 	}
 
 	if *cpa.Spec.PollIntervalInSeconds < MinPollInterval {
@@ -183,6 +243,12 @@ func (v *CPAValidator) validateUpdate(req admission.Request) admission.Response
 		}
 	}
 
+	if newCpa.Spec.PluginConfig != nil {
+		if err := v.validatePluginAccount(newCpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
 	cloudProviderType, err := utils.GetAccountProviderType(newCpa)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
@@ -197,6 +263,26 @@ func (v *CPAValidator) validateUpdate(req admission.Request) admission.Response
 		if err := v.validateAzureAccount(newCpa); err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
+	case runtimev1alpha1.GCPCloudProvider:
+		if err := v.validateGCPAccount(newCpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.OpenStackCloudProvider:
+		if err := v.validateOpenStackAccount(newCpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.SimulatedCloudProvider:
+		if err := v.validateSimulatedAccount(newCpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.StaticCloudProvider:
+		if err := v.validateStaticAccount(newCpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	case runtimev1alpha1.VSphereCloudProvider:
+		if err := v.validateVSphereAccount(newCpa); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
 	}
 
 	if *newCpa.Spec.PollIntervalInSeconds < MinPollInterval {
@@ -222,50 +308,97 @@ func (v *CPAValidator) validateAWSAccount(account *crdv1alpha1.CloudProviderAcco
 	})
 
 	awsConfig := account.Spec.AWSConfig
-
-	err := v.Client.Get(context.TODO(), types.NamespacedName{
-		Namespace: awsConfig.SecretRef.Namespace,
-		Name:      awsConfig.SecretRef.Name}, u)
-	if err != nil {
-		return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
-	}
-	data := u.Object["data"].(map[string]interface{})
-	decode, err := base64.StdEncoding.DecodeString(data[awsConfig.SecretRef.Key].(string))
-	if err != nil {
-		return fmt.Errorf("%s: %s", errorMsgDecodeFail, err.Error())
-	}
+	workloadIdentity := awsConfig.CredentialMode == crdv1alpha1.CredentialModeWorkloadIdentity
 
 	awsCredential := &crdv1alpha1.AwsAccountCredential{}
-	if err = json.Unmarshal(decode, awsCredential); err != nil {
-		return fmt.Errorf("%s: %s", errorMsgJsonUnmarshalFail, err.Error())
+	if awsConfig.SecretRef != nil {
+		err := v.Client.Get(context.TODO(), types.NamespacedName{
+			Namespace: awsConfig.SecretRef.Namespace,
+			Name:      awsConfig.SecretRef.Name}, u)
+		if err != nil {
+			return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
+		}
+		data := u.Object["data"].(map[string]interface{})
+		decode, err := base64.StdEncoding.DecodeString(data[awsConfig.SecretRef.Key].(string))
+		if err != nil {
+			return fmt.Errorf("%s: %s", errorMsgDecodeFail, err.Error())
+		}
+
+		if err = json.Unmarshal(decode, awsCredential); err != nil {
+			return fmt.Errorf("%s: %s", errorMsgJsonUnmarshalFail, err.Error())
+		}
+	} else if !workloadIdentity && awsConfig.CredentialSource == nil {
+		return fmt.Errorf(errorMsgMissingSecretRef)
 	}
 	// validate roleArn or A
-	if len(strings.TrimSpace(awsCredential.RoleArn)) != 0 {
+	if awsConfig.CredentialSource != nil {
+		// credentials of the source are retrieved by the account.
+		if err := validateCredentialSource(account.Namespace, awsConfig.CredentialSource, crdv1alpha1.VaultSecretEngineAWS); err != nil {
+			return err
+		}
+	} else if workloadIdentity {
+		v.Log.Info("Workload identity will be used for cloud-account access")
+	} else if len(strings.TrimSpace(awsCredential.RoleArn)) != 0 {
 		v.Log.Info("Role ARN configured will be used for cloud-account access")
 	} else if len(strings.TrimSpace(awsCredential.AccessKeyID)) == 0 ||
 		len(strings.TrimSpace(awsCredential.AccessKeySecret)) == 0 {
 		return fmt.Errorf(errorMsgMissingCredential)
 	}
 
-	if len(strings.TrimSpace(awsConfig.Region)) == 0 {
+	accountRegions := cloudutils.GetAccountRegions(awsConfig.Region, awsConfig.Regions)
+	if len(accountRegions) == 0 {
 		return fmt.Errorf(errorMsgMissingRegion)
 	}
 
 	// NOTE: currently only AWS standard partition regions supported (aws-cn, aws-us-gov etc are not
 	// supported). As we add support for other partitions, validation needs to be updated.
 	regions := endpoints.AwsPartition().Regions()
-	_, found := regions[awsConfig.Region]
-	if !found {
-		var supportedRegions []string
-		for key := range regions {
-			supportedRegions = append(supportedRegions, key)
+	for _, region := range accountRegions {
+		if region == crdv1alpha1.CloudProviderAccountAllRegions {
+			continue
+		}
+		if _, found := regions[region]; !found {
+			var supportedRegions []string
+			for key := range regions {
+				supportedRegions = append(supportedRegions, key)
+			}
+			return fmt.Errorf("%v %s [%v]", region, errorMsgInvalidRegion, supportedRegions)
 		}
-		return fmt.Errorf("%v %s [%v]", awsConfig.Region, errorMsgInvalidRegion, supportedRegions)
 	}
 
+	if awsConfig.Organization != nil {
+		return validateAWSOrganization(awsConfig.Organization)
+	}
+	return nil
+}
+
+// validateAWSOrganization validates the member accounts of an AWS Organization in CPA.
+func validateAWSOrganization(organization *crdv1alpha1.CloudProviderAccountAWSOrganizationConfig) error {
+	if len(strings.TrimSpace(organization.RoleName)) == 0 {
+		return fmt.Errorf(errorMsgMissingRoleName)
+	}
+	accountIDs := append(append([]string{}, organization.AllowedAccountIDs...), organization.DeniedAccountIDs...)
+	for _, accountID := range accountIDs {
+		if !isAWSAccountID(strings.TrimSpace(accountID)) {
+			return fmt.Errorf("%s: %s", errorMsgInvalidAccountID, accountID)
+		}
+	}
 	return nil
 }
 
+// isAWSAccountID returns true if id is a 12 digit AWS account ID.
+func isAWSAccountID(id string) bool {
+	if len(id) != 12 {
+		return false
+	}
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // validateAzureAccount validates parameters in CPA Azure account credentials.
 func (v *CPAValidator) validateAzureAccount(account *crdv1alpha1.CloudProviderAccount) error {
 	u := &unstructured.Unstructured{}
@@ -276,41 +409,344 @@ func (v *CPAValidator) validateAzureAccount(account *crdv1alpha1.CloudProviderAc
 	})
 
 	azureConfig := account.Spec.AzureConfig
+	secretMode := len(azureConfig.CredentialMode) == 0 || azureConfig.CredentialMode == crdv1alpha1.CredentialModeSecret
+
+	azureCredential := &crdv1alpha1.AzureAccountCredential{}
+	if azureConfig.SecretRef != nil {
+		err := v.Client.Get(context.TODO(), types.NamespacedName{
+			Namespace: azureConfig.SecretRef.Namespace,
+			Name:      azureConfig.SecretRef.Name}, u)
+		if err != nil {
+			return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
+		}
+		data := u.Object["data"].(map[string]interface{})
+		decode, err := base64.StdEncoding.DecodeString(data[azureConfig.SecretRef.Key].(string))
+		if err != nil {
+			return fmt.Errorf("%s: %s", errorMsgDecodeFail, err.Error())
+		}
+
+		if err = json.Unmarshal(decode, azureCredential); err != nil {
+			return fmt.Errorf("%s: %s", errorMsgJsonUnmarshalFail, err.Error())
+		}
+	} else if secretMode && azureConfig.CredentialSource == nil {
+		return fmt.Errorf(errorMsgMissingSecretRef)
+	}
+
+	// credentials of the source are retrieved by the account.
+	if azureConfig.CredentialSource != nil {
+		if err := validateCredentialSource(account.Namespace, azureConfig.CredentialSource,
+			crdv1alpha1.VaultSecretEngineAzure); err != nil {
+			return err
+		}
+	}
+	// validate subscription ID
+	if len(strings.TrimSpace(azureCredential.SubscriptionID)) == 0 && azureConfig.CredentialSource == nil &&
+		(secretMode || len(azureConfig.SubscriptionIDs) == 0) {
+		return fmt.Errorf(errorMsgMissingSubscritionID)
+	}
+	// tenant and client of workload identity and managed identity may be provided by the environment of the Pod.
+	if secretMode && azureConfig.CredentialSource == nil {
+		// validate tenant ID
+		if len(strings.TrimSpace(azureCredential.TenantID)) == 0 {
+			return fmt.Errorf(errorMsgMissingTenantID)
+		}
+		// validate credentials
+		if len(strings.TrimSpace(azureCredential.ClientID)) == 0 || len(strings.TrimSpace(azureCredential.ClientKey)) == 0 {
+			return fmt.Errorf(errorMsgMissingClientDetails)
+		}
+	}
+
+	// validate region
+	if len(cloudutils.GetAccountRegions(azureConfig.Region, azureConfig.Regions)) == 0 {
+		return fmt.Errorf(errorMsgMissingRegion)
+	}
+
+	return nil
+}
+
+// validateCredentialSource validates a credential source of an account in a namespace, whose file must be in the
+// directory of the namespace, and whose Vault server must be allowed by nephe-controller configuration. The Vault
+// secret may be of the KV engine or the secrets engine of the cloud provider.
+func validateCredentialSource(namespace string, source *crdv1alpha1.CloudProviderAccountCredentialSource,
+	engine crdv1alpha1.VaultSecretEngine) error {
+	if (source.File == nil) == (source.Vault == nil) {
+		return fmt.Errorf(errorMsgInvalidCredSource)
+	}
+	if source.File != nil {
+		if err := credentialsource.CheckFilePath(namespace, source.File.Path); err != nil {
+			return fmt.Errorf("%s: %v", errorMsgInvalidCredFile, err)
+		}
+		return nil
+	}
+
+	vault := source.Vault
+	if err := credentialsource.CheckVaultAddress(vault.Address); err != nil {
+		return fmt.Errorf("%s: %v", errorMsgInvalidVaultAddress, err)
+	}
+	if len(strings.Trim(vault.Path, "/ ")) == 0 {
+		return fmt.Errorf(errorMsgMissingVaultPath)
+	}
+	if len(strings.TrimSpace(vault.Role)) == 0 && vault.TokenSecretRef == nil {
+		return fmt.Errorf(errorMsgMissingVaultAuth)
+	}
+	if len(vault.Engine) != 0 && vault.Engine != crdv1alpha1.VaultSecretEngineKV && vault.Engine != engine {
+		return fmt.Errorf("%s: %v", errorMsgInvalidVaultEngine, vault.Engine)
+	}
+	return nil
+}
+
+// validateGCPAccount validates parameters in CPA GCP account credentials.
+func (v *CPAValidator) validateGCPAccount(account *crdv1alpha1.CloudProviderAccount) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+
+	gcpConfig := account.Spec.GCPConfig
 
 	err := v.Client.Get(context.TODO(), types.NamespacedName{
-		Namespace: azureConfig.SecretRef.Namespace,
-		Name:      azureConfig.SecretRef.Name}, u)
+		Namespace: gcpConfig.SecretRef.Namespace,
+		Name:      gcpConfig.SecretRef.Name}, u)
 	if err != nil {
 		return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
 	}
 	data := u.Object["data"].(map[string]interface{})
-	decode, err := base64.StdEncoding.DecodeString(data[azureConfig.SecretRef.Key].(string))
+	decode, err := base64.StdEncoding.DecodeString(data[gcpConfig.SecretRef.Key].(string))
 	if err != nil {
 		return fmt.Errorf("%s: %s", errorMsgDecodeFail, err.Error())
 	}
 
-	azureCredential := &crdv1alpha1.AzureAccountCredential{}
-	if err = json.Unmarshal(decode, azureCredential); err != nil {
+	gcpCredential := &crdv1alpha1.GcpAccountCredential{}
+	if err = json.Unmarshal(decode, gcpCredential); err != nil {
 		return fmt.Errorf("%s: %s", errorMsgJsonUnmarshalFail, err.Error())
 	}
 
-	// validate subscription ID
-	if len(strings.TrimSpace(azureCredential.SubscriptionID)) == 0 {
-		return fmt.Errorf(errorMsgMissingSubscritionID)
+	// validate project ID
+	if len(strings.TrimSpace(gcpCredential.ProjectID)) == 0 {
+		return fmt.Errorf(errorMsgMissingProjectID)
 	}
-	// validate tenant ID
-	if len(strings.TrimSpace(azureCredential.TenantID)) == 0 {
-		return fmt.Errorf(errorMsgMissingTenantID)
+	// validate service account key, it is the json key file downloaded for the service account.
+	serviceAccountKey := struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}{}
+	if err = json.Unmarshal([]byte(gcpCredential.ServiceAccountKey), &serviceAccountKey); err != nil ||
+		serviceAccountKey.Type != "service_account" || len(serviceAccountKey.ClientEmail) == 0 ||
+		len(serviceAccountKey.PrivateKey) == 0 {
+		return fmt.Errorf(errorMsgInvalidServiceKey)
 	}
-	// validate credentials
-	if len(strings.TrimSpace(azureCredential.ClientID)) == 0 || len(strings.TrimSpace(azureCredential.ClientKey)) == 0 {
-		return fmt.Errorf(errorMsgMissingClientDetails)
+
+	// validate region
+	if len(strings.TrimSpace(gcpConfig.Region)) == 0 {
+		return fmt.Errorf(errorMsgMissingRegion)
+	}
+
+	return nil
+}
+
+// validateOpenStackAccount validates parameters in CPA OpenStack account credentials.
+func (v *CPAValidator) validateOpenStackAccount(account *crdv1alpha1.CloudProviderAccount) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+
+	openstackConfig := account.Spec.OpenStackConfig
+
+	err := v.Client.Get(context.TODO(), types.NamespacedName{
+		Namespace: openstackConfig.SecretRef.Namespace,
+		Name:      openstackConfig.SecretRef.Name}, u)
+	if err != nil {
+		return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
+	}
+	data := u.Object["data"].(map[string]interface{})
+	decode, err := base64.StdEncoding.DecodeString(data[openstackConfig.SecretRef.Key].(string))
+	if err != nil {
+		return fmt.Errorf("%s: %s", errorMsgDecodeFail, err.Error())
+	}
+
+	openstackCredential := &crdv1alpha1.OpenStackAccountCredential{}
+	if err = json.Unmarshal(decode, openstackCredential); err != nil {
+		return fmt.Errorf("%s: %s", errorMsgJsonUnmarshalFail, err.Error())
+	}
+
+	// validate username and password
+	if len(strings.TrimSpace(openstackCredential.Username)) == 0 || len(openstackCredential.Password) == 0 {
+		return fmt.Errorf(errorMsgMissingUserPassword)
+	}
+	// validate project ID
+	if len(strings.TrimSpace(openstackCredential.ProjectID)) == 0 {
+		return fmt.Errorf(errorMsgMissingProjectID)
+	}
+
+	// validate keystone URL
+	authURL, err := url.Parse(strings.TrimSpace(openstackConfig.AuthURL))
+	if err != nil || len(authURL.Host) == 0 {
+		return fmt.Errorf(errorMsgInvalidAuthURL)
+	}
+
+	// validate region
+	if len(strings.TrimSpace(openstackConfig.Region)) == 0 {
+		return fmt.Errorf(errorMsgMissingRegion)
+	}
+
+	return nil
+}
+
+// validateSimulatedAccount validates a CPA of the in-memory simulated cloud.
+func (v *CPAValidator) validateSimulatedAccount(account *crdv1alpha1.CloudProviderAccount) error {
+	simulatedConfig := account.Spec.SimulatedConfig
+	if simulatedConfig.ConfigMapRef == nil {
+		return fmt.Errorf(errorMsgMissingConfigMapRef)
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "ConfigMap",
+		Version: "v1",
+	})
+	err := v.Client.Get(context.TODO(), types.NamespacedName{
+		Namespace: simulatedConfig.ConfigMapRef.Namespace,
+		Name:      simulatedConfig.ConfigMapRef.Name}, u)
+	if err != nil {
+		return fmt.Errorf("%s: %s", errorMsgConfigMapNotFound, err.Error())
+	}
+	data, _ := u.Object["data"].(map[string]interface{})
+	if _, found := data[simulatedConfig.ConfigMapRef.Key]; !found {
+		return fmt.Errorf("%s %s", errorMsgMissingConfigMapKey, simulatedConfig.ConfigMapRef.Key)
 	}
 
 	// validate region
-	if len(strings.TrimSpace(azureConfig.Region)) == 0 {
+	if len(strings.TrimSpace(simulatedConfig.Region)) == 0 {
 		return fmt.Errorf(errorMsgMissingRegion)
 	}
 
 	return nil
 }
+
+// validateStaticAccount validates the hosts declared in a CPA of static hosts.
+func (v *CPAValidator) validateStaticAccount(account *crdv1alpha1.CloudProviderAccount) error {
+	hostIDs := make(map[string]struct{})
+	for _, host := range account.Spec.StaticConfig.Hosts {
+		hostID := strings.ToLower(strings.TrimSpace(host.ID))
+		if len(hostID) == 0 {
+			return fmt.Errorf(errorMsgMissingHostID)
+		}
+		if errs := validation.IsDNS1123Subdomain(hostID); len(errs) != 0 {
+			return fmt.Errorf("%s: %s", errorMsgInvalidHostID, host.ID)
+		}
+		if _, found := hostIDs[hostID]; found {
+			return fmt.Errorf("%s: %s", errorMsgDuplicateHostID, host.ID)
+		}
+		hostIDs[hostID] = struct{}{}
+
+		if len(strings.TrimSpace(host.Network)) == 0 {
+			return fmt.Errorf("%s: %s", errorMsgMissingHostNetwork, host.ID)
+		}
+
+		nicNames := make(map[string]struct{})
+		for _, nic := range host.NetworkInterfaces {
+			nicName := strings.ToLower(strings.TrimSpace(nic.Name))
+			if _, found := nicNames[nicName]; found {
+				return fmt.Errorf("%s: %s", errorMsgDuplicateNicName, host.ID)
+			}
+			nicNames[nicName] = struct{}{}
+			for _, ip := range nic.IPs {
+				if net.ParseIP(strings.TrimSpace(ip)) == nil {
+					return fmt.Errorf("%s: %s", errorMsgInvalidHostIP, ip)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateVSphereAccount validates parameters in CPA vSphere account credentials.
+func (v *CPAValidator) validateVSphereAccount(account *crdv1alpha1.CloudProviderAccount) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+
+	vsphereConfig := account.Spec.VSphereConfig
+
+	err := v.Client.Get(context.TODO(), types.NamespacedName{
+		Namespace: vsphereConfig.SecretRef.Namespace,
+		Name:      vsphereConfig.SecretRef.Name}, u)
+	if err != nil {
+		return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
+	}
+	data := u.Object["data"].(map[string]interface{})
+	decode, err := base64.StdEncoding.DecodeString(data[vsphereConfig.SecretRef.Key].(string))
+	if err != nil {
+		return fmt.Errorf("%s: %s", errorMsgDecodeFail, err.Error())
+	}
+
+	vsphereCredential := &crdv1alpha1.VSphereAccountCredential{}
+	if err = json.Unmarshal(decode, vsphereCredential); err != nil {
+		return fmt.Errorf("%s: %s", errorMsgJsonUnmarshalFail, err.Error())
+	}
+
+	// validate username and password
+	if len(strings.TrimSpace(vsphereCredential.Username)) == 0 || len(vsphereCredential.Password) == 0 {
+		return fmt.Errorf(errorMsgMissingUserPassword)
+	}
+
+	// validate vCenter endpoint
+	endpoint, err := url.Parse(strings.TrimSpace(vsphereConfig.Endpoint))
+	if err != nil || len(endpoint.Host) == 0 {
+		return fmt.Errorf(errorMsgInvalidEndpoint)
+	}
+
+	return nil
+}
+
+// validatePluginAccount validates a CPA served by an out-of-tree cloud provider plugin.
+func (v *CPAValidator) validatePluginAccount(account *crdv1alpha1.CloudProviderAccount) error {
+	if account.Spec.AWSConfig != nil || account.Spec.AzureConfig != nil || account.Spec.GCPConfig != nil ||
+		account.Spec.OpenStackConfig != nil || account.Spec.SimulatedConfig != nil || account.Spec.StaticConfig != nil ||
+		account.Spec.VSphereConfig != nil {
+		return fmt.Errorf(errorMsgMultipleProviders)
+	}
+
+	pluginConfig := account.Spec.PluginConfig
+	provider := runtimev1alpha1.CloudProvider(strings.TrimSpace(pluginConfig.Provider))
+	if len(provider) == 0 {
+		return fmt.Errorf(errorMsgMissingProvider)
+	}
+	switch provider {
+	case runtimev1alpha1.AWSCloudProvider, runtimev1alpha1.AzureCloudProvider, runtimev1alpha1.GCPCloudProvider,
+		runtimev1alpha1.OpenStackCloudProvider, runtimev1alpha1.SimulatedCloudProvider, runtimev1alpha1.StaticCloudProvider,
+		runtimev1alpha1.VSphereCloudProvider:
+		return fmt.Errorf(errorMsgInvalidProvider)
+	}
+	if _, err := cloudprovider.GetCloudInterface(cloudcommon.ProviderType(provider)); err != nil {
+		return err
+	}
+
+	// validate secret, if configured.
+	if pluginConfig.SecretRef != nil {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   "",
+			Kind:    "Secret",
+			Version: "v1",
+		})
+		err := v.Client.Get(context.TODO(), types.NamespacedName{
+			Namespace: pluginConfig.SecretRef.Namespace,
+			Name:      pluginConfig.SecretRef.Name}, u)
+		if err != nil {
+			return fmt.Errorf("%s: %s", errorMsgSecretNotConfigured, err.Error())
+		}
+	}
+	return nil
+}
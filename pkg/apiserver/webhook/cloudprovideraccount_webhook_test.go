@@ -45,6 +45,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+	"antrea.io/nephe/pkg/config"
 	"antrea.io/nephe/pkg/controllers/cloud"
 	"antrea.io/nephe/pkg/controllers/utils"
 	"antrea.io/nephe/pkg/logging"
@@ -59,6 +61,7 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			credentials               = "credentials"
 			awsAccount                *v1alpha1.CloudProviderAccount
 			azureAccount              *v1alpha1.CloudProviderAccount
+			gcpAccount                *v1alpha1.CloudProviderAccount
 			account                   *v1alpha1.CloudProviderAccount
 			s1                        *corev1.Secret
 			validator                 *CPAValidator
@@ -72,6 +75,11 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			accountReq                admission.Request
 		)
 		BeforeEach(func() {
+			err = credentialsource.SetConfig(&config.CredentialSourceConfig{
+				FileRoot:       "/etc/nephe/credentials",
+				VaultAddresses: []string{"https://vault.example.com:8200"},
+			})
+			Expect(err).Should(BeNil())
 			awsAccount = &v1alpha1.CloudProviderAccount{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      testAccountNamespacedName.Name,
@@ -108,6 +116,24 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 				},
 			}
 
+			gcpAccount = &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					GCPConfig: &v1alpha1.CloudProviderAccountGCPConfig{
+						Region: "us-central1",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+					},
+				},
+			}
+
 			s1 = &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      testSecretNamespacedName.Name,
@@ -458,6 +484,58 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
 			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidRegion))
 		})
+		It("Validate invalid account ID in AWS organization", func() {
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			awsAccount = &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					AWSConfig: &v1alpha1.CloudProviderAccountAWSConfig{
+						Region: "us-west-1",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+						Organization: &v1alpha1.CloudProviderAccountAWSOrganizationConfig{
+							RoleName:         "OrganizationAccountAccessRole",
+							DeniedAccountIDs: []string{"1234"},
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(awsAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidAccountID))
+		})
 		It("Validate AWS Access and Secret Key with Session Token", func() {
 			cred := `{"accessKeyId": "keyId", "accessKeySecret": "keySecret", "sessionToken": "token"}`
 			s1 := &corev1.Secret{
@@ -629,10 +707,38 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
 		})
-		It("Validate AWS account add with decode error", func() {
-			err = fakeClient.Create(context.Background(), s1)
-			Expect(err).Should(BeNil())
+		It("Validate AWS workload identity without secret", func() {
+			awsAccount.Spec.AWSConfig.SecretRef = nil
+			awsAccount.Spec.AWSConfig.CredentialMode = v1alpha1.CredentialModeWorkloadIdentity
+			encodedAccount, _ = json.Marshal(awsAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
 
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate AWS missing secret with Secret credential mode", func() {
+			awsAccount.Spec.AWSConfig.SecretRef = nil
+			encodedAccount, _ = json.Marshal(awsAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
 					Kind: metav1.GroupVersionKind{
@@ -648,27 +754,63 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 					Name:      testAccountNamespacedName.Name,
 					Namespace: testAccountNamespacedName.Namespace,
 					Operation: v1.Create,
-					Object:    runtime.RawExtension{},
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
 				},
 			}
 
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingSecretRef))
 		})
-		It("Validate AWS secret unmarshall error", func() {
-			s1 = &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testSecretNamespacedName.Name,
-					Namespace: testSecretNamespacedName.Namespace,
+		It("Validate AWS Vault credential source without secret", func() {
+			awsAccount.Spec.AWSConfig.SecretRef = nil
+			awsAccount.Spec.AWSConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				Vault: &v1alpha1.VaultCredentialSource{
+					Address: "https://vault.example.com:8200",
+					Path:    "aws/creds/nephe",
+					Engine:  v1alpha1.VaultSecretEngineAWS,
+					Role:    "nephe",
 				},
-				Data: map[string][]byte{
-					credentials: []byte(""),
+			}
+			encodedAccount, _ = json.Marshal(awsAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
 				},
 			}
-			err = fakeClient.Create(context.Background(), s1)
-			Expect(err).Should(BeNil())
 
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate AWS credential source with Azure Vault secrets engine", func() {
+			awsAccount.Spec.AWSConfig.SecretRef = nil
+			awsAccount.Spec.AWSConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				Vault: &v1alpha1.VaultCredentialSource{
+					Address: "https://vault.example.com:8200",
+					Path:    "azure/creds/nephe",
+					Engine:  v1alpha1.VaultSecretEngineAzure,
+					Role:    "nephe",
+				},
+			}
 			encodedAccount, _ = json.Marshal(awsAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
@@ -694,9 +836,13 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
-			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgJsonUnmarshalFail))
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidVaultEngine))
 		})
-		It("Validate when Azure secret not configured", func() {
+		It("Validate Azure credential source with relative file path", func() {
+			azureAccount.Spec.AzureConfig.SecretRef = nil
+			azureAccount.Spec.AzureConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				File: &v1alpha1.FileCredentialSource{Path: "credentials/azure.json"},
+			}
 			encodedAccount, _ = json.Marshal(azureAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
@@ -722,23 +868,19 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
-			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgSecretNotConfigured))
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidCredFile))
 		})
-		It("Validate an Azure Account add", func() {
-			cred := `{"subscriptionId": "SubID","clientId": "ClientID","tenantId": "TenantID", "clientKey": "ClientKey"}`
-			s1 := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testSecretNamespacedName.Name,
-					Namespace: testSecretNamespacedName.Namespace,
-				},
-				Data: map[string][]byte{
-					credentials: []byte(cred),
+		It("Validate AWS Vault credential source with Vault server without TLS", func() {
+			awsAccount.Spec.AWSConfig.SecretRef = nil
+			awsAccount.Spec.AWSConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				Vault: &v1alpha1.VaultCredentialSource{
+					Address: "http://vault.example.com:8200",
+					Path:    "aws/creds/nephe",
+					Engine:  v1alpha1.VaultSecretEngineAWS,
+					Role:    "nephe",
 				},
 			}
-			err = fakeClient.Create(context.Background(), s1)
-			Expect(err).Should(BeNil())
-
-			encodedAccount, _ = json.Marshal(azureAccount)
+			encodedAccount, _ = json.Marshal(awsAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
 					Kind: metav1.GroupVersionKind{
@@ -762,21 +904,51 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
-			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidVaultAddress))
 		})
-		It("Validate Azure secret unmarshall error", func() {
-			s1 := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testSecretNamespacedName.Name,
-					Namespace: testSecretNamespacedName.Namespace,
+		It("Validate AWS Vault credential source with Vault server not allowed by configuration", func() {
+			awsAccount.Spec.AWSConfig.SecretRef = nil
+			awsAccount.Spec.AWSConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				Vault: &v1alpha1.VaultCredentialSource{
+					Address: "https://vault.attacker.com:8200",
+					Path:    "aws/creds/nephe",
+					Engine:  v1alpha1.VaultSecretEngineAWS,
+					Role:    "nephe",
 				},
-				Data: map[string][]byte{
-					credentials: []byte("dummy"),
+			}
+			encodedAccount, _ = json.Marshal(awsAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
 				},
 			}
-			err = fakeClient.Create(context.Background(), s1)
-			Expect(err).Should(BeNil())
 
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidVaultAddress))
+		})
+		It("Validate Azure credential source with file of another namespace", func() {
+			azureAccount.Spec.AzureConfig.SecretRef = nil
+			azureAccount.Spec.AzureConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				File: &v1alpha1.FileCredentialSource{Path: "/etc/nephe/credentials/namespace01/../namespace02/azure.json"},
+			}
 			encodedAccount, _ = json.Marshal(azureAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
@@ -801,23 +973,14 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
-			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgJsonUnmarshalFail))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidCredFile))
 		})
-		It("Validate missing Azure subscriptionId", func() {
-			cred := `{"clientId": "ClientID","tenantId": "TenantID", "clientKey": "ClientKey"}`
-			s1 := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testSecretNamespacedName.Name,
-					Namespace: testSecretNamespacedName.Namespace,
-				},
-				Data: map[string][]byte{
-					credentials: []byte(cred),
-				},
+		It("Validate Azure credential source with file of account namespace", func() {
+			azureAccount.Spec.AzureConfig.SecretRef = nil
+			azureAccount.Spec.AzureConfig.CredentialSource = &v1alpha1.CloudProviderAccountCredentialSource{
+				File: &v1alpha1.FileCredentialSource{Path: "/etc/nephe/credentials/namespace01/azure.json"},
 			}
-			err = fakeClient.Create(context.Background(), s1)
-			Expect(err).Should(BeNil())
-
 			encodedAccount, _ = json.Marshal(azureAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
@@ -840,26 +1003,51 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 				},
 			}
 
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate AWS account add with decode error", func() {
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object:    runtime.RawExtension{},
+				},
+			}
+
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
-			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingSubscritionID))
 		})
-		It("Validate missing Azure clientID", func() {
-			cred := `{"subscriptionId": "SubID","tenantId": "TenantID", "clientKey": "ClientKey"}`
-			s1 := &corev1.Secret{
+		It("Validate AWS secret unmarshall error", func() {
+			s1 = &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      testSecretNamespacedName.Name,
 					Namespace: testSecretNamespacedName.Namespace,
 				},
 				Data: map[string][]byte{
-					credentials: []byte(cred),
+					credentials: []byte(""),
 				},
 			}
 			err = fakeClient.Create(context.Background(), s1)
 			Expect(err).Should(BeNil())
 
-			encodedAccount, _ = json.Marshal(azureAccount)
+			encodedAccount, _ = json.Marshal(awsAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
 					Kind: metav1.GroupVersionKind{
@@ -884,22 +1072,9 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
-			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingClientDetails))
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgJsonUnmarshalFail))
 		})
-		It("Validate missing Azure TenantID", func() {
-			cred := `{"subscriptionId": "SubID", "clientId": "ClientID", "clientKey": "ClientKey"}`
-			s1 := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testSecretNamespacedName.Name,
-					Namespace: testSecretNamespacedName.Namespace,
-				},
-				Data: map[string][]byte{
-					credentials: []byte(cred),
-				},
-			}
-			err = fakeClient.Create(context.Background(), s1)
-			Expect(err).Should(BeNil())
-
+		It("Validate when Azure secret not configured", func() {
 			encodedAccount, _ = json.Marshal(azureAccount)
 			accountReq = admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{
@@ -925,7 +1100,1022 @@ var _ = Describe("CloudProviderAccountWebhook", func() {
 			response := validator.Handle(context.Background(), accountReq)
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
-			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingTenantID))
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgSecretNotConfigured))
+		})
+		It("Validate an Azure Account add", func() {
+			cred := `{"subscriptionId": "SubID","clientId": "ClientID","tenantId": "TenantID", "clientKey": "ClientKey"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate Azure secret unmarshall error", func() {
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte("dummy"),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgJsonUnmarshalFail))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+		})
+		It("Validate missing Azure subscriptionId", func() {
+			cred := `{"clientId": "ClientID","tenantId": "TenantID", "clientKey": "ClientKey"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingSubscritionID))
+		})
+		It("Validate missing Azure clientID", func() {
+			cred := `{"subscriptionId": "SubID","tenantId": "TenantID", "clientKey": "ClientKey"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingClientDetails))
+		})
+		It("Validate missing Azure TenantID", func() {
+			cred := `{"subscriptionId": "SubID", "clientId": "ClientID", "clientKey": "ClientKey"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingTenantID))
+		})
+		It("Validate Azure managed identity without secret", func() {
+			azureAccount.Spec.AzureConfig.SecretRef = nil
+			azureAccount.Spec.AzureConfig.CredentialMode = v1alpha1.CredentialModeManagedIdentity
+			azureAccount.Spec.AzureConfig.SubscriptionIDs = []string{"SubID"}
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate missing Azure subscription with workload identity", func() {
+			azureAccount.Spec.AzureConfig.SecretRef = nil
+			azureAccount.Spec.AzureConfig.CredentialMode = v1alpha1.CredentialModeWorkloadIdentity
+			encodedAccount, _ = json.Marshal(azureAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingSubscritionID))
+		})
+		It("Validate a GCP Account add", func() {
+			cred := `{"projectId": "ProjectID", "serviceAccountKey": "{\"type\": \"service_account\", ` +
+				`\"client_email\": \"sa@project.iam.gserviceaccount.com\", \"private_key\": \"key\"}"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(gcpAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate missing GCP projectId", func() {
+			cred := `{"serviceAccountKey": "{\"type\": \"service_account\", ` +
+				`\"client_email\": \"sa@project.iam.gserviceaccount.com\", \"private_key\": \"key\"}"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(gcpAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingProjectID))
+		})
+		It("Validate invalid GCP service account key", func() {
+			cred := `{"projectId": "ProjectID", "serviceAccountKey": "dummy"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			encodedAccount, _ = json.Marshal(gcpAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidServiceKey))
+		})
+		It("Validate an OpenStack Account add", func() {
+			cred := `{"username": "admin", "password": "password", "domainName": "Default", "projectId": "0123456789abcdef"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			openstackAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					OpenStackConfig: &v1alpha1.CloudProviderAccountOpenStackConfig{
+						AuthURL: "https://keystone.example.com:5000/v3",
+						Region:  "RegionOne",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(openstackAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate OpenStack Account without project ID", func() {
+			cred := `{"username": "admin", "password": "password", "domainName": "Default"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			openstackAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					OpenStackConfig: &v1alpha1.CloudProviderAccountOpenStackConfig{
+						AuthURL: "https://keystone.example.com:5000/v3",
+						Region:  "RegionOne",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(openstackAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingProjectID))
+		})
+		It("Validate invalid OpenStack auth URL", func() {
+			cred := `{"username": "admin", "password": "password", "domainName": "Default", "projectId": "0123456789abcdef"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			openstackAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					OpenStackConfig: &v1alpha1.CloudProviderAccountOpenStackConfig{
+						AuthURL: "keystone.example.com",
+						Region:  "RegionOne",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(openstackAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidAuthURL))
+		})
+		It("Validate a Static Account add", func() {
+			staticAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					StaticConfig: &v1alpha1.CloudProviderAccountStaticConfig{
+						Region: "dc01",
+						Hosts: []v1alpha1.StaticHost{
+							{
+								ID:      "host01",
+								Network: "rack01",
+								Tags:    map[string]string{"role": "db"},
+								NetworkInterfaces: []v1alpha1.StaticHostNetworkInterface{
+									{Name: "eth0", MAC: "0c:c4:7a:00:00:01", IPs: []string{"192.168.10.11"}},
+									{Name: "eth1", IPs: []string{"fd00::11"}},
+								},
+							},
+							{ID: "host02", Network: "rack01"},
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(staticAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate Static Account with duplicate host ID", func() {
+			staticAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					StaticConfig: &v1alpha1.CloudProviderAccountStaticConfig{
+						Region: "dc01",
+						Hosts: []v1alpha1.StaticHost{
+							{ID: "host01", Network: "rack01"},
+							{ID: "HOST01", Network: "rack02"},
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(staticAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgDuplicateHostID))
+		})
+		It("Validate Static Account with invalid host IP", func() {
+			staticAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					StaticConfig: &v1alpha1.CloudProviderAccountStaticConfig{
+						Region: "dc01",
+						Hosts: []v1alpha1.StaticHost{
+							{
+								ID:      "host01",
+								Network: "rack01",
+								NetworkInterfaces: []v1alpha1.StaticHostNetworkInterface{
+									{Name: "eth0", IPs: []string{"192.168.10.300"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(staticAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidHostIP))
+		})
+		It("Validate a VSphere Account add", func() {
+			cred := `{"username": "administrator@vsphere.local", "password": "password"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			vsphereAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					VSphereConfig: &v1alpha1.CloudProviderAccountVSphereConfig{
+						Endpoint: "https://vcenter.example.com/sdk",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(vsphereAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate invalid VSphere endpoint", func() {
+			cred := `{"username": "administrator@vsphere.local", "password": "password"}`
+			s1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte(cred),
+				},
+			}
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+
+			vsphereAccount := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					VSphereConfig: &v1alpha1.CloudProviderAccountVSphereConfig{
+						Endpoint: "vcenter.example.com",
+						SecretRef: &v1alpha1.SecretReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       credentials,
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(vsphereAccount)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidEndpoint))
+		})
+		It("Validate a Simulated Account add", func() {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string]string{
+					"inventory": "vpcs: []",
+				},
+			}
+			err = fakeClient.Create(context.Background(), cm)
+			Expect(err).Should(BeNil())
+
+			account := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					SimulatedConfig: &v1alpha1.CloudProviderAccountSimulatedConfig{
+						Region: "sim-region-1",
+						ConfigMapRef: &v1alpha1.ConfigMapReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       "inventory",
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(account)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeTrue())
+		})
+		It("Validate Simulated configmap without inventory key", func() {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName.Name,
+					Namespace: testSecretNamespacedName.Namespace,
+				},
+				Data: map[string]string{
+					"other": "vpcs: []",
+				},
+			}
+			err = fakeClient.Create(context.Background(), cm)
+			Expect(err).Should(BeNil())
+
+			account := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					SimulatedConfig: &v1alpha1.CloudProviderAccountSimulatedConfig{
+						Region: "sim-region-1",
+						ConfigMapRef: &v1alpha1.ConfigMapReference{
+							Name:      testSecretNamespacedName.Name,
+							Namespace: testSecretNamespacedName.Namespace,
+							Key:       "inventory",
+						},
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(account)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgMissingConfigMapKey))
+		})
+		It("Validate plugin account with built-in provider", func() {
+			account := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					PluginConfig: &v1alpha1.CloudProviderAccountPluginConfig{
+						Provider: "AWS",
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(account)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring(errorMsgInvalidProvider))
+		})
+		It("Validate plugin account with unregistered provider", func() {
+			account := &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					PluginConfig: &v1alpha1.CloudProviderAccountPluginConfig{
+						Provider: "Unknown",
+					},
+				},
+			}
+			encodedAccount, _ = json.Marshal(account)
+			accountReq = admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "v1alpha1",
+						Kind:    "CloudProviderAccount",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "v1alpha1",
+						Resource: "CloudProviderAccounts",
+					},
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+					Operation: v1.Create,
+					Object: runtime.RawExtension{
+						Raw: encodedAccount,
+					},
+				},
+			}
+
+			response := validator.Handle(context.Background(), accountReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.AdmissionResponse.Allowed).To(BeFalse())
+			Expect(response.AdmissionResponse.String()).Should(ContainSubstring("unsupported crd.v1alpha1 cloud provider"))
 		})
 		It("Validate webhook update", func() {
 			err = fakeClient.Create(context.Background(), s1)
@@ -78,23 +78,71 @@ func (v *SecretValidator) getCPABySecret(s *corev1.Secret) (error, *crdv1alpha1.
 	}
 
 	for _, cpa := range cpaList.Items {
-		if cpa.Spec.AWSConfig != nil {
+		if cpa.Spec.AWSConfig != nil && cpa.Spec.AWSConfig.SecretRef != nil {
 			if cpa.Spec.AWSConfig.SecretRef.Name == s.Name &&
 				cpa.Spec.AWSConfig.SecretRef.Namespace == s.Namespace {
 				return nil, &cpa
 			}
 		}
 
-		if cpa.Spec.AzureConfig != nil {
+		if cpa.Spec.AzureConfig != nil && cpa.Spec.AzureConfig.SecretRef != nil {
 			if cpa.Spec.AzureConfig.SecretRef.Name == s.Name &&
 				cpa.Spec.AzureConfig.SecretRef.Namespace == s.Namespace {
 				return nil, &cpa
 			}
 		}
+
+		if tokenSecretRef := getVaultTokenSecretRef(&cpa); tokenSecretRef != nil {
+			if tokenSecretRef.Name == s.Name && tokenSecretRef.Namespace == s.Namespace {
+				return nil, &cpa
+			}
+		}
+
+		if cpa.Spec.GCPConfig != nil {
+			if cpa.Spec.GCPConfig.SecretRef.Name == s.Name &&
+				cpa.Spec.GCPConfig.SecretRef.Namespace == s.Namespace {
+				return nil, &cpa
+			}
+		}
+
+		if cpa.Spec.OpenStackConfig != nil && cpa.Spec.OpenStackConfig.SecretRef != nil {
+			if cpa.Spec.OpenStackConfig.SecretRef.Name == s.Name &&
+				cpa.Spec.OpenStackConfig.SecretRef.Namespace == s.Namespace {
+				return nil, &cpa
+			}
+		}
+
+		if cpa.Spec.VSphereConfig != nil && cpa.Spec.VSphereConfig.SecretRef != nil {
+			if cpa.Spec.VSphereConfig.SecretRef.Name == s.Name &&
+				cpa.Spec.VSphereConfig.SecretRef.Namespace == s.Namespace {
+				return nil, &cpa
+			}
+		}
+
+		if cpa.Spec.PluginConfig != nil && cpa.Spec.PluginConfig.SecretRef != nil {
+			if cpa.Spec.PluginConfig.SecretRef.Name == s.Name &&
+				cpa.Spec.PluginConfig.SecretRef.Namespace == s.Namespace {
+				return nil, &cpa
+			}
+		}
 	}
 	return nil, nil
 }
 
+// getVaultTokenSecretRef returns the Secret of the Vault token of the credential source of a CloudProviderAccount.
+func getVaultTokenSecretRef(cpa *crdv1alpha1.CloudProviderAccount) *crdv1alpha1.SecretReference {
+	var source *crdv1alpha1.CloudProviderAccountCredentialSource
+	if cpa.Spec.AWSConfig != nil {
+		source = cpa.Spec.AWSConfig.CredentialSource
+	} else if cpa.Spec.AzureConfig != nil {
+		source = cpa.Spec.AzureConfig.CredentialSource
+	}
+	if source == nil || source.Vault == nil {
+		return nil
+	}
+	return source.Vault.TokenSecretRef
+}
+
 // validateCreate does not deny Secret creation.
 func (v *SecretValidator) validateCreate(req admission.Request) admission.Response { // nolint: unparam
 	return admission.Allowed("")
@@ -121,11 +169,24 @@ func (v *SecretValidator) validateUpdate(req admission.Request) admission.Respon
 		return admission.Denied(err.Error())
 	}
 	if cpa != nil {
+		if tokenSecretRef := getVaultTokenSecretRef(cpa); tokenSecretRef != nil &&
+			tokenSecretRef.Name == oldSecret.Name && tokenSecretRef.Namespace == oldSecret.Namespace {
+			// the Vault token may be renewed, it is read again when credentials are retrieved from Vault.
+			return admission.Allowed("")
+		}
 		var key string
 		if cpa.Spec.AWSConfig != nil {
 			key = cpa.Spec.AWSConfig.SecretRef.Key
 		} else if cpa.Spec.AzureConfig != nil {
 			key = cpa.Spec.AzureConfig.SecretRef.Key
+		} else if cpa.Spec.GCPConfig != nil {
+			key = cpa.Spec.GCPConfig.SecretRef.Key
+		} else if cpa.Spec.OpenStackConfig != nil {
+			key = cpa.Spec.OpenStackConfig.SecretRef.Key
+		} else if cpa.Spec.VSphereConfig != nil {
+			key = cpa.Spec.VSphereConfig.SecretRef.Key
+		} else if cpa.Spec.PluginConfig != nil {
+			key = cpa.Spec.PluginConfig.SecretRef.Key
 		}
 		if ok := v.allowSecretUpdate(newSecret, oldSecret, key); !ok {
 			v.Log.Error(nil, "The Secret is referred by a CloudProviderAccount. Cannot modify it,",
@@ -237,6 +237,84 @@ var _ = Describe("Webhook", func() {
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
 			Expect(response.Allowed).To(BeFalse())
 		})
+		It("Validate Vault token Secret update with dependent AWS CPA", func() {
+			err = fakeClient.Create(context.Background(), s1)
+			Expect(err).Should(BeNil())
+			var pollIntv uint = 1
+			account = &v1alpha1.CloudProviderAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testAccountNamespacedName.Name,
+					Namespace: testAccountNamespacedName.Namespace,
+				},
+				Spec: v1alpha1.CloudProviderAccountSpec{
+					PollIntervalInSeconds: &pollIntv,
+					AWSConfig: &v1alpha1.CloudProviderAccountAWSConfig{
+						Region: "us-east-1",
+						CredentialSource: &v1alpha1.CloudProviderAccountCredentialSource{
+							Vault: &v1alpha1.VaultCredentialSource{
+								Address: "http://127.0.0.1:8200",
+								Path:    "aws/creds/nephe",
+								Engine:  v1alpha1.VaultSecretEngineAWS,
+								TokenSecretRef: &v1alpha1.SecretReference{
+									Name:      testSecretNamespacedName1.Name,
+									Namespace: testSecretNamespacedName1.Namespace,
+									Key:       credentials,
+								},
+							},
+						},
+					},
+				},
+			}
+			err = fakeClient.Create(context.Background(), account)
+			Expect(err).Should(BeNil())
+			newS1 := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testSecretNamespacedName1.Name,
+					Namespace: testSecretNamespacedName1.Namespace,
+				},
+				Data: map[string][]byte{
+					credentials: []byte("renewed-vault-token"),
+				},
+			}
+			encodedNewS1, _ := json.Marshal(newS1)
+			newS1Req := admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "",
+						Version: "corev1",
+						Kind:    "Secret",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "",
+						Version:  "corev1",
+						Resource: "Secrets",
+					},
+					Name:      testSecretNamespacedName1.Name,
+					Namespace: testSecretNamespacedName1.Namespace,
+					Operation: v1.Update,
+					Object: runtime.RawExtension{
+						Raw: encodedNewS1,
+					},
+					OldObject: runtime.RawExtension{
+						Raw: encodedS1,
+					},
+				},
+			}
+			SecretValidatorTest1 := &SecretValidator{
+				Client: fakeClient,
+				Log:    logging.GetLogger("webhook").WithName("Secret")}
+			err = SecretValidatorTest1.InjectDecoder(decoder)
+			Expect(err).Should(BeNil())
+			response := SecretValidatorTest1.Handle(context.Background(), newS1Req)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.Allowed).To(BeTrue())
+
+			deleteReq := newS1Req
+			deleteReq.Operation = v1.Delete
+			response = SecretValidatorTest1.Handle(context.Background(), deleteReq)
+			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Got admission response %+v\n", response)))
+			Expect(response.Allowed).To(BeFalse())
+		})
 		It("Validate Secret labels update with dependent AWS CPA", func() {
 			_, _ = GinkgoWriter.Write([]byte(fmt.Sprintf("Creating Secret [%s, %s]\n", s1.Name, s1.Namespace)))
 			err = fakeClient.Create(context.Background(), s1)
@@ -15,36 +15,75 @@
 package aws
 
 import (
-	"context"
-	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"strings"
 
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
 )
 
 type awsAccountConfig struct {
 	crdv1alpha1.AwsAccountCredential
+	// credentialMode of the account, the secret is optional with workload identity.
+	credentialMode crdv1alpha1.CloudProviderAccountCredentialMode
+	// credentialSource of the account, and source shared by all service configs of the account to retrieve the
+	// access keys again before they expire.
+	credentialSource *crdv1alpha1.CloudProviderAccountCredentialSource
+	source           credentialsource.Source
+	// regions configured for the account, which may include all enabled regions.
+	regions []string
+	// region of the service config of a region of the account.
 	region   string
 	endpoint string
+	// organization whose member accounts are imported instead of the account.
+	organization *crdv1alpha1.CloudProviderAccountAWSOrganizationConfig
+	// accountID of the organization member account of the service config.
+	accountID string
 }
 
 // setAccountCredentials sets account credentials.
-func setAccountCredentials(client client.Client, credentials interface{}) (interface{}, error) {
+func setAccountCredentials(client client.Client, namespacedName *types.NamespacedName, credentials interface{}) (interface{}, error) {
 	awsProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountAWSConfig)
-	accCred, err := extractSecret(client, awsProviderConfig.SecretRef)
-	if err != nil {
-		return nil, err
+	accCred := &crdv1alpha1.AwsAccountCredential{}
+	if awsProviderConfig.SecretRef != nil {
+		var err error
+		if accCred, err = extractSecret(client, awsProviderConfig.SecretRef); err != nil {
+			return nil, err
+		}
+	}
+	var source credentialsource.Source
+	if awsProviderConfig.CredentialSource != nil {
+		// the source of the account is kept across reconciles, so that dynamic credentials are not generated again.
+		cachedSource, err := credentialsource.GetAccountSource(client, namespacedName, awsProviderConfig.CredentialSource)
+		if err != nil {
+			return nil, err
+		}
+		source = cachedSource
+		data, _, err := source.Retrieve()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve credentials from credential source: %v", err)
+		}
+		if err = json.Unmarshal(data, accCred); err != nil {
+			return nil, fmt.Errorf("unable to parse credentials of credential source: %v", err)
+		}
+	} else {
+		credentialsource.ReleaseAccountSource(namespacedName)
 	}
 
 	awsConfig := &awsAccountConfig{
 		AwsAccountCredential: *accCred,
-		region:               strings.TrimSpace(awsProviderConfig.Region),
+		credentialMode:       awsProviderConfig.CredentialMode,
+		credentialSource:     awsProviderConfig.CredentialSource.DeepCopy(),
+		source:               source,
+		regions:              utils.GetAccountRegions(awsProviderConfig.Region, awsProviderConfig.Regions),
 		endpoint:             strings.TrimSpace(awsProviderConfig.Endpoint),
+		organization:         awsProviderConfig.Organization.DeepCopy(),
 	}
 
 	return awsConfig, nil
@@ -75,31 +114,32 @@ func compareAccountCredentials(accountName string, existing interface{}, new int
 		credsChanged = true
 		awsPluginLogger().Info("account IAM external id updated", "account", accountName)
 	}
-	if strings.Compare(existingConfig.region, newConfig.region) != 0 {
+	if existingConfig.credentialMode != newConfig.credentialMode {
+		credsChanged = true
+		awsPluginLogger().Info("account credential mode updated", "account", accountName)
+	}
+	if !reflect.DeepEqual(existingConfig.credentialSource, newConfig.credentialSource) {
+		credsChanged = true
+		awsPluginLogger().Info("account credential source updated", "account", accountName)
+	}
+	if strings.Compare(strings.Join(existingConfig.regions, ","), strings.Join(newConfig.regions, ",")) != 0 {
 		credsChanged = true
-		awsPluginLogger().Info("account region updated", "account", accountName)
+		awsPluginLogger().Info("account regions updated", "account", accountName)
 	}
 	if strings.Compare(existingConfig.endpoint, newConfig.endpoint) != 0 {
 		credsChanged = true
 		awsPluginLogger().Info("endpoint url updated", "account", accountName)
 	}
+	if !reflect.DeepEqual(existingConfig.organization, newConfig.organization) {
+		credsChanged = true
+		awsPluginLogger().Info("account organization updated", "account", accountName)
+	}
 	return credsChanged
 }
 
 // extractSecret extracts credentials from a Kubernetes secret.
 func extractSecret(c client.Client, s *crdv1alpha1.SecretReference) (*crdv1alpha1.AwsAccountCredential, error) {
-	u := &unstructured.Unstructured{}
-	u.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "",
-		Kind:    "Secret",
-		Version: "v1",
-	})
-	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
-		return nil, err
-	}
-
-	data := u.Object["data"].(map[string]interface{})
-	decode, err := base64.StdEncoding.DecodeString(data[s.Key].(string))
+	decode, _, err := credentialsource.NewSecretSource(c, s).Retrieve()
 	if err != nil {
 		return nil, err
 	}
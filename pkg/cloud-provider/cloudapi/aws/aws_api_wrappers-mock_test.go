@@ -23,6 +23,7 @@ import (
 	reflect "reflect"
 
 	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+	organizations "github.com/aws/aws-sdk-go/service/organizations"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -79,6 +80,36 @@ func (mr *MockawsEC2WrapperMockRecorder) authorizeSecurityGroupIngress(input int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "authorizeSecurityGroupIngress", reflect.TypeOf((*MockawsEC2Wrapper)(nil).authorizeSecurityGroupIngress), input)
 }
 
+// createManagedPrefixList mocks base method.
+func (m *MockawsEC2Wrapper) createManagedPrefixList(input *ec2.CreateManagedPrefixListInput) (*ec2.CreateManagedPrefixListOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createManagedPrefixList", input)
+	ret0, _ := ret[0].(*ec2.CreateManagedPrefixListOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createManagedPrefixList indicates an expected call of createManagedPrefixList.
+func (mr *MockawsEC2WrapperMockRecorder) createManagedPrefixList(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createManagedPrefixList", reflect.TypeOf((*MockawsEC2Wrapper)(nil).createManagedPrefixList), input)
+}
+
+// createNetworkAclEntry mocks base method.
+func (m *MockawsEC2Wrapper) createNetworkAclEntry(input *ec2.CreateNetworkAclEntryInput) (*ec2.CreateNetworkAclEntryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createNetworkAclEntry", input)
+	ret0, _ := ret[0].(*ec2.CreateNetworkAclEntryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createNetworkAclEntry indicates an expected call of createNetworkAclEntry.
+func (mr *MockawsEC2WrapperMockRecorder) createNetworkAclEntry(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createNetworkAclEntry", reflect.TypeOf((*MockawsEC2Wrapper)(nil).createNetworkAclEntry), input)
+}
+
 // createSecurityGroup mocks base method.
 func (m *MockawsEC2Wrapper) createSecurityGroup(input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +125,51 @@ func (mr *MockawsEC2WrapperMockRecorder) createSecurityGroup(input interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createSecurityGroup", reflect.TypeOf((*MockawsEC2Wrapper)(nil).createSecurityGroup), input)
 }
 
+// createTags mocks base method.
+func (m *MockawsEC2Wrapper) createTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createTags", input)
+	ret0, _ := ret[0].(*ec2.CreateTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createTags indicates an expected call of createTags.
+func (mr *MockawsEC2WrapperMockRecorder) createTags(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createTags", reflect.TypeOf((*MockawsEC2Wrapper)(nil).createTags), input)
+}
+
+// deleteManagedPrefixList mocks base method.
+func (m *MockawsEC2Wrapper) deleteManagedPrefixList(input *ec2.DeleteManagedPrefixListInput) (*ec2.DeleteManagedPrefixListOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteManagedPrefixList", input)
+	ret0, _ := ret[0].(*ec2.DeleteManagedPrefixListOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// deleteManagedPrefixList indicates an expected call of deleteManagedPrefixList.
+func (mr *MockawsEC2WrapperMockRecorder) deleteManagedPrefixList(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteManagedPrefixList", reflect.TypeOf((*MockawsEC2Wrapper)(nil).deleteManagedPrefixList), input)
+}
+
+// deleteNetworkAclEntry mocks base method.
+func (m *MockawsEC2Wrapper) deleteNetworkAclEntry(input *ec2.DeleteNetworkAclEntryInput) (*ec2.DeleteNetworkAclEntryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteNetworkAclEntry", input)
+	ret0, _ := ret[0].(*ec2.DeleteNetworkAclEntryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// deleteNetworkAclEntry indicates an expected call of deleteNetworkAclEntry.
+func (mr *MockawsEC2WrapperMockRecorder) deleteNetworkAclEntry(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteNetworkAclEntry", reflect.TypeOf((*MockawsEC2Wrapper)(nil).deleteNetworkAclEntry), input)
+}
+
 // deleteSecurityGroup mocks base method.
 func (m *MockawsEC2Wrapper) deleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +185,66 @@ func (mr *MockawsEC2WrapperMockRecorder) deleteSecurityGroup(input interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteSecurityGroup", reflect.TypeOf((*MockawsEC2Wrapper)(nil).deleteSecurityGroup), input)
 }
 
+// deleteTags mocks base method.
+func (m *MockawsEC2Wrapper) deleteTags(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteTags", input)
+	ret0, _ := ret[0].(*ec2.DeleteTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// deleteTags indicates an expected call of deleteTags.
+func (mr *MockawsEC2WrapperMockRecorder) deleteTags(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteTags", reflect.TypeOf((*MockawsEC2Wrapper)(nil).deleteTags), input)
+}
+
+// describeManagedPrefixLists mocks base method.
+func (m *MockawsEC2Wrapper) describeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "describeManagedPrefixLists", input)
+	ret0, _ := ret[0].(*ec2.DescribeManagedPrefixListsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// describeManagedPrefixLists indicates an expected call of describeManagedPrefixLists.
+func (mr *MockawsEC2WrapperMockRecorder) describeManagedPrefixLists(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "describeManagedPrefixLists", reflect.TypeOf((*MockawsEC2Wrapper)(nil).describeManagedPrefixLists), input)
+}
+
+// describeNetworkAcls mocks base method.
+func (m *MockawsEC2Wrapper) describeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "describeNetworkAcls", input)
+	ret0, _ := ret[0].(*ec2.DescribeNetworkAclsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// describeNetworkAcls indicates an expected call of describeNetworkAcls.
+func (mr *MockawsEC2WrapperMockRecorder) describeNetworkAcls(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "describeNetworkAcls", reflect.TypeOf((*MockawsEC2Wrapper)(nil).describeNetworkAcls), input)
+}
+
+// describeRegions mocks base method.
+func (m *MockawsEC2Wrapper) describeRegions(input *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "describeRegions", input)
+	ret0, _ := ret[0].(*ec2.DescribeRegionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// describeRegions indicates an expected call of describeRegions.
+func (mr *MockawsEC2WrapperMockRecorder) describeRegions(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "describeRegions", reflect.TypeOf((*MockawsEC2Wrapper)(nil).describeRegions), input)
+}
+
 // describeSecurityGroups mocks base method.
 func (m *MockawsEC2Wrapper) describeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +335,21 @@ func (mr *MockawsEC2WrapperMockRecorder) pagedDescribeNetworkInterfaces(input in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "pagedDescribeNetworkInterfaces", reflect.TypeOf((*MockawsEC2Wrapper)(nil).pagedDescribeNetworkInterfaces), input)
 }
 
+// pagedGetManagedPrefixListEntries mocks base method.
+func (m *MockawsEC2Wrapper) pagedGetManagedPrefixListEntries(input *ec2.GetManagedPrefixListEntriesInput) ([]*ec2.PrefixListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "pagedGetManagedPrefixListEntries", input)
+	ret0, _ := ret[0].([]*ec2.PrefixListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// pagedGetManagedPrefixListEntries indicates an expected call of pagedGetManagedPrefixListEntries.
+func (mr *MockawsEC2WrapperMockRecorder) pagedGetManagedPrefixListEntries(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "pagedGetManagedPrefixListEntries", reflect.TypeOf((*MockawsEC2Wrapper)(nil).pagedGetManagedPrefixListEntries), input)
+}
+
 // revokeSecurityGroupEgress mocks base method.
 func (m *MockawsEC2Wrapper) revokeSecurityGroupEgress(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
 	m.ctrl.T.Helper()
@@ -228,3 +379,41 @@ func (mr *MockawsEC2WrapperMockRecorder) revokeSecurityGroupIngress(input interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "revokeSecurityGroupIngress", reflect.TypeOf((*MockawsEC2Wrapper)(nil).revokeSecurityGroupIngress), input)
 }
+
+// MockawsOrganizationsWrapper is a mock of awsOrganizationsWrapper interface.
+type MockawsOrganizationsWrapper struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsOrganizationsWrapperMockRecorder
+}
+
+// MockawsOrganizationsWrapperMockRecorder is the mock recorder for MockawsOrganizationsWrapper.
+type MockawsOrganizationsWrapperMockRecorder struct {
+	mock *MockawsOrganizationsWrapper
+}
+
+// NewMockawsOrganizationsWrapper creates a new mock instance.
+func NewMockawsOrganizationsWrapper(ctrl *gomock.Controller) *MockawsOrganizationsWrapper {
+	mock := &MockawsOrganizationsWrapper{ctrl: ctrl}
+	mock.recorder = &MockawsOrganizationsWrapperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsOrganizationsWrapper) EXPECT() *MockawsOrganizationsWrapperMockRecorder {
+	return m.recorder
+}
+
+// pagedListAccounts mocks base method.
+func (m *MockawsOrganizationsWrapper) pagedListAccounts(input *organizations.ListAccountsInput) ([]*organizations.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "pagedListAccounts", input)
+	ret0, _ := ret[0].([]*organizations.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// pagedListAccounts indicates an expected call of pagedListAccounts.
+func (mr *MockawsOrganizationsWrapperMockRecorder) pagedListAccounts(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "pagedListAccounts", reflect.TypeOf((*MockawsOrganizationsWrapper)(nil).pagedListAccounts), input)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/organizations"
 )
 
 // awsEC2Wrapper is layer above aws EC2 sdk apis to allow for unit-testing.
@@ -39,6 +40,24 @@ type awsEC2Wrapper interface {
 	revokeSecurityGroupEgress(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error)
 	revokeSecurityGroupIngress(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error)
 
+	// network acls
+	describeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error)
+	createNetworkAclEntry(input *ec2.CreateNetworkAclEntryInput) (*ec2.CreateNetworkAclEntryOutput, error)
+	deleteNetworkAclEntry(input *ec2.DeleteNetworkAclEntryInput) (*ec2.DeleteNetworkAclEntryOutput, error)
+
+	// tags
+	createTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
+	deleteTags(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error)
+
+	// managed prefix lists
+	createManagedPrefixList(input *ec2.CreateManagedPrefixListInput) (*ec2.CreateManagedPrefixListOutput, error)
+	describeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error)
+	pagedGetManagedPrefixListEntries(input *ec2.GetManagedPrefixListEntriesInput) ([]*ec2.PrefixListEntry, error)
+	deleteManagedPrefixList(input *ec2.DeleteManagedPrefixListInput) (*ec2.DeleteManagedPrefixListOutput, error)
+
+	// regions
+	describeRegions(input *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error)
+
 	// vpcs
 	describeVpcsWrapper(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
 
@@ -132,6 +151,72 @@ func (ec2Wrapper *awsEC2WrapperImpl) revokeSecurityGroupIngress(input *ec2.Revok
 	return ec2Wrapper.ec2.RevokeSecurityGroupIngress(input)
 }
 
+func (ec2Wrapper *awsEC2WrapperImpl) describeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	return ec2Wrapper.ec2.DescribeNetworkAcls(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) createNetworkAclEntry(input *ec2.CreateNetworkAclEntryInput) (
+	*ec2.CreateNetworkAclEntryOutput, error) {
+	return ec2Wrapper.ec2.CreateNetworkAclEntry(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) deleteNetworkAclEntry(input *ec2.DeleteNetworkAclEntryInput) (
+	*ec2.DeleteNetworkAclEntryOutput, error) {
+	return ec2Wrapper.ec2.DeleteNetworkAclEntry(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) createTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	return ec2Wrapper.ec2.CreateTags(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) deleteTags(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	return ec2Wrapper.ec2.DeleteTags(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) createManagedPrefixList(input *ec2.CreateManagedPrefixListInput) (
+	*ec2.CreateManagedPrefixListOutput, error) {
+	return ec2Wrapper.ec2.CreateManagedPrefixList(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) describeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (
+	*ec2.DescribeManagedPrefixListsOutput, error) {
+	return ec2Wrapper.ec2.DescribeManagedPrefixLists(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) pagedGetManagedPrefixListEntries(input *ec2.GetManagedPrefixListEntriesInput) (
+	[]*ec2.PrefixListEntry, error) {
+	var entries []*ec2.PrefixListEntry
+	var nextToken *string
+	for {
+		response, err := ec2Wrapper.ec2.GetManagedPrefixListEntries(input)
+		if err != nil {
+			return nil, fmt.Errorf("error getting ec2 managed prefix list entries: %q", err)
+		}
+
+		entries = append(entries, response.Entries...)
+
+		nextToken = response.NextToken
+		if aws.StringValue(nextToken) == "" {
+			break
+		}
+		input.NextToken = nextToken
+	}
+	return entries, nil
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) deleteManagedPrefixList(input *ec2.DeleteManagedPrefixListInput) (
+	*ec2.DeleteManagedPrefixListOutput, error) {
+	return ec2Wrapper.ec2.DeleteManagedPrefixList(input)
+}
+
+func (ec2Wrapper *awsEC2WrapperImpl) describeRegions(input *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
+	regions, err := ec2Wrapper.ec2.DescribeRegions(input)
+	if err != nil {
+		return nil, fmt.Errorf("error describing ec2 regions: %q", err)
+	}
+	return regions, nil
+}
+
 func (ec2Wrapper *awsEC2WrapperImpl) describeVpcsWrapper(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
 	vpcs, err := ec2Wrapper.ec2.DescribeVpcs(input)
 	if err != nil {
@@ -144,3 +229,33 @@ func (ec2Wrapper *awsEC2WrapperImpl) describeVpcPeeringConnectionsWrapper(input
 	*ec2.DescribeVpcPeeringConnectionsOutput, error) {
 	return ec2Wrapper.ec2.DescribeVpcPeeringConnections(input)
 }
+
+// awsOrganizationsWrapper is layer above aws Organizations sdk apis to allow for unit-testing.
+type awsOrganizationsWrapper interface {
+	// accounts
+	pagedListAccounts(input *organizations.ListAccountsInput) ([]*organizations.Account, error)
+}
+type awsOrganizationsWrapperImpl struct {
+	organizations *organizations.Organizations
+}
+
+func (orgWrapper *awsOrganizationsWrapperImpl) pagedListAccounts(input *organizations.ListAccountsInput) ([]*organizations.Account,
+	error) {
+	var accounts []*organizations.Account
+	var nextToken *string
+	for {
+		response, err := orgWrapper.organizations.ListAccounts(input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing organization accounts: %q", err)
+		}
+
+		accounts = append(accounts, response.Accounts...)
+
+		nextToken = response.NextToken
+		if aws.StringValue(nextToken) == "" {
+			break
+		}
+		input.NextToken = nextToken
+	}
+	return accounts, nil
+}
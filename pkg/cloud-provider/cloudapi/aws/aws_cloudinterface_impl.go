@@ -22,6 +22,7 @@ import (
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
 	"antrea.io/nephe/pkg/logging"
 )
 
@@ -57,6 +58,20 @@ func (c *awsCloud) ProviderType() cloudcommon.ProviderType {
 	return providerType
 }
 
+// GetCapabilities returns the network policy features supported by AWS. AWS realizes deny rules in network acls, and
+// ICMP type and code in the ports of security group rules.
+func (c *awsCloud) GetCapabilities() crdv1alpha1.CloudCapabilities {
+	return crdv1alpha1.CloudCapabilities{
+		SecurityGroups: true,
+		DenyRules:      true,
+		IPv6:           true,
+		PortRanges:     true,
+		ICMPTypeCode:   true,
+		SCTP:           true,
+		MaxRules:       awsMaxSecurityGroupRules,
+	}
+}
+
 // /////////////////////////////////////////////
 //
 //	ComputeInterface Implementation
@@ -85,6 +100,7 @@ func (c *awsCloud) AddProviderAccount(client client.Client, account *crdv1alpha1
 // RemoveProviderAccount removes and cleans up any resources of given account of a cloud provider.
 func (c *awsCloud) RemoveProviderAccount(namespacedName *types.NamespacedName) {
 	c.cloudCommon.RemoveCloudAccount(namespacedName)
+	credentialsource.ReleaseAccountSource(namespacedName)
 }
 
 // AddAccountResourceSelector adds account specific resource selector.
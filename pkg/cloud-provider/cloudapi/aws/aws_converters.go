@@ -32,7 +32,7 @@ func convertToIPPermissionProtocol(protocol *int) *string {
 	return aws.String(strconv.FormatInt(int64(*protocol), 10))
 }
 
-func convertToIPPermissionPort(port *int, protocol *int) (*int64, *int64) {
+func convertToIPPermissionPort(port *int, endPort *int, protocol *int) (*int64, *int64) {
 	if port == nil {
 		// For TCP and UDP, aws expects explicit start and end port numbers (for all ports case)
 		if protocol != nil && (*protocol == 6 || *protocol == 17) {
@@ -41,14 +41,32 @@ func convertToIPPermissionPort(port *int, protocol *int) (*int64, *int64) {
 		return nil, nil
 	}
 	portVal := aws.Int64(int64(*port))
+	if endPort != nil {
+		return portVal, aws.Int64(int64(*endPort))
+	}
 	return portVal, portVal
 }
 
+// convertToIPPermissionICMPTypeCode converts ICMP type and code of a rule to ec2.IpPermission port fields,
+// which carry ICMP type and code for ICMP protocols. -1 matches any ICMP type or code.
+func convertToIPPermissionICMPTypeCode(icmpType *int, icmpCode *int) (*int64, *int64) {
+	startPort, endPort := aws.Int64(awsAnyICMPValue), aws.Int64(awsAnyICMPValue)
+	if icmpType != nil {
+		startPort = aws.Int64(int64(*icmpType))
+	}
+	if icmpCode != nil {
+		endPort = aws.Int64(int64(*icmpCode))
+	}
+	return startPort, endPort
+}
+
+// convertToEc2IpRanges converts the IPv4 CIDRs of a rule to ec2.IpRange. IPv6 CIDRs are skipped, they are
+// realized by convertToEc2Ipv6Ranges.
 func convertToEc2IpRanges(ips []*net.IPNet, ruleHasGroups bool, description *string) []*ec2.IpRange {
 	var ipRanges []*ec2.IpRange
 	if len(ips) == 0 && !ruleHasGroups {
 		ipRange := &ec2.IpRange{
-			CidrIp:      aws.String("0.0.0.0/0"),
+			CidrIp:      aws.String(ipv4AnyCidr),
 			Description: description,
 		}
 		ipRanges = append(ipRanges, ipRange)
@@ -56,6 +74,9 @@ func convertToEc2IpRanges(ips []*net.IPNet, ruleHasGroups bool, description *str
 	}
 
 	for _, ip := range ips {
+		if ip.IP.To4() == nil {
+			continue
+		}
 		ipRange := &ec2.IpRange{
 			CidrIp:      aws.String(ip.String()),
 			Description: description,
@@ -65,6 +86,31 @@ func convertToEc2IpRanges(ips []*net.IPNet, ruleHasGroups bool, description *str
 	return ipRanges
 }
 
+// convertToEc2Ipv6Ranges converts the IPv6 CIDRs of a rule to ec2.Ipv6Range.
+func convertToEc2Ipv6Ranges(ips []*net.IPNet, ruleHasGroups bool, description *string) []*ec2.Ipv6Range {
+	var ipv6Ranges []*ec2.Ipv6Range
+	if len(ips) == 0 && !ruleHasGroups {
+		ipv6Range := &ec2.Ipv6Range{
+			CidrIpv6:    aws.String(ipv6AnyCidr),
+			Description: description,
+		}
+		ipv6Ranges = append(ipv6Ranges, ipv6Range)
+		return ipv6Ranges
+	}
+
+	for _, ip := range ips {
+		if ip.IP.To4() != nil {
+			continue
+		}
+		ipv6Range := &ec2.Ipv6Range{
+			CidrIpv6:    aws.String(ip.String()),
+			Description: description,
+		}
+		ipv6Ranges = append(ipv6Ranges, ipv6Range)
+	}
+	return ipv6Ranges
+}
+
 func convertFromIPRange(ipRanges []*ec2.IpRange) ([]*net.IPNet, []*string) {
 	var srcIPNets []*net.IPNet
 	var desc []*string
@@ -80,6 +126,59 @@ func convertFromIPRange(ipRanges []*ec2.IpRange) ([]*net.IPNet, []*string) {
 	return srcIPNets, desc
 }
 
+// convertFromIpv6Range converts ec2.Ipv6Range to IPNets. The IPv6 any CIDR is skipped when the IPv4 any CIDR
+// is also present, as both are realized from a single rule without CIDRs.
+func convertFromIpv6Range(ipv6Ranges []*ec2.Ipv6Range, ipRanges []*ec2.IpRange) ([]*net.IPNet, []*string) {
+	hasIPv4Any := false
+	for _, ipRange := range ipRanges {
+		if ipRange.CidrIp != nil && *ipRange.CidrIp == ipv4AnyCidr {
+			hasIPv4Any = true
+			break
+		}
+	}
+
+	var srcIPNets []*net.IPNet
+	var desc []*string
+	for _, ipv6Range := range ipv6Ranges {
+		if ipv6Range.CidrIpv6 == nil || (hasIPv4Any && *ipv6Range.CidrIpv6 == ipv6AnyCidr) {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(*ipv6Range.CidrIpv6)
+		if err != nil {
+			continue
+		}
+		desc = append(desc, ipv6Range.Description)
+		srcIPNets = append(srcIPNets, ipNet)
+	}
+
+	return srcIPNets, desc
+}
+
+// convertFromPrefixListIds converts managed prefix lists referred by an ec2.IpPermission to IPNets of their entries,
+// each with the description of the prefix list reference.
+func convertFromPrefixListIds(prefixListIDs []*ec2.PrefixListId, prefixListIDToCidrs map[string][]*net.IPNet) (
+	[]*net.IPNet, []*string) {
+	var ipNets []*net.IPNet
+	var desc []*string
+	for _, prefixListID := range prefixListIDs {
+		for _, ipNet := range prefixListIDToCidrs[aws.StringValue(prefixListID.PrefixListId)] {
+			desc = append(desc, prefixListID.Description)
+			ipNets = append(ipNets, ipNet)
+		}
+	}
+	return ipNets, desc
+}
+
+// convertFromIPPermissionRanges converts IPv4 and IPv6 ranges and managed prefix lists of an ec2.IpPermission to IPNets.
+func convertFromIPPermissionRanges(ipPermission *ec2.IpPermission, prefixListIDToCidrs map[string][]*net.IPNet) (
+	[]*net.IPNet, []*string) {
+	ipNets, desc := convertFromIPRange(ipPermission.IpRanges)
+	ipv6Nets, ipv6Desc := convertFromIpv6Range(ipPermission.Ipv6Ranges, ipPermission.IpRanges)
+	prefixListNets, prefixListDesc := convertFromPrefixListIds(ipPermission.PrefixListIds, prefixListIDToCidrs)
+	ipNets = append(append(ipNets, ipv6Nets...), prefixListNets...)
+	return ipNets, append(append(desc, ipv6Desc...), prefixListDesc...)
+}
+
 func convertFromSecurityGroupPair(cloudGroups []*ec2.UserIdGroupPair, managedSGs map[string]*ec2.SecurityGroup,
 	unmanagedSGs map[string]*ec2.SecurityGroup) ([]*securitygroup.CloudResourceID, []*string) {
 	var cloudResourceIDs []*securitygroup.CloudResourceID
@@ -115,10 +214,10 @@ func convertFromSecurityGroupPair(cloudGroups []*ec2.UserIdGroupPair, managedSGs
 // convertFromIPPermissionToIngressRule converts cloud ingress rules from ec2.IpPermission to internal securitygroup.IngressRule.
 // Each AT Sg can have one or more ANPs and an ANP can have one or more rules. Each rule can have a description.
 func convertFromIPPermissionToIngressRule(ipPermissions []*ec2.IpPermission, managedSGs map[string]*ec2.SecurityGroup,
-	unmanagedSGs map[string]*ec2.SecurityGroup) []securitygroup.IngressRule {
+	unmanagedSGs map[string]*ec2.SecurityGroup, prefixListIDToCidrs map[string][]*net.IPNet) []securitygroup.IngressRule {
 	var ingressRules []securitygroup.IngressRule
 	for _, ipPermission := range ipPermissions {
-		fromSrcIPs, desc := convertFromIPRange(ipPermission.IpRanges)
+		fromSrcIPs, desc := convertFromIPPermissionRanges(ipPermission, prefixListIDToCidrs)
 		for i, srcIP := range fromSrcIPs {
 			// Get cloud rule description.
 			_, ok := securitygroup.ExtractCloudDescription(desc[i])
@@ -131,7 +230,11 @@ func convertFromIPPermissionToIngressRule(ipPermissions []*ec2.IpPermission, man
 
 			ingressRule.FromSrcIP = []*net.IPNet{srcIP}
 			ingressRule.Protocol = convertFromIPPermissionProtocol(*ipPermission.IpProtocol)
-			ingressRule.FromPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			if securitygroup.IsICMPProtocol(ingressRule.Protocol) {
+				ingressRule.ICMPType, ingressRule.ICMPCode = convertFromIPPermissionICMPTypeCode(ipPermission.FromPort, ipPermission.ToPort)
+			} else {
+				ingressRule.FromPort, ingressRule.FromEndPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			}
 
 			ingressRules = append(ingressRules, ingressRule)
 		}
@@ -148,7 +251,11 @@ func convertFromIPPermissionToIngressRule(ipPermissions []*ec2.IpPermission, man
 
 			ingressRule.FromSecurityGroups = []*securitygroup.CloudResourceID{SecurityGroup}
 			ingressRule.Protocol = convertFromIPPermissionProtocol(*ipPermission.IpProtocol)
-			ingressRule.FromPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			if securitygroup.IsICMPProtocol(ingressRule.Protocol) {
+				ingressRule.ICMPType, ingressRule.ICMPCode = convertFromIPPermissionICMPTypeCode(ipPermission.FromPort, ipPermission.ToPort)
+			} else {
+				ingressRule.FromPort, ingressRule.FromEndPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			}
 
 			ingressRules = append(ingressRules, ingressRule)
 		}
@@ -159,10 +266,10 @@ func convertFromIPPermissionToIngressRule(ipPermissions []*ec2.IpPermission, man
 // convertFromIPPermissionToEgressRule converts cloud egress rules from ec2.IpPermission to internal securitygroup.EgressRule.
 // Each AT Sg can have one or more ANPs and an ANP can have one or more rules. Each rule can have a description.
 func convertFromIPPermissionToEgressRule(ipPermissions []*ec2.IpPermission, managedSGs map[string]*ec2.SecurityGroup,
-	unmanagedSGs map[string]*ec2.SecurityGroup) []securitygroup.EgressRule {
+	unmanagedSGs map[string]*ec2.SecurityGroup, prefixListIDToCidrs map[string][]*net.IPNet) []securitygroup.EgressRule {
 	var egressRules []securitygroup.EgressRule
 	for _, ipPermission := range ipPermissions {
-		toDstIPs, desc := convertFromIPRange(ipPermission.IpRanges)
+		toDstIPs, desc := convertFromIPPermissionRanges(ipPermission, prefixListIDToCidrs)
 		for i, dstIP := range toDstIPs {
 			// Get cloud rule description.
 			_, ok := securitygroup.ExtractCloudDescription(desc[i])
@@ -175,7 +282,11 @@ func convertFromIPPermissionToEgressRule(ipPermissions []*ec2.IpPermission, mana
 
 			egressRule.ToDstIP = []*net.IPNet{dstIP}
 			egressRule.Protocol = convertFromIPPermissionProtocol(*ipPermission.IpProtocol)
-			egressRule.ToPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			if securitygroup.IsICMPProtocol(egressRule.Protocol) {
+				egressRule.ICMPType, egressRule.ICMPCode = convertFromIPPermissionICMPTypeCode(ipPermission.FromPort, ipPermission.ToPort)
+			} else {
+				egressRule.ToPort, egressRule.ToEndPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			}
 
 			egressRules = append(egressRules, egressRule)
 		}
@@ -192,7 +303,11 @@ func convertFromIPPermissionToEgressRule(ipPermissions []*ec2.IpPermission, mana
 
 			egressRule.ToSecurityGroups = []*securitygroup.CloudResourceID{SecurityGroup}
 			egressRule.Protocol = convertFromIPPermissionProtocol(*ipPermission.IpProtocol)
-			egressRule.ToPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			if securitygroup.IsICMPProtocol(egressRule.Protocol) {
+				egressRule.ICMPType, egressRule.ICMPCode = convertFromIPPermissionICMPTypeCode(ipPermission.FromPort, ipPermission.ToPort)
+			} else {
+				egressRule.ToPort, egressRule.ToEndPort = convertFromIPPermissionPort(ipPermission.FromPort, ipPermission.ToPort)
+			}
 
 			egressRules = append(egressRules, egressRule)
 		}
@@ -200,23 +315,42 @@ func convertFromIPPermissionToEgressRule(ipPermissions []*ec2.IpPermission, mana
 	return egressRules
 }
 
-func convertFromIPPermissionPort(startPort *int64, endPort *int64) *int {
+// convertFromIPPermissionICMPTypeCode converts ec2.IpPermission port fields of an ICMP permission to rule
+// ICMP type and code.
+func convertFromIPPermissionICMPTypeCode(startPort *int64, endPort *int64) (*int, *int) {
+	var icmpType, icmpCode *int
+	if startPort != nil && *startPort != awsAnyICMPValue {
+		icmpType = aws.Int(int(*startPort))
+	}
+	if endPort != nil && *endPort != awsAnyICMPValue {
+		icmpCode = aws.Int(int(*endPort))
+	}
+	return icmpType, icmpCode
+}
+
+// convertFromIPPermissionPort converts ec2.IpPermission port range to rule port and end port.
+// End port is nil for a single port.
+func convertFromIPPermissionPort(startPort *int64, endPort *int64) (*int, *int) {
 	if startPort == nil {
-		return nil
+		return nil, nil
 	}
 	if endPort == nil {
 		retVal := int(*startPort)
-		return &retVal
+		return &retVal, nil
 	}
 	if *startPort == -1 {
-		return nil
+		return nil, nil
 	}
 	if *startPort == *endPort {
 		retVal := int(*startPort)
-		return &retVal
+		return &retVal, nil
+	}
+	if *startPort < *endPort && !(*startPort == int64(tcpUDPPortStart) && *endPort == int64(tcpUDPPortEnd)) {
+		retVal, retEndVal := int(*startPort), int(*endPort)
+		return &retVal, &retEndVal
 	}
 	// other cases along with all (0 - 65535) tcp/udp ports returns nil
-	return nil
+	return nil, nil
 }
 
 func convertFromIPPermissionProtocol(proto string) *int {
@@ -62,6 +62,16 @@ func ec2InstanceToInternalVirtualMachineObject(instance *ec2.Instance, namespace
 				}
 			}
 		}
+		for _, ipv6Address := range nwInf.Ipv6Addresses {
+			if ipv6Address.Ipv6Address == nil {
+				continue
+			}
+			ipAddressCRD := runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeInternalIP,
+				Address:     *ipv6Address.Ipv6Address,
+			}
+			ipAddressCRDs = append(ipAddressCRDs, ipAddressCRD)
+		}
 		networkInterface := runtimev1alpha1.NetworkInterface{
 			Name: *nwInf.NetworkInterfaceId,
 			MAC:  *nwInf.MacAddress,
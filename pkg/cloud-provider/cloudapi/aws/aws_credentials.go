@@ -0,0 +1,126 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/endpointcreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+)
+
+const (
+	// podIdentityEndpointEnv and podIdentityTokenFileEnv are injected into Pods by the EKS Pod Identity webhook.
+	podIdentityEndpointEnv  = "AWS_CONTAINER_CREDENTIALS_FULL_URI"
+	podIdentityTokenFileEnv = "AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"
+	// sourceProviderName is the name of the credentials provider of a credential source.
+	sourceProviderName = "CredentialSourceProvider"
+)
+
+// sourceProvider retrieves the access keys of an account from its credential source. The access keys are retrieved
+// again when they expire, so that the clients of the account use new access keys without being recreated.
+type sourceProvider struct {
+	credentials.Expiry
+	source      credentialsource.Source
+	staticCreds bool
+}
+
+// Retrieve retrieves the access keys from the credential source.
+func (p *sourceProvider) Retrieve() (credentials.Value, error) {
+	data, expiry, err := p.source.Retrieve()
+	if err != nil {
+		return credentials.Value{ProviderName: sourceProviderName}, err
+	}
+	cred := &crdv1alpha1.AwsAccountCredential{}
+	if err = json.Unmarshal(data, cred); err != nil {
+		return credentials.Value{ProviderName: sourceProviderName}, err
+	}
+
+	if expiry.IsZero() {
+		p.staticCreds = true
+	} else {
+		p.SetExpiration(expiry, credentialsource.ExpiryWindow)
+	}
+	return credentials.Value{
+		AccessKeyID:     cred.AccessKeyID,
+		SecretAccessKey: cred.AccessKeySecret,
+		SessionToken:    cred.SessionToken,
+		ProviderName:    sourceProviderName,
+	}, nil
+}
+
+// IsExpired returns true if the access keys must be retrieved again from the credential source.
+func (p *sourceProvider) IsExpired() bool {
+	if p.staticCreds {
+		return false
+	}
+	return p.Expiry.IsExpired()
+}
+
+// newAccessKeyCredentials returns the access key credentials of an account, which are retrieved from the credential
+// source of the account if specified.
+func newAccessKeyCredentials(accConfig *awsAccountConfig) *credentials.Credentials {
+	if accConfig.source != nil {
+		return credentials.NewCredentials(&sourceProvider{source: accConfig.source})
+	}
+	return credentials.NewStaticCredentials(accConfig.AccessKeyID, accConfig.AccessKeySecret, accConfig.SessionToken)
+}
+
+// podIdentityProvider retrieves credentials from the EKS Pod Identity agent. The authorization token is
+// read from the projected token file on every retrieval, as the token is rotated by kubelet.
+type podIdentityProvider struct {
+	*endpointcreds.Provider
+	tokenFile string
+}
+
+// Retrieve retrieves credentials from the EKS Pod Identity agent.
+func (p *podIdentityProvider) Retrieve() (credentials.Value, error) {
+	return p.RetrieveWithContext(aws.BackgroundContext())
+}
+
+// RetrieveWithContext retrieves credentials from the EKS Pod Identity agent.
+func (p *podIdentityProvider) RetrieveWithContext(ctx credentials.Context) (credentials.Value, error) {
+	token, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return credentials.Value{ProviderName: endpointcreds.ProviderName},
+			fmt.Errorf("unable to read pod identity token file %v: %v", p.tokenFile, err)
+	}
+	p.AuthorizationToken = strings.TrimSpace(string(token))
+	return p.Provider.RetrieveWithContext(ctx)
+}
+
+// newWorkloadIdentityCredentials returns the credentials of the workload identity of the Pod. EKS Pod Identity
+// is used when configured for the Pod, otherwise nil is returned and the web identity token of IRSA, i.e.
+// AWS_WEB_IDENTITY_TOKEN_FILE and AWS_ROLE_ARN, is resolved by the default credential chain of the session.
+func newWorkloadIdentityCredentials() *credentials.Credentials {
+	endpoint := os.Getenv(podIdentityEndpointEnv)
+	tokenFile := os.Getenv(podIdentityTokenFileEnv)
+	if len(endpoint) == 0 || len(tokenFile) == 0 {
+		return nil
+	}
+	provider := endpointcreds.NewProviderClient(*defaults.Config(), defaults.Handlers(), endpoint).(*endpointcreds.Provider)
+	return credentials.NewCredentials(&podIdentityProvider{
+		Provider:  provider,
+		tokenFile: tokenFile,
+	})
+}
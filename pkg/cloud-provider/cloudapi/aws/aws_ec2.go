@@ -29,6 +29,7 @@ import (
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
 )
 
 type ec2ServiceConfig struct {
@@ -43,6 +44,8 @@ type ec2ServiceConfig struct {
 	// - key with "some-filter-string" value indicates some filter. Get instances matching those filters only.
 	instanceFilters map[string][][]*ec2.Filter
 	credentials     *awsAccountConfig
+	// networkACLEntries are network acl entries realizing deny rules of each appliedTo group.
+	networkACLEntries map[securitygroup.CloudResourceID][]*ec2.NetworkAclEntry
 }
 
 // ec2ResourcesCacheSnapshot holds the results from querying for all instances.
@@ -69,6 +72,7 @@ func newEC2ServiceConfig(accountNamespacedName types.NamespacedName, service aws
 		inventoryStats:        &internal.CloudServiceStats{},
 		instanceFilters:       make(map[string][][]*ec2.Filter),
 		credentials:           credentials,
+		networkACLEntries:     make(map[securitygroup.CloudResourceID][]*ec2.NetworkAclEntry),
 	}
 	return config, nil
 }
@@ -186,6 +190,16 @@ func (ec2Cfg *ec2ServiceConfig) GetCachedVpcs() []*ec2.Vpc {
 	return vpcsToReturn
 }
 
+// hasVpc returns true if a vpc is in the cached snapshot of the region.
+func (ec2Cfg *ec2ServiceConfig) hasVpc(vpcID string) bool {
+	for _, vpc := range ec2Cfg.GetCachedVpcs() {
+		if strings.EqualFold(aws.StringValue(vpc.VpcId), vpcID) {
+			return true
+		}
+	}
+	return false
+}
+
 // getVpcPeers returns all the peers of a vpc.
 func (ec2Cfg *ec2ServiceConfig) getVpcPeers(vpcID string) []string {
 	snapshot := ec2Cfg.resourcesCache.GetSnapshot()
@@ -309,7 +323,10 @@ func (ec2Cfg *ec2ServiceConfig) GetInternalResourceObjects(namespace string,
 }
 
 func (ec2Cfg *ec2ServiceConfig) GetName() internal.CloudServiceName {
-	return awsComputeServiceNameEC2
+	if len(ec2Cfg.credentials.accountID) != 0 {
+		return getMemberEC2ServiceName(ec2Cfg.credentials.accountID, ec2Cfg.credentials.region)
+	}
+	return getEC2ServiceName(ec2Cfg.credentials.region)
 }
 
 func (ec2Cfg *ec2ServiceConfig) GetType() internal.CloudServiceType {
@@ -387,6 +404,9 @@ func (ec2Cfg *ec2ServiceConfig) GetVpcInventory() map[string]*runtimev1alpha1.Vp
 		}
 		vpcObj := ec2VpcToInternalVpcObject(vpc, ec2Cfg.accountNamespacedName.Namespace, ec2Cfg.accountNamespacedName.Name,
 			strings.ToLower(ec2Cfg.credentials.region), managed)
+		for _, peer := range ec2Cfg.getVpcPeers(*vpc.VpcId) {
+			vpcObj.Status.Peers = append(vpcObj.Status.Peers, strings.ToLower(peer))
+		}
 		vpcMap[strings.ToLower(*vpc.VpcId)] = vpcObj
 	}
 
@@ -0,0 +1,601 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+const (
+	// Nephe creates network acl entries with rule numbers from networkACLEntryStartRuleNumber to
+	// networkACLEntryEndRuleNumber, which are evaluated ahead of the default entry numbered 100. An entry in the range
+	// is only managed by nephe if an appliedTo group owns it, hence entries created by users are never modified.
+	networkACLEntryStartRuleNumber = 1
+	networkACLEntryEndRuleNumber   = 99
+	// networkACLDefaultEntryRuleNumber is the rule number of the default entry of each direction, which does not count
+	// towards the aws quota of entries per network acl.
+	networkACLDefaultEntryRuleNumber = 32767
+	// awsMaxTagsPerResource is the aws limit of tags per resource.
+	awsMaxTagsPerResource = 50
+)
+
+// networkACLEntryRef refers to an entry of a network acl, whose rule number is unique per direction.
+type networkACLEntryRef struct {
+	egress     bool
+	ruleNumber int64
+}
+
+// getNetworkACLOwnerTagKeyPrefix returns the key prefix of network acl tags recording the entries of a direction
+// owned by appliedTo groups.
+func getNetworkACLOwnerTagKeyPrefix(egress bool) string {
+	if egress {
+		return securitygroup.ControllerPrefix + "-nacl-egress/"
+	}
+	return securitygroup.ControllerPrefix + "-nacl-ingress/"
+}
+
+// getNetworkACLOwners returns the entries of a network acl owned by each appliedTo group, as recorded in the tags of
+// the network acl. E.g. tag "nephe-nacl-ingress/nephe-at-web" with value "1 2" records that appliedTo group web owns
+// ingress entries numbered 1 and 2.
+func getNetworkACLOwners(networkAcl *ec2.NetworkAcl) map[string]map[networkACLEntryRef]struct{} {
+	owners := make(map[string]map[networkACLEntryRef]struct{})
+	for _, tag := range networkAcl.Tags {
+		key := aws.StringValue(tag.Key)
+		for _, egress := range []bool{false, true} {
+			prefix := getNetworkACLOwnerTagKeyPrefix(egress)
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			owner := key[len(prefix):]
+			if owners[owner] == nil {
+				owners[owner] = make(map[networkACLEntryRef]struct{})
+			}
+			for _, field := range strings.Fields(aws.StringValue(tag.Value)) {
+				ruleNumber, err := strconv.ParseInt(field, 10, 64)
+				if err != nil || ruleNumber < networkACLEntryStartRuleNumber || ruleNumber > networkACLEntryEndRuleNumber {
+					continue
+				}
+				owners[owner][networkACLEntryRef{egress: egress, ruleNumber: ruleNumber}] = struct{}{}
+			}
+		}
+	}
+	return owners
+}
+
+// getNetworkACLOwnerTagValue returns the value of the owner tag of a direction, which is the sorted rule numbers of
+// the entries of the direction.
+func getNetworkACLOwnerTagValue(refs map[networkACLEntryRef]struct{}, egress bool) string {
+	ruleNumbers := make([]int64, 0, len(refs))
+	for ref := range refs {
+		if ref.egress == egress {
+			ruleNumbers = append(ruleNumbers, ref.ruleNumber)
+		}
+	}
+	sort.Slice(ruleNumbers, func(i, j int) bool {
+		return ruleNumbers[i] < ruleNumbers[j]
+	})
+	values := make([]string, 0, len(ruleNumbers))
+	for _, ruleNumber := range ruleNumbers {
+		values = append(values, strconv.FormatInt(ruleNumber, 10))
+	}
+	return strings.Join(values, " ")
+}
+
+// getNetworkAclsOfVpc returns the network acls of the vpc.
+func (ec2Cfg *ec2ServiceConfig) getNetworkAclsOfVpc(vpcID string) ([]*ec2.NetworkAcl, error) {
+	input := &ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{{Name: aws.String(awsFilterKeyVPCID), Values: []*string{aws.String(vpcID)}}},
+	}
+	output, err := ec2Cfg.apiClient.describeNetworkAcls(input)
+	if err != nil {
+		return nil, err
+	}
+	return output.NetworkAcls, nil
+}
+
+// getAppliedToSubnets returns the subnets of network interfaces attached to the cloud security group of an appliedTo
+// group. Network acl entries apply to all network interfaces of a subnet, hence subnets having network interfaces not
+// attached to the cloud security group are excluded, and returned as error.
+func (ec2Cfg *ec2ServiceConfig) getAppliedToSubnets(appliedToGroupIdentifier *securitygroup.CloudResourceID) (
+	map[string]struct{}, error) {
+	networkInterfaces, err := ec2Cfg.getNetworkInterfacesOfVpc(map[string]struct{}{appliedToGroupIdentifier.Vpc: {}})
+	if err != nil {
+		return nil, err
+	}
+	cloudSgName := appliedToGroupIdentifier.GetCloudName(false)
+	subnetIDs := make(map[string]struct{})
+	nonMemberSubnetIDs := make(map[string]struct{})
+	for _, networkInterface := range networkInterfaces {
+		if networkInterface.SubnetId == nil {
+			continue
+		}
+		member := false
+		for _, group := range networkInterface.Groups {
+			if strings.ToLower(aws.StringValue(group.GroupName)) == cloudSgName {
+				member = true
+				break
+			}
+		}
+		if member {
+			subnetIDs[*networkInterface.SubnetId] = struct{}{}
+		} else {
+			nonMemberSubnetIDs[*networkInterface.SubnetId] = struct{}{}
+		}
+	}
+	var mixedSubnetIDs []string
+	for subnetID := range subnetIDs {
+		if _, found := nonMemberSubnetIDs[subnetID]; found {
+			mixedSubnetIDs = append(mixedSubnetIDs, subnetID)
+			delete(subnetIDs, subnetID)
+		}
+	}
+	if len(mixedSubnetIDs) != 0 {
+		sort.Strings(mixedSubnetIDs)
+		return subnetIDs, fmt.Errorf("deny rules of %v cannot be realized on subnets %v, which have network interfaces "+
+			"not in the appliedTo group, as network acls apply to all network interfaces of a subnet", cloudSgName,
+			mixedSubnetIDs)
+	}
+	return subnetIDs, nil
+}
+
+// convertToNetworkACLEntries converts deny rules to network acl entries without rule numbers, one entry per CIDR.
+// Network acls can only match CIDRs, hence deny rules referring to security groups are not supported.
+func convertToNetworkACLEntries(rules []*securitygroup.CloudRule) ([]*ec2.NetworkAclEntry, error) {
+	var entries []*ec2.NetworkAclEntry
+	for _, obj := range rules {
+		if !obj.IsDeny() {
+			continue
+		}
+		var ips []*net.IPNet
+		var sgs []*securitygroup.CloudResourceID
+		var port, endPort, protocol, icmpType, icmpCode *int
+		egress := false
+		switch rule := obj.Rule.(type) {
+		case *securitygroup.IngressRule:
+			ips, sgs = rule.FromSrcIP, rule.FromSecurityGroups
+			port, endPort, protocol = rule.FromPort, rule.FromEndPort, rule.Protocol
+			icmpType, icmpCode = rule.ICMPType, rule.ICMPCode
+		case *securitygroup.EgressRule:
+			ips, sgs = rule.ToDstIP, rule.ToSecurityGroups
+			port, endPort, protocol = rule.ToPort, rule.ToEndPort, rule.Protocol
+			icmpType, icmpCode = rule.ICMPType, rule.ICMPCode
+			egress = true
+		}
+		if len(sgs) != 0 {
+			return nil, fmt.Errorf("deny rules referring to security groups are not supported by aws network acls, rule %v", obj.Hash)
+		}
+
+		cidrs := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			cidrs = append(cidrs, ip.String())
+		}
+		if len(cidrs) == 0 {
+			cidrs = []string{ipv4AnyCidr, ipv6AnyCidr}
+		}
+		for _, cidr := range cidrs {
+			entry := &ec2.NetworkAclEntry{
+				Egress:     aws.Bool(egress),
+				Protocol:   convertToIPPermissionProtocol(protocol),
+				RuleAction: aws.String(ec2.RuleActionDeny),
+			}
+			if ip, _, _ := net.ParseCIDR(cidr); ip.To4() != nil {
+				entry.CidrBlock = aws.String(cidr)
+			} else {
+				entry.Ipv6CidrBlock = aws.String(cidr)
+			}
+			if securitygroup.IsICMPProtocol(protocol) {
+				t, c := convertToIPPermissionICMPTypeCode(icmpType, icmpCode)
+				entry.IcmpTypeCode = &ec2.IcmpTypeCode{Type: t, Code: c}
+			} else if from, to := convertToIPPermissionPort(port, endPort, protocol); from != nil {
+				entry.PortRange = &ec2.PortRange{From: from, To: to}
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// getNetworkACLEntryKey returns a key identifying the traffic matched by a network acl entry, regardless of its rule number.
+func getNetworkACLEntryKey(entry *ec2.NetworkAclEntry) string {
+	key := fmt.Sprintf("egress=%v,protocol=%v,cidr=%v%v,action=%v", aws.BoolValue(entry.Egress), aws.StringValue(entry.Protocol),
+		aws.StringValue(entry.CidrBlock), aws.StringValue(entry.Ipv6CidrBlock), aws.StringValue(entry.RuleAction))
+	if entry.PortRange != nil {
+		key += fmt.Sprintf(",port=%v-%v", aws.Int64Value(entry.PortRange.From), aws.Int64Value(entry.PortRange.To))
+	}
+	if entry.IcmpTypeCode != nil {
+		key += fmt.Sprintf(",icmp=%v/%v", aws.Int64Value(entry.IcmpTypeCode.Type), aws.Int64Value(entry.IcmpTypeCode.Code))
+	}
+	return key
+}
+
+// getNetworkACLOwnedEntries returns the entries owned by an appliedTo group in network acls, without rule numbers.
+func getNetworkACLOwnedEntries(networkAcls []*ec2.NetworkAcl, owner string) []*ec2.NetworkAclEntry {
+	var entries []*ec2.NetworkAclEntry
+	keys := make(map[string]struct{})
+	for _, networkAcl := range networkAcls {
+		refs := getNetworkACLOwners(networkAcl)[owner]
+		for _, entry := range networkAcl.Entries {
+			ref := networkACLEntryRef{egress: aws.BoolValue(entry.Egress), ruleNumber: aws.Int64Value(entry.RuleNumber)}
+			if _, owned := refs[ref]; !owned {
+				continue
+			}
+			if _, found := keys[getNetworkACLEntryKey(entry)]; found {
+				continue
+			}
+			keys[getNetworkACLEntryKey(entry)] = struct{}{}
+			entries = append(entries, &ec2.NetworkAclEntry{
+				Egress:        entry.Egress,
+				Protocol:      entry.Protocol,
+				RuleAction:    entry.RuleAction,
+				CidrBlock:     entry.CidrBlock,
+				Ipv6CidrBlock: entry.Ipv6CidrBlock,
+				PortRange:     entry.PortRange,
+				IcmpTypeCode:  entry.IcmpTypeCode,
+			})
+		}
+	}
+	return entries
+}
+
+// updateNetworkACLEntries realizes deny rules of an appliedTo group as network acl entries on the subnets of its
+// member network interfaces. rules are all rules of the appliedTo group.
+func (ec2Cfg *ec2ServiceConfig) updateNetworkACLEntries(appliedToGroupIdentifier *securitygroup.CloudResourceID,
+	rules []*securitygroup.CloudRule) error {
+	entries, err := convertToNetworkACLEntries(rules)
+	if err != nil {
+		return err
+	}
+	var subnetIDs map[string]struct{}
+	if len(entries) != 0 {
+		if subnetIDs, err = ec2Cfg.getAppliedToSubnets(appliedToGroupIdentifier); err != nil {
+			return err
+		}
+	}
+	networkAcls, err := ec2Cfg.getNetworkAclsOfVpc(appliedToGroupIdentifier.Vpc)
+	if err != nil {
+		return err
+	}
+	if err = ec2Cfg.realizeNetworkACLEntries(networkAcls, appliedToGroupIdentifier.GetCloudName(false), subnetIDs,
+		entries); err != nil {
+		return err
+	}
+	ec2Cfg.networkACLEntries[*appliedToGroupIdentifier] = entries
+	return nil
+}
+
+// updateNetworkACLSubnets realizes network acl entries of an appliedTo group on the subnets of its member network
+// interfaces, after its members are updated. Entries realized since the plugin started are used, and otherwise the
+// entries owned by the appliedTo group in network acls. Entries are removed from subnets having network interfaces
+// not in the appliedTo group, which are returned as error.
+func (ec2Cfg *ec2ServiceConfig) updateNetworkACLSubnets(appliedToGroupIdentifier *securitygroup.CloudResourceID) error {
+	entries, found := ec2Cfg.networkACLEntries[*appliedToGroupIdentifier]
+	if found && len(entries) == 0 {
+		return nil
+	}
+	owner := appliedToGroupIdentifier.GetCloudName(false)
+	networkAcls, err := ec2Cfg.getNetworkAclsOfVpc(appliedToGroupIdentifier.Vpc)
+	if err != nil {
+		return err
+	}
+	if !found {
+		if entries = getNetworkACLOwnedEntries(networkAcls, owner); len(entries) == 0 {
+			return nil
+		}
+	}
+	subnetIDs, subnetErr := ec2Cfg.getAppliedToSubnets(appliedToGroupIdentifier)
+	if subnetIDs == nil {
+		return subnetErr
+	}
+	if err = ec2Cfg.realizeNetworkACLEntries(networkAcls, owner, subnetIDs, entries); err != nil {
+		return err
+	}
+	return subnetErr
+}
+
+// deleteNetworkACLEntries removes all network acl entries of an appliedTo group.
+func (ec2Cfg *ec2ServiceConfig) deleteNetworkACLEntries(appliedToGroupIdentifier *securitygroup.CloudResourceID) error {
+	networkAcls, err := ec2Cfg.getNetworkAclsOfVpc(appliedToGroupIdentifier.Vpc)
+	if err != nil {
+		return err
+	}
+	if err = ec2Cfg.realizeNetworkACLEntries(networkAcls, appliedToGroupIdentifier.GetCloudName(false), nil, nil); err != nil {
+		return err
+	}
+	delete(ec2Cfg.networkACLEntries, *appliedToGroupIdentifier)
+	return nil
+}
+
+// realizeNetworkACLEntries invokes cloud api and realizes entries of an appliedTo group on network acls associated
+// with its subnets, and removes its entries from other network acls. Updates of all network acls are planned and
+// checked against aws quotas before any of them is realized.
+func (ec2Cfg *ec2ServiceConfig) realizeNetworkACLEntries(networkAcls []*ec2.NetworkAcl, owner string,
+	subnetIDs map[string]struct{}, entries []*ec2.NetworkAclEntry) error {
+	var updates []*networkACLUpdate
+	for _, networkAcl := range networkAcls {
+		var aclEntries []*ec2.NetworkAclEntry
+		for _, association := range networkAcl.Associations {
+			if _, found := subnetIDs[aws.StringValue(association.SubnetId)]; found {
+				aclEntries = entries
+				break
+			}
+		}
+		update, err := planNetworkACLOwnedEntries(networkAcl, owner, aclEntries)
+		if err != nil {
+			return err
+		}
+		if update != nil {
+			updates = append(updates, update)
+		}
+	}
+	for _, update := range updates {
+		if err := ec2Cfg.realizeNetworkACLUpdate(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// networkACLUpdate is the planned update of the entries owned by an appliedTo group on a network acl.
+type networkACLUpdate struct {
+	networkAcl *ec2.NetworkAcl
+	owner      string
+	// previousRefs are the entries owned before the update, claimedRefs are the entries owned while the update is
+	// realized, and refs are the entries owned after the update.
+	previousRefs  map[networkACLEntryRef]struct{}
+	claimedRefs   map[networkACLEntryRef]struct{}
+	refs          map[networkACLEntryRef]struct{}
+	addEntries    []*ec2.CreateNetworkAclEntryInput
+	deleteEntries []*ec2.DeleteNetworkAclEntryInput
+	// deleteFirst is true if entries must be deleted before new entries are added to stay within the entry quota.
+	deleteFirst bool
+}
+
+// networkACLQuotaKey identifies the entries of a network acl subject to the same aws quota, which applies to each
+// direction and IP family separately.
+type networkACLQuotaKey struct {
+	egress bool
+	ipv6   bool
+}
+
+// getNetworkACLQuotaKey returns the quota key of a network acl entry.
+func getNetworkACLQuotaKey(egress *bool, ipv6CidrBlock *string) networkACLQuotaKey {
+	return networkACLQuotaKey{egress: aws.BoolValue(egress), ipv6: ipv6CidrBlock != nil}
+}
+
+// planNetworkACLOwnedEntries returns the update realizing entries owned by an appliedTo group on a network acl, or nil
+// if there is nothing to update. An entry matching the same traffic is shared by all appliedTo groups owning it, and
+// is only deleted when no appliedTo group owns it. New entries are created with the lowest free rule numbers reserved
+// for nephe, and the ownership is recorded in the tags of the network acl. All entries deny traffic, hence their order
+// does not matter. An error is returned if the update exceeds the aws quota of entries or tags of the network acl.
+func planNetworkACLOwnedEntries(networkAcl *ec2.NetworkAcl, owner string,
+	entries []*ec2.NetworkAclEntry) (*networkACLUpdate, error) {
+	owners := getNetworkACLOwners(networkAcl)
+	previousRefs := owners[owner]
+	if len(previousRefs) == 0 && len(entries) == 0 {
+		return nil, nil
+	}
+	// entries owned by other appliedTo groups are kept.
+	sharedRefs := make(map[networkACLEntryRef]struct{})
+	for other, refs := range owners {
+		if other == owner {
+			continue
+		}
+		for ref := range refs {
+			sharedRefs[ref] = struct{}{}
+		}
+	}
+
+	// rule numbers are unique per direction of a network acl.
+	usedRuleNumbers := map[bool]map[int64]struct{}{true: {}, false: {}}
+	entryCounts := make(map[networkACLQuotaKey]int)
+	ownedRefs := make(map[string]networkACLEntryRef)
+	for _, entry := range networkAcl.Entries {
+		ref := networkACLEntryRef{egress: aws.BoolValue(entry.Egress), ruleNumber: aws.Int64Value(entry.RuleNumber)}
+		usedRuleNumbers[ref.egress][ref.ruleNumber] = struct{}{}
+		if ref.ruleNumber < networkACLDefaultEntryRuleNumber {
+			entryCounts[getNetworkACLQuotaKey(entry.Egress, entry.Ipv6CidrBlock)]++
+		}
+		_, owned := previousRefs[ref]
+		if _, shared := sharedRefs[ref]; owned || shared {
+			ownedRefs[getNetworkACLEntryKey(entry)] = ref
+		}
+	}
+
+	update := &networkACLUpdate{
+		networkAcl:   networkAcl,
+		owner:        owner,
+		previousRefs: previousRefs,
+		claimedRefs:  make(map[networkACLEntryRef]struct{}),
+		refs:         make(map[networkACLEntryRef]struct{}),
+	}
+	addCounts := make(map[networkACLQuotaKey]int)
+	for _, entry := range entries {
+		key := getNetworkACLEntryKey(entry)
+		if ref, found := ownedRefs[key]; found {
+			update.refs[ref] = struct{}{}
+			continue
+		}
+		egress := aws.BoolValue(entry.Egress)
+		ruleNumber := int64(networkACLEntryStartRuleNumber)
+		for ; ruleNumber <= networkACLEntryEndRuleNumber; ruleNumber++ {
+			if _, used := usedRuleNumbers[egress][ruleNumber]; !used {
+				break
+			}
+		}
+		if ruleNumber > networkACLEntryEndRuleNumber {
+			return nil, fmt.Errorf("no free rule number from %v to %v in network acl %v", networkACLEntryStartRuleNumber,
+				networkACLEntryEndRuleNumber, *networkAcl.NetworkAclId)
+		}
+		usedRuleNumbers[egress][ruleNumber] = struct{}{}
+		addCounts[getNetworkACLQuotaKey(entry.Egress, entry.Ipv6CidrBlock)]++
+		ref := networkACLEntryRef{egress: egress, ruleNumber: ruleNumber}
+		ownedRefs[key] = ref
+		update.refs[ref] = struct{}{}
+		update.addEntries = append(update.addEntries, &ec2.CreateNetworkAclEntryInput{
+			NetworkAclId:  networkAcl.NetworkAclId,
+			RuleNumber:    aws.Int64(ruleNumber),
+			Egress:        entry.Egress,
+			Protocol:      entry.Protocol,
+			RuleAction:    entry.RuleAction,
+			CidrBlock:     entry.CidrBlock,
+			Ipv6CidrBlock: entry.Ipv6CidrBlock,
+			PortRange:     entry.PortRange,
+			IcmpTypeCode:  entry.IcmpTypeCode,
+		})
+	}
+
+	deleteCounts := make(map[networkACLQuotaKey]int)
+	for _, entry := range networkAcl.Entries {
+		ref := networkACLEntryRef{egress: aws.BoolValue(entry.Egress), ruleNumber: aws.Int64Value(entry.RuleNumber)}
+		_, owned := previousRefs[ref]
+		_, kept := update.refs[ref]
+		_, shared := sharedRefs[ref]
+		if !owned || kept || shared {
+			continue
+		}
+		deleteCounts[getNetworkACLQuotaKey(entry.Egress, entry.Ipv6CidrBlock)]++
+		update.deleteEntries = append(update.deleteEntries, &ec2.DeleteNetworkAclEntryInput{
+			NetworkAclId: networkAcl.NetworkAclId,
+			Egress:       entry.Egress,
+			RuleNumber:   entry.RuleNumber,
+		})
+	}
+	for key, count := range addCounts {
+		if count == 0 {
+			continue
+		}
+		if total := entryCounts[key] + count - deleteCounts[key]; total > awsMaxNetworkACLEntries {
+			return nil, fmt.Errorf("network acl %v would have %v %v entries, exceeding the quota of %v entries",
+				*networkAcl.NetworkAclId, total, getNetworkACLQuotaKeyDescription(key), awsMaxNetworkACLEntries)
+		}
+		if entryCounts[key]+count > awsMaxNetworkACLEntries {
+			update.deleteFirst = true
+		}
+	}
+
+	// claim rule numbers of new entries before creating them, so that entries are never left without owner.
+	for ref := range previousRefs {
+		update.claimedRefs[ref] = struct{}{}
+	}
+	for ref := range update.refs {
+		update.claimedRefs[ref] = struct{}{}
+	}
+	tagCount := len(networkAcl.Tags)
+	for _, egress := range []bool{false, true} {
+		claimed := getNetworkACLOwnerTagValue(update.claimedRefs, egress) != ""
+		if claimed && getNetworkACLOwnerTagValue(previousRefs, egress) == "" {
+			tagCount++
+		}
+	}
+	if tagCount > awsMaxTagsPerResource {
+		return nil, fmt.Errorf("network acl %v would have %v tags, exceeding the limit of %v tags per resource",
+			*networkAcl.NetworkAclId, tagCount, awsMaxTagsPerResource)
+	}
+	return update, nil
+}
+
+// getNetworkACLQuotaKeyDescription returns the description of the entries of a quota key, e.g. "inbound IPv4".
+func getNetworkACLQuotaKeyDescription(key networkACLQuotaKey) string {
+	direction, family := "inbound", "IPv4"
+	if key.egress {
+		direction = "outbound"
+	}
+	if key.ipv6 {
+		family = "IPv6"
+	}
+	return direction + " " + family
+}
+
+// realizeNetworkACLUpdate invokes cloud api and realizes a planned update of a network acl.
+func (ec2Cfg *ec2ServiceConfig) realizeNetworkACLUpdate(update *networkACLUpdate) error {
+	networkAclID := update.networkAcl.NetworkAclId
+	if update.deleteFirst {
+		if err := ec2Cfg.deleteNetworkACLEntriesOfUpdate(update); err != nil {
+			return err
+		}
+	}
+	if len(update.addEntries) != 0 {
+		if err := ec2Cfg.updateNetworkACLOwnerTags(networkAclID, update.owner, update.previousRefs,
+			update.claimedRefs); err != nil {
+			return err
+		}
+	}
+	for _, request := range update.addEntries {
+		awsPluginLogger().V(1).Info("add network acl entry", "networkAcl", *networkAclID, "owner", update.owner,
+			"entry", request)
+		if _, err := ec2Cfg.apiClient.createNetworkAclEntry(request); err != nil {
+			return err
+		}
+	}
+	if !update.deleteFirst {
+		if err := ec2Cfg.deleteNetworkACLEntriesOfUpdate(update); err != nil {
+			return err
+		}
+	}
+	return ec2Cfg.updateNetworkACLOwnerTags(networkAclID, update.owner, update.claimedRefs, update.refs)
+}
+
+// deleteNetworkACLEntriesOfUpdate invokes cloud api and deletes the entries no longer owned in a planned update.
+func (ec2Cfg *ec2ServiceConfig) deleteNetworkACLEntriesOfUpdate(update *networkACLUpdate) error {
+	for _, request := range update.deleteEntries {
+		awsPluginLogger().V(1).Info("delete network acl entry", "networkAcl", *request.NetworkAclId, "owner",
+			update.owner, "egress", aws.BoolValue(request.Egress), "ruleNumber", aws.Int64Value(request.RuleNumber))
+		if _, err := ec2Cfg.apiClient.deleteNetworkAclEntry(request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateNetworkACLOwnerTags invokes cloud api and updates the tags of a network acl recording the entries owned by an
+// appliedTo group from previousRefs to refs.
+func (ec2Cfg *ec2ServiceConfig) updateNetworkACLOwnerTags(networkAclID *string, owner string,
+	previousRefs, refs map[networkACLEntryRef]struct{}) error {
+	var createTags, deleteTags []*ec2.Tag
+	for _, egress := range []bool{false, true} {
+		value := getNetworkACLOwnerTagValue(refs, egress)
+		if value == getNetworkACLOwnerTagValue(previousRefs, egress) {
+			continue
+		}
+		key := aws.String(getNetworkACLOwnerTagKeyPrefix(egress) + owner)
+		if value == "" {
+			deleteTags = append(deleteTags, &ec2.Tag{Key: key})
+			continue
+		}
+		createTags = append(createTags, &ec2.Tag{Key: key, Value: aws.String(value)})
+	}
+	if len(createTags) != 0 {
+		request := &ec2.CreateTagsInput{Resources: []*string{networkAclID}, Tags: createTags}
+		if _, err := ec2Cfg.apiClient.createTags(request); err != nil {
+			return err
+		}
+	}
+	if len(deleteTags) != 0 {
+		request := &ec2.DeleteTagsInput{Resources: []*string{networkAclID}, Tags: deleteTags}
+		if _, err := ec2Cfg.apiClient.deleteTags(request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
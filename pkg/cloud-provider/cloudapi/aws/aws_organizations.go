@@ -0,0 +1,89 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+// awsMemberRoleArnFormat is the ARN of a role of a member account.
+// NOTE: currently only AWS standard partition is supported.
+const awsMemberRoleArnFormat = "arn:aws:iam::%s:role/%s"
+
+func (p *awsServiceSdkConfigProvider) organizations() (awsOrganizationsWrapper, error) {
+	// Organizations is a global service, endpoint of the account is not applicable.
+	organizationsClient := organizations.New(p.session, &aws.Config{Endpoint: aws.String("")})
+
+	awsOrganizations := &awsOrganizationsWrapperImpl{
+		organizations: organizationsClient,
+	}
+
+	return awsOrganizations, nil
+}
+
+// getOrganizationMemberAccounts returns the credentials of the active member accounts of the organization, which
+// are allowed and not denied in the organization config of the account. Credentials of a member account assume the
+// configured role in the member account.
+func getOrganizationMemberAccounts(helper awsServicesHelper, accCredentials *awsAccountConfig) ([]*awsAccountConfig, error) {
+	organization := accCredentials.organization
+	allowedAccountIDs := make(map[string]struct{})
+	for _, accountID := range organization.AllowedAccountIDs {
+		allowedAccountIDs[strings.TrimSpace(accountID)] = struct{}{}
+	}
+	deniedAccountIDs := make(map[string]struct{})
+	for _, accountID := range organization.DeniedAccountIDs {
+		deniedAccountIDs[strings.TrimSpace(accountID)] = struct{}{}
+	}
+
+	managementCredentials := *accCredentials
+	managementCredentials.region = getQueryRegion(accCredentials)
+	awsServiceClientCreator, err := helper.newServiceSdkConfigProvider(&managementCredentials)
+	if err != nil {
+		return nil, err
+	}
+	apiClient, err := awsServiceClientCreator.organizations()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := apiClient.pagedListAccounts(&organizations.ListAccountsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var memberAccounts []*awsAccountConfig
+	for _, account := range accounts {
+		accountID := aws.StringValue(account.Id)
+		if aws.StringValue(account.Status) != organizations.AccountStatusActive {
+			continue
+		}
+		if _, found := allowedAccountIDs[accountID]; len(allowedAccountIDs) != 0 && !found {
+			continue
+		}
+		if _, found := deniedAccountIDs[accountID]; found {
+			continue
+		}
+		memberCredentials := *accCredentials
+		memberCredentials.accountID = accountID
+		memberCredentials.RoleArn = fmt.Sprintf(awsMemberRoleArnFormat, accountID, strings.TrimSpace(organization.RoleName))
+		memberCredentials.ExternalID = organization.ExternalID
+		memberAccounts = append(memberAccounts, &memberCredentials)
+	}
+	awsPluginLogger().Info("Organization member accounts", "accounts", len(accounts), "imported", len(memberAccounts))
+	return memberAccounts, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/cenkalti/backoff/v4"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+const (
+	// awsPrefixListMinEntries is the minimum number of CIDRs of an address family of a rule realized by a managed
+	// prefix list, instead of ip ranges of the security group rule.
+	awsPrefixListMinEntries = 10
+	// awsPrefixListMaxEntries is the aws limit of entries per managed prefix list.
+	awsPrefixListMaxEntries = 1000
+	// awsPrefixListCreateTimeout is the maximum time to wait for a created managed prefix list to become usable.
+	awsPrefixListCreateTimeout = 10 * time.Second
+
+	awsPrefixListAddressFamilyIPv4 = "IPv4"
+	awsPrefixListAddressFamilyIPv6 = "IPv6"
+	awsFilterKeyPrefixListName     = "prefix-list-name"
+)
+
+// getPrefixListCloudNamePrefix returns the name prefix of managed prefix lists created by nephe.
+func getPrefixListCloudNamePrefix() string {
+	return securitygroup.ControllerPrefix + "-pl-"
+}
+
+// getPrefixListCloudName returns the name of the managed prefix list of CIDRs. A prefix list is named after its
+// CIDRs, hence rules with the same CIDRs share a prefix list, and a prefix list is never modified once created.
+func getPrefixListCloudName(cidrs []string) string {
+	return fmt.Sprintf("%v%x", getPrefixListCloudNamePrefix(), sha1.Sum([]byte(strings.Join(cidrs, ","))))
+}
+
+// getPrefixListCidrs returns the sorted CIDRs of an address family of a rule if they are realized by a managed prefix
+// list, or nil if they are realized as ip ranges of the security group rule.
+func getPrefixListCidrs(ips []*net.IPNet, ipv6 bool) []string {
+	var cidrs []string
+	for _, ip := range ips {
+		if (ip.IP.To4() == nil) == ipv6 {
+			cidrs = append(cidrs, ip.String())
+		}
+	}
+	if len(cidrs) < awsPrefixListMinEntries || len(cidrs) > awsPrefixListMaxEntries {
+		return nil
+	}
+	sort.Strings(cidrs)
+	return cidrs
+}
+
+// getPrefixListMaxEntries returns the max entries of the managed prefix list of CIDRs. Aws counts a reference to a
+// prefix list as max entries rules towards the rule limit of a security group, hence max entries is kept to the number
+// of CIDRs, and a prefix list takes as many rules as the ip ranges it replaces.
+func getPrefixListMaxEntries(cidrs []string) int {
+	return len(cidrs)
+}
+
+// getPrefixListsWithName returns the managed prefix lists with given names.
+func (ec2Cfg *ec2ServiceConfig) getPrefixListsWithName(names []string) ([]*ec2.ManagedPrefixList, error) {
+	input := &ec2.DescribeManagedPrefixListsInput{
+		Filters: []*ec2.Filter{{Name: aws.String(awsFilterKeyPrefixListName), Values: aws.StringSlice(names)}},
+	}
+	output, err := ec2Cfg.apiClient.describeManagedPrefixLists(input)
+	if err != nil {
+		return nil, err
+	}
+	return output.PrefixLists, nil
+}
+
+// waitForPrefixListCreation waits for a created managed prefix list to become usable in security group rules.
+func (ec2Cfg *ec2ServiceConfig) waitForPrefixListCreation(name string, duration time.Duration) error {
+	operation := func() error {
+		prefixLists, err := ec2Cfg.getPrefixListsWithName([]string{name})
+		if err != nil {
+			return err
+		}
+		if len(prefixLists) == 0 {
+			return fmt.Errorf("failed to find created managed prefix list name %v", name)
+		}
+		switch state := aws.StringValue(prefixLists[0].State); state {
+		case ec2.PrefixListStateCreateComplete:
+			return nil
+		case ec2.PrefixListStateCreateFailed:
+			return backoff.Permanent(fmt.Errorf("failed to create managed prefix list %v: %v", name,
+				aws.StringValue(prefixLists[0].StateMessage)))
+		default:
+			return fmt.Errorf("managed prefix list %v is in state %v", name, state)
+		}
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = duration
+	return backoff.Retry(operation, b)
+}
+
+// getOrCreatePrefixList returns the ID of the managed prefix list of CIDRs of an address family. A missing prefix list
+// is created if create is true, otherwise nil is returned.
+func (ec2Cfg *ec2ServiceConfig) getOrCreatePrefixList(cidrs []string, ipv6 bool, create bool) (*string, error) {
+	name := getPrefixListCloudName(cidrs)
+	prefixLists, err := ec2Cfg.getPrefixListsWithName([]string{name})
+	if err != nil {
+		return nil, err
+	}
+	for _, prefixList := range prefixLists {
+		switch aws.StringValue(prefixList.State) {
+		case ec2.PrefixListStateCreateFailed, ec2.PrefixListStateDeleteInProgress, ec2.PrefixListStateDeleteComplete:
+			continue
+		}
+		return prefixList.PrefixListId, nil
+	}
+	if !create {
+		return nil, nil
+	}
+
+	addressFamily := awsPrefixListAddressFamilyIPv4
+	if ipv6 {
+		addressFamily = awsPrefixListAddressFamilyIPv6
+	}
+	entries := make([]*ec2.AddPrefixListEntry, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		entries = append(entries, &ec2.AddPrefixListEntry{Cidr: aws.String(cidr)})
+	}
+	input := &ec2.CreateManagedPrefixListInput{
+		AddressFamily:  aws.String(addressFamily),
+		Entries:        entries,
+		MaxEntries:     aws.Int64(int64(getPrefixListMaxEntries(cidrs))),
+		PrefixListName: aws.String(name),
+	}
+	output, err := ec2Cfg.apiClient.createManagedPrefixList(input)
+	if err != nil {
+		return nil, err
+	}
+	awsPluginLogger().Info("Managed prefix list created", "name", name, "entries", len(cidrs))
+
+	if err = ec2Cfg.waitForPrefixListCreation(name, awsPrefixListCreateTimeout); err != nil {
+		awsPluginLogger().Info("Error declared, managed prefix list will be deleted", "name", name)
+		_, _ = ec2Cfg.apiClient.deleteManagedPrefixList(&ec2.DeleteManagedPrefixListInput{
+			PrefixListId: output.PrefixList.PrefixListId,
+		})
+		return nil, err
+	}
+	return output.PrefixList.PrefixListId, nil
+}
+
+// convertToEc2PrefixListIds returns the references to managed prefix lists realizing large CIDR sets of a rule, and
+// the remaining CIDRs of the rule realized as ip ranges. Missing prefix lists are created if create is true. When
+// removing a rule, CIDRs without prefix list are assumed to be realized as ip ranges.
+func (ec2Cfg *ec2ServiceConfig) convertToEc2PrefixListIds(ips []*net.IPNet, create bool, description *string) (
+	[]*net.IPNet, []*ec2.PrefixListId, error) {
+	var prefixListIDs []*ec2.PrefixListId
+	realized := make(map[bool]bool)
+	for _, ipv6 := range []bool{false, true} {
+		cidrs := getPrefixListCidrs(ips, ipv6)
+		if cidrs == nil {
+			continue
+		}
+		prefixListID, err := ec2Cfg.getOrCreatePrefixList(cidrs, ipv6, create)
+		if err != nil {
+			return nil, nil, err
+		}
+		if prefixListID == nil {
+			continue
+		}
+		realized[ipv6] = true
+		prefixListIDs = append(prefixListIDs, &ec2.PrefixListId{PrefixListId: prefixListID, Description: description})
+	}
+	if len(prefixListIDs) == 0 {
+		return ips, nil, nil
+	}
+
+	var rangeIPs []*net.IPNet
+	for _, ip := range ips {
+		if !realized[ip.IP.To4() == nil] {
+			rangeIPs = append(rangeIPs, ip)
+		}
+	}
+	return rangeIPs, prefixListIDs, nil
+}
+
+// getPrefixListEntriesOfSecurityGroups returns the CIDRs of managed prefix lists referred by rules of security groups.
+func (ec2Cfg *ec2ServiceConfig) getPrefixListEntriesOfSecurityGroups(cloudSecurityGroups map[string]*ec2.SecurityGroup) (
+	map[string][]*net.IPNet, error) {
+	prefixListCidrs := make(map[string][]*net.IPNet)
+	for _, cloudSgObj := range cloudSecurityGroups {
+		for _, ipPermission := range append(cloudSgObj.IpPermissions, cloudSgObj.IpPermissionsEgress...) {
+			for _, prefixListID := range ipPermission.PrefixListIds {
+				id := aws.StringValue(prefixListID.PrefixListId)
+				if _, found := prefixListCidrs[id]; found || id == "" {
+					continue
+				}
+				entries, err := ec2Cfg.apiClient.pagedGetManagedPrefixListEntries(&ec2.GetManagedPrefixListEntriesInput{
+					PrefixListId: aws.String(id),
+				})
+				if err != nil {
+					return nil, err
+				}
+				ipNets := make([]*net.IPNet, 0, len(entries))
+				for _, entry := range entries {
+					if _, ipNet, err := net.ParseCIDR(aws.StringValue(entry.Cidr)); err == nil {
+						ipNets = append(ipNets, ipNet)
+					}
+				}
+				prefixListCidrs[id] = ipNets
+			}
+		}
+	}
+	return prefixListCidrs, nil
+}
+
+// deleteUnusedPrefixLists deletes managed prefix lists created by nephe which are not referred by security groups.
+// Aws refuses to delete a prefix list still referred, e.g. by security groups of vpcs of other accounts, which is kept.
+func (ec2Cfg *ec2ServiceConfig) deleteUnusedPrefixLists(referredPrefixListIDs map[string][]*net.IPNet) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	prefixLists, err := ec2Cfg.getPrefixListsWithName([]string{getPrefixListCloudNamePrefix() + "*"})
+	if err != nil {
+		awsPluginLogger().Error(err, "failed to get managed prefix lists", "account", ec2Cfg.accountNamespacedName)
+		return
+	}
+	for _, prefixList := range prefixLists {
+		id := aws.StringValue(prefixList.PrefixListId)
+		if _, found := referredPrefixListIDs[id]; found ||
+			!strings.HasPrefix(aws.StringValue(prefixList.PrefixListName), getPrefixListCloudNamePrefix()) {
+			continue
+		}
+		switch aws.StringValue(prefixList.State) {
+		case ec2.PrefixListStateCreateInProgress, ec2.PrefixListStateDeleteInProgress, ec2.PrefixListStateDeleteComplete:
+			continue
+		}
+		if _, err := ec2Cfg.apiClient.deleteManagedPrefixList(&ec2.DeleteManagedPrefixListInput{
+			PrefixListId: prefixList.PrefixListId,
+		}); err != nil {
+			awsPluginLogger().V(1).Info("Failed to delete managed prefix list", "id", id, "err", err)
+			continue
+		}
+		awsPluginLogger().Info("Unused managed prefix list deleted", "id", id, "name", aws.StringValue(prefixList.PrefixListName))
+	}
+}
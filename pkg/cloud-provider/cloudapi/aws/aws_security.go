@@ -16,6 +16,7 @@ package aws
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -28,7 +29,9 @@ import (
 
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+	"antrea.io/nephe/pkg/config"
 )
 
 const (
@@ -38,13 +41,36 @@ const (
 var (
 	mutex sync.Mutex
 
+	// awsMaxSecurityGroupRules is the aws quota of inbound or outbound rules per security group.
+	awsMaxSecurityGroupRules = config.DefaultAWSSecurityGroupRules
+	// awsMaxSecurityGroupsPerInterface is the aws quota of security groups per network interface.
+	awsMaxSecurityGroupsPerInterface = config.DefaultAWSSecurityGroupsPerInterface
+	// awsMaxNetworkACLEntries is the aws quota of inbound or outbound entries per network acl.
+	awsMaxNetworkACLEntries = config.DefaultAWSNetworkACLEntries
+
 	awsAnyProtocolValue = "-1"
 	tcpUDPPortStart     = 0
 	tcpUDPPortEnd       = 65535
+	ipv4AnyCidr         = "0.0.0.0/0"
+	ipv6AnyCidr         = "::/0"
+	awsAnyICMPValue     = int64(-1)
 )
 
 var vpcIDToDefaultSecurityGroup = make(map[string]string)
 
+// SetQuotas sets the aws quotas raised from their defaults, which cloud rules are realized within.
+func SetQuotas(quotas *config.AWSQuotaConfig) {
+	if quotas.SecurityGroupRules > 0 {
+		awsMaxSecurityGroupRules = quotas.SecurityGroupRules
+	}
+	if quotas.SecurityGroupsPerInterface > 0 {
+		awsMaxSecurityGroupsPerInterface = quotas.SecurityGroupsPerInterface
+	}
+	if quotas.NetworkACLEntries > 0 {
+		awsMaxNetworkACLEntries = quotas.NetworkACLEntries
+	}
+}
+
 func buildEc2UserIDGroupPairs(addressGroupIdentifiers []*securitygroup.CloudResourceID,
 	cloudSGNameToObj map[string]*ec2.SecurityGroup, description *string) []*ec2.UserIdGroupPair {
 	var userIDGroupPairs []*ec2.UserIdGroupPair
@@ -59,7 +85,8 @@ func buildEc2UserIDGroupPairs(addressGroupIdentifiers []*securitygroup.CloudReso
 	return userIDGroupPairs
 }
 
-// buildEc2CloudSgNamesFromRules builds all needed ec2 security group names from address groups in rules and target appliedTo group.
+// buildEc2CloudSgNamesFromRules builds all needed ec2 security group names from address groups in rules, target appliedTo
+// group and its rule shards.
 func buildEc2CloudSgNamesFromRules(appliedToGroupIdentifier *securitygroup.CloudResourceID, ingressRules,
 	egressRules []*securitygroup.CloudRule) map[string]struct{} {
 	cloudSgNames := make(map[string]struct{})
@@ -70,6 +97,7 @@ func buildEc2CloudSgNamesFromRules(appliedToGroupIdentifier *securitygroup.Cloud
 		for _, addressGroupIdentifier := range addressGroupIdentifiers {
 			cloudSgNames[addressGroupIdentifier.GetCloudName(true)] = struct{}{}
 		}
+		cloudSgNames[appliedToGroupIdentifier.GetShardCloudName(obj.Shard)] = struct{}{}
 	}
 
 	for _, obj := range egressRules {
@@ -78,6 +106,7 @@ func buildEc2CloudSgNamesFromRules(appliedToGroupIdentifier *securitygroup.Cloud
 		for _, addressGroupIdentifier := range addressGroupIdentifiers {
 			cloudSgNames[addressGroupIdentifier.GetCloudName(true)] = struct{}{}
 		}
+		cloudSgNames[appliedToGroupIdentifier.GetShardCloudName(obj.Shard)] = struct{}{}
 	}
 	cloudSgNames[appliedToGroupIdentifier.GetCloudName(false)] = struct{}{}
 
@@ -213,13 +242,24 @@ func (ec2Cfg *ec2ServiceConfig) realizeIngressIPPermissions(cloudSgObj *ec2.Secu
 			return fmt.Errorf("unable to generate rule description, err: %v", err)
 		}
 		idGroupPairs := buildEc2UserIDGroupPairs(rule.FromSecurityGroups, cloudSGNameToObj, &description)
-		ipRanges := convertToEc2IpRanges(rule.FromSrcIP, len(rule.FromSecurityGroups) > 0, &description)
-		startPort, endPort := convertToIPPermissionPort(rule.FromPort, rule.Protocol)
+		ips, prefixListIDs, err := ec2Cfg.convertToEc2PrefixListIds(rule.FromSrcIP, !isDelete, &description)
+		if err != nil {
+			return err
+		}
+		hasPeers := len(rule.FromSecurityGroups) > 0 || len(prefixListIDs) > 0
+		ipRanges := convertToEc2IpRanges(ips, hasPeers, &description)
+		ipv6Ranges := convertToEc2Ipv6Ranges(ips, hasPeers, &description)
+		startPort, endPort := convertToIPPermissionPort(rule.FromPort, rule.FromEndPort, rule.Protocol)
+		if securitygroup.IsICMPProtocol(rule.Protocol) {
+			startPort, endPort = convertToIPPermissionICMPTypeCode(rule.ICMPType, rule.ICMPCode)
+		}
 		ipPermission := &ec2.IpPermission{
 			FromPort:         startPort,
 			ToPort:           endPort,
 			IpProtocol:       convertToIPPermissionProtocol(rule.Protocol),
 			IpRanges:         ipRanges,
+			Ipv6Ranges:       ipv6Ranges,
+			PrefixListIds:    prefixListIDs,
 			UserIdGroupPairs: idGroupPairs,
 		}
 		newIpPermissions = append(newIpPermissions, ipPermission)
@@ -263,13 +303,24 @@ func (ec2Cfg *ec2ServiceConfig) realizeEgressIPPermissions(cloudSgObj *ec2.Secur
 		}
 
 		idGroupPairs := buildEc2UserIDGroupPairs(rule.ToSecurityGroups, cloudSGNameToObj, &description)
-		ipRanges := convertToEc2IpRanges(rule.ToDstIP, len(rule.ToSecurityGroups) > 0, &description)
-		startPort, endPort := convertToIPPermissionPort(rule.ToPort, rule.Protocol)
+		ips, prefixListIDs, err := ec2Cfg.convertToEc2PrefixListIds(rule.ToDstIP, !isDelete, &description)
+		if err != nil {
+			return err
+		}
+		hasPeers := len(rule.ToSecurityGroups) > 0 || len(prefixListIDs) > 0
+		ipRanges := convertToEc2IpRanges(ips, hasPeers, &description)
+		ipv6Ranges := convertToEc2Ipv6Ranges(ips, hasPeers, &description)
+		startPort, endPort := convertToIPPermissionPort(rule.ToPort, rule.ToEndPort, rule.Protocol)
+		if securitygroup.IsICMPProtocol(rule.Protocol) {
+			startPort, endPort = convertToIPPermissionICMPTypeCode(rule.ICMPType, rule.ICMPCode)
+		}
 		ipPermission := &ec2.IpPermission{
 			FromPort:         startPort,
 			ToPort:           endPort,
 			IpProtocol:       convertToIPPermissionProtocol(rule.Protocol),
 			IpRanges:         ipRanges,
+			Ipv6Ranges:       ipv6Ranges,
+			PrefixListIds:    prefixListIDs,
 			UserIdGroupPairs: idGroupPairs,
 		}
 		newIpPermissions = append(newIpPermissions, ipPermission)
@@ -298,6 +349,202 @@ func (ec2Cfg *ec2ServiceConfig) realizeEgressIPPermissions(cloudSgObj *ec2.Secur
 	}
 }
 
+// shardRules are the allow rules added to and removed from a rule shard of an appliedTo security group.
+type shardRules struct {
+	addIRule []*securitygroup.CloudRule
+	rmIRule  []*securitygroup.CloudRule
+	addERule []*securitygroup.CloudRule
+	rmERule  []*securitygroup.CloudRule
+}
+
+// reverse returns the shardRules undoing the realization of shardRules.
+func (s *shardRules) reverse() *shardRules {
+	return &shardRules{addIRule: s.rmIRule, rmIRule: s.addIRule, addERule: s.rmERule, rmERule: s.addERule}
+}
+
+// groupRulesByShard groups allow rules by the rule shards realizing them, and returns the shards in order.
+func groupRulesByShard(addIRule, rmIRule, addERule, rmERule []*securitygroup.CloudRule) ([]int, map[int]*shardRules) {
+	shards := make(map[int]*shardRules)
+	getShard := func(rule *securitygroup.CloudRule) *shardRules {
+		s, ok := shards[rule.Shard]
+		if !ok {
+			s = &shardRules{}
+			shards[rule.Shard] = s
+		}
+		return s
+	}
+	for _, rule := range addIRule {
+		s := getShard(rule)
+		s.addIRule = append(s.addIRule, rule)
+	}
+	for _, rule := range rmIRule {
+		s := getShard(rule)
+		s.rmIRule = append(s.rmIRule, rule)
+	}
+	for _, rule := range addERule {
+		s := getShard(rule)
+		s.addERule = append(s.addERule, rule)
+	}
+	for _, rule := range rmERule {
+		s := getShard(rule)
+		s.rmERule = append(s.rmERule, rule)
+	}
+	order := make([]int, 0, len(shards))
+	for shard := range shards {
+		order = append(order, shard)
+	}
+	sort.Ints(order)
+	return order, shards
+}
+
+// realizeShardRules invokes cloud api and realizes the rules of a rule shard on its cloud security group. Rules
+// realized are rolled back on failure.
+func (ec2Cfg *ec2ServiceConfig) realizeShardRules(cloudSgObj *ec2.SecurityGroup, rules *shardRules,
+	cloudSGNameToCloudSGObj map[string]*ec2.SecurityGroup) error {
+	// rollback operation for cloud api failures
+	rollbackRmIngress := false
+	rollbackAddIngress := false
+	rollbackRmEgress := false
+	defer func() {
+		if rollbackRmIngress {
+			_ = ec2Cfg.realizeIngressIPPermissions(cloudSgObj, rules.rmIRule, cloudSGNameToCloudSGObj, false)
+		}
+		if rollbackAddIngress {
+			_ = ec2Cfg.realizeIngressIPPermissions(cloudSgObj, rules.addIRule, cloudSGNameToCloudSGObj, true)
+		}
+		if rollbackRmEgress {
+			_ = ec2Cfg.realizeEgressIPPermissions(cloudSgObj, rules.rmERule, cloudSGNameToCloudSGObj, false)
+		}
+	}()
+
+	// realize security group ingress and egress permissions
+	if err := ec2Cfg.realizeIngressIPPermissions(cloudSgObj, rules.rmIRule, cloudSGNameToCloudSGObj, true); err != nil {
+		return err
+	}
+	if err := ec2Cfg.realizeIngressIPPermissions(cloudSgObj, rules.addIRule, cloudSGNameToCloudSGObj, false); err != nil {
+		rollbackRmIngress = true
+		return err
+	}
+	if err := ec2Cfg.realizeEgressIPPermissions(cloudSgObj, rules.rmERule, cloudSGNameToCloudSGObj, true); err != nil {
+		rollbackRmIngress = true
+		rollbackAddIngress = true
+		return err
+	}
+	if err := ec2Cfg.realizeEgressIPPermissions(cloudSgObj, rules.addERule, cloudSGNameToCloudSGObj, false); err != nil {
+		rollbackRmIngress = true
+		rollbackAddIngress = true
+		rollbackRmEgress = true
+		return err
+	}
+	return nil
+}
+
+// getShardSecurityGroups returns the cloud security groups of rule shards of an appliedTo security group. Shards up to
+// the aws limit of security groups per network interface are returned, including shards beyond a lowered quota.
+func (ec2Cfg *ec2ServiceConfig) getShardSecurityGroups(appliedToGroupIdentifier *securitygroup.CloudResourceID) (
+	map[string]*ec2.SecurityGroup, error) {
+	cloudSgNames := make(map[string]struct{})
+	for shard := 1; shard < config.MaxAWSSecurityGroupsPerInterface; shard++ {
+		cloudSgNames[appliedToGroupIdentifier.GetShardCloudName(shard)] = struct{}{}
+	}
+	return ec2Cfg.getCloudSecurityGroupsWithNameFromCloud([]string{appliedToGroupIdentifier.Vpc}, cloudSgNames)
+}
+
+// getSecurityGroupMemberNics returns network interfaces attached to a cloud security group.
+func (ec2Cfg *ec2ServiceConfig) getSecurityGroupMemberNics(cloudSgID string, vpcID string) ([]*securitygroup.CloudResource, error) {
+	networkInterfaces, err := ec2Cfg.getNetworkInterfacesOfVpc(map[string]struct{}{vpcID: {}})
+	if err != nil {
+		return nil, err
+	}
+	var members []*securitygroup.CloudResource
+	for _, networkInterface := range networkInterfaces {
+		for _, group := range networkInterface.Groups {
+			if *group.GroupId != cloudSgID {
+				continue
+			}
+			members = append(members, &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeNIC,
+				CloudResourceID: securitygroup.CloudResourceID{Name: *networkInterface.NetworkInterfaceId, Vpc: vpcID},
+			})
+			break
+		}
+	}
+	return members, nil
+}
+
+// createShardSecurityGroups creates cloud security groups of rule shards rules are added to, and attaches them to the
+// network interfaces of the appliedTo security group.
+func (ec2Cfg *ec2ServiceConfig) createShardSecurityGroups(appliedToGroupIdentifier *securitygroup.CloudResourceID,
+	rules []*securitygroup.CloudRule) error {
+	vpcID := appliedToGroupIdentifier.Vpc
+	cloudSgNames := make(map[string]struct{})
+	for _, rule := range rules {
+		if rule.Shard > 0 {
+			cloudSgNames[appliedToGroupIdentifier.GetShardCloudName(rule.Shard)] = struct{}{}
+		}
+	}
+	if len(cloudSgNames) == 0 {
+		return nil
+	}
+	out, err := ec2Cfg.getCloudSecurityGroupsWithNameFromCloud([]string{vpcID}, cloudSgNames)
+	if err != nil {
+		return err
+	}
+	cloudSgNamesCreated := make(map[string]struct{})
+	for cloudSgName := range cloudSgNames {
+		if _, found := out[cloudSgName]; found {
+			continue
+		}
+		if err := ec2Cfg.createCloudSecurityGroup(cloudSgName, vpcID); err != nil {
+			return err
+		}
+		cloudSgNamesCreated[cloudSgName] = struct{}{}
+	}
+	if len(cloudSgNamesCreated) == 0 {
+		return nil
+	}
+
+	// rule shards have the same members as the appliedTo security group.
+	cloudSgName := appliedToGroupIdentifier.GetCloudName(false)
+	cloudSgNamesCreated[cloudSgName] = struct{}{}
+	out, err = ec2Cfg.getCloudSecurityGroupsWithNameFromCloud([]string{vpcID}, cloudSgNamesCreated)
+	if err != nil {
+		return err
+	}
+	if len(out) != len(cloudSgNamesCreated) {
+		return fmt.Errorf("failed to find security groups")
+	}
+	members, err := ec2Cfg.getSecurityGroupMemberNics(*out[cloudSgName].GroupId, vpcID)
+	if err != nil {
+		return err
+	}
+	delete(cloudSgNamesCreated, cloudSgName)
+	for shardCloudSgName := range cloudSgNamesCreated {
+		if err := ec2Cfg.updateSecurityGroupMembers(out[shardCloudSgName].GroupId, shardCloudSgName, vpcID, members,
+			false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteCloudSecurityGroup detaches a cloud security group from interfaces and deletes it.
+func (ec2Cfg *ec2ServiceConfig) deleteCloudSecurityGroup(cloudSgID *string, cloudSgName string, vpcID string,
+	membershipOnly bool) error {
+	// Detach security group from interfaces before deleting.
+	err := ec2Cfg.updateSecurityGroupMembers(cloudSgID, cloudSgName, vpcID, nil, membershipOnly)
+	if err != nil {
+		return err
+	}
+
+	// delete security group
+	input := &ec2.DeleteSecurityGroupInput{
+		GroupId: cloudSgID,
+	}
+	_, err = ec2Cfg.apiClient.deleteSecurityGroup(input)
+	return err
+}
+
 func (ec2Cfg *ec2ServiceConfig) getVpcDefaultSecurityGroupID(vpcID string) (string, error) {
 	sgID, found := vpcIDToDefaultSecurityGroup[vpcID]
 	if found {
@@ -531,6 +778,13 @@ func (ec2Cfg *ec2ServiceConfig) getNepheControllerManagedSecurityGroupsCloudView
 	}
 	managedSgIDToCloudSGObj, unmanagedSgIDToCloudSGObj := getCloudSecurityGroupsByType(cloudSecurityGroups)
 
+	// get CIDRs of managed prefix lists referred by rules of managed security groups.
+	prefixListIDToCidrs, err := ec2Cfg.getPrefixListEntriesOfSecurityGroups(managedSgIDToCloudSGObj)
+	if err != nil {
+		awsPluginLogger().Error(err, "failed to get managed prefix lists of security groups", "vpc-ids", vpcIDs)
+		return []securitygroup.SynchronizationContent{}
+	}
+
 	// find all member network-interfaces-ids for managed cloud-security-groups.
 	// also find all member network-interface-ids attached to non nephe created sgs.
 	managedSgIDToMemberCloudResourcesMap := make(map[string][]securitygroup.CloudResource)
@@ -574,11 +828,14 @@ func (ec2Cfg *ec2ServiceConfig) getNepheControllerManagedSecurityGroupsCloudView
 		cloudSgName := *cloudSgObj.GroupName
 		vpcID := *cloudSgObj.VpcId
 
-		// find AT or AG
+		// find AT or AG, and rule shard of AT
 		isMembershipOnly := false
+		shard := 0
 		SgName, isAG, _ := securitygroup.IsNepheControllerCreatedSG(cloudSgName)
 		if isAG {
 			isMembershipOnly = true
+		} else {
+			SgName, shard = securitygroup.GetShardFromCloudName(SgName)
 		}
 
 		// find members and membersAttachedToOtherSGs
@@ -589,8 +846,10 @@ func (ec2Cfg *ec2ServiceConfig) getNepheControllerManagedSecurityGroupsCloudView
 		}
 
 		// build ingress and egress rules
-		inRules := convertFromIPPermissionToIngressRule(cloudSgObj.IpPermissions, managedSgIDToCloudSGObj, unmanagedSgIDToCloudSGObj)
-		egRules := convertFromIPPermissionToEgressRule(cloudSgObj.IpPermissionsEgress, managedSgIDToCloudSGObj, unmanagedSgIDToCloudSGObj)
+		inRules := convertFromIPPermissionToIngressRule(cloudSgObj.IpPermissions, managedSgIDToCloudSGObj, unmanagedSgIDToCloudSGObj,
+			prefixListIDToCidrs)
+		egRules := convertFromIPPermissionToEgressRule(cloudSgObj.IpPermissionsEgress, managedSgIDToCloudSGObj, unmanagedSgIDToCloudSGObj,
+			prefixListIDToCidrs)
 
 		// build sync object
 		groupSyncObj := securitygroup.SynchronizationContent{
@@ -608,11 +867,15 @@ func (ec2Cfg *ec2ServiceConfig) getNepheControllerManagedSecurityGroupsCloudView
 			MembersWithOtherSGAttached: membersWithOtherSGAttached,
 			IngressRules:               inRules,
 			EgressRules:                egRules,
+			Shard:                      shard,
 		}
 
 		enforcedSecurityCloudView = append(enforcedSecurityCloudView, groupSyncObj)
 	}
 
+	// garbage collect managed prefix lists of removed rules.
+	ec2Cfg.deleteUnusedPrefixLists(prefixListIDToCidrs)
+
 	return enforcedSecurityCloudView
 }
 
@@ -664,6 +927,36 @@ func getMemberNicCloudResourcesAttachedToOtherSGs(members []securitygroup.CloudR
 //
 // ////////////////////////////////////////////////////////.
 
+// getEC2ServiceConfigs returns the ec2 service configs of all regions of an account.
+func getEC2ServiceConfigs(accCfg internal.CloudAccountInterface) []*ec2ServiceConfig {
+	var ec2Services []*ec2ServiceConfig
+	for name := range accCfg.GetServiceConfigs() {
+		serviceCfg, err := accCfg.GetServiceConfigByName(name)
+		if err != nil {
+			continue
+		}
+		if ec2Service, ok := serviceCfg.(*ec2ServiceConfig); ok {
+			ec2Services = append(ec2Services, ec2Service)
+		}
+	}
+	return ec2Services
+}
+
+// getEC2ServiceConfigByVpc returns the ec2 service config of the region of a vpc of an account.
+func getEC2ServiceConfigByVpc(accCfg internal.CloudAccountInterface, vpcID string) (*ec2ServiceConfig, error) {
+	ec2Services := getEC2ServiceConfigs(accCfg)
+	// vpcs of an account with a single region are in that region.
+	if len(ec2Services) == 1 {
+		return ec2Services[0], nil
+	}
+	for _, ec2Service := range ec2Services {
+		if ec2Service.hasVpc(vpcID) {
+			return ec2Service, nil
+		}
+	}
+	return nil, fmt.Errorf("aws account %v has no region with virtual private cloud [%v]", accCfg.GetNamespacedName(), vpcID)
+}
+
 // CreateSecurityGroup invokes cloud api and creates the cloud security group based on securityGroupIdentifier.
 func (c *awsCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) (*string, error) {
 	mutex.Lock()
@@ -674,11 +967,10 @@ func (c *awsCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.Cl
 	if !found {
 		return nil, fmt.Errorf("aws account not found managing virtual private cloud [%v]", vpcID)
 	}
-	serviceCfg, err := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+	ec2Service, err := getEC2ServiceConfigByVpc(accCfg, vpcID)
 	if err != nil {
 		return nil, err
 	}
-	ec2Service := serviceCfg.(*ec2ServiceConfig)
 
 	cloudSgName := securityGroupIdentifier.GetCloudName(membershipOnly)
 	resp, err := ec2Service.createOrGetSecurityGroups(securityGroupIdentifier.Vpc, map[string]struct{}{cloudSgName: {}})
@@ -691,8 +983,9 @@ func (c *awsCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.Cl
 }
 
 // UpdateSecurityGroupRules invokes cloud api and updates cloud security group with addRules and rmRules.
+// Deny rules are realized as network acl entries on the subnets of the appliedTo group members.
 func (c *awsCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygroup.CloudResource,
-	addRules, rmRules, _ []*securitygroup.CloudRule) error {
+	addRules, rmRules, allRules []*securitygroup.CloudRule) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
@@ -700,7 +993,13 @@ func (c *awsCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygr
 	rmIRule := make([]*securitygroup.CloudRule, 0)
 	addERule := make([]*securitygroup.CloudRule, 0)
 	rmERule := make([]*securitygroup.CloudRule, 0)
+	addDenyRule := make([]*securitygroup.CloudRule, 0)
+	rmDenyRule := make([]*securitygroup.CloudRule, 0)
 	for _, rule := range addRules {
+		if rule.IsDeny() {
+			addDenyRule = append(addDenyRule, rule)
+			continue
+		}
 		switch rule.Rule.(type) {
 		case *securitygroup.IngressRule:
 			addIRule = append(addIRule, rule)
@@ -709,6 +1008,10 @@ func (c *awsCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygr
 		}
 	}
 	for _, rule := range rmRules {
+		if rule.IsDeny() {
+			rmDenyRule = append(rmDenyRule, rule)
+			continue
+		}
 		switch rule.Rule.(type) {
 		case *securitygroup.IngressRule:
 			rmIRule = append(rmIRule, rule)
@@ -723,11 +1026,25 @@ func (c *awsCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygr
 		return fmt.Errorf("aws account not found managing virtual private cloud [%v]", vpcID)
 	}
 
-	serviceCfg, err := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+	ec2Service, err := getEC2ServiceConfigByVpc(accCfg, vpcID)
 	if err != nil {
 		return err
 	}
-	ec2Service := serviceCfg.(*ec2ServiceConfig)
+
+	// realize deny rules on network acls
+	if len(addDenyRule) != 0 || len(rmDenyRule) != 0 {
+		if err = ec2Service.updateNetworkACLEntries(&appliedToGroupIdentifier.CloudResourceID, allRules); err != nil {
+			return err
+		}
+	}
+	if len(addIRule) == 0 && len(rmIRule) == 0 && len(addERule) == 0 && len(rmERule) == 0 {
+		return nil
+	}
+
+	// create security groups of rule shards rules are added to.
+	if err = ec2Service.createShardSecurityGroups(&appliedToGroupIdentifier.CloudResourceID, append(addIRule, addERule...)); err != nil {
+		return err
+	}
 
 	// build from addressGroups, cloudSgNames from rules
 	cloudSgNames := buildEc2CloudSgNamesFromRules(&appliedToGroupIdentifier.CloudResourceID, append(addIRule, rmIRule...),
@@ -745,42 +1062,17 @@ func (c *awsCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygr
 		return fmt.Errorf("failed to find security groups")
 	}
 
-	cloudSGObjToAddRules := cloudSGNameToCloudSGObj[appliedToGroupIdentifier.GetCloudName(false)]
-
-	// rollback operation for cloud api failures
-	rollbackRmIngress := false
-	rollbackAddIngress := false
-	rollbackRmEgress := false
-	defer func() {
-		if rollbackRmIngress {
-			_ = ec2Service.realizeIngressIPPermissions(cloudSGObjToAddRules, rmIRule, cloudSGNameToCloudSGObj, false)
-		}
-		if rollbackAddIngress {
-			_ = ec2Service.realizeIngressIPPermissions(cloudSGObjToAddRules, addIRule, cloudSGNameToCloudSGObj, true)
-		}
-		if rollbackRmEgress {
-			_ = ec2Service.realizeEgressIPPermissions(cloudSGObjToAddRules, rmERule, cloudSGNameToCloudSGObj, false)
+	// realize rules on the security group of each rule shard, rolling back shards already realized on failure.
+	shards, rulesByShard := groupRulesByShard(addIRule, rmIRule, addERule, rmERule)
+	for i, shard := range shards {
+		cloudSGObjToAddRules := cloudSGNameToCloudSGObj[appliedToGroupIdentifier.GetShardCloudName(shard)]
+		if err = ec2Service.realizeShardRules(cloudSGObjToAddRules, rulesByShard[shard], cloudSGNameToCloudSGObj); err != nil {
+			for _, realized := range shards[:i] {
+				_ = ec2Service.realizeShardRules(cloudSGNameToCloudSGObj[appliedToGroupIdentifier.GetShardCloudName(realized)],
+					rulesByShard[realized].reverse(), cloudSGNameToCloudSGObj)
+			}
+			return err
 		}
-	}()
-
-	// realize security group ingress and egress permissions
-	if err = ec2Service.realizeIngressIPPermissions(cloudSGObjToAddRules, rmIRule, cloudSGNameToCloudSGObj, true); err != nil {
-		return err
-	}
-	if err = ec2Service.realizeIngressIPPermissions(cloudSGObjToAddRules, addIRule, cloudSGNameToCloudSGObj, false); err != nil {
-		rollbackRmIngress = true
-		return err
-	}
-	if err = ec2Service.realizeEgressIPPermissions(cloudSGObjToAddRules, rmERule, cloudSGNameToCloudSGObj, true); err != nil {
-		rollbackRmIngress = true
-		rollbackAddIngress = true
-		return err
-	}
-	if err = ec2Service.realizeEgressIPPermissions(cloudSGObjToAddRules, addERule, cloudSGNameToCloudSGObj, false); err != nil {
-		rollbackRmIngress = true
-		rollbackAddIngress = true
-		rollbackRmEgress = true
-		return err
 	}
 
 	return nil
@@ -798,11 +1090,10 @@ func (c *awsCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securityg
 		return fmt.Errorf("aws account not found managing virtual private cloud [%v]", vpcID)
 	}
 
-	serviceCfg, err := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+	ec2Service, err := getEC2ServiceConfigByVpc(accCfg, vpcID)
 	if err != nil {
 		return err
 	}
-	ec2Service := serviceCfg.(*ec2ServiceConfig)
 
 	cloudSgName := securityGroupIdentifier.GetCloudName(membershipOnly)
 
@@ -823,8 +1114,24 @@ func (c *awsCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securityg
 	if err != nil {
 		return err
 	}
+	if membershipOnly {
+		return nil
+	}
 
-	return nil
+	// rule shards have the same members as the appliedTo security group.
+	shardCloudSGs, err := ec2Service.getShardSecurityGroups(&securityGroupIdentifier.CloudResourceID)
+	if err != nil {
+		return err
+	}
+	for shardCloudSgName, shardCloudSG := range shardCloudSGs {
+		err = ec2Service.updateSecurityGroupMembers(shardCloudSG.GroupId, shardCloudSgName, vpcID, cloudResourceIdentifiers, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	// network acl entries of deny rules follow the subnets of members.
+	return ec2Service.updateNetworkACLSubnets(&securityGroupIdentifier.CloudResourceID)
 }
 
 // DeleteSecurityGroup invokes cloud api and deletes the cloud security group. Any attached resource will be moved to default sg.
@@ -838,11 +1145,26 @@ func (c *awsCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.Cl
 		return fmt.Errorf("aws account not found managing virtual private cloud [%v]", vpcID)
 	}
 
-	serviceCfg, err := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+	ec2Service, err := getEC2ServiceConfigByVpc(accCfg, vpcID)
 	if err != nil {
 		return err
 	}
-	ec2Service := serviceCfg.(*ec2ServiceConfig)
+
+	// delete network acl entries and rule shards of appliedTo security group.
+	if !membershipOnly {
+		if err = ec2Service.deleteNetworkACLEntries(&securityGroupIdentifier.CloudResourceID); err != nil {
+			return err
+		}
+		shardCloudSGs, err := ec2Service.getShardSecurityGroups(&securityGroupIdentifier.CloudResourceID)
+		if err != nil {
+			return err
+		}
+		for shardCloudSgName, shardCloudSG := range shardCloudSGs {
+			if err = ec2Service.deleteCloudSecurityGroup(shardCloudSG.GroupId, shardCloudSgName, vpcID, false); err != nil {
+				return err
+			}
+		}
+	}
 
 	// check if sg exists in cloud and get its cloud sg id to delete
 	vpcIDs := []string{vpcID}
@@ -852,23 +1174,7 @@ func (c *awsCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.Cl
 		return err
 	}
 
-	// Detach security group from interfaces before deleting.
-	cloudSgIDToDelete := out[cloudSgNameToDelete].GroupId
-	err = ec2Service.updateSecurityGroupMembers(cloudSgIDToDelete, cloudSgNameToDelete, vpcID, nil, membershipOnly)
-	if err != nil {
-		return err
-	}
-
-	// delete security group
-	input := &ec2.DeleteSecurityGroupInput{
-		GroupId: cloudSgIDToDelete,
-	}
-	_, err = ec2Service.apiClient.deleteSecurityGroup(input)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return ec2Service.deleteCloudSecurityGroup(out[cloudSgNameToDelete].GroupId, cloudSgNameToDelete, vpcID, membershipOnly)
 }
 
 func (c *awsCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
@@ -904,18 +1210,18 @@ func (c *awsCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent
 				return
 			}
 
-			serviceCfg, err := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
-			if err != nil {
-				awsPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
-				return
-			}
-			ec2Service := serviceCfg.(*ec2ServiceConfig)
-			err = ec2Service.waitForInventoryInit(inventoryInitWaitDuration)
-			if err != nil {
-				awsPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
-				return
+			// the cloud view of an account is the cloud views of all its regions.
+			var cloudView []securitygroup.SynchronizationContent
+			for _, ec2Service := range getEC2ServiceConfigs(accCfg) {
+				err := ec2Service.waitForInventoryInit(inventoryInitWaitDuration)
+				if err != nil {
+					awsPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName(),
+						"region", ec2Service.credentials.region)
+					return
+				}
+				cloudView = append(cloudView, ec2Service.getNepheControllerManagedSecurityGroupsCloudView()...)
 			}
-			sendCh <- ec2Service.getNepheControllerManagedSecurityGroupsCloudView()
+			sendCh <- cloudView
 		}(accNamespacedNameCopy, ch)
 	}
 
@@ -926,3 +1232,15 @@ func (c *awsCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent
 	}
 	return enforcedSecurityCloudView
 }
+
+// GetSecurityGroupLimits returns the configured aws quotas on security groups. Each CIDR and each security group of a
+// rule is a rule of aws security group, as managed prefix lists count as many rules as their CIDRs, and deny rules are
+// realized in network acls.
+func (c *awsCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{
+		MaxRules:                      awsMaxSecurityGroupRules,
+		MaxSecurityGroupsPerInterface: awsMaxSecurityGroupsPerInterface,
+		RulePerPeer:                   true,
+		ExcludeDenyRules:              true,
+	}
+}
@@ -58,6 +58,8 @@ var _ = Describe("AWS Cloud Security", func() {
 		mockawsCloudHelper *MockawsServicesHelper
 		mockawsEC2         *MockawsEC2Wrapper
 		mockawsService     *MockawsServiceClientCreateInterface
+		networkInterfaces  []*ec2.NetworkInterface
+		prefixLists        []*ec2.ManagedPrefixList
 	)
 
 	BeforeEach(func() {
@@ -118,9 +120,18 @@ var _ = Describe("AWS Cloud Security", func() {
 
 		instanceIds := []string{testVMID01, testVMID02}
 		mockawsEC2.EXPECT().pagedDescribeInstancesWrapper(gomock.Any()).Return(getEc2InstanceObject(instanceIds), nil).AnyTimes()
-		mockawsEC2.EXPECT().pagedDescribeNetworkInterfaces(gomock.Any()).Return([]*ec2.NetworkInterface{}, nil).AnyTimes()
+		networkInterfaces = nil
+		mockawsEC2.EXPECT().pagedDescribeNetworkInterfaces(gomock.Any()).AnyTimes().
+			DoAndReturn(func(_ *ec2.DescribeNetworkInterfacesInput) ([]*ec2.NetworkInterface, error) {
+				return networkInterfaces, nil
+			})
 		mockawsEC2.EXPECT().describeVpcsWrapper(gomock.Any()).Return(&ec2.DescribeVpcsOutput{}, nil).AnyTimes()
 		mockawsEC2.EXPECT().describeVpcPeeringConnectionsWrapper(gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{}, nil).AnyTimes()
+		prefixLists = nil
+		mockawsEC2.EXPECT().describeManagedPrefixLists(gomock.Any()).AnyTimes().
+			DoAndReturn(func(_ *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error) {
+				return &ec2.DescribeManagedPrefixListsOutput{PrefixLists: prefixLists}, nil
+			})
 
 		fakeClient := fake.NewClientBuilder().Build()
 		_ = fakeClient.Create(context.Background(), secret)
@@ -286,6 +297,383 @@ var _ = Describe("AWS Cloud Security", func() {
 			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
 			Expect(err).Should(BeNil())
 		})
+		It("Should create IPv6 ingress rules successfully", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			_, ipv4Net, _ := net.ParseCIDR("10.0.0.0/24")
+			_, ipv6Net, _ := net.ParseCIDR("2001:db8::/64")
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.IngressRule{
+					FromPort:  aws.Int(22),
+					FromSrcIP: []*net.IPNet{ipv4Net, ipv6Net},
+					Protocol:  aws.Int(6),
+				}, NetworkPolicy: testAnpNamespacedName.String()},
+			}
+			// rules without security groups only require the appliedTo security group.
+			output := constructEc2DescribeSecurityGroupsOutput(&webSgIdentifier.CloudResourceID, false, false)
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Any()).Return(output, nil).Times(1)
+			mockawsEC2.EXPECT().revokeSecurityGroupIngress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupIngress(gomock.Any()).Times(1).
+				Do(func(req *ec2.AuthorizeSecurityGroupIngressInput) {
+					Expect(len(req.IpPermissions)).To(Equal(1))
+					Expect(len(req.IpPermissions[0].IpRanges)).To(Equal(1))
+					Expect(*req.IpPermissions[0].IpRanges[0].CidrIp).To(Equal("10.0.0.0/24"))
+					Expect(len(req.IpPermissions[0].Ipv6Ranges)).To(Equal(1))
+					Expect(*req.IpPermissions[0].Ipv6Ranges[0].CidrIpv6).To(Equal("2001:db8::/64"))
+				})
+			mockawsEC2.EXPECT().revokeSecurityGroupEgress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupEgress(gomock.Any()).Times(0)
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
+			Expect(err).Should(BeNil())
+		})
+		It("Should create ingress rules with managed prefix list successfully", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			var srcIPs []*net.IPNet
+			for i := 0; i < 12; i++ {
+				_, ipNet, _ := net.ParseCIDR(fmt.Sprintf("10.0.%d.0/24", i))
+				srcIPs = append(srcIPs, ipNet)
+			}
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.IngressRule{
+					FromPort:  aws.Int(22),
+					FromSrcIP: srcIPs,
+					Protocol:  aws.Int(6),
+				}, NetworkPolicy: testAnpNamespacedName.String()},
+			}
+			output := constructEc2DescribeSecurityGroupsOutput(&webSgIdentifier.CloudResourceID, false, false)
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Any()).Return(output, nil).Times(1)
+			mockawsEC2.EXPECT().createManagedPrefixList(gomock.Any()).Times(1).
+				DoAndReturn(func(req *ec2.CreateManagedPrefixListInput) (*ec2.CreateManagedPrefixListOutput, error) {
+					Expect(len(req.Entries)).To(Equal(len(srcIPs)))
+					Expect(*req.MaxEntries).To(Equal(int64(len(srcIPs))))
+					Expect(*req.AddressFamily).To(Equal(awsPrefixListAddressFamilyIPv4))
+					prefixList := &ec2.ManagedPrefixList{
+						PrefixListId:   aws.String("pl-1"),
+						PrefixListName: req.PrefixListName,
+						State:          aws.String(ec2.PrefixListStateCreateComplete),
+					}
+					prefixLists = []*ec2.ManagedPrefixList{prefixList}
+					return &ec2.CreateManagedPrefixListOutput{PrefixList: prefixList}, nil
+				})
+			mockawsEC2.EXPECT().revokeSecurityGroupIngress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupIngress(gomock.Any()).Times(1).
+				Do(func(req *ec2.AuthorizeSecurityGroupIngressInput) {
+					Expect(len(req.IpPermissions)).To(Equal(1))
+					Expect(len(req.IpPermissions[0].IpRanges)).To(Equal(0))
+					Expect(len(req.IpPermissions[0].PrefixListIds)).To(Equal(1))
+					Expect(*req.IpPermissions[0].PrefixListIds[0].PrefixListId).To(Equal("pl-1"))
+				})
+			mockawsEC2.EXPECT().revokeSecurityGroupEgress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupEgress(gomock.Any()).Times(0)
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
+			Expect(err).Should(BeNil())
+		})
+		It("Should create egress rules with port range successfully", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.EgressRule{
+					ToPort:           aws.Int(30000),
+					ToEndPort:        aws.Int(32767),
+					ToDstIP:          []*net.IPNet{},
+					ToSecurityGroups: []*securitygroup.CloudResourceID{&webSgIdentifier.CloudResourceID},
+					Protocol:         aws.Int(6),
+				}, NetworkPolicy: testAnpNamespacedName.String()}}
+			output := constructEc2DescribeSecurityGroupsOutput(&webSgIdentifier.CloudResourceID, true, false)
+			outputAt := constructEc2DescribeSecurityGroupsOutput(&webSgIdentifier.CloudResourceID, false, false)
+			output.SecurityGroups = append(output.SecurityGroups, outputAt.SecurityGroups...)
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Any()).Return(output, nil).Times(1)
+			mockawsEC2.EXPECT().revokeSecurityGroupIngress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupIngress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().revokeSecurityGroupEgress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupEgress(gomock.Any()).Times(1).
+				Do(func(req *ec2.AuthorizeSecurityGroupEgressInput) {
+					Expect(len(req.IpPermissions)).To(Equal(1))
+					Expect(*req.IpPermissions[0].FromPort).To(Equal(int64(30000)))
+					Expect(*req.IpPermissions[0].ToPort).To(Equal(int64(32767)))
+				})
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
+			Expect(err).Should(BeNil())
+		})
+		It("Should create network acl entries for deny rules on subnets of members successfully", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			_, ipNet, _ := net.ParseCIDR("10.10.0.0/16")
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.IngressRule{
+					FromPort:  aws.Int(22),
+					FromSrcIP: []*net.IPNet{ipNet},
+					Protocol:  aws.Int(6),
+					Action:    securitygroup.RuleActionDeny,
+				}, NetworkPolicy: testAnpNamespacedName.String()}}
+			rmRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.EgressRule{
+					ToDstIP: []*net.IPNet{ipNet},
+					Action:  securitygroup.RuleActionDeny,
+				}, NetworkPolicy: testAnpNamespacedName.String()}}
+			webOwner := webSgIdentifier.GetCloudName(false)
+			dbOwner := (&securitygroup.CloudResourceID{Name: "Db", Vpc: testVpcID01}).GetCloudName(false)
+			networkInterfaces = []*ec2.NetworkInterface{{
+				NetworkInterfaceId: aws.String("eni-0d1f6a0e"),
+				SubnetId:           &testSubnetID01,
+				Groups:             []*ec2.GroupIdentifier{{GroupName: aws.String(webOwner)}},
+			}}
+			// acl-1 of the member subnet has a user entry, an entry owned by web and an entry shared with db.
+			// acl-2 of another subnet has an entry owned by web.
+			output := &ec2.DescribeNetworkAclsOutput{NetworkAcls: []*ec2.NetworkAcl{{
+				NetworkAclId: aws.String("acl-1"),
+				Associations: []*ec2.NetworkAclAssociation{{SubnetId: &testSubnetID01}},
+				Entries: []*ec2.NetworkAclEntry{
+					{RuleNumber: aws.Int64(1), Egress: aws.Bool(false), Protocol: aws.String("17"),
+						CidrBlock: aws.String("10.20.0.0/16"), RuleAction: aws.String(ec2.RuleActionDeny)},
+					{RuleNumber: aws.Int64(1), Egress: aws.Bool(true), Protocol: aws.String(awsAnyProtocolValue),
+						CidrBlock: aws.String("10.10.0.0/16"), RuleAction: aws.String(ec2.RuleActionDeny)},
+					{RuleNumber: aws.Int64(2), Egress: aws.Bool(true), Protocol: aws.String(awsAnyProtocolValue),
+						CidrBlock: aws.String("10.30.0.0/16"), RuleAction: aws.String(ec2.RuleActionDeny)},
+					{RuleNumber: aws.Int64(100), Egress: aws.Bool(false), Protocol: aws.String(awsAnyProtocolValue),
+						CidrBlock: aws.String(ipv4AnyCidr), RuleAction: aws.String(ec2.RuleActionAllow)},
+				},
+				Tags: []*ec2.Tag{
+					{Key: aws.String(getNetworkACLOwnerTagKeyPrefix(true) + webOwner), Value: aws.String("1 2")},
+					{Key: aws.String(getNetworkACLOwnerTagKeyPrefix(true) + dbOwner), Value: aws.String("2")},
+				},
+			}, {
+				NetworkAclId: aws.String("acl-2"),
+				Associations: []*ec2.NetworkAclAssociation{{SubnetId: aws.String("subnet-0e2f7b1f")}},
+				Entries: []*ec2.NetworkAclEntry{
+					{RuleNumber: aws.Int64(1), Egress: aws.Bool(true), Protocol: aws.String(awsAnyProtocolValue),
+						CidrBlock: aws.String("10.10.0.0/16"), RuleAction: aws.String(ec2.RuleActionDeny)},
+				},
+				Tags: []*ec2.Tag{
+					{Key: aws.String(getNetworkACLOwnerTagKeyPrefix(true) + webOwner), Value: aws.String("1")},
+				},
+			}}}
+
+			mockawsEC2.EXPECT().describeNetworkAcls(gomock.Any()).Return(output, nil).Times(1).
+				Do(func(req *ec2.DescribeNetworkAclsInput) {
+					Expect(req.Filters[0].Values).To(Equal([]*string{&testVpcID01}))
+				})
+			mockawsEC2.EXPECT().createTags(gomock.Any()).Times(1).
+				Do(func(req *ec2.CreateTagsInput) {
+					Expect(*req.Resources[0]).To(Equal("acl-1"))
+					Expect(*req.Tags[0].Key).To(Equal(getNetworkACLOwnerTagKeyPrefix(false) + webOwner))
+					Expect(*req.Tags[0].Value).To(Equal("2"))
+				})
+			mockawsEC2.EXPECT().createNetworkAclEntry(gomock.Any()).Times(1).
+				Do(func(req *ec2.CreateNetworkAclEntryInput) {
+					Expect(*req.NetworkAclId).To(Equal("acl-1"))
+					Expect(*req.Egress).To(BeFalse())
+					Expect(*req.RuleNumber).To(Equal(int64(2)))
+					Expect(*req.RuleAction).To(Equal(ec2.RuleActionDeny))
+					Expect(*req.CidrBlock).To(Equal("10.10.0.0/16"))
+					Expect(*req.PortRange.From).To(Equal(int64(22)))
+					Expect(*req.PortRange.To).To(Equal(int64(22)))
+				})
+			deletedEntries := make(map[string]int64)
+			mockawsEC2.EXPECT().deleteNetworkAclEntry(gomock.Any()).Times(2).
+				Do(func(req *ec2.DeleteNetworkAclEntryInput) {
+					Expect(*req.Egress).To(BeTrue())
+					deletedEntries[*req.NetworkAclId] = *req.RuleNumber
+				})
+			mockawsEC2.EXPECT().deleteTags(gomock.Any()).Times(2).
+				Do(func(req *ec2.DeleteTagsInput) {
+					Expect(*req.Tags[0].Key).To(Equal(getNetworkACLOwnerTagKeyPrefix(true) + webOwner))
+				})
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Any()).Times(0)
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, rmRule, addRule)
+			Expect(err).Should(BeNil())
+			// the entry shared with db is kept.
+			Expect(deletedEntries).To(Equal(map[string]int64{"acl-1": 1, "acl-2": 1}))
+		})
+		It("Should fail to realize deny rules on subnets with network interfaces not in appliedTo group", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			_, ipNet, _ := net.ParseCIDR("10.10.0.0/16")
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.IngressRule{
+					FromSrcIP: []*net.IPNet{ipNet},
+					Action:    securitygroup.RuleActionDeny,
+				}, NetworkPolicy: testAnpNamespacedName.String()}}
+			networkInterfaces = []*ec2.NetworkInterface{{
+				NetworkInterfaceId: aws.String("eni-0d1f6a0e"),
+				SubnetId:           &testSubnetID01,
+				Groups:             []*ec2.GroupIdentifier{{GroupName: aws.String(webSgIdentifier.GetCloudName(false))}},
+			}, {
+				NetworkInterfaceId: aws.String("eni-0d1f6a0f"),
+				SubnetId:           &testSubnetID01,
+			}}
+			mockawsEC2.EXPECT().describeNetworkAcls(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().createNetworkAclEntry(gomock.Any()).Times(0)
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
+			Expect(err).To(MatchError(ContainSubstring(testSubnetID01)))
+		})
+		It("Should fail to plan network acl entries exceeding aws quotas", func() {
+			owner := (&securitygroup.CloudResourceID{Name: "Web", Vpc: testVpcID01}).GetCloudName(false)
+			entry := &ec2.NetworkAclEntry{Egress: aws.Bool(false), Protocol: aws.String(awsAnyProtocolValue),
+				CidrBlock: aws.String("10.10.0.0/16"), RuleAction: aws.String(ec2.RuleActionDeny)}
+			networkAcl := &ec2.NetworkAcl{NetworkAclId: aws.String("acl-1"), Entries: []*ec2.NetworkAclEntry{
+				{RuleNumber: aws.Int64(networkACLDefaultEntryRuleNumber), Egress: aws.Bool(false),
+					Protocol: aws.String(awsAnyProtocolValue), CidrBlock: aws.String(ipv4AnyCidr),
+					RuleAction: aws.String(ec2.RuleActionDeny)},
+			}}
+			for i := 1; i < awsMaxNetworkACLEntries; i++ {
+				networkAcl.Entries = append(networkAcl.Entries, &ec2.NetworkAclEntry{RuleNumber: aws.Int64(int64(100 + i)),
+					Egress: aws.Bool(false), Protocol: aws.String("6"), CidrBlock: aws.String(fmt.Sprintf("10.20.%d.0/24", i)),
+					RuleAction: aws.String(ec2.RuleActionAllow)})
+			}
+			update, err := planNetworkACLOwnedEntries(networkAcl, owner, []*ec2.NetworkAclEntry{entry})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(update.addEntries).To(HaveLen(1))
+
+			// the default entry does not count towards the entry quota.
+			networkAcl.Entries = append(networkAcl.Entries, &ec2.NetworkAclEntry{RuleNumber: aws.Int64(200),
+				Egress: aws.Bool(false), Protocol: aws.String("17"), CidrBlock: aws.String("10.30.0.0/16"),
+				RuleAction: aws.String(ec2.RuleActionAllow)})
+			_, err = planNetworkACLOwnedEntries(networkAcl, owner, []*ec2.NetworkAclEntry{entry})
+			Expect(err).To(MatchError(ContainSubstring("inbound IPv4")))
+			// the quota applies to each IP family.
+			_, ipv6Net, _ := net.ParseCIDR("2001:db8::/64")
+			ipv6Entry := &ec2.NetworkAclEntry{Egress: aws.Bool(false), Protocol: aws.String(awsAnyProtocolValue),
+				Ipv6CidrBlock: aws.String(ipv6Net.String()), RuleAction: aws.String(ec2.RuleActionDeny)}
+			_, err = planNetworkACLOwnedEntries(networkAcl, owner, []*ec2.NetworkAclEntry{ipv6Entry})
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < awsMaxTagsPerResource; i++ {
+				networkAcl.Tags = append(networkAcl.Tags, &ec2.Tag{Key: aws.String(fmt.Sprintf("tag-%d", i))})
+			}
+			_, err = planNetworkACLOwnedEntries(networkAcl, owner, []*ec2.NetworkAclEntry{ipv6Entry})
+			Expect(err).To(MatchError(ContainSubstring("tags")))
+		})
+		It("Should count managed prefix lists as many rules as their CIDRs in security group limits", func() {
+			var srcIPs []*net.IPNet
+			for i := 0; i < awsPrefixListMinEntries; i++ {
+				_, ipNet, _ := net.ParseCIDR(fmt.Sprintf("10.0.%d.0/24", i))
+				srcIPs = append(srcIPs, ipNet)
+			}
+			_, ipv6Net, _ := net.ParseCIDR("2001:db8::/64")
+			rule := &securitygroup.CloudRule{
+				Rule: &securitygroup.IngressRule{
+					FromPort:  aws.Int(22),
+					FromSrcIP: append(srcIPs, ipv6Net),
+					Protocol:  aws.Int(6),
+				}, NetworkPolicy: testAnpNamespacedName.String()}
+			// the controller shards rules within the limits, hence it must count rules as aws does.
+			limits := cloudInterface.GetSecurityGroupLimits()
+			Expect(limits.GetRuleCount(rule)).To(Equal(getPrefixListMaxEntries(getPrefixListCidrs(srcIPs, false)) + 1))
+		})
+		It("Should advertise configured aws quotas in security group limits", func() {
+			defer SetQuotas(&config.AWSQuotaConfig{
+				SecurityGroupRules:         config.DefaultAWSSecurityGroupRules,
+				SecurityGroupsPerInterface: config.DefaultAWSSecurityGroupsPerInterface,
+				NetworkACLEntries:          config.DefaultAWSNetworkACLEntries,
+			})
+			SetQuotas(&config.AWSQuotaConfig{SecurityGroupRules: 200, SecurityGroupsPerInterface: 4})
+			limits := cloudInterface.GetSecurityGroupLimits()
+			Expect(limits.MaxRules).To(Equal(200))
+			Expect(limits.MaxSecurityGroupsPerInterface).To(Equal(4))
+			Expect(awsMaxNetworkACLEntries).To(Equal(config.DefaultAWSNetworkACLEntries))
+		})
+		It("Should fail to realize deny rules referring to security groups", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.IngressRule{
+					FromSecurityGroups: []*securitygroup.CloudResourceID{&webSgIdentifier.CloudResourceID},
+					Action:             securitygroup.RuleActionDeny,
+				}, NetworkPolicy: testAnpNamespacedName.String()}}
+			mockawsEC2.EXPECT().describeNetworkAcls(gomock.Any()).Times(0)
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
+			Expect(err).Should(HaveOccurred())
+		})
+		It("Should create ICMP ingress rules with type and code successfully", func() {
+			webSgIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+			addRule := []*securitygroup.CloudRule{{
+				Rule: &securitygroup.IngressRule{
+					FromSrcIP:          []*net.IPNet{},
+					FromSecurityGroups: []*securitygroup.CloudResourceID{&webSgIdentifier.CloudResourceID},
+					Protocol:           aws.Int(1),
+					ICMPType:           aws.Int(8),
+				}, NetworkPolicy: testAnpNamespacedName.String()},
+			}
+			output := constructEc2DescribeSecurityGroupsOutput(&webSgIdentifier.CloudResourceID, true, false)
+			outputAt := constructEc2DescribeSecurityGroupsOutput(&webSgIdentifier.CloudResourceID, false, false)
+			output.SecurityGroups = append(output.SecurityGroups, outputAt.SecurityGroups...)
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Any()).Return(output, nil).Times(1)
+			mockawsEC2.EXPECT().revokeSecurityGroupIngress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupIngress(gomock.Any()).Times(1).
+				Do(func(req *ec2.AuthorizeSecurityGroupIngressInput) {
+					Expect(len(req.IpPermissions)).To(Equal(1))
+					Expect(*req.IpPermissions[0].IpProtocol).To(Equal("1"))
+					Expect(*req.IpPermissions[0].FromPort).To(Equal(int64(8)))
+					Expect(*req.IpPermissions[0].ToPort).To(Equal(int64(-1)))
+				})
+			mockawsEC2.EXPECT().revokeSecurityGroupEgress(gomock.Any()).Times(0)
+			mockawsEC2.EXPECT().authorizeSecurityGroupEgress(gomock.Any()).Times(0)
+
+			err := cloudInterface.UpdateSecurityGroupRules(webSgIdentifier, addRule, []*securitygroup.CloudRule{}, addRule)
+			Expect(err).Should(BeNil())
+		})
 		// Ingress rules without a description field is not allowed.
 		It("Should fail to create ingress rules", func() {
 			webSgIdentifier := &securitygroup.CloudResource{
@@ -462,6 +850,212 @@ var _ = Describe("AWS Cloud Security", func() {
 				}
 			}
 		})
+		It("Should sync cloud security group rules with managed prefix list", func() {
+			desc := securitygroup.CloudRuleDescription{
+				Name:           testAnpNamespacedName.Name,
+				Namespace:      testAnpNamespacedName.Namespace,
+				AppliedToGroup: "dummy"}
+			descString := desc.String()
+			webAddressGroupIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+
+			irule := &ec2.IpPermission{
+				FromPort:         aws.Int64(22),
+				IpProtocol:       aws.String("tcp"),
+				IpRanges:         []*ec2.IpRange{{CidrIp: aws.String("1.1.1.1/32"), Description: &descString}},
+				PrefixListIds:    []*ec2.PrefixListId{{PrefixListId: aws.String("pl-1"), Description: &descString}},
+				ToPort:           aws.Int64(22),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			output := constructEc2DescribeSecurityGroupsOutput(&webAddressGroupIdentifier.CloudResourceID, false, false)
+			for _, sg := range output.SecurityGroups {
+				sg.IpPermissions = append(sg.IpPermissions, irule)
+			}
+			var entries []*ec2.PrefixListEntry
+			for i := 0; i < 12; i++ {
+				entries = append(entries, &ec2.PrefixListEntry{Cidr: aws.String(fmt.Sprintf("10.0.%d.0/24", i))})
+			}
+			prefixLists = []*ec2.ManagedPrefixList{
+				{
+					PrefixListId:   aws.String("pl-1"),
+					PrefixListName: aws.String(getPrefixListCloudNamePrefix() + "1"),
+					State:          aws.String(ec2.PrefixListStateCreateComplete),
+				},
+				{
+					PrefixListId:   aws.String("pl-2"),
+					PrefixListName: aws.String(getPrefixListCloudNamePrefix() + "2"),
+					State:          aws.String(ec2.PrefixListStateCreateComplete),
+				},
+			}
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Any()).Return(output, nil).Times(1)
+			mockawsEC2.EXPECT().pagedGetManagedPrefixListEntries(gomock.Any()).Return(entries, nil).Times(1).
+				Do(func(req *ec2.GetManagedPrefixListEntriesInput) {
+					Expect(*req.PrefixListId).To(Equal("pl-1"))
+				})
+			mockawsEC2.EXPECT().deleteManagedPrefixList(gomock.Any()).Return(&ec2.DeleteManagedPrefixListOutput{}, nil).Times(1).
+				Do(func(req *ec2.DeleteManagedPrefixListInput) {
+					Expect(*req.PrefixListId).To(Equal("pl-2"))
+				})
+
+			syncContent := cloudInterface.GetEnforcedSecurity()
+			Expect(len(syncContent)).To(Equal(1))
+			Expect(len(syncContent[0].IngressRules)).To(Equal(1 + len(entries)))
+			Expect(len(syncContent[0].EgressRules)).To(Equal(0))
+		})
+		It("Should sync cloud security groups and IPv6 rules with description", func() {
+			desc := securitygroup.CloudRuleDescription{
+				Name:           testAnpNamespacedName.Name,
+				Namespace:      testAnpNamespacedName.Namespace,
+				AppliedToGroup: "dummy"}
+			descString := desc.String()
+			webAddressGroupIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+
+			input := &ec2.DescribeSecurityGroupsInput{
+				Filters: []*ec2.Filter{{
+					Name:   aws.String(awsFilterKeyVPCID),
+					Values: []*string{aws.String(testVpcID01)},
+				}},
+			}
+			irule := &ec2.IpPermission{
+				FromPort:         aws.Int64(22),
+				IpProtocol:       aws.String("tcp"),
+				IpRanges:         []*ec2.IpRange{{CidrIp: aws.String("1.1.1.1/32"), Description: &descString}},
+				Ipv6Ranges:       []*ec2.Ipv6Range{{CidrIpv6: aws.String("2001:db8::1/128"), Description: &descString}},
+				PrefixListIds:    []*ec2.PrefixListId{},
+				ToPort:           aws.Int64(22),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			// Any destination is realized with both IPv4 and IPv6 any CIDRs, and is synced as a single rule.
+			erule := &ec2.IpPermission{
+				FromPort:         aws.Int64(80),
+				IpProtocol:       aws.String("tcp"),
+				IpRanges:         []*ec2.IpRange{{CidrIp: aws.String(ipv4AnyCidr), Description: &descString}},
+				Ipv6Ranges:       []*ec2.Ipv6Range{{CidrIpv6: aws.String(ipv6AnyCidr), Description: &descString}},
+				PrefixListIds:    []*ec2.PrefixListId{},
+				ToPort:           aws.Int64(80),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			output := constructEc2DescribeSecurityGroupsOutput(&webAddressGroupIdentifier.CloudResourceID, false, false)
+			for _, sg := range output.SecurityGroups {
+				sg.IpPermissions = append(sg.IpPermissions, irule)
+				sg.IpPermissionsEgress = append(sg.IpPermissionsEgress, erule)
+			}
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Eq(input)).Return(output, nil).Times(1)
+
+			syncContent := cloudInterface.GetEnforcedSecurity()
+			Expect(len(syncContent)).To(Equal(1))
+			Expect(len(syncContent[0].IngressRules)).To(Equal(2))
+			Expect(syncContent[0].IngressRules[1].FromSrcIP[0].String()).To(Equal("2001:db8::1/128"))
+			Expect(len(syncContent[0].EgressRules)).To(Equal(1))
+			Expect(syncContent[0].EgressRules[0].ToDstIP[0].String()).To(Equal(ipv4AnyCidr))
+		})
+		It("Should sync cloud security group rules with port range", func() {
+			desc := securitygroup.CloudRuleDescription{
+				Name:           testAnpNamespacedName.Name,
+				Namespace:      testAnpNamespacedName.Namespace,
+				AppliedToGroup: "dummy"}
+			descString := desc.String()
+			webAddressGroupIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+
+			input := &ec2.DescribeSecurityGroupsInput{
+				Filters: []*ec2.Filter{{
+					Name:   aws.String(awsFilterKeyVPCID),
+					Values: []*string{aws.String(testVpcID01)},
+				}},
+			}
+			irule := &ec2.IpPermission{
+				FromPort:         aws.Int64(30000),
+				IpProtocol:       aws.String("tcp"),
+				IpRanges:         []*ec2.IpRange{{CidrIp: aws.String("1.1.1.1/32"), Description: &descString}},
+				Ipv6Ranges:       []*ec2.Ipv6Range{},
+				PrefixListIds:    []*ec2.PrefixListId{},
+				ToPort:           aws.Int64(32767),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			output := constructEc2DescribeSecurityGroupsOutput(&webAddressGroupIdentifier.CloudResourceID, false, false)
+			for _, sg := range output.SecurityGroups {
+				sg.IpPermissions = append(sg.IpPermissions, irule)
+			}
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Eq(input)).Return(output, nil).Times(1)
+
+			syncContent := cloudInterface.GetEnforcedSecurity()
+			Expect(len(syncContent)).To(Equal(1))
+			Expect(len(syncContent[0].IngressRules)).To(Equal(1))
+			Expect(*syncContent[0].IngressRules[0].FromPort).To(Equal(30000))
+			Expect(*syncContent[0].IngressRules[0].FromEndPort).To(Equal(32767))
+		})
+		It("Should sync cloud security group ICMP rules with type and code", func() {
+			desc := securitygroup.CloudRuleDescription{
+				Name:           testAnpNamespacedName.Name,
+				Namespace:      testAnpNamespacedName.Namespace,
+				AppliedToGroup: "dummy"}
+			descString := desc.String()
+			webAddressGroupIdentifier := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "Web",
+					Vpc:  testVpcID01,
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.AWSCloudProvider),
+			}
+
+			input := &ec2.DescribeSecurityGroupsInput{
+				Filters: []*ec2.Filter{{
+					Name:   aws.String(awsFilterKeyVPCID),
+					Values: []*string{aws.String(testVpcID01)},
+				}},
+			}
+			erule := &ec2.IpPermission{
+				FromPort:         aws.Int64(3),
+				IpProtocol:       aws.String("icmp"),
+				IpRanges:         []*ec2.IpRange{{CidrIp: aws.String("1.1.1.1/32"), Description: &descString}},
+				Ipv6Ranges:       []*ec2.Ipv6Range{},
+				PrefixListIds:    []*ec2.PrefixListId{},
+				ToPort:           aws.Int64(4),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			output := constructEc2DescribeSecurityGroupsOutput(&webAddressGroupIdentifier.CloudResourceID, false, false)
+			for _, sg := range output.SecurityGroups {
+				sg.IpPermissionsEgress = append(sg.IpPermissionsEgress, erule)
+			}
+
+			mockawsEC2.EXPECT().describeSecurityGroups(gomock.Eq(input)).Return(output, nil).Times(1)
+
+			syncContent := cloudInterface.GetEnforcedSecurity()
+			Expect(len(syncContent)).To(Equal(1))
+			Expect(len(syncContent[0].EgressRules)).To(Equal(1))
+			Expect(*syncContent[0].EgressRules[0].Protocol).To(Equal(1))
+			Expect(*syncContent[0].EgressRules[0].ICMPType).To(Equal(3))
+			Expect(*syncContent[0].EgressRules[0].ICMPCode).To(Equal(4))
+			Expect(syncContent[0].EgressRules[0].ToPort).To(BeNil())
+		})
 		It("Should sync cloud security groups and rules with an invalid description", func() {
 			// Description does not contain an ATGroup name.
 			desc := securitygroup.CloudRuleDescription{Name: testAnpNamespacedName.Name, Namespace: testAnpNamespacedName.Namespace}
@@ -63,6 +63,21 @@ func (mr *MockawsServiceClientCreateInterfaceMockRecorder) compute() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "compute", reflect.TypeOf((*MockawsServiceClientCreateInterface)(nil).compute))
 }
 
+// organizations mocks base method.
+func (m *MockawsServiceClientCreateInterface) organizations() (awsOrganizationsWrapper, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "organizations")
+	ret0, _ := ret[0].(awsOrganizationsWrapper)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// organizations indicates an expected call of organizations.
+func (mr *MockawsServiceClientCreateInterfaceMockRecorder) organizations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "organizations", reflect.TypeOf((*MockawsServiceClientCreateInterface)(nil).organizations))
+}
+
 // MockawsServicesHelper is a mock of awsServicesHelper interface.
 type MockawsServicesHelper struct {
 	ctrl     *gomock.Controller
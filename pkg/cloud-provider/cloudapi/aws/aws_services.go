@@ -21,25 +21,31 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"k8s.io/apimachinery/pkg/types"
 
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
 )
 
 const (
 	awsComputeServiceNameEC2 = internal.CloudServiceName("EC2")
+	// awsDefaultRegion is the region used to query the regions enabled for an account.
+	awsDefaultRegion = "us-east-1"
 )
 
 // awsServiceClientCreateInterface provides interface to create aws service clients.
 type awsServiceClientCreateInterface interface {
 	compute() (awsEC2Wrapper, error)
+	organizations() (awsOrganizationsWrapper, error)
 	// Add any aws service (like rds, elb etc) apiClient creation methods here
 }
 
 // awsServiceSdkConfigProvider provides config required to create aws service (ec2) clients.
 // Implements awsServiceClientCreateInterface interface
-// NOTE: Currently supporting only static credentials based clients.
+// NOTE: Currently supporting static credentials, assumed role and workload identity based clients.
 type awsServiceSdkConfigProvider struct {
 	session *session.Session
 }
@@ -55,11 +61,12 @@ type awsServicesHelperImpl struct{}
 func (h *awsServicesHelperImpl) newServiceSdkConfigProvider(accConfig *awsAccountConfig) (awsServiceClientCreateInterface, error) {
 	var creds *credentials.Credentials
 	var err error
-	if len(accConfig.RoleArn) != 0 {
+	workloadIdentity := accConfig.credentialMode == crdv1alpha1.CredentialModeWorkloadIdentity
+	if len(accConfig.RoleArn) != 0 || workloadIdentity {
 		var sess *session.Session
 		// If credentials are specified too, create a session with these credentials.
-		if len(accConfig.AccessKeyID) != 0 && len(accConfig.AccessKeySecret) != 0 {
-			tempCreds := credentials.NewStaticCredentials(accConfig.AccessKeyID, accConfig.AccessKeySecret, accConfig.SessionToken)
+		if !workloadIdentity && len(accConfig.AccessKeyID) != 0 && len(accConfig.AccessKeySecret) != 0 {
+			tempCreds := newAccessKeyCredentials(accConfig)
 			if sess, err = session.NewSession(&aws.Config{
 				Region:                        &accConfig.region,
 				Credentials:                   tempCreds,
@@ -70,28 +77,38 @@ func (h *awsServicesHelperImpl) newServiceSdkConfigProvider(accConfig *awsAccoun
 		} else {
 			// use role base access if role provided
 			// new session using worker node role, it should have AssumeRole permissions to the Customer's role ARN resource
+			// with workload identity, the session uses the web identity token (IRSA) or EKS Pod Identity of the Pod.
+			var podCreds *credentials.Credentials
+			if workloadIdentity {
+				podCreds = newWorkloadIdentityCredentials()
+			}
 			if sess, err = session.NewSession(&aws.Config{
 				Region:                        &accConfig.region,
+				Credentials:                   podCreds,
 				CredentialsChainVerboseErrors: aws.Bool(true),
 			}); err != nil {
 				return nil, fmt.Errorf("unable to initialize AWS session: %v", err)
 			}
 		}
 
-		// configure to assume customer role and retrieve temporary credentials
-		externalID := &accConfig.ExternalID
-		if len(accConfig.ExternalID) == 0 {
-			externalID = nil
+		if len(accConfig.RoleArn) != 0 {
+			// configure to assume customer role and retrieve temporary credentials
+			externalID := &accConfig.ExternalID
+			if len(accConfig.ExternalID) == 0 {
+				externalID = nil
+			}
+			stsClient := sts.New(sess)
+			creds = credentials.NewCredentials(&stscreds.AssumeRoleProvider{
+				Client:     stsClient,
+				RoleARN:    accConfig.RoleArn,
+				ExternalID: externalID,
+			})
+		} else {
+			creds = sess.Config.Credentials
 		}
-		stsClient := sts.New(sess)
-		creds = credentials.NewCredentials(&stscreds.AssumeRoleProvider{
-			Client:     stsClient,
-			RoleARN:    accConfig.RoleArn,
-			ExternalID: externalID,
-		})
 	} else {
 		// use static credentials passed in
-		creds = credentials.NewStaticCredentials(accConfig.AccessKeyID, accConfig.AccessKeySecret, accConfig.SessionToken)
+		creds = newAccessKeyCredentials(accConfig)
 	}
 
 	awsConfig := &aws.Config{
@@ -111,6 +128,64 @@ func (h *awsServicesHelperImpl) newServiceSdkConfigProvider(accConfig *awsAccoun
 	return configProvider, nil
 }
 
+// getEC2ServiceName returns the name of the ec2 service config of a region.
+func getEC2ServiceName(region string) internal.CloudServiceName {
+	return internal.CloudServiceName(fmt.Sprintf("%v-%v", awsComputeServiceNameEC2, region))
+}
+
+// getMemberEC2ServiceName returns the name of the ec2 service config of a region of an organization member account.
+func getMemberEC2ServiceName(accountID, region string) internal.CloudServiceName {
+	return internal.CloudServiceName(fmt.Sprintf("%v-%v-%v", awsComputeServiceNameEC2, accountID, region))
+}
+
+// getQueryRegion returns the region used for queries not specific to a region of an account.
+func getQueryRegion(accCredentials *awsAccountConfig) string {
+	for _, region := range accCredentials.regions {
+		if region != crdv1alpha1.CloudProviderAccountAllRegions {
+			return region
+		}
+	}
+	return awsDefaultRegion
+}
+
+// getAccountRegions returns the regions of an account, resolving all regions to the regions enabled for the account.
+func getAccountRegions(helper awsServicesHelper, accCredentials *awsAccountConfig) ([]string, error) {
+	var regions []string
+	allRegions := false
+	for _, region := range accCredentials.regions {
+		if region == crdv1alpha1.CloudProviderAccountAllRegions {
+			allRegions = true
+			continue
+		}
+		regions = append(regions, region)
+	}
+	if !allRegions {
+		return regions, nil
+	}
+
+	regionalCredentials := *accCredentials
+	regionalCredentials.region = getQueryRegion(accCredentials)
+	awsServiceClientCreator, err := helper.newServiceSdkConfigProvider(&regionalCredentials)
+	if err != nil {
+		return nil, err
+	}
+	apiClient, err := awsServiceClientCreator.compute()
+	if err != nil {
+		return nil, err
+	}
+	// only regions enabled for the account are returned by default.
+	output, err := apiClient.describeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, region := range output.Regions {
+		regions = append(regions, aws.StringValue(region.RegionName))
+	}
+	return utils.GetAccountRegions("", regions), nil
+}
+
+// newAwsServiceConfigs creates an ec2 service config for each region of the account, or of each member account
+// of the organization.
 func newAwsServiceConfigs(accountNamespacedName *types.NamespacedName, accCredentials interface{}, awsSpecificHelper interface{}) (
 	[]internal.CloudServiceInterface, error) {
 	awsServicesHelper := awsSpecificHelper.(awsServicesHelper)
@@ -118,16 +193,39 @@ func newAwsServiceConfigs(accountNamespacedName *types.NamespacedName, accCreden
 
 	var serviceConfigs []internal.CloudServiceInterface
 
-	awsServiceClientCreator, err := awsServicesHelper.newServiceSdkConfigProvider(awsAccountCredentials)
-	if err != nil {
-		return nil, err
+	memberAccounts := []*awsAccountConfig{awsAccountCredentials}
+	if awsAccountCredentials.organization != nil {
+		var err error
+		if memberAccounts, err = getOrganizationMemberAccounts(awsServicesHelper, awsAccountCredentials); err != nil {
+			return nil, fmt.Errorf("unable to get member accounts of organization of account %v: %v", accountNamespacedName, err)
+		}
 	}
+	for _, memberCredentials := range memberAccounts {
+		regions, err := getAccountRegions(awsServicesHelper, memberCredentials)
+		if err != nil {
+			if len(memberCredentials.accountID) != 0 {
+				// do not fail other member accounts, if the role of a member account cannot be assumed.
+				awsPluginLogger().Error(err, "unable to get regions of member account", "account", accountNamespacedName,
+					"member", memberCredentials.accountID)
+				continue
+			}
+			return nil, fmt.Errorf("unable to get regions of account %v: %v", accountNamespacedName, err)
+		}
+		for _, region := range regions {
+			regionalCredentials := *memberCredentials
+			regionalCredentials.region = region
+			awsServiceClientCreator, err := awsServicesHelper.newServiceSdkConfigProvider(&regionalCredentials)
+			if err != nil {
+				return nil, err
+			}
 
-	ec2Service, err := newEC2ServiceConfig(*accountNamespacedName, awsServiceClientCreator, awsAccountCredentials)
-	if err != nil {
-		return nil, err
+			ec2Service, err := newEC2ServiceConfig(*accountNamespacedName, awsServiceClientCreator, &regionalCredentials)
+			if err != nil {
+				return nil, err
+			}
+			serviceConfigs = append(serviceConfigs, ec2Service)
+		}
 	}
-	serviceConfigs = append(serviceConfigs, ec2Service)
 
 	return serviceConfigs, nil
 }
@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -38,7 +40,8 @@ import (
 )
 
 var (
-	testVpcID01 = "vpc-cb82c3b2"
+	testVpcID01    = "vpc-cb82c3b2"
+	testSubnetID01 = "subnet-0d1f6a0e"
 )
 
 var _ = Describe("AWS cloud", func() {
@@ -190,6 +193,102 @@ var _ = Describe("AWS cloud", func() {
 				Expect(err).Should(BeNil())
 				Expect(len(vpcMap)).Should(Equal(len(vpcIDs)))
 			})
+			It("Should aggregate inventory of all enabled regions", func() {
+				account.Spec.AWSConfig.Regions = []string{v1alpha1.CloudProviderAccountAllRegions}
+				regions := []string{"us-east-1", "us-west-2"}
+				mockawsServiceWest := NewMockawsServiceClientCreateInterface(mockCtrl)
+				mockawsEC2West := NewMockawsEC2Wrapper(mockCtrl)
+				// enabled regions are queried before creating the service config of each region.
+				mockawsCloudHelper.EXPECT().newServiceSdkConfigProvider(gomock.Any()).Return(mockawsService, nil).Times(1)
+				mockawsCloudHelper.EXPECT().newServiceSdkConfigProvider(gomock.Any()).Return(mockawsServiceWest, nil).Times(1)
+				mockawsServiceWest.EXPECT().compute().Return(mockawsEC2West, nil).AnyTimes()
+				mockawsEC2.EXPECT().describeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String(regions[0])}, {RegionName: aws.String(regions[1])}},
+				}, nil).Times(1)
+
+				vpcIDs := []string{"testVpcID01", "testVpcID02"}
+				westVpcIDs := []string{"testVpcID03"}
+				for ec2Wrapper, ids := range map[*MockawsEC2Wrapper][]string{mockawsEC2: vpcIDs, mockawsEC2West: westVpcIDs} {
+					ec2Wrapper.EXPECT().pagedDescribeInstancesWrapper(gomock.Any()).Return(getEc2InstanceObject([]string{}), nil).AnyTimes()
+					ec2Wrapper.EXPECT().describeVpcsWrapper(gomock.Any()).Return(createVpcObject(ids), nil).AnyTimes()
+					ec2Wrapper.EXPECT().describeVpcPeeringConnectionsWrapper(gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{},
+						nil).AnyTimes()
+				}
+
+				_ = fakeClient.Create(context.Background(), secret)
+				c := newAWSCloud(mockawsCloudHelper)
+
+				err := c.AddProviderAccount(fakeClient, account)
+				Expect(err).Should(BeNil())
+				accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+				Expect(found).To(BeTrue())
+				Expect(len(getEC2ServiceConfigs(accCfg))).To(Equal(len(regions)))
+
+				errPolAdd := c.DoInventoryPoll(&testAccountNamespacedName)
+				Expect(errPolAdd).Should(BeNil())
+
+				vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+				Expect(err).Should(BeNil())
+				Expect(len(vpcMap)).Should(Equal(len(vpcIDs) + len(westVpcIDs)))
+				Expect(vpcMap[strings.ToLower(westVpcIDs[0])].Status.Region).Should(Equal(regions[1]))
+
+				ec2Service, err := getEC2ServiceConfigByVpc(accCfg, westVpcIDs[0])
+				Expect(err).Should(BeNil())
+				Expect(ec2Service.GetName()).Should(Equal(getEC2ServiceName(regions[1])))
+				_, err = getEC2ServiceConfigByVpc(accCfg, "testVpcID04")
+				Expect(err).ShouldNot(BeNil())
+			})
+			It("Should import member accounts of organization", func() {
+				memberAccountIDs := []string{"111111111111", "222222222222", "333333333333"}
+				account.Spec.AWSConfig.Organization = &v1alpha1.CloudProviderAccountAWSOrganizationConfig{
+					RoleName:         "OrganizationAccountAccessRole",
+					ExternalID:       "externalID",
+					DeniedAccountIDs: []string{memberAccountIDs[1]},
+				}
+				mockawsOrganizations := NewMockawsOrganizationsWrapper(mockCtrl)
+				mockawsServiceMember := NewMockawsServiceClientCreateInterface(mockCtrl)
+				mockawsEC2Member := NewMockawsEC2Wrapper(mockCtrl)
+				var memberCredentials *awsAccountConfig
+				// member accounts are listed using the account credentials, before creating the service config of each member.
+				mockawsService.EXPECT().organizations().Return(mockawsOrganizations, nil).Times(1)
+				mockawsOrganizations.EXPECT().pagedListAccounts(gomock.Any()).Return([]*organizations.Account{
+					{Id: aws.String(memberAccountIDs[0]), Status: aws.String(organizations.AccountStatusActive)},
+					{Id: aws.String(memberAccountIDs[1]), Status: aws.String(organizations.AccountStatusActive)},
+					{Id: aws.String(memberAccountIDs[2]), Status: aws.String(organizations.AccountStatusSuspended)},
+				}, nil).Times(1)
+				mockawsCloudHelper.EXPECT().newServiceSdkConfigProvider(gomock.Any()).DoAndReturn(
+					func(accCfg *awsAccountConfig) (awsServiceClientCreateInterface, error) {
+						memberCredentials = accCfg
+						return mockawsServiceMember, nil
+					}).Times(1)
+				mockawsServiceMember.EXPECT().compute().Return(mockawsEC2Member, nil).AnyTimes()
+
+				vpcIDs := []string{"testVpcID01"}
+				mockawsEC2Member.EXPECT().pagedDescribeInstancesWrapper(gomock.Any()).Return(getEc2InstanceObject([]string{}), nil).AnyTimes()
+				mockawsEC2Member.EXPECT().describeVpcsWrapper(gomock.Any()).Return(createVpcObject(vpcIDs), nil).AnyTimes()
+				mockawsEC2Member.EXPECT().describeVpcPeeringConnectionsWrapper(gomock.Any()).Return(&ec2.DescribeVpcPeeringConnectionsOutput{},
+					nil).AnyTimes()
+
+				_ = fakeClient.Create(context.Background(), secret)
+				c := newAWSCloud(mockawsCloudHelper)
+
+				err := c.AddProviderAccount(fakeClient, account)
+				Expect(err).Should(BeNil())
+				accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+				Expect(found).To(BeTrue())
+				Expect(memberCredentials.accountID).To(Equal(memberAccountIDs[0]))
+				Expect(memberCredentials.RoleArn).To(Equal("arn:aws:iam::111111111111:role/OrganizationAccountAccessRole"))
+				Expect(memberCredentials.ExternalID).To(Equal("externalID"))
+				_, err = accCfg.GetServiceConfigByName(getMemberEC2ServiceName(memberAccountIDs[0], "us-east-1"))
+				Expect(err).Should(BeNil())
+
+				errPolAdd := c.DoInventoryPoll(&testAccountNamespacedName)
+				Expect(errPolAdd).Should(BeNil())
+
+				vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+				Expect(err).Should(BeNil())
+				Expect(len(vpcMap)).Should(Equal(len(vpcIDs)))
+			})
 			It("Stop cloud inventory poll on poller delete", func() {
 				credential := `{"accessKeyId": "keyId","accessKeySecret": "keySecret", "sessionToken": "token"}`
 
@@ -455,7 +554,7 @@ var _ = Describe("AWS cloud", func() {
 				Expect(err).Should(BeNil())
 
 				accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-				serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+				serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 				filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 				Expect(filters).To(Equal(expectedFilters))
 			})
@@ -487,7 +586,7 @@ var _ = Describe("AWS cloud", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 			filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 			Expect(filters).To(Equal(expectedFilters))
 		})
@@ -518,7 +617,7 @@ var _ = Describe("AWS cloud", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 			filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 			Expect(filters).To(Equal(expectedFilters))
 		})
@@ -573,7 +672,7 @@ var _ = Describe("AWS cloud", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 			filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 			Expect(filters).To(Equal(expectedFilters))
 		})
@@ -600,7 +699,7 @@ var _ = Describe("AWS cloud", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 			filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 			Expect(filters).To(Equal(expectedFilters))
 		})
@@ -637,7 +736,7 @@ var _ = Describe("AWS cloud", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 			filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 			Expect(filters).To(Equal(expectedFilters))
 		})
@@ -674,7 +773,7 @@ var _ = Describe("AWS cloud", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 			filters := serviceConfig.(*ec2ServiceConfig).instanceFilters[selector.Name]
 			Expect(filters).To(Equal(expectedFilters))
 		})
@@ -686,6 +785,7 @@ func getEc2InstanceObject(instanceIDs []string) []*ec2.Instance {
 	for _, instanceID := range instanceIDs {
 		ec2Instance := &ec2.Instance{
 			VpcId:      &testVpcID01,
+			SubnetId:   &testSubnetID01,
 			InstanceId: aws.String(instanceID),
 		}
 		ec2Instances = append(ec2Instances, ec2Instance)
@@ -726,7 +826,7 @@ func checkAccountAddSuccessCondition(c *awsCloud, namespacedName types.Namespace
 			return true, errors.New("failed to find account")
 		}
 
-		serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+		serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 		instances := serviceConfig.(*ec2ServiceConfig).getCachedInstances()
 		instanceIds := make([]string, 0, len(instances))
 		for _, instance := range instances {
@@ -752,7 +852,7 @@ func checkVpcPollResult(c *awsCloud, namespacedName types.NamespacedName, ids []
 			return true, errors.New("failed to find account")
 		}
 
-		serviceConfig, _ := accCfg.GetServiceConfigByName(awsComputeServiceNameEC2)
+		serviceConfig, _ := accCfg.GetServiceConfigByName(getEC2ServiceName("us-east-1"))
 		vpcs := serviceConfig.(*ec2ServiceConfig).GetCachedVpcs()
 		vpcIDs := make([]string, 0, len(vpcs))
 		for _, vpc := range vpcs {
@@ -15,34 +15,97 @@
 package azure
 
 import (
-	"context"
-	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"strings"
 
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
 )
 
 type azureAccountConfig struct {
 	crdv1alpha1.AzureAccountCredential
+	// credentialMode of the account, the secret is optional with workload identity and managed identity.
+	credentialMode crdv1alpha1.CloudProviderAccountCredentialMode
+	// credentialSource of the account, and source shared by all service configs of the account to retrieve the
+	// client secret again before it expires.
+	credentialSource *crdv1alpha1.CloudProviderAccountCredentialSource
+	source           credentialsource.Source
+	// regions configured for the account, which may include all regions of virtual networks.
+	regions []string
+	// region of the service config of a region of the account.
 	region string
+	// subscriptionIDs of the account in addition to the subscription of the credentials.
+	subscriptionIDs []string
+	// managementGroupID whose subscriptions are included in the account.
+	managementGroupID string
+}
+
+// getSubscriptionIDs returns the IDs of all subscriptions of the account.
+func (accCfg *azureAccountConfig) getSubscriptionIDs() []string {
+	subscriptionIDs := []string{strings.ToLower(accCfg.SubscriptionID)}
+	subscriptionIDSet := map[string]struct{}{subscriptionIDs[0]: {}}
+	for _, subscriptionID := range accCfg.subscriptionIDs {
+		subscriptionID = strings.ToLower(strings.TrimSpace(subscriptionID))
+		if _, found := subscriptionIDSet[subscriptionID]; found || len(subscriptionID) == 0 {
+			continue
+		}
+		subscriptionIDSet[subscriptionID] = struct{}{}
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+	return subscriptionIDs
 }
 
 // setAccountCredentials sets account credentials.
-func setAccountCredentials(client client.Client, credentials interface{}) (interface{}, error) {
+func setAccountCredentials(client client.Client, namespacedName *types.NamespacedName, credentials interface{}) (interface{}, error) {
 	azureProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountAzureConfig)
-	accCred, err := extractSecret(client, azureProviderConfig.SecretRef)
-	if err != nil {
-		return nil, err
+	accCred := &crdv1alpha1.AzureAccountCredential{}
+	if azureProviderConfig.SecretRef != nil {
+		var err error
+		if accCred, err = extractSecret(client, azureProviderConfig.SecretRef); err != nil {
+			return nil, err
+		}
+	}
+	var source credentialsource.Source
+	if azureProviderConfig.CredentialSource != nil {
+		// the source of the account is kept across reconciles, so that dynamic credentials are not generated again.
+		cachedSource, err := credentialsource.GetAccountSource(client, namespacedName, azureProviderConfig.CredentialSource)
+		if err != nil {
+			return nil, err
+		}
+		source = cachedSource
+		data, _, err := source.Retrieve()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve credentials from credential source: %v", err)
+		}
+		if err = json.Unmarshal(data, accCred); err != nil {
+			return nil, fmt.Errorf("unable to parse credentials of credential source: %v", err)
+		}
+	} else {
+		credentialsource.ReleaseAccountSource(namespacedName)
 	}
 
 	azureConfig := &azureAccountConfig{
 		AzureAccountCredential: *accCred,
-		region:                 strings.TrimSpace(azureProviderConfig.Region),
+		credentialMode:         azureProviderConfig.CredentialMode,
+		credentialSource:       azureProviderConfig.CredentialSource.DeepCopy(),
+		source:                 source,
+		regions:                utils.GetAccountRegions(azureProviderConfig.Region, azureProviderConfig.Regions),
+		subscriptionIDs:        azureProviderConfig.SubscriptionIDs,
+		managementGroupID:      strings.TrimSpace(azureProviderConfig.ManagementGroupID),
+	}
+	// without a secret, the subscription of the credentials is the first of the subscriptions of the account.
+	if len(strings.TrimSpace(azureConfig.SubscriptionID)) == 0 && len(azureConfig.subscriptionIDs) != 0 {
+		azureConfig.SubscriptionID = azureConfig.subscriptionIDs[0]
+	}
+	setWorkloadIdentityDefaults(azureConfig)
+	if len(strings.TrimSpace(azureConfig.TenantID)) == 0 {
+		return nil, fmt.Errorf("tenant ID is not specified in secret or %v", azureTenantIDEnv)
 	}
 
 	return azureConfig, nil
@@ -69,27 +132,32 @@ func compareAccountCredentials(accountName string, existing interface{}, new int
 		credsChanged = true
 		azurePluginLogger().Info("account client key updated", "account", accountName)
 	}
-	if strings.Compare(existingConfig.region, newConfig.region) != 0 {
+	if existingConfig.credentialMode != newConfig.credentialMode {
+		credsChanged = true
+		azurePluginLogger().Info("account credential mode updated", "account", accountName)
+	}
+	if !reflect.DeepEqual(existingConfig.credentialSource, newConfig.credentialSource) {
 		credsChanged = true
-		azurePluginLogger().Info("account region updated", "account", accountName)
+		azurePluginLogger().Info("account credential source updated", "account", accountName)
+	}
+	if strings.Compare(strings.Join(existingConfig.regions, ","), strings.Join(newConfig.regions, ",")) != 0 {
+		credsChanged = true
+		azurePluginLogger().Info("account regions updated", "account", accountName)
+	}
+	if strings.Compare(strings.Join(existingConfig.subscriptionIDs, ","), strings.Join(newConfig.subscriptionIDs, ",")) != 0 {
+		credsChanged = true
+		azurePluginLogger().Info("account subscription IDs updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.managementGroupID, newConfig.managementGroupID) != 0 {
+		credsChanged = true
+		azurePluginLogger().Info("account management group ID updated", "account", accountName)
 	}
 	return credsChanged
 }
 
 // extractSecret extracts credentials from a Kubernetes secret.
 func extractSecret(c client.Client, s *crdv1alpha1.SecretReference) (*crdv1alpha1.AzureAccountCredential, error) {
-	u := &unstructured.Unstructured{}
-	u.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "",
-		Kind:    "Secret",
-		Version: "v1",
-	})
-	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
-		return nil, err
-	}
-
-	data := u.Object["data"].(map[string]interface{})
-	decode, err := base64.StdEncoding.DecodeString(data[s.Key].(string))
+	decode, _, err := credentialsource.NewSecretSource(c, s).Retrieve()
 	if err != nil {
 		return nil, err
 	}
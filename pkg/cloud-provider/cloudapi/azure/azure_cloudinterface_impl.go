@@ -22,6 +22,7 @@ import (
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
 	"antrea.io/nephe/pkg/logging"
 )
 
@@ -57,6 +58,19 @@ func (c *azureCloud) ProviderType() cloudcommon.ProviderType {
 	return providerType
 }
 
+// GetCapabilities returns the network policy features supported by Azure. Azure security rules cannot match ICMP type
+// and code, or SCTP.
+func (c *azureCloud) GetCapabilities() crdv1alpha1.CloudCapabilities {
+	return crdv1alpha1.CloudCapabilities{
+		SecurityGroups: true,
+		DenyRules:      true,
+		RuleOrdering:   true,
+		IPv6:           true,
+		PortRanges:     true,
+		MaxRules:       azureMaxSecurityRules,
+	}
+}
+
 // /////////////////////////////////////////////
 //
 //	ComputeInterface Implementation
@@ -85,6 +99,7 @@ func (c *azureCloud) AddProviderAccount(client client.Client, account *crdv1alph
 // RemoveProviderAccount removes and cleans up any resources of given account of a cloud provider.
 func (c *azureCloud) RemoveProviderAccount(namespacedName *types.NamespacedName) {
 	c.cloudCommon.RemoveCloudAccount(namespacedName)
+	credentialsource.ReleaseAccountSource(namespacedName)
 }
 
 // AddAccountResourceSelector adds account specific resource selector.
@@ -42,6 +42,9 @@ type computeServiceConfig struct {
 	inventoryStats         *internal.CloudServiceStats
 	credentials            *azureAccountConfig
 	computeFilters         map[string][]*string
+	// subscriptionServices are the compute service configs of other subscriptions of the account in the region,
+	// keyed by subscription ID. They share the inventory of the region.
+	subscriptionServices map[string]*computeServiceConfig
 }
 
 type computeResourcesCacheSnapshot struct {
@@ -91,10 +94,54 @@ func newComputeServiceConfig(account types.NamespacedName, service azureServiceC
 		inventoryStats:         &internal.CloudServiceStats{},
 		credentials:            credentials,
 		computeFilters:         make(map[string][]*string),
+		subscriptionServices:   make(map[string]*computeServiceConfig),
 	}
+
+	// create sdk api clients of other subscriptions of the account.
+	for _, subscriptionID := range credentials.getSubscriptionIDs()[1:] {
+		subscriptionCredentials := *credentials
+		subscriptionCredentials.SubscriptionID = subscriptionID
+		subscriptionCredentials.subscriptionIDs = nil
+		subscriptionService, err := newComputeServiceConfig(account, service, &subscriptionCredentials)
+		if err != nil {
+			return nil, err
+		}
+		config.subscriptionServices[subscriptionID] = subscriptionService.(*computeServiceConfig)
+	}
+	config.shareInventory()
 	return config, nil
 }
 
+// shareInventory shares the inventory of the region with the compute service configs of other subscriptions.
+func (computeCfg *computeServiceConfig) shareInventory() {
+	for _, subscriptionService := range computeCfg.subscriptionServices {
+		subscriptionService.resourcesCache = computeCfg.resourcesCache
+		subscriptionService.inventoryStats = computeCfg.inventoryStats
+	}
+}
+
+// getSubscriptionServices returns the compute service configs of all subscriptions of the account in the region.
+func (computeCfg *computeServiceConfig) getSubscriptionServices() []*computeServiceConfig {
+	subscriptionServices := []*computeServiceConfig{computeCfg}
+	for _, subscriptionService := range computeCfg.subscriptionServices {
+		subscriptionServices = append(subscriptionServices, subscriptionService)
+	}
+	return subscriptionServices
+}
+
+// getSubscriptionService returns the compute service config of the subscription of an azure resource, which defaults
+// to the subscription of the credentials.
+func (computeCfg *computeServiceConfig) getSubscriptionService(resourceID string) *computeServiceConfig {
+	subscriptionID, _, _, err := extractFieldsFromAzureResourceID(resourceID)
+	if err != nil {
+		return computeCfg
+	}
+	if subscriptionService, found := computeCfg.subscriptionServices[subscriptionID]; found {
+		return subscriptionService
+	}
+	return computeCfg
+}
+
 func (computeCfg *computeServiceConfig) waitForInventoryInit(duration time.Duration) error {
 	operation := func() error {
 		done := computeCfg.inventoryStats.IsInventoryInitialized()
@@ -174,7 +221,10 @@ func (computeCfg *computeServiceConfig) getVirtualMachines() ([]*virtualMachineT
 			"account", computeCfg.account, "resource-filters", "configured")
 	}
 	var subscriptions []*string
-	subscriptions = append(subscriptions, &computeCfg.credentials.SubscriptionID)
+	for _, subscriptionID := range computeCfg.credentials.getSubscriptionIDs() {
+		subscriptionID := subscriptionID
+		subscriptions = append(subscriptions, &subscriptionID)
+	}
 
 	var virtualMachines []*virtualMachineTable
 	for _, filter := range filters {
@@ -203,7 +253,7 @@ func (computeCfg *computeServiceConfig) getComputeResourceFilters() ([]*string,
 		// if any selector found with nil filter, skip all other selectors. As nil indicates all
 		if len(filters) == 0 {
 			var queries []*string
-			subscriptionIDs := []string{computeCfg.credentials.SubscriptionID}
+			subscriptionIDs := computeCfg.credentials.getSubscriptionIDs()
 			tenantIDs := []string{computeCfg.credentials.TenantID}
 			locations := []string{computeCfg.credentials.region}
 			queryStr, err := getVMsBySubscriptionIDsAndTenantIDsAndLocationsMatchQuery(subscriptionIDs, tenantIDs, locations)
@@ -246,7 +296,7 @@ func (computeCfg *computeServiceConfig) DoResourceInventory() error {
 }
 
 func (computeCfg *computeServiceConfig) SetResourceFilters(selector *crdv1alpha1.CloudEntitySelector) {
-	subscriptionIDs := []string{computeCfg.credentials.SubscriptionID}
+	subscriptionIDs := computeCfg.credentials.getSubscriptionIDs()
 	tenantIDs := []string{computeCfg.credentials.TenantID}
 	locations := []string{computeCfg.credentials.region}
 	if filters, found := convertSelectorToComputeQuery(selector, subscriptionIDs, tenantIDs, locations); found {
@@ -281,7 +331,7 @@ func (computeCfg *computeServiceConfig) GetInternalResourceObjects(namespace str
 }
 
 func (computeCfg *computeServiceConfig) GetName() internal.CloudServiceName {
-	return azureComputeServiceNameCompute
+	return getComputeServiceName(computeCfg.credentials.region)
 }
 
 func (computeCfg *computeServiceConfig) GetType() internal.CloudServiceType {
@@ -305,11 +355,35 @@ func (computeCfg *computeServiceConfig) UpdateServiceConfig(newConfig internal.C
 	computeCfg.vnetAPIClient = newComputeServiceConfig.vnetAPIClient
 	computeCfg.resourceGraphAPIClient = newComputeServiceConfig.resourceGraphAPIClient
 	computeCfg.credentials = newComputeServiceConfig.credentials
+	computeCfg.subscriptionServices = newComputeServiceConfig.subscriptionServices
+	computeCfg.shareInventory()
+}
+
+// hasVnet returns true if a vnet of the region is in the cached snapshot.
+func (computeCfg *computeServiceConfig) hasVnet(vnetID string) bool {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		return false
+	}
+	for _, vnet := range snapshot.(*computeResourcesCacheSnapshot).vnets {
+		if strings.EqualFold(*vnet.ID, vnetID) && strings.EqualFold(*vnet.Location, computeCfg.credentials.region) {
+			return true
+		}
+	}
+	return false
 }
 
 // getVpcs invokes cloud API to fetch the list of vnets.
 func (computeCfg *computeServiceConfig) getVpcs() ([]armnetwork.VirtualNetwork, error) {
-	return computeCfg.vnetAPIClient.listAllComplete(context.Background())
+	var vnets []armnetwork.VirtualNetwork
+	for _, subscriptionService := range computeCfg.getSubscriptionServices() {
+		subscriptionVnets, err := subscriptionService.vnetAPIClient.listAllComplete(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		vnets = append(vnets, subscriptionVnets...)
+	}
+	return vnets, nil
 }
 
 func (computeCfg *computeServiceConfig) buildMapVpcPeers(results []armnetwork.VirtualNetwork) map[string][][]string {
@@ -365,6 +439,12 @@ func (computeCfg *computeServiceConfig) GetVpcInventory() map[string]*runtimev1a
 			}
 			vpcObj := ComputeVpcToInternalVpcObject(&vpc, computeCfg.account.Namespace, computeCfg.account.Name,
 				strings.ToLower(computeCfg.credentials.region), managed)
+			// each peer is a list of remote vnet ID, remote address space and local address space.
+			for _, peer := range computeCfg.getVnetPeers(strings.ToLower(*vpc.ID)) {
+				if len(peer) > 0 && len(peer[0]) > 0 {
+					vpcObj.Status.Peers = append(vpcObj.Status.Peers, peer[0])
+				}
+			}
 			vpcMap[strings.ToLower(*vpc.ID)] = vpcObj
 		}
 	}
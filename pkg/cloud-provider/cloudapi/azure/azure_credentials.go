@@ -0,0 +1,132 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+)
+
+const (
+	// azureTenantIDEnv, azureClientIDEnv and azureFederatedTokenFileEnv are injected into Pods by the Azure
+	// workload identity webhook.
+	azureTenantIDEnv           = "AZURE_TENANT_ID"
+	azureClientIDEnv           = "AZURE_CLIENT_ID"
+	azureFederatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+)
+
+// setWorkloadIdentityDefaults sets the tenant and client of the account, which are not specified in the
+// secret, to the workload identity of the Pod.
+func setWorkloadIdentityDefaults(accCreds *azureAccountConfig) {
+	if accCreds.credentialMode == crdv1alpha1.CredentialModeSecret || len(accCreds.credentialMode) == 0 {
+		return
+	}
+	if len(strings.TrimSpace(accCreds.TenantID)) == 0 {
+		accCreds.TenantID = os.Getenv(azureTenantIDEnv)
+	}
+	if len(strings.TrimSpace(accCreds.ClientID)) == 0 {
+		accCreds.ClientID = os.Getenv(azureClientIDEnv)
+	}
+}
+
+// newTokenCredential returns the token credential of the credential mode of the account.
+func newTokenCredential(accCreds *azureAccountConfig) (azcore.TokenCredential, error) {
+	switch accCreds.credentialMode {
+	case crdv1alpha1.CredentialModeWorkloadIdentity:
+		tokenFile := os.Getenv(azureFederatedTokenFileEnv)
+		if len(tokenFile) == 0 {
+			return nil, fmt.Errorf("%v is not set, workload identity is not configured", azureFederatedTokenFileEnv)
+		}
+		// the federated token is read on every token request, as the token is rotated by kubelet.
+		getAssertion := func(context.Context) (string, error) {
+			token, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return "", fmt.Errorf("unable to read federated token file %v: %v", tokenFile, err)
+			}
+			return strings.TrimSpace(string(token)), nil
+		}
+		return azidentity.NewClientAssertionCredential(accCreds.TenantID, accCreds.ClientID, getAssertion, nil)
+	case crdv1alpha1.CredentialModeManagedIdentity:
+		options := &azidentity.ManagedIdentityCredentialOptions{}
+		// system assigned identity is used when client ID of a user assigned identity is not specified.
+		if len(accCreds.ClientID) != 0 {
+			options.ID = azidentity.ClientID(accCreds.ClientID)
+		}
+		return azidentity.NewManagedIdentityCredential(options)
+	default:
+		if accCreds.source != nil {
+			return &sourceCredential{source: accCreds.source, tenantID: accCreds.TenantID, clientID: accCreds.ClientID}, nil
+		}
+		return azidentity.NewClientSecretCredential(accCreds.TenantID, accCreds.ClientID, accCreds.ClientKey, nil)
+	}
+}
+
+// sourceCredential is a client secret credential, whose client secret is retrieved from the credential source of
+// the account. The client secret is retrieved again before it expires, so that the clients of the account use the
+// new client secret without being recreated.
+type sourceCredential struct {
+	mutex    sync.Mutex
+	source   credentialsource.Source
+	tenantID string
+	clientID string
+	data     []byte
+	cred     *azidentity.ClientSecretCredential
+}
+
+// GetToken requests an access token using the current client secret of the credential source.
+func (c *sourceCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	cred, err := c.getClientSecretCredential()
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+	return cred.GetToken(ctx, options)
+}
+
+// getClientSecretCredential returns the client secret credential of the current credentials of the credential source.
+func (c *sourceCredential) getClientSecretCredential() (*azidentity.ClientSecretCredential, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, _, err := c.source.Retrieve()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credentials from credential source: %v", err)
+	}
+	if c.cred != nil && bytes.Equal(data, c.data) {
+		return c.cred, nil
+	}
+
+	accCred := &crdv1alpha1.AzureAccountCredential{TenantID: c.tenantID, ClientID: c.clientID}
+	if err = json.Unmarshal(data, accCred); err != nil {
+		return nil, fmt.Errorf("unable to parse credentials of credential source: %v", err)
+	}
+	cred, err := azidentity.NewClientSecretCredential(accCred.TenantID, accCred.ClientID, accCred.ClientKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.data, c.cred = data, cred
+	return c.cred, nil
+}
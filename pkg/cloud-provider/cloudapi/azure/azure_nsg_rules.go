@@ -17,6 +17,7 @@ package azure
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,11 +28,13 @@ import (
 )
 
 const (
-	ruleStartPriority             = 1000
+	ruleStartPriority             = 100
 	vnetToVnetDenyRulePriority    = 4096
 	vnetToVnetDenyRuleDescription = "nephe-at-" + appliedToSecurityGroupNamePerVnet
 	emptyPort                     = "*"
 	virtualnetworkAddressPrefix   = "VirtualNetwork"
+	// azureMaxSecurityRules is the azure limit of security rules per network security group.
+	azureMaxSecurityRules = 1000
 )
 
 var protoNumAzureNameMap = map[int]armnetwork.SecurityRuleProtocol{
@@ -47,14 +50,17 @@ var azureProtoNameToNumMap = map[armnetwork.SecurityRuleProtocol]int{
 }
 
 // updateSecurityRuleNameAndPriority updates rule name and priority from existing
-// and new security rules and returns all the security rules.
+// and new security rules and returns all the security rules. Allow and deny rules are
+// placed from ruleStartPriority in the order of their rule priorities, and rules without
+// rule priority are placed last.
 func updateSecurityRuleNameAndPriority(existingRules []armnetwork.SecurityRule,
 	newRules []armnetwork.SecurityRule) []armnetwork.SecurityRule {
 	var rules []armnetwork.SecurityRule
+	var orderedRules []armnetwork.SecurityRule
+	var rulePriorities []*securitygroup.RulePriority
 	defaultRulesByName := make(map[string]armnetwork.SecurityRule)
 
-	rulePriority := int32(ruleStartPriority)
-	for _, rule := range existingRules {
+	for _, rule := range append(append([]armnetwork.SecurityRule{}, existingRules...), newRules...) {
 		if rule.Properties == nil {
 			continue
 		}
@@ -62,22 +68,24 @@ func updateSecurityRuleNameAndPriority(existingRules []armnetwork.SecurityRule,
 			defaultRulesByName[*rule.Name] = rule
 			continue
 		}
-		ruleName := fmt.Sprintf("%v-%v", rulePriority, *rule.Properties.Direction)
-		rule.Name = &ruleName
-		rule.Properties.Priority = to.Int32Ptr(rulePriority)
-
-		rules = append(rules, rule)
-		rulePriority++
+		orderedRules = append(orderedRules, rule)
+		rulePriorities = append(rulePriorities, getAzureRulePriority(rule))
 	}
 
-	for _, rule := range newRules {
-		if rule.Properties == nil {
-			continue
-		}
-		if *rule.Properties.Priority == vnetToVnetDenyRulePriority {
-			defaultRulesByName[*rule.Name] = rule
-			continue
+	indexes := make([]int, len(orderedRules))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		pi, pj := rulePriorities[indexes[i]], rulePriorities[indexes[j]]
+		if pi == nil || pj == nil {
+			return pi != nil && pj == nil
 		}
+		return pi.Less(pj)
+	})
+	rulePriority := int32(ruleStartPriority)
+	for _, i := range indexes {
+		rule := orderedRules[i]
 		ruleName := fmt.Sprintf("%v-%v", rulePriority, *rule.Properties.Direction)
 		rule.Name = &ruleName
 		rule.Properties.Priority = to.Int32Ptr(rulePriority)
@@ -93,6 +101,59 @@ func updateSecurityRuleNameAndPriority(existingRules []armnetwork.SecurityRule,
 	return rules
 }
 
+// validateSecurityRulesLimit returns error if security rules of a NSG, e.g. CIDR complements of IPBlocks with except,
+// exceed the azure limit of security rules per NSG. The NSG is shared by all appliedTo groups of a vnet, whose rules
+// were within the limit when realized, hence the overflow is reported against the appliedTo group being updated.
+func validateSecurityRulesLimit(nsgName string, appliedToGroupID *securitygroup.CloudResourceID,
+	rules []*armnetwork.SecurityRule) error {
+	if len(rules) <= azureMaxSecurityRules {
+		return nil
+	}
+	owned := 0
+	for _, rule := range rules {
+		if rule.Properties == nil {
+			continue
+		}
+		desc, ok := securitygroup.ExtractCloudDescription(rule.Properties.Description)
+		if ok && desc.AppliedToGroup == appliedToGroupID.GetCloudName(false) {
+			owned++
+		}
+	}
+	return fmt.Errorf("appliedTo group %v requires %v security rules, exceeding the %v rules left by other appliedTo "+
+		"groups of vnet %v in network security group %v, of the azure limit of %v rules", appliedToGroupID.Name, owned,
+		azureMaxSecurityRules-(len(rules)-owned), appliedToGroupID.Vpc, nsgName, azureMaxSecurityRules)
+}
+
+// isAzureDenyRule returns true if a security rule is a deny rule created from a deny securitygroup.CloudRule.
+func isAzureDenyRule(rule armnetwork.SecurityRule) bool {
+	return rule.Properties.Access != nil && *rule.Properties.Access == armnetwork.SecurityRuleAccessDeny &&
+		*rule.Properties.Priority != vnetToVnetDenyRulePriority
+}
+
+// getAzureRulePriority returns the rule priority of a security rule, which is carried in its description, or nil
+// if the description has no rule priority.
+func getAzureRulePriority(rule armnetwork.SecurityRule) *securitygroup.RulePriority {
+	desc, ok := securitygroup.ExtractCloudDescription(rule.Properties.Description)
+	if !ok {
+		return nil
+	}
+	return desc.Priority
+}
+
+// buildSecurityRuleAccessAndDescription returns the access and description of the security rules of a
+// securitygroup.CloudRule. The description carries the rule priority, so that allow and deny rules of all
+// applied to groups sharing a NSG can be ordered.
+func buildSecurityRuleAccessAndDescription(obj *securitygroup.CloudRule, appliedToGroupID *securitygroup.CloudResourceID) (
+	armnetwork.SecurityRuleAccess, string, error) {
+	access := armnetwork.SecurityRuleAccessAllow
+	if obj.IsDeny() {
+		access = armnetwork.SecurityRuleAccessDeny
+	}
+	description, err := securitygroup.GenerateCloudDescriptionWithPriority(obj.NetworkPolicy, appliedToGroupID.GetCloudName(false),
+		obj.GetPriority())
+	return access, description, err
+}
+
 // convertIngressToNsgSecurityRules converts ingress rules from securitygroup.CloudRule to azure rules.
 func convertIngressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResourceID, rules []*securitygroup.CloudRule,
 	agAsgMapByNepheControllerName map[string]armnetwork.ApplicationSecurityGroup,
@@ -110,7 +171,7 @@ func convertIngressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResou
 		if rule == nil {
 			continue
 		}
-		description, err := securitygroup.GenerateCloudDescription(obj.NetworkPolicy, appliedToGroupID.GetCloudName(false))
+		access, description, err := buildSecurityRuleAccessAndDescription(obj, appliedToGroupID)
 		if err != nil {
 			return []armnetwork.SecurityRule{}, fmt.Errorf("unable to generate rule description, err: %v", err)
 		}
@@ -118,18 +179,23 @@ func convertIngressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResou
 		if err != nil {
 			return []armnetwork.SecurityRule{}, err
 		}
+		if err := validateAzureICMPTypeCode(rule.ICMPType, rule.ICMPCode); err != nil {
+			return []armnetwork.SecurityRule{}, err
+		}
 
-		srcPort := convertToAzurePortRange(rule.FromPort)
+		srcPort := convertToAzurePortRange(rule.FromPort, rule.FromEndPort)
 
 		if len(rule.FromSrcIP) != 0 || len(rule.FromSecurityGroups) == 0 {
-			srcAddrPrefix, srcAddrPrefixes := convertToAzureAddressPrefix(rule.FromSrcIP)
-			if srcAddrPrefix != nil || srcAddrPrefixes != nil {
-				securityRule := buildSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
-					to.StringPtr(emptyPort), srcAddrPrefix, srcAddrPrefixes, nil,
-					&srcPort, nil, nil, []*armnetwork.ApplicationSecurityGroup{&dstAsgObj}, &description,
-					armnetwork.SecurityRuleAccessAllow)
-				securityRules = append(securityRules, securityRule)
-				rulePriority++
+			for _, srcIPs := range groupIPNetsByFamily(rule.FromSrcIP) {
+				srcAddrPrefix, srcAddrPrefixes := convertToAzureAddressPrefix(srcIPs)
+				if srcAddrPrefix != nil || srcAddrPrefixes != nil {
+					securityRule := buildSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
+						to.StringPtr(emptyPort), srcAddrPrefix, srcAddrPrefixes, nil,
+						&srcPort, nil, nil, []*armnetwork.ApplicationSecurityGroup{&dstAsgObj}, &description,
+						access)
+					securityRules = append(securityRules, securityRule)
+					rulePriority++
+				}
 			}
 		}
 
@@ -138,7 +204,7 @@ func convertIngressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResou
 			securityRule := buildSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
 				to.StringPtr(emptyPort), nil, nil, srcApplicationSecurityGroups,
 				&srcPort, nil, nil, []*armnetwork.ApplicationSecurityGroup{&dstAsgObj}, &description,
-				armnetwork.SecurityRuleAccessAllow)
+				access)
 			securityRules = append(securityRules, securityRule)
 			rulePriority++
 		}
@@ -165,7 +231,7 @@ func convertIngressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudR
 		if rule == nil {
 			continue
 		}
-		description, err := securitygroup.GenerateCloudDescription(obj.NetworkPolicy, appliedToGroupID.GetCloudName(false))
+		access, description, err := buildSecurityRuleAccessAndDescription(obj, appliedToGroupID)
 		if err != nil {
 			return []armnetwork.SecurityRule{}, fmt.Errorf("unable to generate rule description, err: %v", err)
 		}
@@ -173,18 +239,23 @@ func convertIngressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudR
 		if err != nil {
 			return []armnetwork.SecurityRule{}, err
 		}
+		if err := validateAzureICMPTypeCode(rule.ICMPType, rule.ICMPCode); err != nil {
+			return []armnetwork.SecurityRule{}, err
+		}
 
-		srcPort := convertToAzurePortRange(rule.FromPort)
+		srcPort := convertToAzurePortRange(rule.FromPort, rule.FromEndPort)
 
 		if len(rule.FromSrcIP) != 0 || len(rule.FromSecurityGroups) == 0 {
-			srcAddrPrefix, srcAddrPrefixes := convertToAzureAddressPrefix(rule.FromSrcIP)
-			if srcAddrPrefix != nil || srcAddrPrefixes != nil {
-				securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
-					to.StringPtr(emptyPort), srcAddrPrefix, srcAddrPrefixes, nil,
-					&srcPort, to.StringPtr(emptyPort), nil, nil, &description,
-					armnetwork.SecurityRuleAccessAllow, appliedToGroupID.Name)
-				securityRules = append(securityRules, securityRule)
-				rulePriority++
+			for _, srcIPs := range groupIPNetsByFamily(rule.FromSrcIP) {
+				srcAddrPrefix, srcAddrPrefixes := convertToAzureAddressPrefix(srcIPs)
+				if srcAddrPrefix != nil || srcAddrPrefixes != nil {
+					securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
+						to.StringPtr(emptyPort), srcAddrPrefix, srcAddrPrefixes, nil,
+						&srcPort, to.StringPtr(emptyPort), nil, nil, &description,
+						access, appliedToGroupID.Name)
+					securityRules = append(securityRules, securityRule)
+					rulePriority++
+				}
 			}
 		}
 		flag := 0
@@ -195,7 +266,7 @@ func convertIngressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudR
 					securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
 						to.StringPtr(emptyPort), nil, nil, srcApplicationSecurityGroups,
 						&srcPort, to.StringPtr(emptyPort), nil, nil, &description,
-						armnetwork.SecurityRuleAccessAllow, appliedToGroupID.Name)
+						access, appliedToGroupID.Name)
 					securityRules = append(securityRules, securityRule)
 					rulePriority++
 					flag = 1
@@ -207,7 +278,7 @@ func convertIngressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudR
 			securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionInbound,
 				to.StringPtr(emptyPort), ruleIP, nil, nil,
 				&srcPort, to.StringPtr(emptyPort), nil, nil, &description,
-				armnetwork.SecurityRuleAccessAllow, appliedToGroupID.Name)
+				access, appliedToGroupID.Name)
 			securityRules = append(securityRules, securityRule)
 			rulePriority++
 		}
@@ -239,7 +310,7 @@ func convertEgressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResour
 		if rule == nil {
 			continue
 		}
-		description, err := securitygroup.GenerateCloudDescription(obj.NetworkPolicy, appliedToGroupID.GetCloudName(false))
+		access, description, err := buildSecurityRuleAccessAndDescription(obj, appliedToGroupID)
 		if err != nil {
 			return []armnetwork.SecurityRule{}, fmt.Errorf("unable to generate rule description, err: %v", err)
 		}
@@ -247,17 +318,22 @@ func convertEgressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResour
 		if err != nil {
 			return []armnetwork.SecurityRule{}, err
 		}
+		if err := validateAzureICMPTypeCode(rule.ICMPType, rule.ICMPCode); err != nil {
+			return []armnetwork.SecurityRule{}, err
+		}
 
-		dstPort := convertToAzurePortRange(rule.ToPort)
+		dstPort := convertToAzurePortRange(rule.ToPort, rule.ToEndPort)
 
 		if len(rule.ToDstIP) != 0 || len(rule.ToSecurityGroups) == 0 {
-			dstAddrPrefix, dstAddrPrefixes := convertToAzureAddressPrefix(rule.ToDstIP)
-			if dstAddrPrefix != nil || dstAddrPrefixes != nil {
-				securityRule := buildSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
-					to.StringPtr(emptyPort), nil, nil, []*armnetwork.ApplicationSecurityGroup{&srcAsgObj},
-					&dstPort, dstAddrPrefix, dstAddrPrefixes, nil, &description, armnetwork.SecurityRuleAccessAllow)
-				securityRules = append(securityRules, securityRule)
-				rulePriority++
+			for _, dstIPs := range groupIPNetsByFamily(rule.ToDstIP) {
+				dstAddrPrefix, dstAddrPrefixes := convertToAzureAddressPrefix(dstIPs)
+				if dstAddrPrefix != nil || dstAddrPrefixes != nil {
+					securityRule := buildSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
+						to.StringPtr(emptyPort), nil, nil, []*armnetwork.ApplicationSecurityGroup{&srcAsgObj},
+						&dstPort, dstAddrPrefix, dstAddrPrefixes, nil, &description, access)
+					securityRules = append(securityRules, securityRule)
+					rulePriority++
+				}
 			}
 		}
 
@@ -265,7 +341,7 @@ func convertEgressToNsgSecurityRules(appliedToGroupID *securitygroup.CloudResour
 		if len(dstApplicationSecurityGroups) != 0 {
 			securityRule := buildSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
 				to.StringPtr(emptyPort), nil, nil, []*armnetwork.ApplicationSecurityGroup{&srcAsgObj},
-				&dstPort, nil, nil, dstApplicationSecurityGroups, &description, armnetwork.SecurityRuleAccessAllow)
+				&dstPort, nil, nil, dstApplicationSecurityGroups, &description, access)
 			securityRules = append(securityRules, securityRule)
 			rulePriority++
 		}
@@ -293,7 +369,7 @@ func convertEgressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudRe
 		if rule == nil {
 			continue
 		}
-		description, err := securitygroup.GenerateCloudDescription(obj.NetworkPolicy, appliedToGroupID.GetCloudName(false))
+		access, description, err := buildSecurityRuleAccessAndDescription(obj, appliedToGroupID)
 		if err != nil {
 			return []armnetwork.SecurityRule{}, fmt.Errorf("unable to generate rule description, err: %v", err)
 		}
@@ -301,17 +377,22 @@ func convertEgressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudRe
 		if err != nil {
 			return []armnetwork.SecurityRule{}, err
 		}
+		if err := validateAzureICMPTypeCode(rule.ICMPType, rule.ICMPCode); err != nil {
+			return []armnetwork.SecurityRule{}, err
+		}
 
-		dstPort := convertToAzurePortRange(rule.ToPort)
+		dstPort := convertToAzurePortRange(rule.ToPort, rule.ToEndPort)
 
 		if len(rule.ToDstIP) != 0 || len(rule.ToSecurityGroups) == 0 {
-			dstAddrPrefix, dstAddrPrefixes := convertToAzureAddressPrefix(rule.ToDstIP)
-			if dstAddrPrefix != nil || dstAddrPrefixes != nil {
-				securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
-					to.StringPtr(emptyPort), to.StringPtr(emptyPort), nil, nil,
-					&dstPort, dstAddrPrefix, dstAddrPrefixes, nil, &description, armnetwork.SecurityRuleAccessAllow, appliedToGroupID.Name)
-				securityRules = append(securityRules, securityRule)
-				rulePriority++
+			for _, dstIPs := range groupIPNetsByFamily(rule.ToDstIP) {
+				dstAddrPrefix, dstAddrPrefixes := convertToAzureAddressPrefix(dstIPs)
+				if dstAddrPrefix != nil || dstAddrPrefixes != nil {
+					securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
+						to.StringPtr(emptyPort), to.StringPtr(emptyPort), nil, nil,
+						&dstPort, dstAddrPrefix, dstAddrPrefixes, nil, &description, access, appliedToGroupID.Name)
+					securityRules = append(securityRules, securityRule)
+					rulePriority++
+				}
 			}
 		}
 		flag := 0
@@ -321,7 +402,7 @@ func convertEgressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudRe
 				if len(dstApplicationSecurityGroups) != 0 {
 					securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
 						to.StringPtr(emptyPort), to.StringPtr(emptyPort), nil, nil,
-						&dstPort, nil, nil, dstApplicationSecurityGroups, &description, armnetwork.SecurityRuleAccessAllow, appliedToGroupID.Name)
+						&dstPort, nil, nil, dstApplicationSecurityGroups, &description, access, appliedToGroupID.Name)
 					securityRules = append(securityRules, securityRule)
 					rulePriority++
 					flag = 1
@@ -332,7 +413,7 @@ func convertEgressToPeerNsgSecurityRules(appliedToGroupID *securitygroup.CloudRe
 		if flag == 0 {
 			securityRule := buildPeerSecurityRule(to.Int32Ptr(rulePriority), protoName, armnetwork.SecurityRuleDirectionOutbound,
 				to.StringPtr(emptyPort), to.StringPtr(emptyPort), nil, nil,
-				&dstPort, ruleIP, nil, nil, &description, armnetwork.SecurityRuleAccessAllow, appliedToGroupID.Name)
+				&dstPort, ruleIP, nil, nil, &description, access, appliedToGroupID.Name)
 			securityRules = append(securityRules, securityRule)
 			rulePriority++
 		}
@@ -418,6 +499,8 @@ func convertToAzureApplicationSecurityGroups(securityGroups []*securitygroup.Clo
 	return asgsToReturn
 }
 
+// convertToAzureProtocolName converts rule protocol to azure protocol name. Protocols other than TCP, UDP
+// and ICMP, e.g. SCTP, are not supported by azure security rules.
 func convertToAzureProtocolName(protoNum *int) (armnetwork.SecurityRuleProtocol, error) {
 	if protoNum == nil {
 		return armnetwork.SecurityRuleProtocolAsterisk, nil
@@ -425,19 +508,56 @@ func convertToAzureProtocolName(protoNum *int) (armnetwork.SecurityRuleProtocol,
 
 	protocolName, found := protoNumAzureNameMap[*protoNum]
 	if !found {
-		return "", fmt.Errorf("unsupported protocol number %v", *protoNum)
+		return "", fmt.Errorf("unsupported protocol number %v, azure security rules only support tcp, udp and icmp", *protoNum)
 	}
 
 	return protocolName, nil
 }
 
-func convertToAzurePortRange(port *int) string {
+// validateAzureICMPTypeCode returns error if a rule has ICMP type or code, as azure security rules can only match
+// all ICMP traffic.
+func validateAzureICMPTypeCode(icmpType *int, icmpCode *int) error {
+	if icmpType != nil || icmpCode != nil {
+		return fmt.Errorf("icmp type and code are not supported by azure security rules")
+	}
+	return nil
+}
+
+func convertToAzurePortRange(port *int, endPort *int) string {
 	if port == nil {
 		return emptyPort
 	}
+	if endPort != nil {
+		return fmt.Sprintf("%d-%d", *port, *endPort)
+	}
 	return strconv.Itoa(*port)
 }
 
+// groupIPNetsByFamily splits rule IPs into IPv4 and IPv6 groups, as azure does not allow mixing address families
+// in a security rule. Rules without IPs are returned as a single empty group, which matches any address.
+func groupIPNetsByFamily(ruleIPs []*net.IPNet) [][]*net.IPNet {
+	if len(ruleIPs) == 0 {
+		return [][]*net.IPNet{nil}
+	}
+	var ipv4Nets, ipv6Nets []*net.IPNet
+	for _, ip := range ruleIPs {
+		if ip.IP.To4() != nil {
+			ipv4Nets = append(ipv4Nets, ip)
+		} else {
+			ipv6Nets = append(ipv6Nets, ip)
+		}
+	}
+	var groups [][]*net.IPNet
+	if len(ipv4Nets) != 0 {
+		groups = append(groups, ipv4Nets)
+	}
+	if len(ipv6Nets) != 0 {
+		groups = append(groups, ipv6Nets)
+	}
+	return groups
+}
+
+// convertToAzureAddressPrefix converts rule IPs of the same address family to azure address prefixes.
 func convertToAzureAddressPrefix(ruleIPs []*net.IPNet) (*string, []*string) {
 	var prefixes []*string
 	for _, ip := range ruleIPs {
@@ -483,6 +603,11 @@ func convertToInternalRulesByAppliedToSGName(azureSecurityRules []*armnetwork.Se
 			continue
 		}
 		ruleName := azureSecurityRule.Name
+		var action securitygroup.RuleAction
+		if isAzureDenyRule(*azureSecurityRule) {
+			action = securitygroup.RuleActionDeny
+		}
+		priority := desc.Priority
 
 		if *azureSecurityRule.Properties.Direction == armnetwork.SecurityRuleDirectionInbound {
 			ingressRule, err := convertFromAzureSecurityRuleToInternalIngressRule(*azureSecurityRule, vnetID)
@@ -490,6 +615,10 @@ func convertToInternalRulesByAppliedToSGName(azureSecurityRules []*armnetwork.Se
 				azurePluginLogger().Error(err, "failed to convert to ingress rule", "ruleName", ruleName)
 				continue
 			}
+			for i := range ingressRule {
+				ingressRule[i].Action = action
+				ingressRule[i].Priority = priority
+			}
 			rules := nepheControllerATSgNameToIngressRules[sgName]
 			rules = append(rules, ingressRule...)
 			nepheControllerATSgNameToIngressRules[sgName] = rules
@@ -499,6 +628,10 @@ func convertToInternalRulesByAppliedToSGName(azureSecurityRules []*armnetwork.Se
 				azurePluginLogger().Error(err, "failed to convert to egress rule", "ruleName", ruleName)
 				continue
 			}
+			for i := range egressRule {
+				egressRule[i].Action = action
+				egressRule[i].Priority = priority
+			}
 			rules := nepheControllerATSgNameToEgressRules[sgName]
 			rules = append(rules, egressRule...)
 			nepheControllerATSgNameToEgressRules[sgName] = rules
@@ -513,7 +646,7 @@ func convertFromAzureSecurityRuleToInternalIngressRule(rule armnetwork.SecurityR
 	vnetID string) ([]securitygroup.IngressRule, error) {
 	ingressList := make([]securitygroup.IngressRule, 0)
 
-	port := convertFromAzurePortToNepheControllerPort(rule.Properties.DestinationPortRange)
+	port, endPort := convertFromAzurePortToNepheControllerPort(rule.Properties.DestinationPortRange)
 	srcIP := convertFromAzurePrefixesToNepheControllerIPs(rule.Properties.SourceAddressPrefix, rule.Properties.SourceAddressPrefixes)
 	securityGroups := convertFromAzureASGsToNepheControllerSecurityGroups(rule.Properties.SourceApplicationSecurityGroups, vnetID)
 	protoNum, err := convertFromAzureProtocolToNepheControllerProtocol(rule.Properties.Protocol)
@@ -522,15 +655,17 @@ func convertFromAzureSecurityRuleToInternalIngressRule(rule armnetwork.SecurityR
 	}
 	for _, ip := range srcIP {
 		ingressRule := securitygroup.IngressRule{
-			FromPort:  port,
-			FromSrcIP: []*net.IPNet{ip},
-			Protocol:  protoNum,
+			FromPort:    port,
+			FromEndPort: endPort,
+			FromSrcIP:   []*net.IPNet{ip},
+			Protocol:    protoNum,
 		}
 		ingressList = append(ingressList, ingressRule)
 	}
 	for _, sg := range securityGroups {
 		ingressRule := securitygroup.IngressRule{
 			FromPort:           port,
+			FromEndPort:        endPort,
 			FromSecurityGroups: []*securitygroup.CloudResourceID{sg},
 			Protocol:           protoNum,
 		}
@@ -545,7 +680,7 @@ func convertFromAzureSecurityRuleToInternalEgressRule(rule armnetwork.SecurityRu
 	vnetID string) ([]securitygroup.EgressRule, error) {
 	egressList := make([]securitygroup.EgressRule, 0)
 
-	port := convertFromAzurePortToNepheControllerPort(rule.Properties.DestinationPortRange)
+	port, endPort := convertFromAzurePortToNepheControllerPort(rule.Properties.DestinationPortRange)
 	dstIP := convertFromAzurePrefixesToNepheControllerIPs(rule.Properties.DestinationAddressPrefix, rule.Properties.DestinationAddressPrefixes)
 	securityGroups := convertFromAzureASGsToNepheControllerSecurityGroups(rule.Properties.DestinationApplicationSecurityGroups, vnetID)
 	protoNum, err := convertFromAzureProtocolToNepheControllerProtocol(rule.Properties.Protocol)
@@ -555,15 +690,17 @@ func convertFromAzureSecurityRuleToInternalEgressRule(rule armnetwork.SecurityRu
 
 	for _, ip := range dstIP {
 		egressRule := securitygroup.EgressRule{
-			ToPort:   port,
-			ToDstIP:  []*net.IPNet{ip},
-			Protocol: protoNum,
+			ToPort:    port,
+			ToEndPort: endPort,
+			ToDstIP:   []*net.IPNet{ip},
+			Protocol:  protoNum,
 		}
 		egressList = append(egressList, egressRule)
 	}
 	for _, sg := range securityGroups {
 		egressRule := securitygroup.EgressRule{
 			ToPort:           port,
+			ToEndPort:        endPort,
 			ToSecurityGroups: []*securitygroup.CloudResourceID{sg},
 			Protocol:         protoNum,
 		}
@@ -635,13 +772,26 @@ func convertFromAzurePrefixesToNepheControllerIPs(ipPrefix *string, ipPrefixes [
 	return ipNetList
 }
 
-func convertFromAzurePortToNepheControllerPort(port *string) *int {
+// convertFromAzurePortToNepheControllerPort converts azure port or port range to rule port and end port.
+// End port is nil for a single port.
+func convertFromAzurePortToNepheControllerPort(port *string) (*int, *int) {
 	if port == nil || *port == emptyPort {
-		return nil
+		return nil, nil
 	}
-	portNum, err := strconv.ParseInt(*port, 10, 32)
+	ports := strings.Split(*port, "-")
+	portNum, err := strconv.ParseInt(strings.TrimSpace(ports[0]), 10, 32)
 	if err != nil {
-		return nil
+		return nil, nil
+	}
+	if len(ports) != 2 {
+		return to.IntPtr(int(portNum)), nil
+	}
+	endPortNum, err := strconv.ParseInt(strings.TrimSpace(ports[1]), 10, 32)
+	if err != nil {
+		return nil, nil
+	}
+	if endPortNum == portNum {
+		return to.IntPtr(int(portNum)), nil
 	}
-	return to.IntPtr(int(portNum))
+	return to.IntPtr(int(portNum)), to.IntPtr(int(endPortNum))
 }
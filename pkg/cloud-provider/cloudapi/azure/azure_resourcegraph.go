@@ -16,6 +16,7 @@ package azure
 
 import (
 	"context"
+	"fmt"
 
 	resourcegraph "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcegraph/armresourcegraph"
 )
@@ -29,6 +30,13 @@ const (
 	vmIDsNotFoundErrorMsg           = "vm ID(s) required for the query"
 	vmNamesNotFoundErrorMsg         = "vm name(s) required for the query"
 	vmIDorNameNotFoundErrorMsg      = "vm ID(s) or name(s) required for the query"
+
+	// subscriptionsQuery returns the IDs of subscriptions in the scope of the query.
+	subscriptionsQuery = "resourcecontainers " +
+		"| where type =~ 'microsoft.resources/subscriptions' " +
+		"| project subscriptionId"
+	// maxQueryRecords is the maximum number of records returned by a query.
+	maxQueryRecords = int32(1000)
 )
 
 // resourceGraph returns resource-graph SDK apiClient.
@@ -61,3 +69,35 @@ func invokeResourceGraphQuery(resourceGraphAPIClient azureResourceGraphWrapper,
 	}
 	return nil, 0, queryErr
 }
+
+// getSubscriptionIDsOfManagementGroup returns the IDs of all subscriptions under a management group.
+func getSubscriptionIDsOfManagementGroup(resourceGraphAPIClient azureResourceGraphWrapper, managementGroupID string) ([]string, error) {
+	query := subscriptionsQuery
+	top := maxQueryRecords
+	resultFmt := resourcegraph.ResultFormatObjectArray
+	request := resourcegraph.QueryRequest{
+		ManagementGroups: []*string{&managementGroupID},
+		Query:            &query,
+		Options: &resourcegraph.QueryRequestOptions{
+			ResultFormat: &resultFmt,
+			Top:          &top,
+		},
+	}
+
+	results, err := resourceGraphAPIClient.resources(context.Background(), request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke query: %q", err)
+	}
+	var subscriptionIDs []string
+	subscriptionRows, _ := results.Data.([]interface{})
+	for _, subscriptionRow := range subscriptionRows {
+		row, ok := subscriptionRow.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if subscriptionID, ok := row["subscriptionId"].(string); ok {
+			subscriptionIDs = append(subscriptionIDs, subscriptionID)
+		}
+	}
+	return subscriptionIDs, nil
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
 )
 
@@ -38,9 +39,40 @@ var (
 	mutex sync.Mutex
 )
 
+// getComputeServiceConfigs returns the compute service configs of all regions of an account.
+func getComputeServiceConfigs(accCfg internal.CloudAccountInterface) []*computeServiceConfig {
+	var computeServices []*computeServiceConfig
+	for name := range accCfg.GetServiceConfigs() {
+		serviceCfg, err := accCfg.GetServiceConfigByName(name)
+		if err != nil {
+			continue
+		}
+		if computeService, ok := serviceCfg.(*computeServiceConfig); ok {
+			computeServices = append(computeServices, computeService)
+		}
+	}
+	return computeServices
+}
+
+// getComputeServiceConfigByVnet returns the compute service config of the region and the subscription of a vnet of an
+// account.
+func getComputeServiceConfigByVnet(accCfg internal.CloudAccountInterface, vnetID string) (*computeServiceConfig, error) {
+	computeServices := getComputeServiceConfigs(accCfg)
+	// vnets of an account with a single region are in that region.
+	if len(computeServices) == 1 {
+		return computeServices[0].getSubscriptionService(vnetID), nil
+	}
+	for _, computeService := range computeServices {
+		if computeService.hasVnet(vnetID) {
+			return computeService.getSubscriptionService(vnetID), nil
+		}
+	}
+	return nil, fmt.Errorf("azure account %v has no region with virtual network [%v]", accCfg.GetNamespacedName(), vnetID)
+}
+
 func (computeCfg *computeServiceConfig) getNetworkInterfacesOfVnet(vnetIDSet map[string]struct{}) ([]*networkInterfaceTable, error) {
 	location := computeCfg.credentials.region
-	subscriptionID := computeCfg.credentials.SubscriptionID
+	subscriptionIDs := computeCfg.credentials.getSubscriptionIDs()
 	tenentID := computeCfg.credentials.TenantID
 
 	var vnetIDs []string
@@ -48,12 +80,16 @@ func (computeCfg *computeServiceConfig) getNetworkInterfacesOfVnet(vnetIDSet map
 		vnetIDs = append(vnetIDs, vnetID)
 	}
 
-	query, err := getNwIntfsByVnetIDsAndSubscriptionIDsAndTenantIDsAndLocationsMatchQuery(vnetIDs, []string{subscriptionID},
+	query, err := getNwIntfsByVnetIDsAndSubscriptionIDsAndTenantIDsAndLocationsMatchQuery(vnetIDs, subscriptionIDs,
 		[]string{tenentID}, []string{location})
 	if err != nil {
 		return nil, err
 	}
-	nwIntfs, _, err := getNetworkInterfaceTable(computeCfg.resourceGraphAPIClient, query, []*string{&subscriptionID})
+	var subscriptions []*string
+	for i := range subscriptionIDs {
+		subscriptions = append(subscriptions, &subscriptionIDs[i])
+	}
+	nwIntfs, _, err := getNetworkInterfaceTable(computeCfg.resourceGraphAPIClient, query, subscriptions)
 	return nwIntfs, err
 }
 
@@ -655,9 +691,13 @@ func (computeCfg *computeServiceConfig) processAndBuildATSgView(networkInterface
 func (computeCfg *computeServiceConfig) getATGroupView(nepheControllerATSGNameToCloudResourcesMap map[string][]securitygroup.CloudResource,
 	perVnetNsgIDToNepheControllerATSGNameSet map[string]map[string]struct{}, nsgIDToVnetID map[string]string) (
 	[]securitygroup.SynchronizationContent, error) {
-	networkSecurityGroups, err := computeCfg.nsgAPIClient.listAllComplete(context.Background())
-	if err != nil {
-		return []securitygroup.SynchronizationContent{}, err
+	var networkSecurityGroups []armnetwork.SecurityGroup
+	for _, subscriptionService := range computeCfg.getSubscriptionServices() {
+		subscriptionNsgs, err := subscriptionService.nsgAPIClient.listAllComplete(context.Background())
+		if err != nil {
+			return []securitygroup.SynchronizationContent{}, err
+		}
+		networkSecurityGroups = append(networkSecurityGroups, subscriptionNsgs...)
 	}
 
 	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
@@ -779,9 +819,13 @@ func (computeCfg *computeServiceConfig) processAndBuildAGSgView(
 // getAGGroupView creates synchronization content for ASGs created by nephe under managed VNETs.
 func (computeCfg *computeServiceConfig) getAGGroupView(nepheControllerAGSgNameToCloudResourcesMap map[string][]securitygroup.CloudResource,
 	asgIDToVnetID map[string]string) ([]securitygroup.SynchronizationContent, error) {
-	appSecurityGroups, err := computeCfg.asgAPIClient.listAllComplete(context.Background())
-	if err != nil {
-		return []securitygroup.SynchronizationContent{}, err
+	var appSecurityGroups []armnetwork.ApplicationSecurityGroup
+	for _, subscriptionService := range computeCfg.getSubscriptionServices() {
+		subscriptionAsgs, err := subscriptionService.asgAPIClient.listAllComplete(context.Background())
+		if err != nil {
+			return []securitygroup.SynchronizationContent{}, err
+		}
+		appSecurityGroups = append(appSecurityGroups, subscriptionAsgs...)
 	}
 
 	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
@@ -839,11 +883,10 @@ func (c *azureCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.
 	}
 
 	// create/get nsg/asg on/from cloud
-	serviceCfg, err := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+	computeService, err := getComputeServiceConfigByVnet(accCfg, vnetID)
 	if err != nil {
 		return nil, err
 	}
-	computeService := serviceCfg.(*computeServiceConfig)
 	location := computeService.credentials.region
 
 	if !membershipOnly {
@@ -902,11 +945,10 @@ func (c *azureCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *security
 	if !found {
 		return fmt.Errorf("azure account not found managing virtual network [%v]", vnetID)
 	}
-	serviceCfg, err := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+	computeService, err := getComputeServiceConfigByVnet(accCfg, vnetID)
 	if err != nil {
 		return err
 	}
-	computeService := serviceCfg.(*computeServiceConfig)
 	location := computeService.credentials.region
 
 	// extract resource-group-name from vnet ID
@@ -961,6 +1003,10 @@ func (c *azureCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *security
 			return err
 		}
 	}
+	if err = validateSecurityRulesLimit(appliedToGroupPerVnetNsgNepheControllerName, &appliedToGroupIdentifier.CloudResourceID,
+		rules); err != nil {
+		return err
+	}
 	// update network security group with rules
 	return updateNetworkSecurityGroupRules(computeService.nsgAPIClient, location, rgName, appliedToGroupPerVnetNsgNepheControllerName, rules)
 }
@@ -976,11 +1022,10 @@ func (c *azureCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securit
 	if !found {
 		return fmt.Errorf("azure account not found managing virtual network [%v]", vnetID)
 	}
-	serviceCfg, err := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+	computeService, err := getComputeServiceConfigByVnet(accCfg, vnetID)
 	if err != nil {
 		return err
 	}
-	computeService := serviceCfg.(*computeServiceConfig)
 
 	return computeService.updateSecurityGroupMembers(&securityGroupIdentifier.CloudResourceID, computeResourceIdentifier, membershipOnly)
 }
@@ -995,11 +1040,10 @@ func (c *azureCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.
 	if !found {
 		return fmt.Errorf("azure account not found managing virtual network [%v]", vnetID)
 	}
-	serviceCfg, err := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+	computeService, err := getComputeServiceConfigByVnet(accCfg, vnetID)
 	if err != nil {
 		return err
 	}
-	computeService := serviceCfg.(*computeServiceConfig)
 	location := computeService.credentials.region
 
 	_ = computeService.updateSecurityGroupMembers(&securityGroupIdentifier.CloudResourceID, nil, membershipOnly)
@@ -1061,18 +1105,18 @@ func (c *azureCloud) GetEnforcedSecurity() []securitygroup.SynchronizationConten
 				return
 			}
 
-			serviceCfg, err := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
-			if err != nil {
-				azurePluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
-				return
-			}
-			computeService := serviceCfg.(*computeServiceConfig)
-			err = computeService.waitForInventoryInit(inventoryInitWaitDuration)
-			if err != nil {
-				azurePluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
-				return
+			// the cloud view of an account is the cloud views of all its regions.
+			var cloudView []securitygroup.SynchronizationContent
+			for _, computeService := range getComputeServiceConfigs(accCfg) {
+				err := computeService.waitForInventoryInit(inventoryInitWaitDuration)
+				if err != nil {
+					azurePluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName(),
+						"region", computeService.credentials.region)
+					return
+				}
+				cloudView = append(cloudView, computeService.getNepheControllerManagedSecurityGroupsCloudView()...)
 			}
-			sendCh <- computeService.getNepheControllerManagedSecurityGroupsCloudView()
+			sendCh <- cloudView
 		}(accNamespacedNameCopy, ch)
 	}
 
@@ -1084,6 +1128,18 @@ func (c *azureCloud) GetEnforcedSecurity() []securitygroup.SynchronizationConten
 	return enforcedSecurityCloudView
 }
 
+// GetSecurityGroupLimits returns the azure limits on security rules of a network security group. A network interface
+// has a single network security group, hence rules cannot be sharded. The network security group is shared by all
+// appliedTo groups of a vnet, hence MaxRules only bounds the rules of an appliedTo group, and rules exceeding the limit
+// together with rules of other appliedTo groups fail to realize in UpdateSecurityGroupRules.
+func (c *azureCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{
+		MaxRules:                      azureMaxSecurityRules,
+		SharedDirections:              true,
+		MaxSecurityGroupsPerInterface: 1,
+	}
+}
+
 func (computeCfg *computeServiceConfig) getNepheControllerManagedSecurityGroupsCloudView() []securitygroup.SynchronizationContent {
 	vnetIDs := computeCfg.getManagedVnetIDs()
 	if len(vnetIDs) == 0 {
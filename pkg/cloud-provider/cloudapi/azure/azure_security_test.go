@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	network "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -230,7 +231,7 @@ var _ = Describe("Azure Cloud Security", func() {
 			Expect(err).Should(BeNil())
 
 			accCfg, _ := c.cloudCommon.GetCloudAccountByName(testAccountNamespacedName)
-			serviceConfig, _ := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+			serviceConfig, _ := accCfg.GetServiceConfigByName(getComputeServiceName(testRegion))
 
 			vnetIDs := make(map[string]struct{})
 			vnetIDs[strings.ToLower(testVnetID01)] = struct{}{}
@@ -412,6 +413,169 @@ var _ = Describe("Azure Cloud Security", func() {
 				Expect(err).Should(BeNil())
 			})
 
+			It("Should build separate security rules for IPv4 and IPv6 prefixes", func() {
+				atGroupID := &securitygroup.CloudResourceID{Name: atAsgName, Vpc: testVnetID01}
+				_, ipv4Net, _ := net.ParseCIDR("10.0.0.0/24")
+				_, ipv6Net, _ := net.ParseCIDR("2001:db8::/64")
+				rules := []*securitygroup.CloudRule{{
+					Rule: &securitygroup.IngressRule{
+						Protocol:  &testProtocol,
+						FromPort:  &testFromPort,
+						FromSrcIP: []*net.IPNet{ipv4Net, ipv6Net},
+					}, NetworkPolicy: testAnpNamespace.String()},
+				}
+				atAsgMap := map[string]network.ApplicationSecurityGroup{
+					strings.ToLower(atAsgName): {ID: &testATAsgID, Name: &atAsgID},
+				}
+
+				securityRules, err := convertIngressToNsgSecurityRules(atGroupID, rules, nil, atAsgMap)
+				Expect(err).Should(BeNil())
+				// One rule for each address family, along with the vnet to vnet deny rule.
+				Expect(len(securityRules)).To(Equal(3))
+				Expect(securityRules[0].Properties.SourceAddressPrefixes).To(HaveLen(1))
+				Expect(*securityRules[0].Properties.SourceAddressPrefixes[0]).To(Equal("10.0.0.0/24"))
+				Expect(securityRules[1].Properties.SourceAddressPrefixes).To(HaveLen(1))
+				Expect(*securityRules[1].Properties.SourceAddressPrefixes[0]).To(Equal("2001:db8::/64"))
+			})
+
+			It("Should order allow and deny security rules by priority", func() {
+				atGroupID := &securitygroup.CloudResourceID{Name: atAsgName, Vpc: testVnetID01}
+				_, ipNet, _ := net.ParseCIDR("10.0.0.0/24")
+				allowPriority := securitygroup.RulePriority{Tier: 250, Policy: 5, Rule: 0}
+				lowPriority := securitygroup.RulePriority{Tier: 250, Policy: 10.5, Rule: 1}
+				highPriority := securitygroup.RulePriority{Tier: 100, Policy: 1, Rule: 0}
+				otherPriority := securitygroup.RulePriority{Tier: 200, Policy: 1, Rule: 0}
+				rules := []*securitygroup.CloudRule{
+					{Rule: &securitygroup.IngressRule{Protocol: &testProtocol, FromSrcIP: []*net.IPNet{ipNet},
+						Priority: &allowPriority}, NetworkPolicy: testAnpNamespace.String()},
+					{Rule: &securitygroup.IngressRule{FromSrcIP: []*net.IPNet{ipNet}, Action: securitygroup.RuleActionDeny,
+						Priority: &lowPriority}, NetworkPolicy: testAnpNamespace.String()},
+					{Rule: &securitygroup.IngressRule{FromSrcIP: []*net.IPNet{ipNet}, Action: securitygroup.RuleActionDeny,
+						Priority: &highPriority}, NetworkPolicy: testAnpNamespace.String()},
+				}
+				atAsgMap := map[string]network.ApplicationSecurityGroup{
+					strings.ToLower(atAsgName): {ID: &testATAsgID, Name: &atAsgID},
+				}
+				otherGroupID := &securitygroup.CloudResourceID{Name: "other", Vpc: testVnetID01}
+				otherDescription, _ := securitygroup.GenerateCloudDescriptionWithPriority(testAnpNamespace.String(),
+					otherGroupID.GetCloudName(false), &otherPriority)
+				existingRule := buildSecurityRule(to.Int32Ptr(ruleStartPriority), network.SecurityRuleProtocolAsterisk,
+					network.SecurityRuleDirectionInbound, to.StringPtr(emptyPort), to.StringPtr(testCidrStr), nil, nil,
+					to.StringPtr(emptyPort), to.StringPtr(emptyPort), nil, nil, &otherDescription, network.SecurityRuleAccessDeny)
+				// rules created without rule priority are placed last.
+				legacyDescription, _ := securitygroup.GenerateCloudDescription(testAnpNamespace.String(),
+					otherGroupID.GetCloudName(false))
+				legacyRule := buildSecurityRule(to.Int32Ptr(ruleStartPriority+1), network.SecurityRuleProtocolAsterisk,
+					network.SecurityRuleDirectionInbound, to.StringPtr(emptyPort), to.StringPtr(testCidrStr), nil, nil,
+					to.StringPtr(emptyPort), to.StringPtr(emptyPort), nil, nil, &legacyDescription, network.SecurityRuleAccessAllow)
+
+				securityRules, err := convertIngressToNsgSecurityRules(atGroupID, rules, nil, atAsgMap)
+				Expect(err).Should(BeNil())
+				Expect(*securityRules[0].Properties.Access).To(Equal(network.SecurityRuleAccessAllow))
+				Expect(*securityRules[1].Properties.Access).To(Equal(network.SecurityRuleAccessDeny))
+				Expect(*securityRules[2].Properties.Access).To(Equal(network.SecurityRuleAccessDeny))
+
+				allRules := updateSecurityRuleNameAndPriority([]network.SecurityRule{legacyRule, existingRule}, securityRules)
+				// Five rules, along with the vnet to vnet deny rule.
+				Expect(allRules).To(HaveLen(6))
+				Expect(*allRules[0].Properties.Priority).To(Equal(int32(ruleStartPriority)))
+				Expect(*getAzureRulePriority(allRules[0])).To(Equal(highPriority))
+				Expect(*allRules[1].Properties.Priority).To(Equal(int32(ruleStartPriority + 1)))
+				Expect(*allRules[1].Properties.Description).To(Equal(otherDescription))
+				Expect(*allRules[2].Properties.Priority).To(Equal(int32(ruleStartPriority + 2)))
+				Expect(*allRules[2].Properties.Access).To(Equal(network.SecurityRuleAccessAllow))
+				Expect(*getAzureRulePriority(allRules[2])).To(Equal(allowPriority))
+				Expect(*allRules[3].Properties.Priority).To(Equal(int32(ruleStartPriority + 3)))
+				Expect(*getAzureRulePriority(allRules[3])).To(Equal(lowPriority))
+				Expect(*allRules[4].Properties.Priority).To(Equal(int32(ruleStartPriority + 4)))
+				Expect(*allRules[4].Properties.Description).To(Equal(legacyDescription))
+				Expect(*allRules[5].Properties.Priority).To(Equal(int32(vnetToVnetDenyRulePriority)))
+
+				ingressRulesBySgName, _ := convertToInternalRulesByAppliedToSGName([]*network.SecurityRule{&allRules[0], &allRules[2]},
+					testVnetID01)
+				Expect(ingressRulesBySgName).To(HaveLen(1))
+				for _, ingressRules := range ingressRulesBySgName {
+					Expect(ingressRules).To(HaveLen(2))
+					Expect(ingressRules[0].Action).To(Equal(securitygroup.RuleActionDeny))
+					Expect(*ingressRules[0].Priority).To(Equal(highPriority))
+					Expect(ingressRules[1].Action).To(BeEmpty())
+					Expect(*ingressRules[1].Priority).To(Equal(allowPriority))
+				}
+			})
+
+			It("Should fail security rules exceeding azure limits against the updated appliedTo group", func() {
+				atGroupID := &securitygroup.CloudResourceID{Name: atAsgName, Vpc: testVnetID01}
+				otherGroupID := &securitygroup.CloudResourceID{Name: "other", Vpc: testVnetID01}
+				ownDescription, _ := securitygroup.GenerateCloudDescription(testAnpNamespace.String(), atGroupID.GetCloudName(false))
+				otherDescription, _ := securitygroup.GenerateCloudDescription(testAnpNamespace.String(),
+					otherGroupID.GetCloudName(false))
+				rules := make([]*network.SecurityRule, 0, azureMaxSecurityRules+1)
+				for i := 0; i < azureMaxSecurityRules-100; i++ {
+					rules = append(rules, &network.SecurityRule{Properties: &network.SecurityRulePropertiesFormat{
+						Description: &otherDescription}})
+				}
+				for i := 0; i < 100; i++ {
+					rules = append(rules, &network.SecurityRule{Properties: &network.SecurityRulePropertiesFormat{
+						Description: &ownDescription}})
+				}
+				Expect(validateSecurityRulesLimit(atAsgName, atGroupID, rules)).Should(BeNil())
+				rules = append(rules, rules[len(rules)-1])
+				err := validateSecurityRulesLimit(atAsgName, atGroupID, rules)
+				Expect(err).To(MatchError(ContainSubstring("appliedTo group %v requires 101 security rules, exceeding the 100 rules",
+					atAsgName)))
+			})
+
+			It("Should round-trip port ranges of security rules", func() {
+				atGroupID := &securitygroup.CloudResourceID{Name: atAsgName, Vpc: testVnetID01}
+				_, ipNet, _ := net.ParseCIDR("10.0.0.0/24")
+				fromPort, fromEndPort := 30000, 32767
+				rules := []*securitygroup.CloudRule{{
+					Rule: &securitygroup.IngressRule{
+						Protocol:    &testProtocol,
+						FromPort:    &fromPort,
+						FromEndPort: &fromEndPort,
+						FromSrcIP:   []*net.IPNet{ipNet},
+					}, NetworkPolicy: testAnpNamespace.String()},
+				}
+				atAsgMap := map[string]network.ApplicationSecurityGroup{
+					strings.ToLower(atAsgName): {ID: &testATAsgID, Name: &atAsgID},
+				}
+
+				securityRules, err := convertIngressToNsgSecurityRules(atGroupID, rules, nil, atAsgMap)
+				Expect(err).Should(BeNil())
+				Expect(*securityRules[0].Properties.DestinationPortRange).To(Equal("30000-32767"))
+
+				ingressRules, err := convertFromAzureSecurityRuleToInternalIngressRule(securityRules[0], testVnetID01)
+				Expect(err).Should(BeNil())
+				Expect(ingressRules).To(HaveLen(1))
+				Expect(*ingressRules[0].FromPort).To(Equal(fromPort))
+				Expect(*ingressRules[0].FromEndPort).To(Equal(fromEndPort))
+			})
+
+			It("Should reject ICMP type and code, and SCTP in security rules", func() {
+				atGroupID := &securitygroup.CloudResourceID{Name: atAsgName, Vpc: testVnetID01}
+				icmpProtocol, sctpProtocol, icmpType := 1, 132, 8
+				atAsgMap := map[string]network.ApplicationSecurityGroup{
+					strings.ToLower(atAsgName): {ID: &testATAsgID, Name: &atAsgID},
+				}
+
+				rules := []*securitygroup.CloudRule{{
+					Rule:          &securitygroup.IngressRule{Protocol: &icmpProtocol},
+					NetworkPolicy: testAnpNamespace.String()},
+				}
+				securityRules, err := convertIngressToNsgSecurityRules(atGroupID, rules, nil, atAsgMap)
+				Expect(err).Should(BeNil())
+				Expect(*securityRules[0].Properties.Protocol).To(Equal(network.SecurityRuleProtocolIcmp))
+
+				rules[0].Rule.(*securitygroup.IngressRule).ICMPType = &icmpType
+				_, err = convertIngressToNsgSecurityRules(atGroupID, rules, nil, atAsgMap)
+				Expect(err).ShouldNot(BeNil())
+
+				rules[0].Rule = &securitygroup.IngressRule{Protocol: &sctpProtocol}
+				_, err = convertIngressToNsgSecurityRules(atGroupID, rules, nil, atAsgMap)
+				Expect(err).ShouldNot(BeNil())
+			})
+
 			//  Creating cloud security rules without a description field is not allowed.
 			It("Should fail to update Security rules -- invalid namespacedname", func() {
 				webAddressGroupIdentifier03 := &securitygroup.CloudResource{
@@ -590,7 +754,7 @@ var _ = Describe("Azure Cloud Security", func() {
 				}
 
 				accCfg, _ := c.cloudCommon.GetCloudAccountByName(testAccountNamespacedName)
-				serviceConfig, _ := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+				serviceConfig, _ := accCfg.GetServiceConfigByName(getComputeServiceName(testRegion))
 				serviceConfig.(*computeServiceConfig).resourcesCache.UpdateSnapshot(&computeResourcesCacheSnapshot{vmToUpdateMap, nil, nil, nil})
 
 				serviceConfig.(*computeServiceConfig).GetInternalResourceObjects(testAccountNamespacedName.Namespace, testAccountNamespacedName)
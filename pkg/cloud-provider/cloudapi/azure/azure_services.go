@@ -15,12 +15,16 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"k8s.io/apimachinery/pkg/types"
 
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
 )
 
 const (
@@ -40,7 +44,7 @@ type azureServiceClientCreateInterface interface {
 // azureServiceSdkConfigProvider provides config required to create azure service clients.
 // Implements azureServiceClientCreateInterface interface.
 type azureServiceSdkConfigProvider struct {
-	cred *azidentity.ClientSecretCredential
+	cred azcore.TokenCredential
 }
 
 // azureServicesHelper.
@@ -56,7 +60,7 @@ func (h *azureServicesHelperImpl) newServiceSdkConfigProvider(accCreds *azureAcc
 	var err error
 
 	// TODO: Expose an option in CPA to specify the cloud type, AzurePublic, AzureGovernment and AzureChina.
-	cred, err := azidentity.NewClientSecretCredential(accCreds.TenantID, accCreds.ClientID, accCreds.ClientKey, nil)
+	cred, err := newTokenCredential(accCreds)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize Azure authorizer from credentials: %v", err)
 	}
@@ -67,23 +71,98 @@ func (h *azureServicesHelperImpl) newServiceSdkConfigProvider(accCreds *azureAcc
 	return configProvider, nil
 }
 
+// getComputeServiceName returns the name of the compute service config of a region.
+func getComputeServiceName(region string) internal.CloudServiceName {
+	return internal.CloudServiceName(fmt.Sprintf("%v-%v", azureComputeServiceNameCompute, region))
+}
+
+// getAccountSubscriptionIDs returns the subscriptions of an account in addition to the subscription of the
+// credentials, including the subscriptions under the management group of the account.
+func getAccountSubscriptionIDs(service azureServiceClientCreateInterface, accCredentials *azureAccountConfig) ([]string, error) {
+	subscriptionIDs := accCredentials.subscriptionIDs
+	if len(accCredentials.managementGroupID) == 0 {
+		return subscriptionIDs, nil
+	}
+
+	resourceGraphAPIClient, err := service.resourceGraph()
+	if err != nil {
+		return nil, err
+	}
+	managementGroupSubscriptionIDs, err := getSubscriptionIDsOfManagementGroup(resourceGraphAPIClient, accCredentials.managementGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]string{}, subscriptionIDs...), managementGroupSubscriptionIDs...), nil
+}
+
+// getAccountRegions returns the regions of an account, resolving all regions to the regions of virtual networks of the
+// subscriptions.
+func getAccountRegions(service azureServiceClientCreateInterface, accCredentials *azureAccountConfig) ([]string, error) {
+	var regions []string
+	allRegions := false
+	for _, region := range accCredentials.regions {
+		if region == crdv1alpha1.CloudProviderAccountAllRegions {
+			allRegions = true
+			continue
+		}
+		regions = append(regions, region)
+	}
+	if !allRegions {
+		return regions, nil
+	}
+
+	for _, subscriptionID := range accCredentials.getSubscriptionIDs() {
+		vnetAPIClient, err := service.virtualNetworks(subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		vnets, err := vnetAPIClient.listAllComplete(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		for _, vnet := range vnets {
+			if vnet.Location != nil {
+				regions = append(regions, strings.ToLower(*vnet.Location))
+			}
+		}
+	}
+	return utils.GetAccountRegions("", regions), nil
+}
+
+// newAzureServiceConfigs creates a compute service config for each region of the account, which covers all subscriptions
+// of the account.
 func newAzureServiceConfigs(accountNamespacedName *types.NamespacedName, accCredentials interface{}, azureSpecificHelper interface{}) (
 	[]internal.CloudServiceInterface, error) {
 	azureServicesHelper := azureSpecificHelper.(azureServicesHelper)
-	azureAccountCredentials := accCredentials.(*azureAccountConfig)
+	azureAccountCredentials := *accCredentials.(*azureAccountConfig)
 
 	var serviceConfigs []internal.CloudServiceInterface
 
-	azureServiceClientCreator, err := azureServicesHelper.newServiceSdkConfigProvider(azureAccountCredentials)
+	azureServiceClientCreator, err := azureServicesHelper.newServiceSdkConfigProvider(&azureAccountCredentials)
 	if err != nil {
 		return nil, err
 	}
 
-	computeService, err := newComputeServiceConfig(*accountNamespacedName, azureServiceClientCreator, azureAccountCredentials)
+	subscriptionIDs, err := getAccountSubscriptionIDs(azureServiceClientCreator, &azureAccountCredentials)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to get subscriptions of account %v: %v", accountNamespacedName, err)
+	}
+	azureAccountCredentials.subscriptionIDs = subscriptionIDs
+	azureAccountCredentials.managementGroupID = ""
+
+	regions, err := getAccountRegions(azureServiceClientCreator, &azureAccountCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get regions of account %v: %v", accountNamespacedName, err)
+	}
+	for _, region := range regions {
+		regionalCredentials := azureAccountCredentials
+		regionalCredentials.region = region
+		computeService, err := newComputeServiceConfig(*accountNamespacedName, azureServiceClientCreator, &regionalCredentials)
+		if err != nil {
+			return nil, err
+		}
+		serviceConfigs = append(serviceConfigs, computeService)
 	}
-	serviceConfigs = append(serviceConfigs, computeService)
 
 	return serviceConfigs, nil
 }
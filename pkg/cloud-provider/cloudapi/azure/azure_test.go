@@ -190,6 +190,51 @@ var _ = Describe("Azure", func() {
 				Expect(err).Should(BeNil())
 				Expect(len(vnetMap)).Should(Equal(len(vnetIDs)))
 			})
+			It("Should create compute service for regions of all vnets", func() {
+				vnetIDs := []string{"testVnetID01", "testVnetID02"}
+				mockazureVirtualNetworksWrapper.EXPECT().listAllComplete(gomock.Any()).Return(createVnetObject(vnetIDs), nil).AnyTimes()
+				account.Spec.AzureConfig.Regions = []string{v1alpha1.CloudProviderAccountAllRegions}
+				c := newAzureCloud(mockAzureServiceHelper)
+
+				err := c.AddProviderAccount(fakeClient, account)
+				Expect(err).Should(BeNil())
+				accCfg, found := c.cloudCommon.GetCloudAccountByName(testAccountNamespacedName)
+				Expect(found).To(BeTrue())
+				Expect(accCfg.GetServiceConfigs()).Should(HaveLen(1))
+				_, err = accCfg.GetServiceConfigByName(getComputeServiceName(testRegion))
+				Expect(err).Should(BeNil())
+
+				computeService, err := getComputeServiceConfigByVnet(accCfg, vnetIDs[0])
+				Expect(err).Should(BeNil())
+				Expect(computeService.credentials.region).Should(Equal(testRegion))
+			})
+			It("Should route vnets to the clients of their subscriptions", func() {
+				testSubID02 := "subid02"
+				testVnetIDSub02 := fmt.Sprintf("/subscriptions/%v/resourceGroups/%v/providers/Microsoft.Network/virtualNetworks/%v",
+					testSubID02, testRG, testVnet02)
+				vnetIDs := []string{testVnetID01, testVnetIDSub02}
+				mockazureVirtualNetworksWrapper.EXPECT().listAllComplete(gomock.Any()).Return(createVnetObject(vnetIDs), nil).AnyTimes()
+				account.Spec.AzureConfig.SubscriptionIDs = []string{testSubID02}
+				c := newAzureCloud(mockAzureServiceHelper)
+
+				err := c.AddProviderAccount(fakeClient, account)
+				Expect(err).Should(BeNil())
+				accCfg, found := c.cloudCommon.GetCloudAccountByName(testAccountNamespacedName)
+				Expect(found).To(BeTrue())
+
+				errPolAdd := c.DoInventoryPoll(testAccountNamespacedName)
+				Expect(errPolAdd).Should(BeNil())
+				vnetMap, err := c.GetVpcInventory(testAccountNamespacedName)
+				Expect(err).Should(BeNil())
+				Expect(len(vnetMap)).Should(Equal(len(vnetIDs)))
+
+				computeService, err := getComputeServiceConfigByVnet(accCfg, testVnetID01)
+				Expect(err).Should(BeNil())
+				Expect(computeService.credentials.SubscriptionID).Should(Equal(testSubID))
+				computeService, err = getComputeServiceConfigByVnet(accCfg, testVnetIDSub02)
+				Expect(err).Should(BeNil())
+				Expect(computeService.credentials.SubscriptionID).Should(Equal(testSubID02))
+			})
 			It("Stop cloud inventory poll on poller delete", func() {
 				vnetIDs := []string{"testVnetID01", "testVnetID02"}
 				mockazureVirtualNetworksWrapper.EXPECT().listAllComplete(gomock.Any()).Return(createVnetObject(vnetIDs), nil).MinTimes(1)
@@ -475,7 +520,7 @@ func getResourceGraphResult() resourcegraph.ClientResourcesResponse {
 
 func getFilters(c *azureCloud, selectorName string) []*string {
 	accCfg, _ := c.cloudCommon.GetCloudAccountByName(&types.NamespacedName{Namespace: "namespace01", Name: "account01"})
-	serviceConfig, _ := accCfg.GetServiceConfigByName(azureComputeServiceNameCompute)
+	serviceConfig, _ := accCfg.GetServiceConfigByName(getComputeServiceName(region))
 	filters := serviceConfig.(*computeServiceConfig).computeFilters[selectorName]
 	return filters
 }
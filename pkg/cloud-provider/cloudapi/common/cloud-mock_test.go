@@ -153,6 +153,20 @@ func (mr *MockCloudInterfaceMockRecorder) GetAccountStatus(accNamespacedName int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatus", reflect.TypeOf((*MockCloudInterface)(nil).GetAccountStatus), accNamespacedName)
 }
 
+// GetCapabilities mocks base method.
+func (m *MockCloudInterface) GetCapabilities() v1alpha1.CloudCapabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapabilities")
+	ret0, _ := ret[0].(v1alpha1.CloudCapabilities)
+	return ret0
+}
+
+// GetCapabilities indicates an expected call of GetCapabilities.
+func (mr *MockCloudInterfaceMockRecorder) GetCapabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapabilities", reflect.TypeOf((*MockCloudInterface)(nil).GetCapabilities))
+}
+
 // GetEnforcedSecurity mocks base method.
 func (m *MockCloudInterface) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
 	m.ctrl.T.Helper()
@@ -167,6 +181,20 @@ func (mr *MockCloudInterfaceMockRecorder) GetEnforcedSecurity() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnforcedSecurity", reflect.TypeOf((*MockCloudInterface)(nil).GetEnforcedSecurity))
 }
 
+// GetSecurityGroupLimits mocks base method.
+func (m *MockCloudInterface) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityGroupLimits")
+	ret0, _ := ret[0].(securitygroup.SecurityGroupLimits)
+	return ret0
+}
+
+// GetSecurityGroupLimits indicates an expected call of GetSecurityGroupLimits.
+func (mr *MockCloudInterfaceMockRecorder) GetSecurityGroupLimits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupLimits", reflect.TypeOf((*MockCloudInterface)(nil).GetSecurityGroupLimits))
+}
+
 // GetVpcInventory mocks base method.
 func (m *MockCloudInterface) GetVpcInventory(accountNamespacedName *types.NamespacedName) (map[string]*v1alpha10.Vpc, error) {
 	m.ctrl.T.Helper()
@@ -500,6 +528,20 @@ func (mr *MockSecurityInterfaceMockRecorder) GetEnforcedSecurity() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnforcedSecurity", reflect.TypeOf((*MockSecurityInterface)(nil).GetEnforcedSecurity))
 }
 
+// GetSecurityGroupLimits mocks base method.
+func (m *MockSecurityInterface) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityGroupLimits")
+	ret0, _ := ret[0].(securitygroup.SecurityGroupLimits)
+	return ret0
+}
+
+// GetSecurityGroupLimits indicates an expected call of GetSecurityGroupLimits.
+func (mr *MockSecurityInterfaceMockRecorder) GetSecurityGroupLimits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupLimits", reflect.TypeOf((*MockSecurityInterface)(nil).GetSecurityGroupLimits))
+}
+
 // UpdateSecurityGroupMembers mocks base method.
 func (m *MockSecurityInterface) UpdateSecurityGroupMembers(securityGroupIdentifier *securitygroup.CloudResource, computeResourceIdentifier []*securitygroup.CloudResource, membershipOnly bool) error {
 	m.ctrl.T.Helper()
@@ -39,6 +39,9 @@ type InstanceID string
 type CloudInterface interface {
 	// ProviderType returns the cloud provider type (aws, azure, gce etc).
 	ProviderType() (providerType ProviderType)
+	// GetCapabilities returns the network policy features the cloud provider can realize, so that unsupported
+	// network policies are rejected before being realized.
+	GetCapabilities() crdv1alpha1.CloudCapabilities
 
 	AccountMgmtInterface
 
@@ -95,4 +98,8 @@ type SecurityInterface interface {
 	DeleteSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) error
 	// GetEnforcedSecurity returns the cloud view of enforced security.
 	GetEnforcedSecurity() []securitygroup.SynchronizationContent
+	// GetSecurityGroupLimits returns the limits of the cloud on security groups. Rules of an appliedTo group
+	// exceeding the limits are compacted, and sharded across security groups if the cloud allows multiple security
+	// groups per network interface, in which case CloudRule.Shard selects the security group realizing a rule.
+	GetSecurityGroupLimits() securitygroup.SecurityGroupLimits
 }
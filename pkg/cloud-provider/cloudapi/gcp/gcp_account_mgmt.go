@@ -0,0 +1,97 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+type gcpAccountConfig struct {
+	crdv1alpha1.GcpAccountCredential
+	region string
+}
+
+// setAccountCredentials sets account credentials.
+func setAccountCredentials(client client.Client, _ *types.NamespacedName, credentials interface{}) (interface{}, error) {
+	gcpProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountGCPConfig)
+	accCred, err := extractSecret(client, gcpProviderConfig.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	gcpConfig := &gcpAccountConfig{
+		GcpAccountCredential: *accCred,
+		region:               strings.TrimSpace(gcpProviderConfig.Region),
+	}
+	gcpConfig.ProjectID = strings.TrimSpace(gcpConfig.ProjectID)
+
+	return gcpConfig, nil
+}
+
+func compareAccountCredentials(accountName string, existing interface{}, new interface{}) bool {
+	existingConfig := existing.(*gcpAccountConfig)
+	newConfig := new.(*gcpAccountConfig)
+
+	credsChanged := false
+	if strings.Compare(existingConfig.ProjectID, newConfig.ProjectID) != 0 {
+		credsChanged = true
+		gcpPluginLogger().Info("account project id updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.ServiceAccountKey, newConfig.ServiceAccountKey) != 0 {
+		credsChanged = true
+		gcpPluginLogger().Info("account service account key updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.region, newConfig.region) != 0 {
+		credsChanged = true
+		gcpPluginLogger().Info("account region updated", "account", accountName)
+	}
+	return credsChanged
+}
+
+// extractSecret extracts credentials from a Kubernetes secret.
+func extractSecret(c client.Client, s *crdv1alpha1.SecretReference) (*crdv1alpha1.GcpAccountCredential, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
+		return nil, err
+	}
+
+	data := u.Object["data"].(map[string]interface{})
+	decode, err := base64.StdEncoding.DecodeString(data[s.Key].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &crdv1alpha1.GcpAccountCredential{}
+	if err = json.Unmarshal(decode, cred); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
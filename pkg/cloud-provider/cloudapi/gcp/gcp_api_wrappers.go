@@ -0,0 +1,139 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// gcpComputeWrapper is layer above gcp compute sdk apis to allow for unit-testing.
+type gcpComputeWrapper interface {
+	// instances
+	pagedListInstancesWrapper(zonePrefix string) ([]*compute.Instance, error)
+	setInstanceTags(zone string, instance string, tags *compute.Tags) error
+
+	// networks
+	pagedListNetworksWrapper() ([]*compute.Network, error)
+
+	// firewall rules
+	pagedListFirewallsWrapper() ([]*compute.Firewall, error)
+	insertFirewall(firewall *compute.Firewall) error
+	deleteFirewall(name string) error
+}
+
+type gcpComputeWrapperImpl struct {
+	ctx       context.Context
+	projectID string
+	compute   *compute.Service
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) pagedListInstancesWrapper(zonePrefix string) ([]*compute.Instance, error) {
+	var instances []*compute.Instance
+	call := computeWrapper.compute.Instances.AggregatedList(computeWrapper.projectID)
+	err := call.Pages(computeWrapper.ctx, func(page *compute.InstanceAggregatedList) error {
+		for scope, scopedList := range page.Items {
+			// scope is of the format zones/<zone-name>.
+			if !strings.HasPrefix(strings.TrimPrefix(scope, "zones/"), zonePrefix) {
+				continue
+			}
+			instances = append(instances, scopedList.Instances...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing compute instances: %q", err)
+	}
+	return instances, nil
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) setInstanceTags(zone string, instance string, tags *compute.Tags) error {
+	op, err := computeWrapper.compute.Instances.SetTags(computeWrapper.projectID, zone, instance, tags).
+		Context(computeWrapper.ctx).Do()
+	if err != nil {
+		return err
+	}
+	op, err = computeWrapper.compute.ZoneOperations.Wait(computeWrapper.projectID, zone, op.Name).
+		Context(computeWrapper.ctx).Do()
+	if err != nil {
+		return err
+	}
+	return operationError(op)
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) pagedListNetworksWrapper() ([]*compute.Network, error) {
+	var networks []*compute.Network
+	call := computeWrapper.compute.Networks.List(computeWrapper.projectID)
+	err := call.Pages(computeWrapper.ctx, func(page *compute.NetworkList) error {
+		networks = append(networks, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing compute networks: %q", err)
+	}
+	return networks, nil
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) pagedListFirewallsWrapper() ([]*compute.Firewall, error) {
+	var firewalls []*compute.Firewall
+	call := computeWrapper.compute.Firewalls.List(computeWrapper.projectID)
+	err := call.Pages(computeWrapper.ctx, func(page *compute.FirewallList) error {
+		firewalls = append(firewalls, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing compute firewalls: %q", err)
+	}
+	return firewalls, nil
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) insertFirewall(firewall *compute.Firewall) error {
+	op, err := computeWrapper.compute.Firewalls.Insert(computeWrapper.projectID, firewall).Context(computeWrapper.ctx).Do()
+	if err != nil {
+		return err
+	}
+	return computeWrapper.waitForGlobalOperation(op)
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) deleteFirewall(name string) error {
+	op, err := computeWrapper.compute.Firewalls.Delete(computeWrapper.projectID, name).Context(computeWrapper.ctx).Do()
+	if err != nil {
+		return err
+	}
+	return computeWrapper.waitForGlobalOperation(op)
+}
+
+func (computeWrapper *gcpComputeWrapperImpl) waitForGlobalOperation(op *compute.Operation) error {
+	op, err := computeWrapper.compute.GlobalOperations.Wait(computeWrapper.projectID, op.Name).Context(computeWrapper.ctx).Do()
+	if err != nil {
+		return err
+	}
+	return operationError(op)
+}
+
+// operationError converts errors reported in a completed compute operation to error.
+func operationError(op *compute.Operation) error {
+	if op == nil || op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(op.Error.Errors))
+	for _, e := range op.Error.Errors {
+		msgs = append(msgs, fmt.Sprintf("%v: %v", e.Code, e.Message))
+	}
+	return fmt.Errorf("compute operation %v failed: %v", op.Name, strings.Join(msgs, ", "))
+}
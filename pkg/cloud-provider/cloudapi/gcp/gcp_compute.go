@@ -0,0 +1,344 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+type computeServiceConfig struct {
+	accountNamespacedName types.NamespacedName
+	apiClient             gcpComputeWrapper
+	resourcesCache        *internal.CloudServiceResourcesCache
+	inventoryStats        *internal.CloudServiceStats
+	// instanceFilters has following possible values
+	// - empty map indicates no selectors configured for this account. NO cloud api call for inventory will be made.
+	// - non-empty map indicates selectors are configured. Cloud api call for inventory will be made.
+	// - key with nil value indicates no filters. Get all instances for account.
+	// - key with non-nil value indicates some filter. Get instances matching those filters only.
+	instanceFilters map[string][]*gcpInstanceFilter
+	credentials     *gcpAccountConfig
+}
+
+// computeResourcesCacheSnapshot holds the results from querying for all instances.
+type computeResourcesCacheSnapshot struct {
+	instances      map[cloudcommon.InstanceID]*compute.Instance
+	networks       []*compute.Network
+	networkIDs     map[string]struct{}
+	networkURLToID map[string]string
+}
+
+func newComputeServiceConfig(accountNamespacedName types.NamespacedName, service gcpServiceClientCreateInterface,
+	credentials *gcpAccountConfig) (internal.CloudServiceInterface, error) {
+	// create compute sdk api client
+	apiClient, err := service.compute()
+	if err != nil {
+		return nil, fmt.Errorf("error creating compute sdk api client for account : %v, err: %v", accountNamespacedName.String(), err)
+	}
+
+	config := &computeServiceConfig{
+		apiClient:             apiClient,
+		accountNamespacedName: accountNamespacedName,
+		resourcesCache:        &internal.CloudServiceResourcesCache{},
+		inventoryStats:        &internal.CloudServiceStats{},
+		instanceFilters:       make(map[string][]*gcpInstanceFilter),
+		credentials:           credentials,
+	}
+	return config, nil
+}
+
+// compute returns GCP Compute Engine SDK apiClient.
+func (p *gcpServiceSdkConfigProvider) compute() (gcpComputeWrapper, error) {
+	computeService, err := compute.NewService(p.ctx, p.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	gcpCompute := &gcpComputeWrapperImpl{
+		ctx:       p.ctx,
+		projectID: p.projectID,
+		compute:   computeService,
+	}
+	return gcpCompute, nil
+}
+
+func (computeCfg *computeServiceConfig) waitForInventoryInit(duration time.Duration) error {
+	operation := func() error {
+		done := computeCfg.inventoryStats.IsInventoryInitialized()
+		if !done {
+			return fmt.Errorf("inventory for account %v not initialized (waited %v duration)", computeCfg.accountNamespacedName, duration)
+		}
+		return nil
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = duration
+
+	return backoff.Retry(operation, b)
+}
+
+// getInstanceResourceFilters returns filters to be applied to listed instances if filters are configured.
+// Otherwise, returns (nil, false). false indicates no selectors configured for the account and hence no cloud api needs
+// to be made for instance inventory.
+func (computeCfg *computeServiceConfig) getInstanceResourceFilters() ([]*gcpInstanceFilter, bool) {
+	var allFilters []*gcpInstanceFilter
+
+	if len(computeCfg.instanceFilters) == 0 {
+		return nil, false
+	}
+
+	for _, filters := range computeCfg.instanceFilters {
+		// if any selector found with nil filter, skip all other selectors. As nil indicates all
+		if len(filters) == 0 {
+			return nil, true
+		}
+		allFilters = append(allFilters, filters...)
+	}
+	return allFilters, true
+}
+
+// getCachedInstances returns instances from the cache for the account.
+func (computeCfg *computeServiceConfig) getCachedInstances() []*compute.Instance {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		gcpPluginLogger().V(4).Info("cache snapshot nil", "service", gcpComputeServiceNameCompute, "account", computeCfg.accountNamespacedName)
+		return []*compute.Instance{}
+	}
+	instances := snapshot.(*computeResourcesCacheSnapshot).instances
+	instancesToReturn := make([]*compute.Instance, 0, len(instances))
+	for _, instance := range instances {
+		instancesToReturn = append(instancesToReturn, instance)
+	}
+	gcpPluginLogger().V(1).Info("cached vm instances", "service", gcpComputeServiceNameCompute, "account", computeCfg.accountNamespacedName,
+		"instances", len(instancesToReturn))
+	return instancesToReturn
+}
+
+// getManagedNetworkIDs returns IDs of networks containing managed vms.
+func (computeCfg *computeServiceConfig) getManagedNetworkIDs() map[string]struct{} {
+	networkIDsCopy := make(map[string]struct{})
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		gcpPluginLogger().V(4).Info("cache snapshot nil", "service", gcpComputeServiceNameCompute, "account", computeCfg.accountNamespacedName)
+		return networkIDsCopy
+	}
+	for networkID := range snapshot.(*computeResourcesCacheSnapshot).networkIDs {
+		networkIDsCopy[networkID] = struct{}{}
+	}
+	return networkIDsCopy
+}
+
+// getCachedNetworks returns networks from cached snapshot for the account.
+func (computeCfg *computeServiceConfig) getCachedNetworks() []*compute.Network {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		gcpPluginLogger().V(4).Info("cache snapshot nil", "service", gcpComputeServiceNameCompute, "account", computeCfg.accountNamespacedName)
+		return []*compute.Network{}
+	}
+	networks := snapshot.(*computeResourcesCacheSnapshot).networks
+	networksToReturn := make([]*compute.Network, 0, len(networks))
+	networksToReturn = append(networksToReturn, networks...)
+	return networksToReturn
+}
+
+// getCachedNetworkURLToID returns the map networkURLToID from the cache.
+func (computeCfg *computeServiceConfig) getCachedNetworkURLToID() map[string]string {
+	networkURLToIDCopy := make(map[string]string)
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		gcpPluginLogger().V(4).Info("cache snapshot nil", "service", gcpComputeServiceNameCompute, "account", computeCfg.accountNamespacedName)
+		return networkURLToIDCopy
+	}
+	for k, v := range snapshot.(*computeResourcesCacheSnapshot).networkURLToID {
+		networkURLToIDCopy[k] = v
+	}
+	return networkURLToIDCopy
+}
+
+// getNetworkURL returns the self link of the network with given ID from the cache.
+func (computeCfg *computeServiceConfig) getNetworkURL(networkID string) (string, bool) {
+	for url, id := range computeCfg.getCachedNetworkURLToID() {
+		if id == networkID {
+			return url, true
+		}
+	}
+	return "", false
+}
+
+// getInstances gets instances in the account region from gcp compute API, applying the configured filters.
+func (computeCfg *computeServiceConfig) getInstances(networks map[string]*compute.Network) ([]*compute.Instance, error) {
+	filters, hasFilters := computeCfg.getInstanceResourceFilters()
+	if !hasFilters {
+		gcpPluginLogger().V(1).Info("fetching vm resources from cloud skipped",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "not-configured")
+		return nil, nil
+	}
+
+	allInstances, err := computeCfg.apiClient.pagedListInstancesWrapper(computeCfg.credentials.region + "-")
+	if err != nil {
+		return nil, err
+	}
+	if filters == nil {
+		gcpPluginLogger().V(1).Info("fetching vm resources from cloud",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "all(nil)")
+		return allInstances, nil
+	}
+
+	gcpPluginLogger().V(1).Info("fetching vm resources from cloud",
+		"account", computeCfg.accountNamespacedName, "resource-filters", "configured")
+	var instances []*compute.Instance
+	for _, instance := range allInstances {
+		var network *compute.Network
+		if len(instance.NetworkInterfaces) > 0 {
+			network = networks[instance.NetworkInterfaces[0].Network]
+		}
+		for _, filter := range filters {
+			if filter.matches(instance, network) {
+				instances = append(instances, instance)
+				break
+			}
+		}
+	}
+
+	gcpPluginLogger().V(1).Info("vm instances from cloud", "service", gcpComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(instances))
+
+	return instances, nil
+}
+
+// DoResourceInventory gets inventory from cloud for given cloud account.
+func (computeCfg *computeServiceConfig) DoResourceInventory() error {
+	networks, err := computeCfg.apiClient.pagedListNetworksWrapper()
+	if err != nil {
+		gcpPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+	networkURLToID := make(map[string]string)
+	networksByURL := make(map[string]*compute.Network)
+	for _, network := range networks {
+		networkURLToID[network.SelfLink] = strconv.FormatUint(network.Id, 10)
+		networksByURL[network.SelfLink] = network
+	}
+
+	instances, err := computeCfg.getInstances(networksByURL)
+	if err != nil {
+		gcpPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+
+	exists := struct{}{}
+	networkIDs := make(map[string]struct{})
+	instanceIDs := make(map[cloudcommon.InstanceID]*compute.Instance)
+	for _, instance := range instances {
+		id := cloudcommon.InstanceID(strconv.FormatUint(instance.Id, 10))
+		instanceIDs[id] = instance
+		for _, nwInf := range instance.NetworkInterfaces {
+			if networkID, ok := networkURLToID[nwInf.Network]; ok {
+				networkIDs[networkID] = exists
+			}
+		}
+	}
+	computeCfg.resourcesCache.UpdateSnapshot(&computeResourcesCacheSnapshot{instanceIDs, networks, networkIDs, networkURLToID})
+	return nil
+}
+
+// SetResourceFilters add/updates instances resource filter for the service.
+func (computeCfg *computeServiceConfig) SetResourceFilters(selector *crdv1alpha1.CloudEntitySelector) {
+	if filters, found := convertSelectorToComputeInstanceFilters(selector); found {
+		computeCfg.instanceFilters[selector.GetName()] = filters
+	} else {
+		if selector != nil {
+			delete(computeCfg.instanceFilters, selector.GetName())
+		}
+		computeCfg.resourcesCache.UpdateSnapshot(nil)
+	}
+}
+
+func (computeCfg *computeServiceConfig) RemoveResourceFilters(selectorName string) {
+	delete(computeCfg.instanceFilters, selectorName)
+}
+
+func (computeCfg *computeServiceConfig) GetInternalResourceObjects(namespace string,
+	account *types.NamespacedName) map[string]*runtimev1alpha1.VirtualMachine {
+	instances := computeCfg.getCachedInstances()
+	networkURLToID := computeCfg.getCachedNetworkURLToID()
+	vmObjects := map[string]*runtimev1alpha1.VirtualMachine{}
+	for _, instance := range instances {
+		// build runtimev1alpha1 VirtualMachine object.
+		vmObject := computeInstanceToInternalVirtualMachineObject(instance, networkURLToID, namespace, account,
+			computeCfg.credentials.region)
+		vmObjects[vmObject.Name] = vmObject
+	}
+
+	gcpPluginLogger().V(1).Info("Internal resource objects", "Service", gcpComputeServiceNameCompute,
+		"Account", computeCfg.accountNamespacedName, "VirtualMachine objects", len(vmObjects))
+
+	return vmObjects
+}
+
+func (computeCfg *computeServiceConfig) GetName() internal.CloudServiceName {
+	return gcpComputeServiceNameCompute
+}
+
+func (computeCfg *computeServiceConfig) GetType() internal.CloudServiceType {
+	return internal.CloudServiceTypeCompute
+}
+
+func (computeCfg *computeServiceConfig) GetInventoryStats() *internal.CloudServiceStats {
+	return computeCfg.inventoryStats
+}
+
+func (computeCfg *computeServiceConfig) ResetCachedState() {
+	computeCfg.SetResourceFilters(nil)
+	computeCfg.inventoryStats.ResetInventoryPollStats()
+}
+
+func (computeCfg *computeServiceConfig) UpdateServiceConfig(newConfig internal.CloudServiceInterface) {
+	newComputeServiceConfig := newConfig.(*computeServiceConfig)
+	computeCfg.apiClient = newComputeServiceConfig.apiClient
+	computeCfg.credentials = newComputeServiceConfig.credentials
+}
+
+// GetVpcInventory generates vpc object for the networks stored in snapshot(in cloud format) and return a map of vpc runtime objects.
+func (computeCfg *computeServiceConfig) GetVpcInventory() map[string]*runtimev1alpha1.Vpc {
+	networks := computeCfg.getCachedNetworks()
+	networkIDs := computeCfg.getManagedNetworkIDs()
+	// Convert to kubernetes object and return a map indexed using network ID.
+	vpcMap := map[string]*runtimev1alpha1.Vpc{}
+	for _, network := range networks {
+		networkID := strconv.FormatUint(network.Id, 10)
+		_, managed := networkIDs[networkID]
+		vpcObj := computeNetworkToInternalVpcObject(network, computeCfg.accountNamespacedName.Namespace,
+			computeCfg.accountNamespacedName.Name, strings.ToLower(computeCfg.credentials.region), managed)
+		vpcMap[networkID] = vpcObj
+	}
+
+	gcpPluginLogger().V(1).Info("cached vpcs", "service", gcpComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "vpc objects", len(vpcMap))
+
+	return vpcMap
+}
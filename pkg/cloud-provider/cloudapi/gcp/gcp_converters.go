@@ -0,0 +1,179 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// convertToFirewallAllowed converts protocol and port range of a rule to compute firewall allowed entry.
+func convertToFirewallAllowed(protocol *int, port *int, endPort *int) []*compute.FirewallAllowed {
+	if protocol == nil {
+		return []*compute.FirewallAllowed{{IPProtocol: gcpAnyProtocolValue}}
+	}
+	allowed := &compute.FirewallAllowed{IPProtocol: strconv.Itoa(*protocol)}
+	if port != nil && isPortProtocol(*protocol) {
+		portStr := strconv.Itoa(*port)
+		if endPort != nil {
+			portStr = fmt.Sprintf("%d-%d", *port, *endPort)
+		}
+		allowed.Ports = []string{portStr}
+	}
+	return []*compute.FirewallAllowed{allowed}
+}
+
+// convertToFirewallRanges converts ip blocks of a rule to compute firewall ranges.
+func convertToFirewallRanges(ips []*net.IPNet, ruleHasGroups bool) []string {
+	if len(ips) == 0 && !ruleHasGroups {
+		return []string{"0.0.0.0/0"}
+	}
+	ranges := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		ranges = append(ranges, ip.String())
+	}
+	return ranges
+}
+
+// convertToFirewallSourceTags converts address groups of a rule to compute firewall source tags.
+func convertToFirewallSourceTags(addressGroupIdentifiers []*securitygroup.CloudResourceID) []string {
+	tags := make([]string, 0, len(addressGroupIdentifiers))
+	for _, addressGroupIdentifier := range addressGroupIdentifiers {
+		tags = append(tags, addressGroupIdentifier.GetCloudName(true))
+	}
+	return tags
+}
+
+// convertFromFirewallAllowed converts compute firewall allowed entry to rule protocol, port and end port.
+func convertFromFirewallAllowed(allowed *compute.FirewallAllowed) (*int, *int, *int) {
+	if allowed == nil || allowed.IPProtocol == gcpAnyProtocolValue {
+		return nil, nil, nil
+	}
+	protocolNum, err := strconv.Atoi(allowed.IPProtocol)
+	if err != nil {
+		value, found := securitygroup.ProtocolNameNumMap[allowed.IPProtocol]
+		if !found {
+			return nil, nil, nil
+		}
+		protocolNum = value
+	}
+	if len(allowed.Ports) == 0 {
+		return &protocolNum, nil, nil
+	}
+	ports := strings.Split(allowed.Ports[0], "-")
+	portNum, err := strconv.Atoi(ports[0])
+	if err != nil {
+		return &protocolNum, nil, nil
+	}
+	if len(ports) != 2 {
+		return &protocolNum, &portNum, nil
+	}
+	endPortNum, err := strconv.Atoi(ports[1])
+	if err != nil || endPortNum == portNum {
+		return &protocolNum, &portNum, nil
+	}
+	return &protocolNum, &portNum, &endPortNum
+}
+
+// convertFromFirewallRanges converts compute firewall ranges to ip blocks.
+func convertFromFirewallRanges(ranges []string) []*net.IPNet {
+	var ipNets []*net.IPNet
+	for _, ipRange := range ranges {
+		_, ipNet, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			continue
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets
+}
+
+// convertFromFirewallSourceTags converts compute firewall source tags to address groups.
+func convertFromFirewallSourceTags(tags []string, networkID string) []*securitygroup.CloudResourceID {
+	var cloudResourceIDs []*securitygroup.CloudResourceID
+	for _, tag := range tags {
+		sgName, isAG, _ := securitygroup.IsNepheControllerCreatedSG(tag)
+		if !isAG {
+			continue
+		}
+		cloudResourceIDs = append(cloudResourceIDs, &securitygroup.CloudResourceID{
+			Name: sgName,
+			Vpc:  networkID,
+		})
+	}
+	return cloudResourceIDs
+}
+
+// convertFromFirewallToIngressRule converts compute ingress firewall rule to internal securitygroup.IngressRule.
+// Each firewall rule is split into one ingress rule per source range and per source address group.
+func convertFromFirewallToIngressRule(firewall *compute.Firewall, networkID string) []securitygroup.IngressRule {
+	var ingressRules []securitygroup.IngressRule
+	// Get cloud rule description.
+	if _, ok := securitygroup.ExtractCloudDescription(&firewall.Description); !ok {
+		// Ignore rules that don't have a valid description field.
+		gcpPluginLogger().V(4).Info("Failed to extract cloud rule description", "desc", firewall.Description)
+		return ingressRules
+	}
+	for _, allowed := range firewall.Allowed {
+		protocol, port, endPort := convertFromFirewallAllowed(allowed)
+		for _, srcIP := range convertFromFirewallRanges(firewall.SourceRanges) {
+			ingressRules = append(ingressRules, securitygroup.IngressRule{
+				FromSrcIP:   []*net.IPNet{srcIP},
+				Protocol:    protocol,
+				FromPort:    port,
+				FromEndPort: endPort,
+			})
+		}
+		for _, group := range convertFromFirewallSourceTags(firewall.SourceTags, networkID) {
+			ingressRules = append(ingressRules, securitygroup.IngressRule{
+				FromSecurityGroups: []*securitygroup.CloudResourceID{group},
+				Protocol:           protocol,
+				FromPort:           port,
+				FromEndPort:        endPort,
+			})
+		}
+	}
+	return ingressRules
+}
+
+// convertFromFirewallToEgressRule converts compute egress firewall rule to internal securitygroup.EgressRule.
+// Each firewall rule is split into one egress rule per destination range.
+func convertFromFirewallToEgressRule(firewall *compute.Firewall) []securitygroup.EgressRule {
+	var egressRules []securitygroup.EgressRule
+	// Get cloud rule description.
+	if _, ok := securitygroup.ExtractCloudDescription(&firewall.Description); !ok {
+		// Ignore rules that don't have a valid description field.
+		gcpPluginLogger().V(4).Info("Failed to extract cloud rule description", "desc", firewall.Description)
+		return egressRules
+	}
+	for _, allowed := range firewall.Allowed {
+		protocol, port, endPort := convertFromFirewallAllowed(allowed)
+		for _, dstIP := range convertFromFirewallRanges(firewall.DestinationRanges) {
+			egressRules = append(egressRules, securitygroup.EgressRule{
+				ToDstIP:   []*net.IPNet{dstIP},
+				Protocol:  protocol,
+				ToPort:    port,
+				ToEndPort: endPort,
+			})
+		}
+	}
+	return egressRules
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
+)
+
+var gcpStateMap = map[string]runtimev1alpha1.VMState{
+	"PROVISIONING": runtimev1alpha1.Starting,
+	"STAGING":      runtimev1alpha1.Starting,
+	"RUNNING":      runtimev1alpha1.Running,
+	"STOPPING":     runtimev1alpha1.Stopping,
+	"SUSPENDING":   runtimev1alpha1.Stopping,
+	"SUSPENDED":    runtimev1alpha1.Stopped,
+	"STOPPED":      runtimev1alpha1.Stopped,
+	"TERMINATED":   runtimev1alpha1.Stopped,
+	"REPAIRING":    runtimev1alpha1.Unknown,
+}
+
+// computeInstanceToInternalVirtualMachineObject converts compute instance to VirtualMachine runtime object.
+func computeInstanceToInternalVirtualMachineObject(instance *compute.Instance, networkURLToID map[string]string,
+	namespace string, account *types.NamespacedName, region string) *runtimev1alpha1.VirtualMachine {
+	tags := make(map[string]string)
+	for key, value := range instance.Labels {
+		tags[key] = value
+	}
+
+	// Network interfaces associated with Virtual machine
+	var cloudNetworkID string
+	networkInterfaces := make([]runtimev1alpha1.NetworkInterface, 0, len(instance.NetworkInterfaces))
+	for _, nwInf := range instance.NetworkInterfaces {
+		if len(cloudNetworkID) == 0 {
+			cloudNetworkID = networkURLToID[nwInf.Network]
+		}
+		var ipAddressObjs []runtimev1alpha1.IPAddress
+		if len(nwInf.NetworkIP) > 0 {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeInternalIP,
+				Address:     nwInf.NetworkIP,
+			})
+		}
+		for _, accessConfig := range nwInf.AccessConfigs {
+			if len(accessConfig.NatIP) == 0 {
+				continue
+			}
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeExternalIP,
+				Address:     accessConfig.NatIP,
+			})
+		}
+		networkInterface := runtimev1alpha1.NetworkInterface{
+			Name: nwInf.Name,
+			IPs:  ipAddressObjs,
+		}
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+
+	cloudID := strconv.FormatUint(instance.Id, 10)
+	cloudName := strings.ToLower(instance.Name)
+
+	state, ok := gcpStateMap[instance.Status]
+	if !ok {
+		state = runtimev1alpha1.Unknown
+	}
+	return utils.GenerateInternalVirtualMachineObject(cloudID, cloudName, cloudID, strings.ToLower(region),
+		namespace, cloudNetworkID, cloudNetworkID, state, tags, networkInterfaces, providerType, account)
+}
+
+// computeNetworkToInternalVpcObject converts compute network object to vpc runtime object.
+func computeNetworkToInternalVpcObject(network *compute.Network, accountNamespace, accountName,
+	region string, managed bool) *runtimev1alpha1.Vpc {
+	cloudID := strconv.FormatUint(network.Id, 10)
+	cidrs := make([]string, 0)
+	// Only legacy networks carry a network wide range, subnet mode networks have per region subnet ranges.
+	if len(network.IPv4Range) > 0 {
+		cidrs = append(cidrs, network.IPv4Range)
+	}
+
+	return utils.GenerateInternalVpcObject(cloudID, accountNamespace, accountName, strings.ToLower(network.Name),
+		cloudID, map[string]string{}, runtimev1alpha1.GCPCloudProvider, region, cidrs, managed)
+}
+
+// instanceHasNetworkTag returns true if the instance carries the network tag.
+func instanceHasNetworkTag(instance *compute.Instance, tag string) bool {
+	if instance.Tags == nil {
+		return false
+	}
+	for _, item := range instance.Tags.Items {
+		if item == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// nepheNetworkTags returns the nephe created network tags of the instance.
+func nepheNetworkTags(instance *compute.Instance) []string {
+	var tags []string
+	if instance.Tags == nil {
+		return tags
+	}
+	for _, item := range instance.Tags.Items {
+		_, isAG, isAT := securitygroup.IsNepheControllerCreatedSG(item)
+		if isAG || isAT {
+			tags = append(tags, item)
+		}
+	}
+	return tags
+}
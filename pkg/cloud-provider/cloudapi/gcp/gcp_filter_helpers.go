@@ -0,0 +1,94 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// gcpInstanceFilter is a client side filter for compute instances. Compute Engine filter expressions cannot express
+// the network of an instance, hence instances are listed per region and filtered by nephe.
+// Empty fields of the filter match any value.
+type gcpInstanceFilter struct {
+	networkID   string
+	networkName string
+	instanceID  string
+	name        string
+}
+
+// convertSelectorToComputeInstanceFilters converts vm selector to gcp instance filters.
+func convertSelectorToComputeInstanceFilters(selector *crdv1alpha1.CloudEntitySelector) ([]*gcpInstanceFilter, bool) {
+	if selector == nil {
+		return nil, false
+	}
+	if selector.Spec.VMSelector == nil {
+		return nil, true
+	}
+
+	return buildComputeInstanceFilters(selector.Spec.VMSelector), true
+}
+
+// buildComputeInstanceFilters builds instance filters for VirtualMachineSelector. Each vmMatch section of a
+// vmSelector generates a filter combined with the vpcMatch of the vmSelector.
+func buildComputeInstanceFilters(vmSelector []crdv1alpha1.VirtualMachineSelector) []*gcpInstanceFilter {
+	var filters []*gcpInstanceFilter
+	for _, match := range vmSelector {
+		vpcFilter := gcpInstanceFilter{}
+		if match.VpcMatch != nil {
+			vpcFilter.networkID = strings.TrimSpace(match.VpcMatch.MatchID)
+			vpcFilter.networkName = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchName))
+		}
+		if len(match.VMMatch) == 0 {
+			filter := vpcFilter
+			filters = append(filters, &filter)
+			continue
+		}
+		for _, vmMatch := range match.VMMatch {
+			filter := vpcFilter
+			filter.instanceID = strings.TrimSpace(vmMatch.MatchID)
+			filter.name = strings.ToLower(strings.TrimSpace(vmMatch.MatchName))
+			filters = append(filters, &filter)
+		}
+	}
+	return filters
+}
+
+// matches returns true if the instance, attached to given network, matches the filter.
+func (f *gcpInstanceFilter) matches(instance *compute.Instance, network *compute.Network) bool {
+	if len(f.instanceID) != 0 && f.instanceID != strconv.FormatUint(instance.Id, 10) {
+		return false
+	}
+	if len(f.name) != 0 && f.name != strings.ToLower(instance.Name) {
+		return false
+	}
+	if len(f.networkID) == 0 && len(f.networkName) == 0 {
+		return true
+	}
+	if network == nil {
+		return false
+	}
+	if len(f.networkID) != 0 && f.networkID != strconv.FormatUint(network.Id, 10) {
+		return false
+	}
+	if len(f.networkName) != 0 && f.networkName != strings.ToLower(network.Name) {
+		return false
+	}
+	return true
+}
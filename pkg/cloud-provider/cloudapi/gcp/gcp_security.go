@@ -0,0 +1,529 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// Nephe security groups are realized in GCP as network tags. An instance is a member of a security group if it carries
+// the network tag of the security group. Rules of an AppliedTo security group are realized as VPC firewall rules
+// targeting the network tag.
+const (
+	gcpFirewallRulePriority     = 1000
+	gcpFirewallDenyRulePriority = 65534
+	gcpFirewallNameMaxLength    = 63
+	gcpFirewallDirectionIngress = "INGRESS"
+	gcpFirewallDirectionEgress  = "EGRESS"
+	gcpFirewallDenyIngressName  = "deny-in"
+	gcpFirewallDenyEgressName   = "deny-eg"
+)
+
+var (
+	mutex sync.Mutex
+
+	gcpAnyProtocolValue = "all"
+)
+
+// isPortProtocol returns true if compute firewall accepts ports for the protocol.
+func isPortProtocol(protocol int) bool {
+	return protocol == 6 || protocol == 17 || protocol == 132
+}
+
+// shortHash returns first n hex characters of sha1 of the given values.
+func shortHash(n int, values ...string) string {
+	hash := sha1.New()
+	for _, value := range values {
+		hash.Write([]byte(value))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:n]
+}
+
+// getFirewallNamePrefix returns prefix of names of firewall rules realizing the rules of a network tag. Firewall rule
+// names are unique per project, hence the network is encoded in the prefix.
+func getFirewallNamePrefix(tag string, networkID string) string {
+	suffix := "-" + shortHash(6, networkID) + "-"
+	// 8 characters are reserved for the rule identifier.
+	if maxLen := gcpFirewallNameMaxLength - len(suffix) - 8; len(tag) > maxLen {
+		tag = strings.TrimRight(tag[:maxLen], "-")
+	}
+	return tag + suffix
+}
+
+// getFirewallName returns the name of the firewall rule realizing a cloud rule.
+func getFirewallName(prefix string, rule *securitygroup.CloudRule) string {
+	hash := rule.Hash
+	if len(hash) == 0 {
+		hash = rule.GetHash()
+	}
+	return prefix + hash[:8]
+}
+
+// getComputeService returns the compute service config of the account managing the cloud resource.
+func (c *gcpCloud) getComputeService(resource *securitygroup.CloudResource) (*computeServiceConfig, error) {
+	accCfg, found := c.cloudCommon.GetCloudAccountByAccountId(&resource.AccountID)
+	if !found {
+		return nil, fmt.Errorf("gcp account not found managing virtual private cloud [%v]", resource.Vpc)
+	}
+	serviceCfg, err := accCfg.GetServiceConfigByName(gcpComputeServiceNameCompute)
+	if err != nil {
+		return nil, err
+	}
+	return serviceCfg.(*computeServiceConfig), nil
+}
+
+// buildFirewall builds compute firewall rule for a cloud rule of the network tag.
+func buildFirewall(name, networkURL, tag string, rule *securitygroup.CloudRule) (*compute.Firewall, error) {
+	if rule.IsDeny() {
+		return nil, fmt.Errorf("deny rules are not supported by gcp firewall, rule %v", rule.Hash)
+	}
+	description, err := securitygroup.GenerateCloudDescription(rule.NetworkPolicy, rule.AppliedToGrp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate rule description, err: %v", err)
+	}
+	firewall := &compute.Firewall{
+		Name:        name,
+		Network:     networkURL,
+		Priority:    gcpFirewallRulePriority,
+		TargetTags:  []string{tag},
+		Description: description,
+	}
+	switch r := rule.Rule.(type) {
+	case *securitygroup.IngressRule:
+		if r.ICMPType != nil || r.ICMPCode != nil {
+			return nil, fmt.Errorf("icmp type and code are not supported by gcp firewall, rule %v", rule.Hash)
+		}
+		firewall.Direction = gcpFirewallDirectionIngress
+		firewall.Allowed = convertToFirewallAllowed(r.Protocol, r.FromPort, r.FromEndPort)
+		firewall.SourceTags = convertToFirewallSourceTags(r.FromSecurityGroups)
+		firewall.SourceRanges = convertToFirewallRanges(r.FromSrcIP, len(r.FromSecurityGroups) != 0)
+	case *securitygroup.EgressRule:
+		if len(r.ToSecurityGroups) != 0 {
+			return nil, fmt.Errorf("egress rules to security groups are not supported by gcp firewall, rule %v", rule.Hash)
+		}
+		if r.ICMPType != nil || r.ICMPCode != nil {
+			return nil, fmt.Errorf("icmp type and code are not supported by gcp firewall, rule %v", rule.Hash)
+		}
+		firewall.Direction = gcpFirewallDirectionEgress
+		firewall.Allowed = convertToFirewallAllowed(r.Protocol, r.ToPort, r.ToEndPort)
+		firewall.DestinationRanges = convertToFirewallRanges(r.ToDstIP, false)
+	}
+	return firewall, nil
+}
+
+// buildDenyAllFirewall builds compute firewall rule with lowest priority denying all traffic of the network tag in
+// the given direction. Together with allow rules this realizes the whitelist semantics of nephe security groups.
+func buildDenyAllFirewall(name, networkURL, tag, direction string) *compute.Firewall {
+	firewall := &compute.Firewall{
+		Name:       name,
+		Network:    networkURL,
+		Priority:   gcpFirewallDenyRulePriority,
+		TargetTags: []string{tag},
+		Direction:  direction,
+		Denied:     []*compute.FirewallDenied{{IPProtocol: gcpAnyProtocolValue}},
+	}
+	if direction == gcpFirewallDirectionIngress {
+		firewall.SourceRanges = []string{"0.0.0.0/0"}
+	} else {
+		firewall.DestinationRanges = []string{"0.0.0.0/0"}
+	}
+	return firewall
+}
+
+// getFirewallsWithPrefix returns firewall rules of the network with name prefix.
+func (computeCfg *computeServiceConfig) getFirewallsWithPrefix(networkURL string, prefix string) ([]*compute.Firewall, error) {
+	firewalls, err := computeCfg.apiClient.pagedListFirewallsWrapper()
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*compute.Firewall
+	for _, firewall := range firewalls {
+		if firewall.Network == networkURL && strings.HasPrefix(firewall.Name, prefix) {
+			filtered = append(filtered, firewall)
+		}
+	}
+	return filtered, nil
+}
+
+// getInstancesOfNetwork gets instances of the account region attached to the network from cloud.
+func (computeCfg *computeServiceConfig) getInstancesOfNetwork(networkURL string) ([]*compute.Instance, error) {
+	instances, err := computeCfg.apiClient.pagedListInstancesWrapper(computeCfg.credentials.region + "-")
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*compute.Instance
+	for _, instance := range instances {
+		for _, nwInf := range instance.NetworkInterfaces {
+			if nwInf.Network == networkURL {
+				filtered = append(filtered, instance)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// updateNetworkTagMembers adds the network tag to member instances and removes it from other instances of the network.
+func (computeCfg *computeServiceConfig) updateNetworkTagMembers(tag string, networkURL string,
+	members []*securitygroup.CloudResource) error {
+	instances, err := computeCfg.getInstancesOfNetwork(networkURL)
+	if err != nil {
+		return err
+	}
+	memberIDs, _ := securitygroup.FindResourcesBasedOnKind(members)
+
+	var retErr error
+	for _, instance := range instances {
+		_, isMember := memberIDs[strconv.FormatUint(instance.Id, 10)]
+		hasTag := instanceHasNetworkTag(instance, tag)
+		if isMember == hasTag {
+			continue
+		}
+		tags := &compute.Tags{}
+		if instance.Tags != nil {
+			tags.Fingerprint = instance.Tags.Fingerprint
+			for _, item := range instance.Tags.Items {
+				if item != tag {
+					tags.Items = append(tags.Items, item)
+				}
+			}
+		}
+		if isMember {
+			tags.Items = append(tags.Items, tag)
+		}
+		sort.Strings(tags.Items)
+		zone := instance.Zone[strings.LastIndex(instance.Zone, "/")+1:]
+		if err := computeCfg.apiClient.setInstanceTags(zone, instance.Name, tags); err != nil {
+			gcpPluginLogger().Error(err, "failed to update network tags", "instance", instance.Name, "tag", tag)
+			retErr = multierr.Append(retErr, err)
+		}
+	}
+	return retErr
+}
+
+// getNepheControllerManagedSecurityGroupsCloudView returns the network tags and firewall rules realized by nephe.
+func (computeCfg *computeServiceConfig) getNepheControllerManagedSecurityGroupsCloudView() []securitygroup.SynchronizationContent {
+	networkIDs := computeCfg.getManagedNetworkIDs()
+	if len(networkIDs) == 0 {
+		return []securitygroup.SynchronizationContent{}
+	}
+	networkURLToID := computeCfg.getCachedNetworkURLToID()
+
+	firewalls, err := computeCfg.apiClient.pagedListFirewallsWrapper()
+	if err != nil {
+		gcpPluginLogger().Error(err, "failed to get firewall rules", "account", computeCfg.accountNamespacedName)
+		return []securitygroup.SynchronizationContent{}
+	}
+
+	type tagKey struct {
+		tag       string
+		networkID string
+	}
+	syncObjs := make(map[tagKey]*securitygroup.SynchronizationContent)
+	getSyncObj := func(tag, networkID string) *securitygroup.SynchronizationContent {
+		key := tagKey{tag: tag, networkID: networkID}
+		if obj, ok := syncObjs[key]; ok {
+			return obj
+		}
+		sgName, isAG, _ := securitygroup.IsNepheControllerCreatedSG(tag)
+		obj := &securitygroup.SynchronizationContent{
+			Resource: securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: sgName,
+					Vpc:  networkID,
+				},
+				AccountID:     computeCfg.accountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.GCPCloudProvider),
+			},
+			MembershipOnly: isAG,
+		}
+		syncObjs[key] = obj
+		return obj
+	}
+
+	// find members of nephe network tags.
+	for _, instance := range computeCfg.getCachedInstances() {
+		for _, nwInf := range instance.NetworkInterfaces {
+			networkID, ok := networkURLToID[nwInf.Network]
+			if !ok {
+				continue
+			}
+			for _, tag := range nepheNetworkTags(instance) {
+				syncObj := getSyncObj(tag, networkID)
+				syncObj.Members = append(syncObj.Members, securitygroup.CloudResource{
+					Type: securitygroup.CloudResourceTypeVM,
+					CloudResourceID: securitygroup.CloudResourceID{
+						Name: strconv.FormatUint(instance.Id, 10),
+						Vpc:  networkID,
+					},
+					AccountID:     computeCfg.accountNamespacedName.String(),
+					CloudProvider: string(runtimev1alpha1.GCPCloudProvider),
+				})
+			}
+		}
+	}
+
+	// build rules from firewall rules targeting nephe AppliedTo network tags.
+	for _, firewall := range firewalls {
+		networkID, ok := networkURLToID[firewall.Network]
+		if !ok {
+			continue
+		}
+		if _, ok := networkIDs[networkID]; !ok {
+			continue
+		}
+		for _, tag := range firewall.TargetTags {
+			if _, _, isAT := securitygroup.IsNepheControllerCreatedSG(tag); !isAT {
+				continue
+			}
+			syncObj := getSyncObj(tag, networkID)
+			if firewall.Direction == gcpFirewallDirectionEgress {
+				syncObj.EgressRules = append(syncObj.EgressRules, convertFromFirewallToEgressRule(firewall)...)
+			} else {
+				syncObj.IngressRules = append(syncObj.IngressRules, convertFromFirewallToIngressRule(firewall, networkID)...)
+			}
+		}
+	}
+
+	enforcedSecurityCloudView := make([]securitygroup.SynchronizationContent, 0, len(syncObjs))
+	for _, syncObj := range syncObjs {
+		enforcedSecurityCloudView = append(enforcedSecurityCloudView, *syncObj)
+	}
+	return enforcedSecurityCloudView
+}
+
+// CreateSecurityGroup creates the firewall rules denying all traffic of an AppliedTo network tag. Network tags do not
+// need to be created in GCP, hence AddressGroup creation is a no-op. Returns the network tag name.
+func (c *gcpCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) (*string, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	tag := securityGroupIdentifier.GetCloudName(membershipOnly)
+	if membershipOnly {
+		return &tag, nil
+	}
+
+	networkURL, found := computeService.getNetworkURL(securityGroupIdentifier.Vpc)
+	if !found {
+		return nil, fmt.Errorf("gcp network [%v] not found", securityGroupIdentifier.Vpc)
+	}
+	prefix := getFirewallNamePrefix(tag, securityGroupIdentifier.Vpc)
+	existing, err := computeService.getFirewallsWithPrefix(networkURL, prefix)
+	if err != nil {
+		return nil, err
+	}
+	existingNames := make(map[string]struct{})
+	for _, firewall := range existing {
+		existingNames[firewall.Name] = struct{}{}
+	}
+	for name, direction := range map[string]string{
+		prefix + gcpFirewallDenyIngressName: gcpFirewallDirectionIngress,
+		prefix + gcpFirewallDenyEgressName:  gcpFirewallDirectionEgress,
+	} {
+		if _, ok := existingNames[name]; ok {
+			continue
+		}
+		if err := computeService.apiClient.insertFirewall(buildDenyAllFirewall(name, networkURL, tag, direction)); err != nil {
+			return nil, err
+		}
+	}
+	return &tag, nil
+}
+
+// UpdateSecurityGroupRules invokes cloud api and updates firewall rules of the network tag with addRules and rmRules.
+func (c *gcpCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygroup.CloudResource,
+	addRules, rmRules, _ []*securitygroup.CloudRule) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(appliedToGroupIdentifier)
+	if err != nil {
+		return err
+	}
+	networkURL, found := computeService.getNetworkURL(appliedToGroupIdentifier.Vpc)
+	if !found {
+		return fmt.Errorf("gcp network [%v] not found", appliedToGroupIdentifier.Vpc)
+	}
+
+	tag := appliedToGroupIdentifier.GetCloudName(false)
+	prefix := getFirewallNamePrefix(tag, appliedToGroupIdentifier.Vpc)
+	existing, err := computeService.getFirewallsWithPrefix(networkURL, prefix)
+	if err != nil {
+		return err
+	}
+	existingNames := make(map[string]struct{})
+	for _, firewall := range existing {
+		existingNames[firewall.Name] = struct{}{}
+	}
+
+	for _, rule := range rmRules {
+		name := getFirewallName(prefix, rule)
+		if _, ok := existingNames[name]; !ok {
+			continue
+		}
+		if err := computeService.apiClient.deleteFirewall(name); err != nil {
+			return err
+		}
+		delete(existingNames, name)
+	}
+	for _, rule := range addRules {
+		name := getFirewallName(prefix, rule)
+		if _, ok := existingNames[name]; ok {
+			continue
+		}
+		firewall, err := buildFirewall(name, networkURL, tag, rule)
+		if err != nil {
+			return err
+		}
+		if err := computeService.apiClient.insertFirewall(firewall); err != nil {
+			return err
+		}
+		existingNames[name] = struct{}{}
+	}
+	return nil
+}
+
+// UpdateSecurityGroupMembers invokes cloud api and attaches/detaches the network tag to/from instances.
+func (c *gcpCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securitygroup.CloudResource,
+	cloudResourceIdentifiers []*securitygroup.CloudResource, membershipOnly bool) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return err
+	}
+	networkURL, found := computeService.getNetworkURL(securityGroupIdentifier.Vpc)
+	if !found {
+		return fmt.Errorf("gcp network [%v] not found", securityGroupIdentifier.Vpc)
+	}
+
+	tag := securityGroupIdentifier.GetCloudName(membershipOnly)
+	return computeService.updateNetworkTagMembers(tag, networkURL, cloudResourceIdentifiers)
+}
+
+// DeleteSecurityGroup invokes cloud api and removes the network tag from all instances along with its firewall rules.
+func (c *gcpCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return err
+	}
+	networkURL, found := computeService.getNetworkURL(securityGroupIdentifier.Vpc)
+	if !found {
+		return nil
+	}
+
+	tag := securityGroupIdentifier.GetCloudName(membershipOnly)
+	if err := computeService.updateNetworkTagMembers(tag, networkURL, nil); err != nil {
+		return err
+	}
+	if membershipOnly {
+		return nil
+	}
+
+	firewalls, err := computeService.getFirewallsWithPrefix(networkURL, getFirewallNamePrefix(tag, securityGroupIdentifier.Vpc))
+	if err != nil {
+		return err
+	}
+	for _, firewall := range firewalls {
+		if err := computeService.apiClient.deleteFirewall(firewall.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *gcpCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	inventoryInitWaitDuration := 30 * time.Second
+
+	var accNamespacedNames []types.NamespacedName
+	accountConfigs := c.cloudCommon.GetCloudAccounts()
+	for _, accCfg := range accountConfigs {
+		accNamespacedNames = append(accNamespacedNames, *accCfg.GetNamespacedName())
+	}
+
+	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
+	var wg sync.WaitGroup
+	ch := make(chan []securitygroup.SynchronizationContent)
+	wg.Add(len(accNamespacedNames))
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	for _, accNamespacedName := range accNamespacedNames {
+		accNamespacedNameCopy := &types.NamespacedName{
+			Namespace: accNamespacedName.Namespace,
+			Name:      accNamespacedName.Name,
+		}
+
+		go func(name *types.NamespacedName, sendCh chan<- []securitygroup.SynchronizationContent) {
+			defer wg.Done()
+
+			accCfg, found := c.cloudCommon.GetCloudAccountByName(name)
+			if !found {
+				gcpPluginLogger().Info("enforced-security-cloud-view GET for account skipped (account no longer exists)", "account", name)
+				return
+			}
+
+			serviceCfg, err := accCfg.GetServiceConfigByName(gcpComputeServiceNameCompute)
+			if err != nil {
+				gcpPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
+				return
+			}
+			computeService := serviceCfg.(*computeServiceConfig)
+			err = computeService.waitForInventoryInit(inventoryInitWaitDuration)
+			if err != nil {
+				gcpPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
+				return
+			}
+			sendCh <- computeService.getNepheControllerManagedSecurityGroupsCloudView()
+		}(accNamespacedNameCopy, ch)
+	}
+
+	for val := range ch {
+		if val != nil {
+			enforcedSecurityCloudView = append(enforcedSecurityCloudView, val...)
+		}
+	}
+	return enforcedSecurityCloudView
+}
+
+// GetSecurityGroupLimits returns no limits, as each rule of an appliedTo group is a separate gcp firewall rule.
+func (c *gcpCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{}
+}
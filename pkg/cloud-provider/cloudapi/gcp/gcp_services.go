@@ -0,0 +1,85 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/option"
+	"k8s.io/apimachinery/pkg/types"
+
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+const (
+	gcpComputeServiceNameCompute = internal.CloudServiceName("ComputeEngine")
+)
+
+// gcpServiceClientCreateInterface provides interface to create gcp service clients.
+type gcpServiceClientCreateInterface interface {
+	compute() (gcpComputeWrapper, error)
+	// Add any gcp service (like cloud sql, gke etc) apiClient creation methods here
+}
+
+// gcpServiceSdkConfigProvider provides config required to create gcp service (compute) clients.
+// Implements gcpServiceClientCreateInterface interface.
+// NOTE: Currently supporting only service account key based clients.
+type gcpServiceSdkConfigProvider struct {
+	ctx       context.Context
+	projectID string
+	options   []option.ClientOption
+}
+
+// gcpServicesHelper.
+type gcpServicesHelper interface {
+	newServiceSdkConfigProvider(accCfg *gcpAccountConfig) (gcpServiceClientCreateInterface, error)
+}
+
+type gcpServicesHelperImpl struct{}
+
+// newServiceSdkConfigProvider returns config to create gcp services clients.
+func (h *gcpServicesHelperImpl) newServiceSdkConfigProvider(accConfig *gcpAccountConfig) (gcpServiceClientCreateInterface, error) {
+	if len(accConfig.ServiceAccountKey) == 0 {
+		return nil, fmt.Errorf("unable to initialize GCP client: service account key not configured")
+	}
+	configProvider := &gcpServiceSdkConfigProvider{
+		ctx:       context.Background(),
+		projectID: accConfig.ProjectID,
+		options:   []option.ClientOption{option.WithCredentialsJSON([]byte(accConfig.ServiceAccountKey))},
+	}
+	return configProvider, nil
+}
+
+func newGcpServiceConfigs(accountNamespacedName *types.NamespacedName, accCredentials interface{}, gcpSpecificHelper interface{}) (
+	[]internal.CloudServiceInterface, error) {
+	gcpServicesHelper := gcpSpecificHelper.(gcpServicesHelper)
+	gcpAccountCredentials := accCredentials.(*gcpAccountConfig)
+
+	var serviceConfigs []internal.CloudServiceInterface
+
+	gcpServiceClientCreator, err := gcpServicesHelper.newServiceSdkConfigProvider(gcpAccountCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	computeService, err := newComputeServiceConfig(*accountNamespacedName, gcpServiceClientCreator, gcpAccountCredentials)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfigs = append(serviceConfigs, computeService)
+
+	return serviceConfigs, nil
+}
@@ -0,0 +1,374 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+const (
+	testNetworkURL  = "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/net01"
+	testNetworkID   = uint64(1001)
+	testZoneURL     = "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a"
+	testCredentials = `{"projectId": "test-project", "serviceAccountKey": "{\"type\": \"service_account\"}"}`
+)
+
+var _ = Describe("GCP cloud", func() {
+	var (
+		testAccountNamespacedName = types.NamespacedName{Namespace: "namespace01", Name: "account01"}
+		credentials               = "credentials"
+
+		account            *v1alpha1.CloudProviderAccount
+		selector           *v1alpha1.CloudEntitySelector
+		secret             *corev1.Secret
+		fakeClient         client.WithWatch
+		mockCtrl           *gomock.Controller
+		mockgcpCloudHelper *MockgcpServicesHelper
+		mockgcpService     *MockgcpServiceClientCreateInterface
+		mockgcpCompute     *MockgcpComputeWrapper
+	)
+
+	BeforeEach(func() {
+		var pollIntv uint = 1
+		account = &v1alpha1.CloudProviderAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudProviderAccountSpec{
+				PollIntervalInSeconds: &pollIntv,
+				GCPConfig: &v1alpha1.CloudProviderAccountGCPConfig{
+					Region: "us-central1",
+					SecretRef: &v1alpha1.SecretReference{
+						Name:      testAccountNamespacedName.Name,
+						Namespace: testAccountNamespacedName.Namespace,
+						Key:       credentials,
+					},
+				},
+			},
+		}
+		selector = &v1alpha1.CloudEntitySelector{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "selector-all",
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudEntitySelectorSpec{
+				AccountName: testAccountNamespacedName.Name,
+				VMSelector:  []v1alpha1.VirtualMachineSelector{},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Data: map[string][]byte{
+				credentials: []byte(testCredentials),
+			},
+		}
+		fakeClient = fake.NewClientBuilder().Build()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockgcpCloudHelper = NewMockgcpServicesHelper(mockCtrl)
+		mockgcpService = NewMockgcpServiceClientCreateInterface(mockCtrl)
+		mockgcpCompute = NewMockgcpComputeWrapper(mockCtrl)
+
+		mockgcpCloudHelper.EXPECT().newServiceSdkConfigProvider(gomock.Any()).Return(mockgcpService, nil).AnyTimes()
+		mockgcpService.EXPECT().compute().Return(mockgcpCompute, nil).AnyTimes()
+		mockgcpCompute.EXPECT().pagedListNetworksWrapper().Return(getComputeNetworkObjects(), nil).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	addAccount := func() *gcpCloud {
+		_ = fakeClient.Create(context.Background(), secret)
+		c := newGCPCloud(mockgcpCloudHelper)
+		err := c.AddProviderAccount(fakeClient, account)
+		Expect(err).Should(BeNil())
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+		Expect(found).To(BeTrue())
+		Expect(accCfg).To(Not(BeNil()))
+		return c
+	}
+
+	Context("AddProviderAccount", func() {
+		It("On account add expect cloud api call for retrieving network list", func() {
+			mockgcpCompute.EXPECT().pagedListInstancesWrapper(gomock.Any()).Times(0)
+
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap).Should(HaveLen(1))
+			Expect(vpcMap).Should(HaveKey(strconv.FormatUint(testNetworkID, 10)))
+		})
+		It("Should discover few instances with get ALL selector", func() {
+			instanceIDs := []uint64{101, 102}
+			mockgcpCompute.EXPECT().pagedListInstancesWrapper("us-central1-").Return(getComputeInstanceObjects(instanceIDs), nil).AnyTimes()
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			err = checkAccountAddSuccessCondition(c, testAccountNamespacedName, []string{"101", "102"})
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(len(instanceIDs)))
+			for _, vm := range vms {
+				Expect(vm.Status.CloudVpcId).Should(Equal(strconv.FormatUint(testNetworkID, 10)))
+				Expect(vm.Status.Region).Should(Equal("us-central1"))
+			}
+		})
+		It("Should discover instances matching vm name selector", func() {
+			instanceIDs := []uint64{101, 102}
+			mockgcpCompute.EXPECT().pagedListInstancesWrapper("us-central1-").Return(getComputeInstanceObjects(instanceIDs), nil).AnyTimes()
+			selector.Spec.VMSelector = []v1alpha1.VirtualMachineSelector{
+				{
+					VMMatch: []v1alpha1.EntityMatch{{MatchName: "vm-102"}},
+				},
+			}
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			err = checkAccountAddSuccessCondition(c, testAccountNamespacedName, []string{"102"})
+			Expect(err).Should(BeNil())
+		})
+	})
+
+	Context("SecurityInterface", func() {
+		var (
+			c              *gcpCloud
+			atIdentifier   *securitygroup.CloudResource
+			agIdentifier   *securitygroup.CloudResource
+			networkIDValue = strconv.FormatUint(testNetworkID, 10)
+		)
+
+		BeforeEach(func() {
+			instances := getComputeInstanceObjects([]uint64{101, 102})
+			mockgcpCompute.EXPECT().pagedListInstancesWrapper(gomock.Any()).Return(instances, nil).AnyTimes()
+
+			c = addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			atIdentifier = &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "at-sg", Vpc: networkIDValue},
+				AccountID:       testAccountNamespacedName.String(),
+			}
+			agIdentifier = &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "ag-sg", Vpc: networkIDValue},
+				AccountID:       testAccountNamespacedName.String(),
+			}
+		})
+
+		It("Should create deny all firewall rules for AppliedTo group", func() {
+			mockgcpCompute.EXPECT().pagedListFirewallsWrapper().Return([]*compute.Firewall{}, nil).Times(1)
+			mockgcpCompute.EXPECT().insertFirewall(gomock.Any()).Return(nil).Times(2)
+
+			tag, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			Expect(*tag).Should(Equal(atIdentifier.GetCloudName(false)))
+		})
+		It("Should not invoke cloud api to create AddressGroup", func() {
+			mockgcpCompute.EXPECT().insertFirewall(gomock.Any()).Times(0)
+
+			tag, err := c.CreateSecurityGroup(agIdentifier, true)
+			Expect(err).Should(BeNil())
+			Expect(*tag).Should(Equal(agIdentifier.GetCloudName(true)))
+		})
+		It("Should add network tag to member instances", func() {
+			members := []*securitygroup.CloudResource{
+				{
+					Type:            securitygroup.CloudResourceTypeVM,
+					CloudResourceID: securitygroup.CloudResourceID{Name: "101", Vpc: networkIDValue},
+				},
+			}
+			mockgcpCompute.EXPECT().setInstanceTags("us-central1-a", "vm-101", gomock.Any()).
+				DoAndReturn(func(_ string, _ string, tags *compute.Tags) error {
+					Expect(tags.Items).Should(ContainElement(agIdentifier.GetCloudName(true)))
+					return nil
+				}).Times(1)
+
+			err := c.UpdateSecurityGroupMembers(agIdentifier, members, true)
+			Expect(err).Should(BeNil())
+		})
+		It("Should create firewall rule for ingress rule", func() {
+			_, ipNet, _ := net.ParseCIDR("10.0.0.0/24")
+			port := 22
+			protocol := 6
+			rule := &securitygroup.CloudRule{
+				Rule: &securitygroup.IngressRule{
+					FromPort:           &port,
+					FromSrcIP:          []*net.IPNet{ipNet},
+					FromSecurityGroups: []*securitygroup.CloudResourceID{&agIdentifier.CloudResourceID},
+					Protocol:           &protocol,
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			rule.Hash = rule.GetHash()
+			mockgcpCompute.EXPECT().pagedListFirewallsWrapper().Return([]*compute.Firewall{}, nil).Times(1)
+			mockgcpCompute.EXPECT().insertFirewall(gomock.Any()).DoAndReturn(func(firewall *compute.Firewall) error {
+				Expect(firewall.Direction).Should(Equal(gcpFirewallDirectionIngress))
+				Expect(firewall.TargetTags).Should(Equal([]string{atIdentifier.GetCloudName(false)}))
+				Expect(firewall.SourceRanges).Should(Equal([]string{"10.0.0.0/24"}))
+				Expect(firewall.SourceTags).Should(Equal([]string{agIdentifier.GetCloudName(true)}))
+				Expect(firewall.Allowed[0].IPProtocol).Should(Equal("6"))
+				Expect(firewall.Allowed[0].Ports).Should(Equal([]string{"22"}))
+				Expect(len(firewall.Name)).Should(BeNumerically("<=", gcpFirewallNameMaxLength))
+				return nil
+			}).Times(1)
+
+			err := c.UpdateSecurityGroupRules(atIdentifier, []*securitygroup.CloudRule{rule}, nil, nil)
+			Expect(err).Should(BeNil())
+		})
+		It("Should fail egress rule to security group", func() {
+			rule := &securitygroup.CloudRule{
+				Rule: &securitygroup.EgressRule{
+					ToSecurityGroups: []*securitygroup.CloudResourceID{&agIdentifier.CloudResourceID},
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			rule.Hash = rule.GetHash()
+			mockgcpCompute.EXPECT().pagedListFirewallsWrapper().Return([]*compute.Firewall{}, nil).Times(1)
+			mockgcpCompute.EXPECT().insertFirewall(gomock.Any()).Times(0)
+
+			err := c.UpdateSecurityGroupRules(atIdentifier, []*securitygroup.CloudRule{rule}, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should report enforced security from firewall rules and network tags", func() {
+			atTag := atIdentifier.GetCloudName(false)
+			description, _ := securitygroup.GenerateCloudDescription("namespace01/anp01", atIdentifier.CloudResourceID.String())
+			firewalls := []*compute.Firewall{
+				{
+					Name:         getFirewallNamePrefix(atTag, networkIDValue) + "01234567",
+					Network:      testNetworkURL,
+					Direction:    gcpFirewallDirectionIngress,
+					TargetTags:   []string{atTag},
+					SourceRanges: []string{"10.0.0.0/24", "10.0.1.0/24"},
+					Allowed:      []*compute.FirewallAllowed{{IPProtocol: "6", Ports: []string{"22"}}},
+					Description:  description,
+				},
+				{
+					Name:              getFirewallNamePrefix(atTag, networkIDValue) + gcpFirewallDenyEgressName,
+					Network:           testNetworkURL,
+					Direction:         gcpFirewallDirectionEgress,
+					TargetTags:        []string{atTag},
+					DestinationRanges: []string{"0.0.0.0/0"},
+					Denied:            []*compute.FirewallDenied{{IPProtocol: gcpAnyProtocolValue}},
+				},
+			}
+			mockgcpCompute.EXPECT().pagedListFirewallsWrapper().Return(firewalls, nil).Times(1)
+
+			enforced := c.GetEnforcedSecurity()
+			Expect(enforced).Should(HaveLen(1))
+			Expect(enforced[0].Resource.Name).Should(Equal(atIdentifier.Name))
+			Expect(enforced[0].MembershipOnly).Should(BeFalse())
+			Expect(enforced[0].IngressRules).Should(HaveLen(2))
+			Expect(enforced[0].EgressRules).Should(BeEmpty())
+		})
+	})
+})
+
+func getComputeNetworkObjects() []*compute.Network {
+	return []*compute.Network{
+		{
+			Id:       testNetworkID,
+			Name:     "net01",
+			SelfLink: testNetworkURL,
+		},
+	}
+}
+
+func getComputeInstanceObjects(instanceIDs []uint64) []*compute.Instance {
+	var instances []*compute.Instance
+	for _, id := range instanceIDs {
+		idStr := strconv.FormatUint(id, 10)
+		instances = append(instances, &compute.Instance{
+			Id:     id,
+			Name:   "vm-" + idStr,
+			Zone:   testZoneURL,
+			Status: "RUNNING",
+			Labels: map[string]string{"app": "web"},
+			NetworkInterfaces: []*compute.NetworkInterface{
+				{
+					Name:      "nic0",
+					Network:   testNetworkURL,
+					NetworkIP: "10.0.0." + strconv.FormatUint(id%256, 10),
+				},
+			},
+			Tags: &compute.Tags{Fingerprint: "fp"},
+		})
+	}
+	return instances
+}
+
+func checkAccountAddSuccessCondition(c *gcpCloud, namespacedName types.NamespacedName, ids []string) error {
+	conditionFunc := func() (done bool, e error) {
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&namespacedName)
+		if !found {
+			return true, errors.New("failed to find account")
+		}
+
+		serviceConfig, _ := accCfg.GetServiceConfigByName(gcpComputeServiceNameCompute)
+		instances := serviceConfig.(*computeServiceConfig).getCachedInstances()
+		instanceIDs := make([]string, 0, len(instances))
+		for _, instance := range instances {
+			instanceIDs = append(instanceIDs, strconv.FormatUint(instance.Id, 10))
+		}
+
+		sort.Strings(instanceIDs)
+		sort.Strings(ids)
+		return reflect.DeepEqual(instanceIDs, ids), nil
+	}
+
+	return wait.PollImmediate(1*time.Second, 5*time.Second, conditionFunc)
+}
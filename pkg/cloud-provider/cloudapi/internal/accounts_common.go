@@ -34,18 +34,25 @@ type CloudAccountInterface interface {
 
 	performInventorySync() error
 	resetInventorySyncCache()
+	setResourceFilters(selector *cloudv1alpha1.CloudEntitySelector)
+	removeResourceFilters(selectorName string)
 }
 
 type cloudAccountConfig struct {
 	mutex          sync.Mutex
 	namespacedName *types.NamespacedName
 	credentials    interface{}
+	// serviceMutex guards serviceConfigs and selectors, as services are added and removed when the regions of the
+	// account are updated.
+	serviceMutex   sync.RWMutex
 	serviceConfigs map[CloudServiceName]*CloudServiceCommon
+	selectors      map[string]*cloudv1alpha1.CloudEntitySelector
 	logger         func() logging.Logger
 	Status         *cloudv1alpha1.CloudProviderAccountStatus
 }
 
-type CloudCredentialValidatorFunc func(client client.Client, credentials interface{}) (interface{}, error)
+type CloudCredentialValidatorFunc func(client client.Client, namespacedName *types.NamespacedName,
+	credentials interface{}) (interface{}, error)
 type CloudCredentialComparatorFunc func(accountName string, existing interface{}, new interface{}) bool
 type CloudServiceConfigCreatorFunc func(namespacedName *types.NamespacedName, cloudConvertedCredentials interface{},
 	helper interface{}) ([]CloudServiceInterface, error)
@@ -56,7 +63,7 @@ func (c *cloudCommon) newCloudAccountConfig(client client.Client, namespacedName
 	if credentialsValidatorFunc == nil {
 		return nil, fmt.Errorf("registered cloud-credentials validator function cannot be nil")
 	}
-	cloudConvertedCredential, err := credentialsValidatorFunc(client, credentials)
+	cloudConvertedCredential, err := credentialsValidatorFunc(client, namespacedName, credentials)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +92,7 @@ func (c *cloudCommon) newCloudAccountConfig(client client.Client, namespacedName
 		logger:         loggerFunc,
 		namespacedName: namespacedName,
 		serviceConfigs: serviceConfigMap,
+		selectors:      make(map[string]*cloudv1alpha1.CloudEntitySelector),
 		credentials:    cloudConvertedCredential,
 		Status:         status,
 	}, nil
@@ -96,7 +104,7 @@ func (c *cloudCommon) updateCloudAccountConfig(client client.Client, credentials
 	if credentialsValidatorFunc == nil {
 		return fmt.Errorf("registered cloud-credentials validator function cannot be nil")
 	}
-	cloudConvertedNewCredential, err := credentialsValidatorFunc(client, credentials)
+	cloudConvertedNewCredential, err := credentialsValidatorFunc(client, currentConfig.namespacedName, credentials)
 	if err != nil {
 		return err
 	}
@@ -143,24 +151,42 @@ func (accCfg *cloudAccountConfig) update(credentialComparator CloudCredentialCom
 	accCfg.credentials = newCredentials
 	logger.Info("credentials updated.", "account", accCfg.namespacedName)
 
-	existingSvcConfigMap := accCfg.serviceConfigs
-	for name, svcConfig := range existingSvcConfigMap {
+	accCfg.serviceMutex.Lock()
+	defer accCfg.serviceMutex.Unlock()
+
+	for name, svcConfig := range accCfg.serviceConfigs {
 		newSvcCfg, found := newSvcConfigMap[name]
 		if !found {
-			// should not happen
+			// service of a region removed from the account.
+			delete(accCfg.serviceConfigs, name)
+			logger.Info("service config removed", "account", accCfg.namespacedName, "serviceName", name)
 			continue
 		}
 		svcConfig.updateServiceConfig(newSvcCfg)
 		logger.Info("service config updated (api-clients to use new creds)", "account", accCfg.namespacedName,
 			"serviceName", name)
 	}
+	for name, newSvcCfg := range newSvcConfigMap {
+		if _, found := accCfg.serviceConfigs[name]; found {
+			continue
+		}
+		// service of a region added to the account, which applies the existing selectors.
+		svcConfig := &CloudServiceCommon{
+			serviceInterface: newSvcCfg,
+		}
+		for _, selector := range accCfg.selectors {
+			svcConfig.setResourceFilters(selector)
+		}
+		accCfg.serviceConfigs[name] = svcConfig
+		logger.Info("service config added", "account", accCfg.namespacedName, "serviceName", name)
+	}
 }
 
 func (accCfg *cloudAccountConfig) performInventorySync() error {
 	accCfg.mutex.Lock()
 	defer accCfg.mutex.Unlock()
 
-	serviceConfigs := accCfg.serviceConfigs
+	serviceConfigs := accCfg.GetServiceConfigs()
 
 	ch := make(chan error)
 	var wg sync.WaitGroup
@@ -199,6 +225,9 @@ func (accCfg *cloudAccountConfig) GetNamespacedName() *types.NamespacedName {
 }
 
 func (accCfg *cloudAccountConfig) GetServiceConfigs() map[CloudServiceName]*CloudServiceCommon {
+	accCfg.serviceMutex.RLock()
+	defer accCfg.serviceMutex.RUnlock()
+
 	svcNameCfgMap := make(map[CloudServiceName]*CloudServiceCommon)
 	for name, serviceCommon := range accCfg.serviceConfigs {
 		svcNameCfgMap[name] = serviceCommon
@@ -207,6 +236,9 @@ func (accCfg *cloudAccountConfig) GetServiceConfigs() map[CloudServiceName]*Clou
 }
 
 func (accCfg *cloudAccountConfig) GetServiceConfigByName(name CloudServiceName) (CloudServiceInterface, error) {
+	accCfg.serviceMutex.RLock()
+	defer accCfg.serviceMutex.RUnlock()
+
 	if serviceCfg, found := accCfg.serviceConfigs[name]; found {
 		return serviceCfg.serviceInterface, nil
 	}
@@ -219,7 +251,30 @@ func (accCfg *cloudAccountConfig) GetStatus() *cloudv1alpha1.CloudProviderAccoun
 }
 
 func (accCfg *cloudAccountConfig) resetInventorySyncCache() {
-	for _, serviceConfig := range accCfg.serviceConfigs {
+	for _, serviceConfig := range accCfg.GetServiceConfigs() {
 		serviceConfig.resetCachedState()
 	}
 }
+
+// setResourceFilters sets the resource filters of a selector on all services, and saves the selector for services
+// added later.
+func (accCfg *cloudAccountConfig) setResourceFilters(selector *cloudv1alpha1.CloudEntitySelector) {
+	accCfg.serviceMutex.Lock()
+	defer accCfg.serviceMutex.Unlock()
+
+	accCfg.selectors[selector.GetName()] = selector
+	for _, serviceCfg := range accCfg.serviceConfigs {
+		serviceCfg.setResourceFilters(selector)
+	}
+}
+
+// removeResourceFilters removes the resource filters of a selector from all services.
+func (accCfg *cloudAccountConfig) removeResourceFilters(selectorName string) {
+	accCfg.serviceMutex.Lock()
+	defer accCfg.serviceMutex.Unlock()
+
+	delete(accCfg.selectors, selectorName)
+	for _, serviceCfg := range accCfg.serviceConfigs {
+		serviceCfg.removeResourceFilters(selectorName)
+	}
+}
@@ -157,11 +157,15 @@ func (c *cloudCommon) GetCloudAccountComputeInternalResourceObjects(accountNames
 		return nil, fmt.Errorf("unable to find cloud account: %v", *accountNamespacedName)
 	}
 
+	// aggregate the instances of compute services of all regions of the account.
 	computeCRs := map[string]*runtimev1alpha1.VirtualMachine{}
 	serviceConfigs := accCfg.GetServiceConfigs()
 	for _, serviceConfig := range serviceConfigs {
 		if serviceConfig.getType() == CloudServiceTypeCompute {
-			return serviceConfig.getInternalResourceObjects(accCfg.GetNamespacedName().Namespace, accCfg.GetNamespacedName()), nil
+			vms := serviceConfig.getInternalResourceObjects(accCfg.GetNamespacedName().Namespace, accCfg.GetNamespacedName())
+			for name, vm := range vms {
+				computeCRs[name] = vm
+			}
 		}
 	}
 
@@ -174,9 +178,7 @@ func (c *cloudCommon) AddSelector(accountNamespacedName *types.NamespacedName, s
 		return fmt.Errorf("unable to find cloud account: %v", *accountNamespacedName)
 	}
 
-	for _, serviceCfg := range accCfg.GetServiceConfigs() {
-		serviceCfg.setResourceFilters(selector)
-	}
+	accCfg.setResourceFilters(selector)
 
 	return nil
 }
@@ -188,9 +190,7 @@ func (c *cloudCommon) RemoveSelector(accNamespacedName *types.NamespacedName, se
 		return
 	}
 
-	for _, serviceCfg := range accCfg.GetServiceConfigs() {
-		serviceCfg.removeResourceFilters(selectorName)
-	}
+	accCfg.removeResourceFilters(selectorName)
 }
 
 func (c *cloudCommon) GetStatus(accountNamespacedName *types.NamespacedName) (*crdv1alpha1.CloudProviderAccountStatus, error) {
@@ -235,11 +235,23 @@ func (c *cloudCommon) GetVpcInventory(accountNamespacedName *types.NamespacedNam
 		return nil, fmt.Errorf("unable to find cloud account: %v", *accountNamespacedName)
 	}
 
+	// aggregate the vpcs of compute services of all regions of the account.
+	var vpcMap map[string]*runtimev1alpha1.Vpc
 	serviceConfigs := accCfg.GetServiceConfigs()
 	for _, serviceConfig := range serviceConfigs {
-		if serviceConfig.getType() == CloudServiceTypeCompute {
-			return serviceConfig.getVpcInventory(), nil
+		if serviceConfig.getType() != CloudServiceTypeCompute {
+			continue
+		}
+		vpcs := serviceConfig.getVpcInventory()
+		if vpcs == nil {
+			continue
+		}
+		if vpcMap == nil {
+			vpcMap = make(map[string]*runtimev1alpha1.Vpc)
+		}
+		for id, vpc := range vpcs {
+			vpcMap[id] = vpc
 		}
 	}
-	return nil, nil
+	return vpcMap, nil
 }
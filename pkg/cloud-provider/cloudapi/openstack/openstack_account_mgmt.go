@@ -0,0 +1,113 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+type openstackAccountConfig struct {
+	crdv1alpha1.OpenStackAccountCredential
+	authURL string
+	region  string
+}
+
+// setAccountCredentials sets account credentials.
+func setAccountCredentials(client client.Client, _ *types.NamespacedName, credentials interface{}) (interface{}, error) {
+	openstackProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountOpenStackConfig)
+	accCred, err := extractSecret(client, openstackProviderConfig.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	openstackConfig := &openstackAccountConfig{
+		OpenStackAccountCredential: *accCred,
+		authURL:                    strings.TrimSpace(openstackProviderConfig.AuthURL),
+		region:                     strings.TrimSpace(openstackProviderConfig.Region),
+	}
+	openstackConfig.Username = strings.TrimSpace(openstackConfig.Username)
+	openstackConfig.DomainName = strings.TrimSpace(openstackConfig.DomainName)
+	openstackConfig.ProjectID = strings.TrimSpace(openstackConfig.ProjectID)
+
+	return openstackConfig, nil
+}
+
+func compareAccountCredentials(accountName string, existing interface{}, new interface{}) bool {
+	existingConfig := existing.(*openstackAccountConfig)
+	newConfig := new.(*openstackAccountConfig)
+
+	credsChanged := false
+	if strings.Compare(existingConfig.Username, newConfig.Username) != 0 {
+		credsChanged = true
+		openstackPluginLogger().Info("account username updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.Password, newConfig.Password) != 0 {
+		credsChanged = true
+		openstackPluginLogger().Info("account password updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.DomainName, newConfig.DomainName) != 0 {
+		credsChanged = true
+		openstackPluginLogger().Info("account domain name updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.ProjectID, newConfig.ProjectID) != 0 {
+		credsChanged = true
+		openstackPluginLogger().Info("account project id updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.authURL, newConfig.authURL) != 0 {
+		credsChanged = true
+		openstackPluginLogger().Info("account auth url updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.region, newConfig.region) != 0 {
+		credsChanged = true
+		openstackPluginLogger().Info("account region updated", "account", accountName)
+	}
+	return credsChanged
+}
+
+// extractSecret extracts credentials from a Kubernetes secret.
+func extractSecret(c client.Client, s *crdv1alpha1.SecretReference) (*crdv1alpha1.OpenStackAccountCredential, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
+		return nil, err
+	}
+
+	data := u.Object["data"].(map[string]interface{})
+	decode, err := base64.StdEncoding.DecodeString(data[s.Key].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &crdv1alpha1.OpenStackAccountCredential{}
+	if err = json.Unmarshal(decode, cred); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
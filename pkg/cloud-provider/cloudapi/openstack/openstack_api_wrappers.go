@@ -0,0 +1,121 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+// openstackComputeWrapper is layer above gophercloud nova apis to allow for unit-testing.
+type openstackComputeWrapper interface {
+	// servers
+	listServers() ([]servers.Server, error)
+}
+
+// openstackNetworkWrapper is layer above gophercloud neutron apis to allow for unit-testing.
+type openstackNetworkWrapper interface {
+	// networks
+	listNetworks() ([]networks.Network, error)
+	listSubnets() ([]subnets.Subnet, error)
+
+	// ports
+	listPorts(opts ports.ListOpts) ([]ports.Port, error)
+	updatePortSecurityGroups(portID string, securityGroupIDs []string) error
+
+	// security groups
+	listSecurityGroups(opts groups.ListOpts) ([]groups.SecGroup, error)
+	createSecurityGroup(opts groups.CreateOpts) (*groups.SecGroup, error)
+	deleteSecurityGroup(securityGroupID string) error
+	createSecurityGroupRule(opts rules.CreateOpts) (*rules.SecGroupRule, error)
+	deleteSecurityGroupRule(ruleID string) error
+}
+
+type openstackComputeWrapperImpl struct {
+	compute *gophercloud.ServiceClient
+}
+
+type openstackNetworkWrapperImpl struct {
+	network *gophercloud.ServiceClient
+}
+
+func (computeWrapper *openstackComputeWrapperImpl) listServers() ([]servers.Server, error) {
+	pages, err := servers.List(computeWrapper.compute, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing servers: %q", err)
+	}
+	return servers.ExtractServers(pages)
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) listNetworks() ([]networks.Network, error) {
+	pages, err := networks.List(networkWrapper.network, networks.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing networks: %q", err)
+	}
+	return networks.ExtractNetworks(pages)
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) listSubnets() ([]subnets.Subnet, error) {
+	pages, err := subnets.List(networkWrapper.network, subnets.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing subnets: %q", err)
+	}
+	return subnets.ExtractSubnets(pages)
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) listPorts(opts ports.ListOpts) ([]ports.Port, error) {
+	pages, err := ports.List(networkWrapper.network, opts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing ports: %q", err)
+	}
+	return ports.ExtractPorts(pages)
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) updatePortSecurityGroups(portID string, securityGroupIDs []string) error {
+	opts := ports.UpdateOpts{SecurityGroups: &securityGroupIDs}
+	_, err := ports.Update(networkWrapper.network, portID, opts).Extract()
+	return err
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) listSecurityGroups(opts groups.ListOpts) ([]groups.SecGroup, error) {
+	pages, err := groups.List(networkWrapper.network, opts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing security groups: %q", err)
+	}
+	return groups.ExtractGroups(pages)
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) createSecurityGroup(opts groups.CreateOpts) (*groups.SecGroup, error) {
+	return groups.Create(networkWrapper.network, opts).Extract()
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) deleteSecurityGroup(securityGroupID string) error {
+	return groups.Delete(networkWrapper.network, securityGroupID).ExtractErr()
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) createSecurityGroupRule(opts rules.CreateOpts) (*rules.SecGroupRule, error) {
+	return rules.Create(networkWrapper.network, opts).Extract()
+}
+
+func (networkWrapper *openstackNetworkWrapperImpl) deleteSecurityGroupRule(ruleID string) error {
+	return rules.Delete(networkWrapper.network, ruleID).ExtractErr()
+}
@@ -0,0 +1,389 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+type computeServiceConfig struct {
+	accountNamespacedName types.NamespacedName
+	computeClient         openstackComputeWrapper
+	networkClient         openstackNetworkWrapper
+	resourcesCache        *internal.CloudServiceResourcesCache
+	inventoryStats        *internal.CloudServiceStats
+	// serverFilters has following possible values
+	// - empty map indicates no selectors configured for this account. NO cloud api call for inventory will be made.
+	// - non-empty map indicates selectors are configured. Cloud api call for inventory will be made.
+	// - key with nil value indicates no filters. Get all servers for account.
+	// - key with non-nil value indicates some filter. Get servers matching those filters only.
+	serverFilters map[string][]*openstackServerFilter
+	credentials   *openstackAccountConfig
+}
+
+// computeResourcesCacheSnapshot holds the results from querying for all servers.
+type computeResourcesCacheSnapshot struct {
+	servers     map[cloudcommon.InstanceID]*servers.Server
+	serverPorts map[string][]ports.Port
+	networks    []networks.Network
+	networkIDs  map[string]struct{}
+	cidrs       map[string][]string
+}
+
+func newComputeServiceConfig(accountNamespacedName types.NamespacedName, service openstackServiceClientCreateInterface,
+	credentials *openstackAccountConfig) (internal.CloudServiceInterface, error) {
+	// create nova and neutron sdk api clients
+	computeClient, err := service.compute()
+	if err != nil {
+		return nil, fmt.Errorf("error creating compute sdk api client for account : %v, err: %v", accountNamespacedName.String(), err)
+	}
+	networkClient, err := service.network()
+	if err != nil {
+		return nil, fmt.Errorf("error creating network sdk api client for account : %v, err: %v", accountNamespacedName.String(), err)
+	}
+
+	config := &computeServiceConfig{
+		computeClient:         computeClient,
+		networkClient:         networkClient,
+		accountNamespacedName: accountNamespacedName,
+		resourcesCache:        &internal.CloudServiceResourcesCache{},
+		inventoryStats:        &internal.CloudServiceStats{},
+		serverFilters:         make(map[string][]*openstackServerFilter),
+		credentials:           credentials,
+	}
+	return config, nil
+}
+
+// compute returns OpenStack Nova SDK apiClient.
+func (p *openstackServiceSdkConfigProvider) compute() (openstackComputeWrapper, error) {
+	computeClient, err := openstack.NewComputeV2(p.providerClient, p.endpointOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &openstackComputeWrapperImpl{compute: computeClient}, nil
+}
+
+// network returns OpenStack Neutron SDK apiClient.
+func (p *openstackServiceSdkConfigProvider) network() (openstackNetworkWrapper, error) {
+	networkClient, err := openstack.NewNetworkV2(p.providerClient, p.endpointOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &openstackNetworkWrapperImpl{network: networkClient}, nil
+}
+
+func (computeCfg *computeServiceConfig) waitForInventoryInit(duration time.Duration) error {
+	operation := func() error {
+		done := computeCfg.inventoryStats.IsInventoryInitialized()
+		if !done {
+			return fmt.Errorf("inventory for account %v not initialized (waited %v duration)", computeCfg.accountNamespacedName, duration)
+		}
+		return nil
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = duration
+
+	return backoff.Retry(operation, b)
+}
+
+// getServerResourceFilters returns filters to be applied to listed servers if filters are configured.
+// Otherwise, returns (nil, false). false indicates no selectors configured for the account and hence no cloud api needs
+// to be made for server inventory.
+func (computeCfg *computeServiceConfig) getServerResourceFilters() ([]*openstackServerFilter, bool) {
+	var allFilters []*openstackServerFilter
+
+	if len(computeCfg.serverFilters) == 0 {
+		return nil, false
+	}
+
+	for _, filters := range computeCfg.serverFilters {
+		// if any selector found with nil filter, skip all other selectors. As nil indicates all
+		if len(filters) == 0 {
+			return nil, true
+		}
+		allFilters = append(allFilters, filters...)
+	}
+	return allFilters, true
+}
+
+// getCachedServers returns servers from the cache for the account.
+func (computeCfg *computeServiceConfig) getCachedServers() []*servers.Server {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		openstackPluginLogger().V(4).Info("cache snapshot nil", "service", openstackComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []*servers.Server{}
+	}
+	cachedServers := snapshot.(*computeResourcesCacheSnapshot).servers
+	serversToReturn := make([]*servers.Server, 0, len(cachedServers))
+	for _, server := range cachedServers {
+		serversToReturn = append(serversToReturn, server)
+	}
+	openstackPluginLogger().V(1).Info("cached vm instances", "service", openstackComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(serversToReturn))
+	return serversToReturn
+}
+
+// getCachedServerPorts returns the neutron ports of servers from the cache, indexed by server ID.
+func (computeCfg *computeServiceConfig) getCachedServerPorts() map[string][]ports.Port {
+	serverPortsCopy := make(map[string][]ports.Port)
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		openstackPluginLogger().V(4).Info("cache snapshot nil", "service", openstackComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return serverPortsCopy
+	}
+	for serverID, serverPorts := range snapshot.(*computeResourcesCacheSnapshot).serverPorts {
+		serverPortsCopy[serverID] = append([]ports.Port{}, serverPorts...)
+	}
+	return serverPortsCopy
+}
+
+// getManagedNetworkIDs returns IDs of networks containing managed vms.
+func (computeCfg *computeServiceConfig) getManagedNetworkIDs() map[string]struct{} {
+	networkIDsCopy := make(map[string]struct{})
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		openstackPluginLogger().V(4).Info("cache snapshot nil", "service", openstackComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return networkIDsCopy
+	}
+	for networkID := range snapshot.(*computeResourcesCacheSnapshot).networkIDs {
+		networkIDsCopy[networkID] = struct{}{}
+	}
+	return networkIDsCopy
+}
+
+// getCachedNetworks returns networks and their subnet cidrs from cached snapshot for the account.
+func (computeCfg *computeServiceConfig) getCachedNetworks() ([]networks.Network, map[string][]string) {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		openstackPluginLogger().V(4).Info("cache snapshot nil", "service", openstackComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []networks.Network{}, map[string][]string{}
+	}
+	cachedNetworks := snapshot.(*computeResourcesCacheSnapshot).networks
+	networksToReturn := make([]networks.Network, 0, len(cachedNetworks))
+	networksToReturn = append(networksToReturn, cachedNetworks...)
+	cidrsToReturn := make(map[string][]string)
+	for networkID, cidrs := range snapshot.(*computeResourcesCacheSnapshot).cidrs {
+		cidrsToReturn[networkID] = append([]string{}, cidrs...)
+	}
+	return networksToReturn, cidrsToReturn
+}
+
+// getServerPorts gets neutron ports bound to nova servers from cloud, indexed by server ID.
+func (computeCfg *computeServiceConfig) getServerPorts() (map[string][]ports.Port, error) {
+	allPorts, err := computeCfg.networkClient.listPorts(ports.ListOpts{ProjectID: computeCfg.credentials.ProjectID})
+	if err != nil {
+		return nil, err
+	}
+	serverPorts := make(map[string][]ports.Port)
+	for i := range allPorts {
+		port := allPorts[i]
+		if !isServerPort(&port) {
+			continue
+		}
+		serverPorts[port.DeviceID] = append(serverPorts[port.DeviceID], port)
+	}
+	return serverPorts, nil
+}
+
+// getServers gets servers of the account project from nova API, applying the configured filters.
+func (computeCfg *computeServiceConfig) getServers(networksByID map[string]*networks.Network,
+	serverPorts map[string][]ports.Port) ([]servers.Server, error) {
+	filters, hasFilters := computeCfg.getServerResourceFilters()
+	if !hasFilters {
+		openstackPluginLogger().V(1).Info("fetching vm resources from cloud skipped",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "not-configured")
+		return nil, nil
+	}
+
+	allServers, err := computeCfg.computeClient.listServers()
+	if err != nil {
+		return nil, err
+	}
+	if filters == nil {
+		openstackPluginLogger().V(1).Info("fetching vm resources from cloud",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "all(nil)")
+		return allServers, nil
+	}
+
+	openstackPluginLogger().V(1).Info("fetching vm resources from cloud",
+		"account", computeCfg.accountNamespacedName, "resource-filters", "configured")
+	var filtered []servers.Server
+	for i := range allServers {
+		server := &allServers[i]
+		var network *networks.Network
+		if portList := serverPorts[server.ID]; len(portList) > 0 {
+			network = networksByID[portList[0].NetworkID]
+		}
+		for _, filter := range filters {
+			if filter.matches(server, network) {
+				filtered = append(filtered, *server)
+				break
+			}
+		}
+	}
+
+	openstackPluginLogger().V(1).Info("vm instances from cloud", "service", openstackComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(filtered))
+
+	return filtered, nil
+}
+
+// DoResourceInventory gets inventory from cloud for given cloud account.
+func (computeCfg *computeServiceConfig) DoResourceInventory() error {
+	allNetworks, err := computeCfg.networkClient.listNetworks()
+	if err != nil {
+		openstackPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+	allSubnets, err := computeCfg.networkClient.listSubnets()
+	if err != nil {
+		openstackPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+	networksByID := make(map[string]*networks.Network)
+	for i := range allNetworks {
+		networksByID[allNetworks[i].ID] = &allNetworks[i]
+	}
+	cidrs := make(map[string][]string)
+	for _, subnet := range allSubnets {
+		cidrs[subnet.NetworkID] = append(cidrs[subnet.NetworkID], subnet.CIDR)
+	}
+
+	serverPorts, err := computeCfg.getServerPorts()
+	if err != nil {
+		openstackPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+	allServers, err := computeCfg.getServers(networksByID, serverPorts)
+	if err != nil {
+		openstackPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+
+	exists := struct{}{}
+	networkIDs := make(map[string]struct{})
+	serverIDs := make(map[cloudcommon.InstanceID]*servers.Server)
+	managedServerPorts := make(map[string][]ports.Port)
+	for i := range allServers {
+		server := &allServers[i]
+		serverIDs[cloudcommon.InstanceID(server.ID)] = server
+		managedServerPorts[server.ID] = serverPorts[server.ID]
+		for _, port := range serverPorts[server.ID] {
+			if _, ok := networksByID[port.NetworkID]; ok {
+				networkIDs[port.NetworkID] = exists
+			}
+		}
+	}
+	computeCfg.resourcesCache.UpdateSnapshot(&computeResourcesCacheSnapshot{serverIDs, managedServerPorts, allNetworks,
+		networkIDs, cidrs})
+	return nil
+}
+
+// SetResourceFilters add/updates servers resource filter for the service.
+func (computeCfg *computeServiceConfig) SetResourceFilters(selector *crdv1alpha1.CloudEntitySelector) {
+	if filters, found := convertSelectorToServerFilters(selector); found {
+		computeCfg.serverFilters[selector.GetName()] = filters
+	} else {
+		if selector != nil {
+			delete(computeCfg.serverFilters, selector.GetName())
+		}
+		computeCfg.resourcesCache.UpdateSnapshot(nil)
+	}
+}
+
+func (computeCfg *computeServiceConfig) RemoveResourceFilters(selectorName string) {
+	delete(computeCfg.serverFilters, selectorName)
+}
+
+func (computeCfg *computeServiceConfig) GetInternalResourceObjects(namespace string,
+	account *types.NamespacedName) map[string]*runtimev1alpha1.VirtualMachine {
+	cachedServers := computeCfg.getCachedServers()
+	serverPorts := computeCfg.getCachedServerPorts()
+	vmObjects := map[string]*runtimev1alpha1.VirtualMachine{}
+	for _, server := range cachedServers {
+		// build runtimev1alpha1 VirtualMachine object.
+		vmObject := serverToInternalVirtualMachineObject(server, serverPorts[server.ID], namespace, account,
+			computeCfg.credentials.region)
+		vmObjects[vmObject.Name] = vmObject
+	}
+
+	openstackPluginLogger().V(1).Info("Internal resource objects", "Service", openstackComputeServiceNameCompute,
+		"Account", computeCfg.accountNamespacedName, "VirtualMachine objects", len(vmObjects))
+
+	return vmObjects
+}
+
+func (computeCfg *computeServiceConfig) GetName() internal.CloudServiceName {
+	return openstackComputeServiceNameCompute
+}
+
+func (computeCfg *computeServiceConfig) GetType() internal.CloudServiceType {
+	return internal.CloudServiceTypeCompute
+}
+
+func (computeCfg *computeServiceConfig) GetInventoryStats() *internal.CloudServiceStats {
+	return computeCfg.inventoryStats
+}
+
+func (computeCfg *computeServiceConfig) ResetCachedState() {
+	computeCfg.SetResourceFilters(nil)
+	computeCfg.inventoryStats.ResetInventoryPollStats()
+}
+
+func (computeCfg *computeServiceConfig) UpdateServiceConfig(newConfig internal.CloudServiceInterface) {
+	newComputeServiceConfig := newConfig.(*computeServiceConfig)
+	computeCfg.computeClient = newComputeServiceConfig.computeClient
+	computeCfg.networkClient = newComputeServiceConfig.networkClient
+	computeCfg.credentials = newComputeServiceConfig.credentials
+}
+
+// GetVpcInventory generates vpc object for the networks stored in snapshot(in cloud format) and return a map of vpc runtime objects.
+func (computeCfg *computeServiceConfig) GetVpcInventory() map[string]*runtimev1alpha1.Vpc {
+	cachedNetworks, cidrs := computeCfg.getCachedNetworks()
+	networkIDs := computeCfg.getManagedNetworkIDs()
+	// Convert to kubernetes object and return a map indexed using network ID.
+	vpcMap := map[string]*runtimev1alpha1.Vpc{}
+	for i := range cachedNetworks {
+		network := &cachedNetworks[i]
+		_, managed := networkIDs[network.ID]
+		vpcObj := networkToInternalVpcObject(network, cidrs[network.ID], computeCfg.accountNamespacedName.Namespace,
+			computeCfg.accountNamespacedName.Name, strings.ToLower(computeCfg.credentials.region), managed)
+		vpcMap[network.ID] = vpcObj
+	}
+
+	openstackPluginLogger().V(1).Info("cached vpcs", "service", openstackComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "vpc objects", len(vpcMap))
+
+	return vpcMap
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// convertToRuleProtocol converts protocol of a rule to neutron security group rule protocol. Empty protocol matches
+// any protocol.
+func convertToRuleProtocol(protocol *int) rules.RuleProtocol {
+	if protocol == nil {
+		return openstackAnyProtocolValue
+	}
+	return rules.RuleProtocol(strconv.Itoa(*protocol))
+}
+
+// convertToRulePortRange converts port range of a rule to neutron security group rule port range. Zero port range
+// matches all ports.
+func convertToRulePortRange(port *int, endPort *int, protocol *int) (int, int) {
+	if port == nil || protocol == nil || !isPortProtocol(*protocol) {
+		return 0, 0
+	}
+	if endPort != nil {
+		return *port, *endPort
+	}
+	return *port, *port
+}
+
+// convertToRemoteIPPrefixes converts ip blocks of a rule to neutron security group rule remote ip prefixes.
+func convertToRemoteIPPrefixes(ips []*net.IPNet, ruleHasGroups bool) []string {
+	if len(ips) == 0 && !ruleHasGroups {
+		return []string{"0.0.0.0/0"}
+	}
+	prefixes := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		prefixes = append(prefixes, ip.String())
+	}
+	return prefixes
+}
+
+// convertToRemoteGroupIDs converts address groups of a rule to neutron security group rule remote group IDs.
+func convertToRemoteGroupIDs(addressGroupIdentifiers []*securitygroup.CloudResourceID,
+	cloudSgNameToObj map[string]*neutronSecurityGroup) []string {
+	groupIDs := make([]string, 0, len(addressGroupIdentifiers))
+	for _, addressGroupIdentifier := range addressGroupIdentifiers {
+		group, found := cloudSgNameToObj[addressGroupIdentifier.GetCloudName(true)]
+		if !found {
+			continue
+		}
+		groupIDs = append(groupIDs, group.ID)
+	}
+	return groupIDs
+}
+
+// convertFromRuleProtocol converts neutron security group rule protocol to rule protocol.
+func convertFromRuleProtocol(protocol string) *int {
+	if protocol == string(openstackAnyProtocolValue) {
+		return nil
+	}
+	protocolNum, err := strconv.Atoi(protocol)
+	if err != nil {
+		value, found := securitygroup.ProtocolNameNumMap[protocol]
+		if !found {
+			return nil
+		}
+		protocolNum = value
+	}
+	return &protocolNum
+}
+
+// convertFromRulePortRange converts neutron security group rule port range to rule port and end port.
+func convertFromRulePortRange(portRangeMin int, portRangeMax int) (*int, *int) {
+	if portRangeMin == 0 {
+		return nil, nil
+	}
+	port := portRangeMin
+	if portRangeMax <= portRangeMin {
+		return &port, nil
+	}
+	endPort := portRangeMax
+	return &port, &endPort
+}
+
+// convertFromRemoteIPPrefix converts neutron security group rule remote ip prefix to ip blocks.
+func convertFromRemoteIPPrefix(prefix string) []*net.IPNet {
+	if len(prefix) == 0 {
+		return nil
+	}
+	_, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return nil
+	}
+	return []*net.IPNet{ipNet}
+}
+
+// convertFromRemoteGroupID converts neutron security group rule remote group to address groups.
+func convertFromRemoteGroupID(groupID string, managedSgIDToObj map[string]*neutronSecurityGroup) []*securitygroup.CloudResourceID {
+	if len(groupID) == 0 {
+		return nil
+	}
+	group, found := managedSgIDToObj[groupID]
+	if !found || !group.membershipOnly {
+		return nil
+	}
+	return []*securitygroup.CloudResourceID{{
+		Name: group.sgName,
+		Vpc:  group.networkID,
+	}}
+}
+
+// convertFromSecurityGroupRules converts neutron security group rules to internal securitygroup.IngressRule and
+// securitygroup.EgressRule. Each neutron rule carries a single remote ip prefix or remote group.
+func convertFromSecurityGroupRules(sgRules []rules.SecGroupRule, managedSgIDToObj map[string]*neutronSecurityGroup) (
+	[]securitygroup.IngressRule, []securitygroup.EgressRule) {
+	var ingressRules []securitygroup.IngressRule
+	var egressRules []securitygroup.EgressRule
+	for _, sgRule := range sgRules {
+		// Get cloud rule description.
+		description := sgRule.Description
+		if _, ok := securitygroup.ExtractCloudDescription(&description); !ok {
+			// Ignore rules that don't have a valid description field.
+			openstackPluginLogger().V(4).Info("Failed to extract cloud rule description", "desc", description)
+			continue
+		}
+		protocol := convertFromRuleProtocol(sgRule.Protocol)
+		port, endPort := convertFromRulePortRange(sgRule.PortRangeMin, sgRule.PortRangeMax)
+		ips := convertFromRemoteIPPrefix(sgRule.RemoteIPPrefix)
+		groups := convertFromRemoteGroupID(sgRule.RemoteGroupID, managedSgIDToObj)
+		if len(ips) == 0 && len(groups) == 0 {
+			continue
+		}
+		if sgRule.Direction == string(rules.DirEgress) {
+			egressRules = append(egressRules, securitygroup.EgressRule{
+				ToPort:           port,
+				ToEndPort:        endPort,
+				ToDstIP:          ips,
+				ToSecurityGroups: groups,
+				Protocol:         protocol,
+			})
+		} else {
+			ingressRules = append(ingressRules, securitygroup.IngressRule{
+				FromPort:           port,
+				FromEndPort:        endPort,
+				FromSrcIP:          ips,
+				FromSecurityGroups: groups,
+				Protocol:           protocol,
+			})
+		}
+	}
+	return ingressRules, egressRules
+}
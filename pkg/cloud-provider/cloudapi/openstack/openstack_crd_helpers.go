@@ -0,0 +1,137 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
+)
+
+const (
+	openstackAddressTypeKey      = "OS-EXT-IPS:type"
+	openstackAddressMACKey       = "OS-EXT-IPS-MAC:mac_addr"
+	openstackAddressTypeFloating = "floating"
+)
+
+var openstackStateMap = map[string]runtimev1alpha1.VMState{
+	"BUILD":             runtimev1alpha1.Starting,
+	"REBUILD":           runtimev1alpha1.Starting,
+	"REBOOT":            runtimev1alpha1.Starting,
+	"HARD_REBOOT":       runtimev1alpha1.Starting,
+	"ACTIVE":            runtimev1alpha1.Running,
+	"PAUSED":            runtimev1alpha1.Stopped,
+	"SUSPENDED":         runtimev1alpha1.Stopped,
+	"SHUTOFF":           runtimev1alpha1.Stopped,
+	"SHELVED":           runtimev1alpha1.Stopped,
+	"SHELVED_OFFLOADED": runtimev1alpha1.Stopped,
+	"ERROR":             runtimev1alpha1.Unknown,
+}
+
+// getFloatingIPsByMAC returns floating IPs of the server indexed by the MAC address of the port they are associated to.
+func getFloatingIPsByMAC(server *servers.Server) map[string][]string {
+	floatingIPs := make(map[string][]string)
+	for _, addresses := range server.Addresses {
+		addressList, ok := addresses.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, address := range addressList {
+			addressMap, ok := address.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if addressType, _ := addressMap[openstackAddressTypeKey].(string); addressType != openstackAddressTypeFloating {
+				continue
+			}
+			mac, _ := addressMap[openstackAddressMACKey].(string)
+			ip, _ := addressMap["addr"].(string)
+			if len(mac) == 0 || len(ip) == 0 {
+				continue
+			}
+			floatingIPs[strings.ToLower(mac)] = append(floatingIPs[strings.ToLower(mac)], ip)
+		}
+	}
+	return floatingIPs
+}
+
+// serverToInternalVirtualMachineObject converts nova server and its neutron ports to VirtualMachine runtime object.
+func serverToInternalVirtualMachineObject(server *servers.Server, serverPorts []ports.Port, namespace string,
+	account *types.NamespacedName, region string) *runtimev1alpha1.VirtualMachine {
+	tags := make(map[string]string)
+	for key, value := range server.Metadata {
+		tags[key] = value
+	}
+
+	// Network interfaces associated with Virtual machine
+	var cloudNetworkID string
+	floatingIPs := getFloatingIPsByMAC(server)
+	networkInterfaces := make([]runtimev1alpha1.NetworkInterface, 0, len(serverPorts))
+	for _, port := range serverPorts {
+		if len(cloudNetworkID) == 0 {
+			cloudNetworkID = port.NetworkID
+		}
+		var ipAddressObjs []runtimev1alpha1.IPAddress
+		for _, fixedIP := range port.FixedIPs {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeInternalIP,
+				Address:     fixedIP.IPAddress,
+			})
+		}
+		for _, floatingIP := range floatingIPs[strings.ToLower(port.MACAddress)] {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeExternalIP,
+				Address:     floatingIP,
+			})
+		}
+		networkInterface := runtimev1alpha1.NetworkInterface{
+			Name: port.ID,
+			MAC:  port.MACAddress,
+			IPs:  ipAddressObjs,
+		}
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+
+	cloudID := server.ID
+	cloudName := strings.ToLower(server.Name)
+
+	state, ok := openstackStateMap[server.Status]
+	if !ok {
+		state = runtimev1alpha1.Unknown
+	}
+	return utils.GenerateInternalVirtualMachineObject(cloudID, cloudName, cloudID, strings.ToLower(region),
+		namespace, cloudNetworkID, cloudNetworkID, state, tags, networkInterfaces, providerType, account)
+}
+
+// networkToInternalVpcObject converts neutron network object to vpc runtime object.
+func networkToInternalVpcObject(network *networks.Network, cidrs []string, accountNamespace, accountName,
+	region string, managed bool) *runtimev1alpha1.Vpc {
+	if cidrs == nil {
+		cidrs = make([]string, 0)
+	}
+	return utils.GenerateInternalVpcObject(network.ID, accountNamespace, accountName, strings.ToLower(network.Name),
+		network.ID, map[string]string{}, runtimev1alpha1.OpenStackCloudProvider, region, cidrs, managed)
+}
+
+// isServerPort returns true if the neutron port is bound to a nova server.
+func isServerPort(port *ports.Port) bool {
+	return len(port.DeviceID) != 0 && strings.HasPrefix(port.DeviceOwner, "compute:")
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// openstackServerFilter is a client side filter for nova servers. Nova list filters cannot express the neutron
+// network of a server, hence all servers of the project are listed and filtered by nephe.
+// Empty fields of the filter match any value.
+type openstackServerFilter struct {
+	networkID   string
+	networkName string
+	serverID    string
+	name        string
+}
+
+// convertSelectorToServerFilters converts vm selector to openstack server filters.
+func convertSelectorToServerFilters(selector *crdv1alpha1.CloudEntitySelector) ([]*openstackServerFilter, bool) {
+	if selector == nil {
+		return nil, false
+	}
+	if selector.Spec.VMSelector == nil {
+		return nil, true
+	}
+
+	return buildServerFilters(selector.Spec.VMSelector), true
+}
+
+// buildServerFilters builds server filters for VirtualMachineSelector. Each vmMatch section of a vmSelector
+// generates a filter combined with the vpcMatch of the vmSelector.
+func buildServerFilters(vmSelector []crdv1alpha1.VirtualMachineSelector) []*openstackServerFilter {
+	var filters []*openstackServerFilter
+	for _, match := range vmSelector {
+		vpcFilter := openstackServerFilter{}
+		if match.VpcMatch != nil {
+			vpcFilter.networkID = strings.TrimSpace(match.VpcMatch.MatchID)
+			vpcFilter.networkName = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchName))
+		}
+		if len(match.VMMatch) == 0 {
+			filter := vpcFilter
+			filters = append(filters, &filter)
+			continue
+		}
+		for _, vmMatch := range match.VMMatch {
+			filter := vpcFilter
+			filter.serverID = strings.TrimSpace(vmMatch.MatchID)
+			filter.name = strings.ToLower(strings.TrimSpace(vmMatch.MatchName))
+			filters = append(filters, &filter)
+		}
+	}
+	return filters
+}
+
+// matches returns true if the server, attached to given network, matches the filter.
+func (f *openstackServerFilter) matches(server *servers.Server, network *networks.Network) bool {
+	if len(f.serverID) != 0 && f.serverID != server.ID {
+		return false
+	}
+	if len(f.name) != 0 && f.name != strings.ToLower(server.Name) {
+		return false
+	}
+	if len(f.networkID) == 0 && len(f.networkName) == 0 {
+		return true
+	}
+	if network == nil {
+		return false
+	}
+	if len(f.networkID) != 0 && f.networkID != network.ID {
+		return false
+	}
+	if len(f.networkName) != 0 && f.networkName != strings.ToLower(network.Name) {
+		return false
+	}
+	return true
+}
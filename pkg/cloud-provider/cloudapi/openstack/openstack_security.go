@@ -0,0 +1,775 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// Nephe security groups are realized in OpenStack as neutron security groups. Neutron security groups are project wide,
+// hence the network of a nephe security group is encoded in the neutron security group name as
+// <cloud-sg-name>@<network-id>. Address groups referred in rules are realized as remote group rules.
+const (
+	openstackDefaultSecurityGroupName      = "default"
+	openstackSecurityGroupNetworkSeparator = "@"
+	openstackSecurityGroupDescription      = "Managed by nephe controller"
+)
+
+var (
+	mutex sync.Mutex
+
+	openstackAnyProtocolValue rules.RuleProtocol = ""
+)
+
+// neutronSecurityGroup is a neutron security group created by nephe, along with the nephe security group it realizes.
+type neutronSecurityGroup struct {
+	groups.SecGroup
+	cloudSgName    string
+	sgName         string
+	networkID      string
+	membershipOnly bool
+}
+
+// neutronSecurityGroupName returns the name of the neutron security group realizing a nephe security group in network.
+func neutronSecurityGroupName(cloudSgName string, networkID string) string {
+	return cloudSgName + openstackSecurityGroupNetworkSeparator + networkID
+}
+
+// parseNeutronSecurityGroupName returns the nephe cloud security group name and network ID encoded in the name of a
+// neutron security group.
+func parseNeutronSecurityGroupName(name string) (string, string, bool) {
+	idx := strings.LastIndex(name, openstackSecurityGroupNetworkSeparator)
+	if idx <= 0 || idx == len(name)-1 {
+		return "", "", false
+	}
+	return name[:idx], name[idx+1:], true
+}
+
+// newNeutronSecurityGroup returns the nephe view of a neutron security group, nil if it is not created by nephe.
+func newNeutronSecurityGroup(group groups.SecGroup) *neutronSecurityGroup {
+	cloudSgName, networkID, ok := parseNeutronSecurityGroupName(group.Name)
+	if !ok {
+		return nil
+	}
+	sgName, isAG, isAT := securitygroup.IsNepheControllerCreatedSG(cloudSgName)
+	if !isAG && !isAT {
+		return nil
+	}
+	return &neutronSecurityGroup{
+		SecGroup:       group,
+		cloudSgName:    cloudSgName,
+		sgName:         sgName,
+		networkID:      networkID,
+		membershipOnly: isAG,
+	}
+}
+
+// isPortProtocol returns true if neutron security group rules accept port ranges for the protocol.
+func isPortProtocol(protocol int) bool {
+	return protocol == 6 || protocol == 17 || protocol == 132
+}
+
+// isConflictError returns true if the neutron api call failed as the resource already exists.
+func isConflictError(err error) bool {
+	var conflict gophercloud.ErrDefault409
+	return errors.As(err, &conflict)
+}
+
+// isNotFoundError returns true if the neutron api call failed as the resource does not exist.
+func isNotFoundError(err error) bool {
+	var notFound gophercloud.ErrDefault404
+	return errors.As(err, &notFound)
+}
+
+// buildCloudSgNamesFromRules builds all needed cloud security group names from address groups in rules and target
+// appliedTo group.
+func buildCloudSgNamesFromRules(appliedToGroupIdentifier *securitygroup.CloudResourceID,
+	cloudRules []*securitygroup.CloudRule) map[string]struct{} {
+	cloudSgNames := make(map[string]struct{})
+	for _, obj := range cloudRules {
+		var addressGroupIdentifiers []*securitygroup.CloudResourceID
+		switch rule := obj.Rule.(type) {
+		case *securitygroup.IngressRule:
+			addressGroupIdentifiers = rule.FromSecurityGroups
+		case *securitygroup.EgressRule:
+			addressGroupIdentifiers = rule.ToSecurityGroups
+		}
+		for _, addressGroupIdentifier := range addressGroupIdentifiers {
+			cloudSgNames[addressGroupIdentifier.GetCloudName(true)] = struct{}{}
+		}
+	}
+	cloudSgNames[appliedToGroupIdentifier.GetCloudName(false)] = struct{}{}
+	return cloudSgNames
+}
+
+// buildSecurityGroupRules builds neutron security group rules for a cloud rule of the security group. A rule is
+// built per remote ip prefix and per remote group.
+func buildSecurityGroupRules(cloudSgObj *neutronSecurityGroup, obj *securitygroup.CloudRule,
+	cloudSgNameToObj map[string]*neutronSecurityGroup) ([]rules.CreateOpts, error) {
+	if obj.IsDeny() {
+		return nil, fmt.Errorf("deny rules are not supported for openstack security group rules")
+	}
+	description, err := securitygroup.GenerateCloudDescription(obj.NetworkPolicy, cloudSgObj.cloudSgName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate rule description, err: %v", err)
+	}
+
+	base := rules.CreateOpts{
+		SecGroupID:  cloudSgObj.ID,
+		EtherType:   rules.EtherType4,
+		Description: description,
+	}
+	var ips []string
+	var groupIDs []string
+	switch rule := obj.Rule.(type) {
+	case *securitygroup.IngressRule:
+		if rule.ICMPType != nil || rule.ICMPCode != nil {
+			return nil, fmt.Errorf("icmp type and code are not supported for openstack security group rules")
+		}
+		base.Direction = rules.DirIngress
+		base.Protocol = convertToRuleProtocol(rule.Protocol)
+		base.PortRangeMin, base.PortRangeMax = convertToRulePortRange(rule.FromPort, rule.FromEndPort, rule.Protocol)
+		ips = convertToRemoteIPPrefixes(rule.FromSrcIP, len(rule.FromSecurityGroups) > 0)
+		groupIDs = convertToRemoteGroupIDs(rule.FromSecurityGroups, cloudSgNameToObj)
+	case *securitygroup.EgressRule:
+		if rule.ICMPType != nil || rule.ICMPCode != nil {
+			return nil, fmt.Errorf("icmp type and code are not supported for openstack security group rules")
+		}
+		base.Direction = rules.DirEgress
+		base.Protocol = convertToRuleProtocol(rule.Protocol)
+		base.PortRangeMin, base.PortRangeMax = convertToRulePortRange(rule.ToPort, rule.ToEndPort, rule.Protocol)
+		ips = convertToRemoteIPPrefixes(rule.ToDstIP, len(rule.ToSecurityGroups) > 0)
+		groupIDs = convertToRemoteGroupIDs(rule.ToSecurityGroups, cloudSgNameToObj)
+	default:
+		return nil, nil
+	}
+
+	opts := make([]rules.CreateOpts, 0, len(ips)+len(groupIDs))
+	for _, ip := range ips {
+		opt := base
+		opt.RemoteIPPrefix = ip
+		opts = append(opts, opt)
+	}
+	for _, groupID := range groupIDs {
+		opt := base
+		opt.RemoteGroupID = groupID
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
+// securityGroupRuleMatches returns true if the neutron security group rule is the one built from opts.
+func securityGroupRuleMatches(sgRule *rules.SecGroupRule, opts *rules.CreateOpts) bool {
+	return sgRule.Direction == string(opts.Direction) &&
+		sgRule.EtherType == string(opts.EtherType) &&
+		sgRule.PortRangeMin == opts.PortRangeMin &&
+		sgRule.PortRangeMax == opts.PortRangeMax &&
+		sgRule.RemoteIPPrefix == opts.RemoteIPPrefix &&
+		sgRule.RemoteGroupID == opts.RemoteGroupID &&
+		sgRule.Description == opts.Description &&
+		equalProtocol(convertFromRuleProtocol(sgRule.Protocol), convertFromRuleProtocol(string(opts.Protocol)))
+}
+
+func equalProtocol(p1, p2 *int) bool {
+	if p1 == nil || p2 == nil {
+		return p1 == p2
+	}
+	return *p1 == *p2
+}
+
+// getComputeService returns the compute service config of the account managing the cloud resource.
+func (c *openstackCloud) getComputeService(resource *securitygroup.CloudResource) (*computeServiceConfig, error) {
+	accCfg, found := c.cloudCommon.GetCloudAccountByAccountId(&resource.AccountID)
+	if !found {
+		return nil, fmt.Errorf("openstack account not found managing network [%v]", resource.Vpc)
+	}
+	serviceCfg, err := accCfg.GetServiceConfigByName(openstackComputeServiceNameCompute)
+	if err != nil {
+		return nil, err
+	}
+	return serviceCfg.(*computeServiceConfig), nil
+}
+
+// getProjectSecurityGroups gets all neutron security groups of the account project from cloud.
+func (computeCfg *computeServiceConfig) getProjectSecurityGroups() ([]groups.SecGroup, error) {
+	return computeCfg.networkClient.listSecurityGroups(groups.ListOpts{ProjectID: computeCfg.credentials.ProjectID})
+}
+
+// getCloudSecurityGroupsWithName gets nephe created neutron security groups of the network with given cloud names.
+func (computeCfg *computeServiceConfig) getCloudSecurityGroupsWithName(networkID string, cloudSgNames map[string]struct{}) (
+	map[string]*neutronSecurityGroup, error) {
+	allGroups, err := computeCfg.getProjectSecurityGroups()
+	if err != nil {
+		return nil, err
+	}
+	cloudSgNameToObj := make(map[string]*neutronSecurityGroup)
+	for _, group := range allGroups {
+		sgObj := newNeutronSecurityGroup(group)
+		if sgObj == nil || sgObj.networkID != networkID {
+			continue
+		}
+		if _, found := cloudSgNames[sgObj.cloudSgName]; found {
+			cloudSgNameToObj[sgObj.cloudSgName] = sgObj
+		}
+	}
+	return cloudSgNameToObj, nil
+}
+
+func (computeCfg *computeServiceConfig) createOrGetSecurityGroups(networkID string, cloudSgNames map[string]struct{}) (
+	map[string]*neutronSecurityGroup, error) {
+	// for cloudSgs get details from clouds, if they already exist in cloud.
+	cloudSgNameToObj, err := computeCfg.getCloudSecurityGroupsWithName(networkID, cloudSgNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// find the ones which do not exist in cloud and create those
+	created := false
+	for cloudSgName := range cloudSgNames {
+		if _, found := cloudSgNameToObj[cloudSgName]; found {
+			continue
+		}
+		if err := computeCfg.createCloudSecurityGroup(cloudSgName, networkID); err != nil {
+			openstackPluginLogger().Info("Failed to create the security group", "Error", err, "networkID", networkID)
+			return nil, err
+		}
+		created = true
+	}
+
+	// return the up-to-date cloud objects for SGs
+	if !created {
+		openstackPluginLogger().Info("No new security group to be created")
+		return cloudSgNameToObj, nil
+	}
+	return computeCfg.getCloudSecurityGroupsWithName(networkID, cloudSgNames)
+}
+
+func (computeCfg *computeServiceConfig) createCloudSecurityGroup(cloudSgName string, networkID string) error {
+	opts := groups.CreateOpts{
+		Name:        neutronSecurityGroupName(cloudSgName, networkID),
+		Description: openstackSecurityGroupDescription,
+	}
+	group, err := computeCfg.networkClient.createSecurityGroup(opts)
+	if err != nil {
+		return err
+	}
+
+	// Neutron creates security groups with rules allowing all egress traffic, remove them.
+	for _, rule := range group.Rules {
+		if err := computeCfg.networkClient.deleteSecurityGroupRule(rule.ID); err != nil && !isNotFoundError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// realizeSecurityGroupRules invokes cloud api and realizes rules on the neutron security group.
+func (computeCfg *computeServiceConfig) realizeSecurityGroupRules(cloudSgObj *neutronSecurityGroup,
+	cloudRules []*securitygroup.CloudRule, cloudSgNameToObj map[string]*neutronSecurityGroup, isDelete bool) error {
+	var allOpts []rules.CreateOpts
+	for _, obj := range cloudRules {
+		opts, err := buildSecurityGroupRules(cloudSgObj, obj, cloudSgNameToObj)
+		if err != nil {
+			return err
+		}
+		allOpts = append(allOpts, opts...)
+	}
+
+	if isDelete {
+		openstackPluginLogger().V(1).Info("delete rules", "rules", allOpts)
+		for i := range allOpts {
+			for j := range cloudSgObj.Rules {
+				if !securityGroupRuleMatches(&cloudSgObj.Rules[j], &allOpts[i]) {
+					continue
+				}
+				err := computeCfg.networkClient.deleteSecurityGroupRule(cloudSgObj.Rules[j].ID)
+				if err != nil && !isNotFoundError(err) {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	openstackPluginLogger().V(1).Info("add rules", "rules", allOpts)
+	for _, opts := range allOpts {
+		if _, err := computeCfg.networkClient.createSecurityGroupRule(opts); err != nil && !isConflictError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSecurityGroupMembers attaches the neutron security group to ports of member servers and detaches it from
+// ports of other servers in the network.
+func (computeCfg *computeServiceConfig) updateSecurityGroupMembers(cloudSgObj *neutronSecurityGroup,
+	cloudResourceIdentifiers []*securitygroup.CloudResource, membershipOnly bool) error {
+	// find all ports of the network
+	networkPorts, err := computeCfg.networkClient.listPorts(ports.ListOpts{
+		NetworkID: cloudSgObj.networkID,
+		ProjectID: computeCfg.credentials.ProjectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// find nephe created security groups and default sg ID of the project
+	allGroups, err := computeCfg.getProjectSecurityGroups()
+	if err != nil {
+		return err
+	}
+	var defaultSgID string
+	managedSgIDToObj := make(map[string]*neutronSecurityGroup)
+	for _, group := range allGroups {
+		if group.Name == openstackDefaultSecurityGroupName {
+			defaultSgID = group.ID
+		}
+		if sgObj := newNeutronSecurityGroup(group); sgObj != nil {
+			managedSgIDToObj[group.ID] = sgObj
+		}
+	}
+	if len(defaultSgID) == 0 {
+		return fmt.Errorf("default security group not found in project %v", computeCfg.credentials.ProjectID)
+	}
+
+	// find all servers and ports which needs to be attached to SG
+	memberServers, memberPorts := securitygroup.FindResourcesBasedOnKind(cloudResourceIdentifiers)
+
+	// find ports which are using or need to use the provided SG
+	portsToModify := make(map[string]map[string]struct{})
+	for i := range networkPorts {
+		port := &networkPorts[i]
+		// for ports not bound to any servers, skip processing
+		if !isServerPort(port) {
+			continue
+		}
+
+		isGroupSgAttached := false
+		numAppliedToGroupSgsAttached := 0
+		portNepheControllerCreatedCloudSgsSet := make(map[string]struct{})
+		portOtherCloudSgsSet := make(map[string]struct{})
+		for _, sgID := range port.SecurityGroups {
+			sgObj, isManagedSg := managedSgIDToObj[sgID]
+			if !isManagedSg {
+				portOtherCloudSgsSet[sgID] = struct{}{}
+				continue
+			}
+			if !sgObj.membershipOnly {
+				numAppliedToGroupSgsAttached++
+			}
+			if sgID == cloudSgObj.ID {
+				isGroupSgAttached = true
+			}
+			portNepheControllerCreatedCloudSgsSet[sgID] = struct{}{}
+		}
+
+		// if port is owned by any of member servers or member port, its sg needs update
+		_, isPortOfMemberServer := memberServers[strings.ToLower(port.DeviceID)]
+		_, isMemberPort := memberPorts[strings.ToLower(port.ID)]
+		if isGroupSgAttached {
+			if !isPortOfMemberServer && !isMemberPort {
+				delete(portNepheControllerCreatedCloudSgsSet, cloudSgObj.ID)
+
+				portCloudSgsSetToAttach := portNepheControllerCreatedCloudSgsSet
+
+				// If port has only one AT sg attached, and we are processing AT sg to be removed, port will be attached
+				// to default sg along with any attached AG sg(s)
+				if !membershipOnly && numAppliedToGroupSgsAttached == 1 {
+					portCloudSgsSetToAttach[defaultSgID] = struct{}{}
+				}
+				// if port is not attached to AT sg, and we're processing detach from AG sg, keep all sgs. Also, if member-only
+				// address group will be the only sg attached to port, attach default sg along with AG security group.
+				if membershipOnly && numAppliedToGroupSgsAttached == 0 {
+					portCloudSgsSetToAttach = buildNeutronSgsToAttachForCaseMemberOnlySgWithNoATSgAttached(
+						portNepheControllerCreatedCloudSgsSet, portOtherCloudSgsSet, defaultSgID)
+				}
+
+				portsToModify[port.ID] = portCloudSgsSetToAttach
+			}
+		} else {
+			if isPortOfMemberServer || isMemberPort {
+				portNepheControllerCreatedCloudSgsSet[cloudSgObj.ID] = struct{}{}
+
+				portCloudSgsSetToAttach := portNepheControllerCreatedCloudSgsSet
+
+				// if port is not attached to AT sg, and we're processing attach of AG sg, keep all existing sgs. Also,
+				// if AG sg will be the only sg attached to port, attach default sg along with AG sg.
+				if membershipOnly && numAppliedToGroupSgsAttached == 0 {
+					portCloudSgsSetToAttach = buildNeutronSgsToAttachForCaseMemberOnlySgWithNoATSgAttached(
+						portNepheControllerCreatedCloudSgsSet, portOtherCloudSgsSet, defaultSgID)
+				}
+
+				portsToModify[port.ID] = portCloudSgsSetToAttach
+			}
+		}
+	}
+
+	// update port security groups
+	return computeCfg.processPortModifyConcurrently(portsToModify, defaultSgID)
+}
+
+func (computeCfg *computeServiceConfig) processPortModifyConcurrently(portsToModify map[string]map[string]struct{},
+	defaultSgID string) error {
+	ch := make(chan error)
+	var err error
+	var wg sync.WaitGroup
+
+	wg.Add(len(portsToModify))
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	for portID, cloudSgIDSet := range portsToModify {
+		go func(portID string, sgIDSet map[string]struct{}, ch chan error) {
+			defer wg.Done()
+			sgIDs := make([]string, 0, len(sgIDSet))
+			for sgID := range sgIDSet {
+				sgIDs = append(sgIDs, sgID)
+			}
+			if len(sgIDs) == 0 {
+				sgIDs = append(sgIDs, defaultSgID)
+			}
+			ch <- computeCfg.networkClient.updatePortSecurityGroups(portID, sgIDs)
+		}(portID, cloudSgIDSet, ch)
+	}
+	for e := range ch {
+		if e != nil {
+			err = multierr.Append(err, e)
+		}
+	}
+
+	return err
+}
+
+func buildNeutronSgsToAttachForCaseMemberOnlySgWithNoATSgAttached(portNepheControllerCreatedCloudSgsSet map[string]struct{},
+	portOtherCloudSgsSet map[string]struct{}, defaultSgID string) map[string]struct{} {
+	portCloudSgsSet := make(map[string]struct{})
+	// add all nephe created sgs
+	for key, value := range portNepheControllerCreatedCloudSgsSet {
+		portCloudSgsSet[key] = value
+	}
+	// add all others sgs
+	for key, value := range portOtherCloudSgsSet {
+		portCloudSgsSet[key] = value
+	}
+	// add project default sg id, if port is going to have all member-only sgs.
+	if len(portOtherCloudSgsSet) == 0 {
+		portCloudSgsSet[defaultSgID] = struct{}{}
+	}
+
+	return portCloudSgsSet
+}
+
+// getNepheControllerManagedSecurityGroupsCloudView returns the neutron security groups realized by nephe.
+func (computeCfg *computeServiceConfig) getNepheControllerManagedSecurityGroupsCloudView() []securitygroup.SynchronizationContent {
+	networkIDs := computeCfg.getManagedNetworkIDs()
+	if len(networkIDs) == 0 {
+		return []securitygroup.SynchronizationContent{}
+	}
+
+	allPorts, err := computeCfg.networkClient.listPorts(ports.ListOpts{ProjectID: computeCfg.credentials.ProjectID})
+	if err != nil {
+		openstackPluginLogger().Error(err, "failed to get ports", "account", computeCfg.accountNamespacedName)
+		return []securitygroup.SynchronizationContent{}
+	}
+	allGroups, err := computeCfg.getProjectSecurityGroups()
+	if err != nil {
+		openstackPluginLogger().Error(err, "failed to get security groups", "account", computeCfg.accountNamespacedName)
+		return []securitygroup.SynchronizationContent{}
+	}
+	managedSgIDToObj := make(map[string]*neutronSecurityGroup)
+	for _, group := range allGroups {
+		if sgObj := newNeutronSecurityGroup(group); sgObj != nil {
+			managedSgIDToObj[group.ID] = sgObj
+		}
+	}
+
+	// find all member ports for managed security groups.
+	// also find all member ports attached to non nephe created sgs.
+	managedSgIDToMemberCloudResourcesMap := make(map[string][]securitygroup.CloudResource)
+	memberCloudResourcesWithOtherSGsAttachedMap := make(map[string]struct{})
+	for i := range allPorts {
+		port := &allPorts[i]
+		if _, ok := networkIDs[port.NetworkID]; !ok || !isServerPort(port) {
+			continue
+		}
+		isAttachedToOtherSG := false
+		isAttachedToNepheControllerSG := false
+		for _, sgID := range port.SecurityGroups {
+			if _, isManagedSg := managedSgIDToObj[sgID]; !isManagedSg {
+				isAttachedToOtherSG = true
+				continue
+			}
+			cloudResource := securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeNIC,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: port.ID,
+					Vpc:  port.NetworkID,
+				},
+				AccountID:     computeCfg.accountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.OpenStackCloudProvider),
+			}
+			managedSgIDToMemberCloudResourcesMap[sgID] = append(managedSgIDToMemberCloudResourcesMap[sgID], cloudResource)
+			isAttachedToNepheControllerSG = true
+		}
+
+		if isAttachedToNepheControllerSG && isAttachedToOtherSG {
+			memberCloudResourcesWithOtherSGsAttachedMap[port.ID] = struct{}{}
+		}
+	}
+
+	// build sync objects for managed security groups
+	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
+	for sgID, cloudSgObj := range managedSgIDToObj {
+		if _, ok := networkIDs[cloudSgObj.networkID]; !ok {
+			continue
+		}
+
+		// find members and membersAttachedToOtherSGs
+		var membersWithOtherSGAttached []securitygroup.CloudResource
+		members, found := managedSgIDToMemberCloudResourcesMap[sgID]
+		if found {
+			membersWithOtherSGAttached = getMemberNicCloudResourcesAttachedToOtherSGs(members, memberCloudResourcesWithOtherSGsAttachedMap)
+		}
+
+		// build ingress and egress rules
+		inRules, egRules := convertFromSecurityGroupRules(cloudSgObj.Rules, managedSgIDToObj)
+
+		groupSyncObj := securitygroup.SynchronizationContent{
+			Resource: securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: cloudSgObj.sgName,
+					Vpc:  cloudSgObj.networkID,
+				},
+				AccountID:     computeCfg.accountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.OpenStackCloudProvider),
+			},
+			MembershipOnly:             cloudSgObj.membershipOnly,
+			Members:                    members,
+			MembersWithOtherSGAttached: membersWithOtherSGAttached,
+			IngressRules:               inRules,
+			EgressRules:                egRules,
+		}
+		enforcedSecurityCloudView = append(enforcedSecurityCloudView, groupSyncObj)
+	}
+
+	return enforcedSecurityCloudView
+}
+
+func getMemberNicCloudResourcesAttachedToOtherSGs(members []securitygroup.CloudResource,
+	memberNicsAttachedToOtherSGs map[string]struct{}) []securitygroup.CloudResource {
+	var nicCloudResources []securitygroup.CloudResource
+	for _, member := range members {
+		if _, found := memberNicsAttachedToOtherSGs[member.Name]; !found {
+			continue
+		}
+		nicCloudResources = append(nicCloudResources, member)
+	}
+	return nicCloudResources
+}
+
+// ////////////////////////////////////////////////////////
+//
+//	SecurityInterface Implementation
+//
+// ////////////////////////////////////////////////////////.
+
+// CreateSecurityGroup invokes cloud api and creates the neutron security group based on securityGroupIdentifier.
+func (c *openstackCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) (*string, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudSgName := securityGroupIdentifier.GetCloudName(membershipOnly)
+	resp, err := computeService.createOrGetSecurityGroups(securityGroupIdentifier.Vpc, map[string]struct{}{cloudSgName: {}})
+	if err != nil {
+		return nil, err
+	}
+	cloudSgObj, found := resp[cloudSgName]
+	if !found {
+		return nil, fmt.Errorf("failed to find security group %v in network %v", cloudSgName, securityGroupIdentifier.Vpc)
+	}
+	return &cloudSgObj.ID, nil
+}
+
+// UpdateSecurityGroupRules invokes cloud api and updates neutron security group with addRules and rmRules.
+func (c *openstackCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygroup.CloudResource,
+	addRules, rmRules, _ []*securitygroup.CloudRule) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(appliedToGroupIdentifier)
+	if err != nil {
+		return err
+	}
+
+	// make sure all required security groups pre-exist
+	cloudSgNames := buildCloudSgNamesFromRules(&appliedToGroupIdentifier.CloudResourceID, append(addRules, rmRules...))
+	cloudSgNameToObj, err := computeService.getCloudSecurityGroupsWithName(appliedToGroupIdentifier.Vpc, cloudSgNames)
+	if err != nil {
+		return err
+	}
+	if len(cloudSgNameToObj) != len(cloudSgNames) {
+		return fmt.Errorf("failed to find security groups")
+	}
+	cloudSgObj := cloudSgNameToObj[appliedToGroupIdentifier.GetCloudName(false)]
+
+	if err = computeService.realizeSecurityGroupRules(cloudSgObj, rmRules, cloudSgNameToObj, true); err != nil {
+		return err
+	}
+	if err = computeService.realizeSecurityGroupRules(cloudSgObj, addRules, cloudSgNameToObj, false); err != nil {
+		// rollback removed rules for cloud api failures.
+		_ = computeService.realizeSecurityGroupRules(cloudSgObj, rmRules, cloudSgNameToObj, false)
+		return err
+	}
+	return nil
+}
+
+// UpdateSecurityGroupMembers invokes cloud api and attaches/detaches ports to/from the neutron security group.
+func (c *openstackCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securitygroup.CloudResource,
+	cloudResourceIdentifiers []*securitygroup.CloudResource, membershipOnly bool) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return err
+	}
+
+	cloudSgName := securityGroupIdentifier.GetCloudName(membershipOnly)
+	out, err := computeService.getCloudSecurityGroupsWithName(securityGroupIdentifier.Vpc, map[string]struct{}{cloudSgName: {}})
+	if err != nil {
+		return err
+	}
+	cloudSgObj, found := out[cloudSgName]
+	if !found {
+		return fmt.Errorf("failed to find cloud sg (%v) corresponding to address group (%v)",
+			cloudSgName, securityGroupIdentifier.Name)
+	}
+
+	return computeService.updateSecurityGroupMembers(cloudSgObj, cloudResourceIdentifiers, membershipOnly)
+}
+
+// DeleteSecurityGroup invokes cloud api and deletes the neutron security group. Any attached port will be moved to
+// default sg.
+func (c *openstackCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return err
+	}
+
+	// check if sg exists in cloud and get its cloud sg id to delete
+	cloudSgNameToDelete := securityGroupIdentifier.GetCloudName(membershipOnly)
+	out, err := computeService.getCloudSecurityGroupsWithName(securityGroupIdentifier.Vpc,
+		map[string]struct{}{cloudSgNameToDelete: {}})
+	if err != nil || len(out) == 0 {
+		return err
+	}
+	cloudSgObj := out[cloudSgNameToDelete]
+
+	// Detach security group from ports before deleting.
+	if err = computeService.updateSecurityGroupMembers(cloudSgObj, nil, membershipOnly); err != nil {
+		return err
+	}
+	if err = computeService.networkClient.deleteSecurityGroup(cloudSgObj.ID); err != nil && !isNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *openstackCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	inventoryInitWaitDuration := 30 * time.Second
+
+	var accNamespacedNames []types.NamespacedName
+	accountConfigs := c.cloudCommon.GetCloudAccounts()
+	for _, accCfg := range accountConfigs {
+		accNamespacedNames = append(accNamespacedNames, *accCfg.GetNamespacedName())
+	}
+
+	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
+	var wg sync.WaitGroup
+	ch := make(chan []securitygroup.SynchronizationContent)
+	wg.Add(len(accNamespacedNames))
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	for _, accNamespacedName := range accNamespacedNames {
+		accNamespacedNameCopy := &types.NamespacedName{
+			Namespace: accNamespacedName.Namespace,
+			Name:      accNamespacedName.Name,
+		}
+
+		go func(name *types.NamespacedName, sendCh chan<- []securitygroup.SynchronizationContent) {
+			defer wg.Done()
+
+			accCfg, found := c.cloudCommon.GetCloudAccountByName(name)
+			if !found {
+				openstackPluginLogger().Info("enforced-security-cloud-view GET for account skipped (account no longer exists)",
+					"account", name)
+				return
+			}
+
+			serviceCfg, err := accCfg.GetServiceConfigByName(openstackComputeServiceNameCompute)
+			if err != nil {
+				openstackPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
+				return
+			}
+			computeService := serviceCfg.(*computeServiceConfig)
+			err = computeService.waitForInventoryInit(inventoryInitWaitDuration)
+			if err != nil {
+				openstackPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account", accCfg.GetNamespacedName())
+				return
+			}
+			sendCh <- computeService.getNepheControllerManagedSecurityGroupsCloudView()
+		}(accNamespacedNameCopy, ch)
+	}
+
+	for val := range ch {
+		if val != nil {
+			enforcedSecurityCloudView = append(enforcedSecurityCloudView, val...)
+		}
+	}
+	return enforcedSecurityCloudView
+}
+
+// GetSecurityGroupLimits returns no limits, as neutron limits security group rules by project quota only.
+func (c *openstackCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{}
+}
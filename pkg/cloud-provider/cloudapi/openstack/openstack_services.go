@@ -0,0 +1,96 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"k8s.io/apimachinery/pkg/types"
+
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+const (
+	openstackComputeServiceNameCompute = internal.CloudServiceName("Nova")
+)
+
+// openstackServiceClientCreateInterface provides interface to create openstack service clients.
+type openstackServiceClientCreateInterface interface {
+	compute() (openstackComputeWrapper, error)
+	network() (openstackNetworkWrapper, error)
+	// Add any openstack service (like octavia, designate etc) apiClient creation methods here
+}
+
+// openstackServiceSdkConfigProvider provides config required to create openstack service (nova, neutron) clients.
+// Implements openstackServiceClientCreateInterface interface.
+// NOTE: Currently supporting only keystone v3 password based clients.
+type openstackServiceSdkConfigProvider struct {
+	providerClient *gophercloud.ProviderClient
+	endpointOpts   gophercloud.EndpointOpts
+}
+
+// openstackServicesHelper.
+type openstackServicesHelper interface {
+	newServiceSdkConfigProvider(accCfg *openstackAccountConfig) (openstackServiceClientCreateInterface, error)
+}
+
+type openstackServicesHelperImpl struct{}
+
+// newServiceSdkConfigProvider authenticates with keystone and returns config to create openstack services clients.
+func (h *openstackServicesHelperImpl) newServiceSdkConfigProvider(accConfig *openstackAccountConfig) (
+	openstackServiceClientCreateInterface, error) {
+	authOptions := gophercloud.AuthOptions{
+		IdentityEndpoint: accConfig.authURL,
+		Username:         accConfig.Username,
+		Password:         accConfig.Password,
+		DomainName:       accConfig.DomainName,
+		Scope: &gophercloud.AuthScope{
+			ProjectID: accConfig.ProjectID,
+		},
+		AllowReauth: true,
+	}
+	providerClient, err := openstack.AuthenticatedClient(authOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize OpenStack client: %v", err)
+	}
+	configProvider := &openstackServiceSdkConfigProvider{
+		providerClient: providerClient,
+		endpointOpts:   gophercloud.EndpointOpts{Region: accConfig.region},
+	}
+	return configProvider, nil
+}
+
+func newOpenStackServiceConfigs(accountNamespacedName *types.NamespacedName, accCredentials interface{},
+	openstackSpecificHelper interface{}) ([]internal.CloudServiceInterface, error) {
+	openstackServicesHelper := openstackSpecificHelper.(openstackServicesHelper)
+	openstackAccountCredentials := accCredentials.(*openstackAccountConfig)
+
+	var serviceConfigs []internal.CloudServiceInterface
+
+	openstackServiceClientCreator, err := openstackServicesHelper.newServiceSdkConfigProvider(openstackAccountCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	computeService, err := newComputeServiceConfig(*accountNamespacedName, openstackServiceClientCreator, openstackAccountCredentials)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfigs = append(serviceConfigs, computeService)
+
+	return serviceConfigs, nil
+}
@@ -0,0 +1,446 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+const (
+	testNetworkID   = "net-0001"
+	testProjectID   = "project01"
+	testDefaultSgID = "sg-default"
+	testCredentials = `{"username": "admin", "password": "secret", "domainName": "Default", "projectId": "project01"}`
+)
+
+var _ = Describe("OpenStack cloud", func() {
+	var (
+		testAccountNamespacedName = types.NamespacedName{Namespace: "namespace01", Name: "account01"}
+		credentials               = "credentials"
+
+		account                  *v1alpha1.CloudProviderAccount
+		selector                 *v1alpha1.CloudEntitySelector
+		secret                   *corev1.Secret
+		fakeClient               client.WithWatch
+		mockCtrl                 *gomock.Controller
+		mockopenstackCloudHelper *MockopenstackServicesHelper
+		mockopenstackService     *MockopenstackServiceClientCreateInterface
+		mockopenstackCompute     *MockopenstackComputeWrapper
+		mockopenstackNetwork     *MockopenstackNetworkWrapper
+	)
+
+	BeforeEach(func() {
+		var pollIntv uint = 1
+		account = &v1alpha1.CloudProviderAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudProviderAccountSpec{
+				PollIntervalInSeconds: &pollIntv,
+				OpenStackConfig: &v1alpha1.CloudProviderAccountOpenStackConfig{
+					AuthURL: "https://keystone.example.com:5000/v3",
+					Region:  "RegionOne",
+					SecretRef: &v1alpha1.SecretReference{
+						Name:      testAccountNamespacedName.Name,
+						Namespace: testAccountNamespacedName.Namespace,
+						Key:       credentials,
+					},
+				},
+			},
+		}
+		selector = &v1alpha1.CloudEntitySelector{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "selector-all",
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudEntitySelectorSpec{
+				AccountName: testAccountNamespacedName.Name,
+				VMSelector:  []v1alpha1.VirtualMachineSelector{},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Data: map[string][]byte{
+				credentials: []byte(testCredentials),
+			},
+		}
+		fakeClient = fake.NewClientBuilder().Build()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockopenstackCloudHelper = NewMockopenstackServicesHelper(mockCtrl)
+		mockopenstackService = NewMockopenstackServiceClientCreateInterface(mockCtrl)
+		mockopenstackCompute = NewMockopenstackComputeWrapper(mockCtrl)
+		mockopenstackNetwork = NewMockopenstackNetworkWrapper(mockCtrl)
+
+		mockopenstackCloudHelper.EXPECT().newServiceSdkConfigProvider(gomock.Any()).Return(mockopenstackService, nil).AnyTimes()
+		mockopenstackService.EXPECT().compute().Return(mockopenstackCompute, nil).AnyTimes()
+		mockopenstackService.EXPECT().network().Return(mockopenstackNetwork, nil).AnyTimes()
+		mockopenstackNetwork.EXPECT().listNetworks().Return(getNetworkObjects(), nil).AnyTimes()
+		mockopenstackNetwork.EXPECT().listSubnets().Return(getSubnetObjects(), nil).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	addAccount := func() *openstackCloud {
+		_ = fakeClient.Create(context.Background(), secret)
+		c := newOpenStackCloud(mockopenstackCloudHelper)
+		err := c.AddProviderAccount(fakeClient, account)
+		Expect(err).Should(BeNil())
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+		Expect(found).To(BeTrue())
+		Expect(accCfg).To(Not(BeNil()))
+		return c
+	}
+
+	Context("AddProviderAccount", func() {
+		It("On account add expect cloud api call for retrieving network list", func() {
+			mockopenstackNetwork.EXPECT().listPorts(ports.ListOpts{ProjectID: testProjectID}).Return(nil, nil).AnyTimes()
+			mockopenstackCompute.EXPECT().listServers().Times(0)
+
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap).Should(HaveLen(1))
+			Expect(vpcMap).Should(HaveKey(testNetworkID))
+			Expect(vpcMap[testNetworkID].Status.Cidrs).Should(Equal([]string{"10.0.0.0/24"}))
+		})
+		It("Should discover few servers with get ALL selector", func() {
+			serverIDs := []string{"101", "102"}
+			mockopenstackNetwork.EXPECT().listPorts(gomock.Any()).Return(getPortObjects(serverIDs, nil), nil).AnyTimes()
+			mockopenstackCompute.EXPECT().listServers().Return(getServerObjects(serverIDs), nil).AnyTimes()
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			err = checkAccountAddSuccessCondition(c, testAccountNamespacedName, serverIDs)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(len(serverIDs)))
+			for _, vm := range vms {
+				Expect(vm.Status.CloudVpcId).Should(Equal(testNetworkID))
+				Expect(vm.Status.Region).Should(Equal("regionone"))
+				Expect(vm.Status.NetworkInterfaces).Should(HaveLen(1))
+			}
+		})
+		It("Should discover servers matching vm name selector", func() {
+			serverIDs := []string{"101", "102"}
+			mockopenstackNetwork.EXPECT().listPorts(gomock.Any()).Return(getPortObjects(serverIDs, nil), nil).AnyTimes()
+			mockopenstackCompute.EXPECT().listServers().Return(getServerObjects(serverIDs), nil).AnyTimes()
+			selector.Spec.VMSelector = []v1alpha1.VirtualMachineSelector{
+				{
+					VMMatch: []v1alpha1.EntityMatch{{MatchName: "vm-102"}},
+				},
+			}
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			err = checkAccountAddSuccessCondition(c, testAccountNamespacedName, []string{"102"})
+			Expect(err).Should(BeNil())
+		})
+	})
+
+	Context("SecurityInterface", func() {
+		var (
+			c            *openstackCloud
+			atIdentifier *securitygroup.CloudResource
+			agIdentifier *securitygroup.CloudResource
+			atSg         groups.SecGroup
+			agSg         groups.SecGroup
+			defaultSg    groups.SecGroup
+			portSgIDs    []string
+		)
+
+		BeforeEach(func() {
+			serverIDs := []string{"101", "102"}
+			portSgIDs = []string{testDefaultSgID}
+			mockopenstackNetwork.EXPECT().listPorts(ports.ListOpts{ProjectID: testProjectID}).
+				DoAndReturn(func(_ ports.ListOpts) ([]ports.Port, error) {
+					return getPortObjects(serverIDs, portSgIDs), nil
+				}).AnyTimes()
+			mockopenstackCompute.EXPECT().listServers().Return(getServerObjects(serverIDs), nil).AnyTimes()
+
+			c = addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			atIdentifier = &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "at-sg", Vpc: testNetworkID},
+				AccountID:       testAccountNamespacedName.String(),
+			}
+			agIdentifier = &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "ag-sg", Vpc: testNetworkID},
+				AccountID:       testAccountNamespacedName.String(),
+			}
+			atSg = groups.SecGroup{ID: "sg-at", Name: neutronSecurityGroupName(atIdentifier.GetCloudName(false), testNetworkID)}
+			agSg = groups.SecGroup{ID: "sg-ag", Name: neutronSecurityGroupName(agIdentifier.GetCloudName(true), testNetworkID)}
+			defaultSg = groups.SecGroup{ID: testDefaultSgID, Name: openstackDefaultSecurityGroupName}
+		})
+
+		It("Should create security group and remove its default rules", func() {
+			created := atSg
+			created.Rules = []rules.SecGroupRule{{ID: "rule-egress-v4"}, {ID: "rule-egress-v6"}}
+			gomock.InOrder(
+				mockopenstackNetwork.EXPECT().listSecurityGroups(groups.ListOpts{ProjectID: testProjectID}).
+					Return([]groups.SecGroup{defaultSg}, nil).Times(1),
+				mockopenstackNetwork.EXPECT().listSecurityGroups(groups.ListOpts{ProjectID: testProjectID}).
+					Return([]groups.SecGroup{defaultSg, atSg}, nil).Times(1),
+			)
+			mockopenstackNetwork.EXPECT().createSecurityGroup(gomock.Any()).DoAndReturn(func(opts groups.CreateOpts) (*groups.SecGroup, error) {
+				Expect(opts.Name).Should(Equal(atSg.Name))
+				return &created, nil
+			}).Times(1)
+			mockopenstackNetwork.EXPECT().deleteSecurityGroupRule("rule-egress-v4").Return(nil).Times(1)
+			mockopenstackNetwork.EXPECT().deleteSecurityGroupRule("rule-egress-v6").Return(nil).Times(1)
+
+			sgID, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			Expect(*sgID).Should(Equal(atSg.ID))
+		})
+		It("Should not create security group that already exists", func() {
+			mockopenstackNetwork.EXPECT().listSecurityGroups(gomock.Any()).Return([]groups.SecGroup{defaultSg, agSg}, nil).Times(1)
+			mockopenstackNetwork.EXPECT().createSecurityGroup(gomock.Any()).Times(0)
+
+			sgID, err := c.CreateSecurityGroup(agIdentifier, true)
+			Expect(err).Should(BeNil())
+			Expect(*sgID).Should(Equal(agSg.ID))
+		})
+		It("Should create remote group rule for address group", func() {
+			_, ipNet, _ := net.ParseCIDR("10.0.0.0/24")
+			port := 22
+			protocol := 6
+			rule := &securitygroup.CloudRule{
+				Rule: &securitygroup.IngressRule{
+					FromPort:           &port,
+					FromSrcIP:          []*net.IPNet{ipNet},
+					FromSecurityGroups: []*securitygroup.CloudResourceID{&agIdentifier.CloudResourceID},
+					Protocol:           &protocol,
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			rule.Hash = rule.GetHash()
+			mockopenstackNetwork.EXPECT().listSecurityGroups(gomock.Any()).Return([]groups.SecGroup{defaultSg, atSg, agSg}, nil).Times(1)
+
+			var created []rules.CreateOpts
+			mockopenstackNetwork.EXPECT().createSecurityGroupRule(gomock.Any()).DoAndReturn(
+				func(opts rules.CreateOpts) (*rules.SecGroupRule, error) {
+					created = append(created, opts)
+					return &rules.SecGroupRule{}, nil
+				}).Times(2)
+
+			err := c.UpdateSecurityGroupRules(atIdentifier, []*securitygroup.CloudRule{rule}, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(created[0].SecGroupID).Should(Equal(atSg.ID))
+			Expect(created[0].Direction).Should(Equal(rules.DirIngress))
+			Expect(created[0].RemoteIPPrefix).Should(Equal("10.0.0.0/24"))
+			Expect(created[0].PortRangeMin).Should(Equal(22))
+			Expect(created[0].PortRangeMax).Should(Equal(22))
+			Expect(created[1].RemoteGroupID).Should(Equal(agSg.ID))
+			Expect(created[1].RemoteIPPrefix).Should(BeEmpty())
+		})
+		It("Should fail rule update when address group does not exist", func() {
+			rule := &securitygroup.CloudRule{
+				Rule: &securitygroup.EgressRule{
+					ToSecurityGroups: []*securitygroup.CloudResourceID{&agIdentifier.CloudResourceID},
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			rule.Hash = rule.GetHash()
+			mockopenstackNetwork.EXPECT().listSecurityGroups(gomock.Any()).Return([]groups.SecGroup{defaultSg, atSg}, nil).Times(1)
+			mockopenstackNetwork.EXPECT().createSecurityGroupRule(gomock.Any()).Times(0)
+
+			err := c.UpdateSecurityGroupRules(atIdentifier, []*securitygroup.CloudRule{rule}, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should attach security group to ports of member servers", func() {
+			members := []*securitygroup.CloudResource{
+				{
+					Type:            securitygroup.CloudResourceTypeVM,
+					CloudResourceID: securitygroup.CloudResourceID{Name: "101", Vpc: testNetworkID},
+				},
+			}
+			mockopenstackNetwork.EXPECT().listSecurityGroups(gomock.Any()).Return([]groups.SecGroup{defaultSg, atSg}, nil).Times(2)
+			mockopenstackNetwork.EXPECT().listPorts(ports.ListOpts{NetworkID: testNetworkID, ProjectID: testProjectID}).
+				Return(getPortObjects([]string{"101", "102"}, []string{testDefaultSgID}), nil).Times(1)
+			mockopenstackNetwork.EXPECT().updatePortSecurityGroups("port-101", []string{atSg.ID}).Return(nil).Times(1)
+
+			err := c.UpdateSecurityGroupMembers(atIdentifier, members, false)
+			Expect(err).Should(BeNil())
+		})
+		It("Should report enforced security from neutron security groups", func() {
+			description, _ := securitygroup.GenerateCloudDescription("namespace01/anp01", atIdentifier.GetCloudName(false))
+			at := atSg
+			at.Rules = []rules.SecGroupRule{
+				{
+					ID:             "rule01",
+					Direction:      string(rules.DirIngress),
+					EtherType:      string(rules.EtherType4),
+					Protocol:       "6",
+					PortRangeMin:   22,
+					PortRangeMax:   22,
+					RemoteIPPrefix: "10.0.0.0/24",
+					Description:    description,
+				},
+				{
+					ID:            "rule02",
+					Direction:     string(rules.DirIngress),
+					EtherType:     string(rules.EtherType4),
+					Protocol:      "tcp",
+					PortRangeMin:  22,
+					PortRangeMax:  22,
+					RemoteGroupID: agSg.ID,
+					Description:   description,
+				},
+			}
+			mockopenstackNetwork.EXPECT().listSecurityGroups(gomock.Any()).Return([]groups.SecGroup{defaultSg, at, agSg}, nil).Times(1)
+			portSgIDs = []string{at.ID, testDefaultSgID}
+
+			enforced := c.GetEnforcedSecurity()
+			Expect(enforced).Should(HaveLen(2))
+			sort.Slice(enforced, func(i, j int) bool {
+				return enforced[i].Resource.Name < enforced[j].Resource.Name
+			})
+			Expect(enforced[0].Resource.Name).Should(Equal(agIdentifier.Name))
+			Expect(enforced[0].MembershipOnly).Should(BeTrue())
+			Expect(enforced[1].Resource.Name).Should(Equal(atIdentifier.Name))
+			Expect(enforced[1].MembershipOnly).Should(BeFalse())
+			Expect(enforced[1].Members).Should(HaveLen(2))
+			Expect(enforced[1].MembersWithOtherSGAttached).Should(HaveLen(2))
+			Expect(enforced[1].IngressRules).Should(HaveLen(2))
+			Expect(*enforced[1].IngressRules[1].Protocol).Should(Equal(6))
+			Expect(enforced[1].IngressRules[1].FromSecurityGroups[0].Name).Should(Equal(agIdentifier.Name))
+			Expect(enforced[1].EgressRules).Should(BeEmpty())
+		})
+	})
+})
+
+func getNetworkObjects() []networks.Network {
+	return []networks.Network{
+		{
+			ID:      testNetworkID,
+			Name:    "net01",
+			Subnets: []string{"subnet-0001"},
+		},
+	}
+}
+
+func getSubnetObjects() []subnets.Subnet {
+	return []subnets.Subnet{
+		{
+			ID:        "subnet-0001",
+			NetworkID: testNetworkID,
+			CIDR:      "10.0.0.0/24",
+		},
+	}
+}
+
+func getServerObjects(serverIDs []string) []servers.Server {
+	var serverList []servers.Server
+	for _, id := range serverIDs {
+		serverList = append(serverList, servers.Server{
+			ID:       id,
+			Name:     "vm-" + id,
+			TenantID: testProjectID,
+			Status:   "ACTIVE",
+			Metadata: map[string]string{"app": "web"},
+		})
+	}
+	return serverList
+}
+
+func getPortObjects(serverIDs []string, sgIDs []string) []ports.Port {
+	var portList []ports.Port
+	for i, id := range serverIDs {
+		portList = append(portList, ports.Port{
+			ID:             "port-" + id,
+			NetworkID:      testNetworkID,
+			MACAddress:     "fa:16:3e:00:00:" + strconv.Itoa(10+i),
+			DeviceID:       id,
+			DeviceOwner:    "compute:nova",
+			FixedIPs:       []ports.IP{{SubnetID: "subnet-0001", IPAddress: "10.0.0." + strconv.Itoa(i+1)}},
+			SecurityGroups: sgIDs,
+		})
+	}
+	return portList
+}
+
+func checkAccountAddSuccessCondition(c *openstackCloud, namespacedName types.NamespacedName, ids []string) error {
+	conditionFunc := func() (done bool, e error) {
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&namespacedName)
+		if !found {
+			return true, errors.New("failed to find account")
+		}
+
+		serviceConfig, _ := accCfg.GetServiceConfigByName(openstackComputeServiceNameCompute)
+		cachedServers := serviceConfig.(*computeServiceConfig).getCachedServers()
+		serverIDs := make([]string, 0, len(cachedServers))
+		for _, server := range cachedServers {
+			serverIDs = append(serverIDs, server.ID)
+		}
+
+		sort.Strings(serverIDs)
+		sort.Strings(ids)
+		return reflect.DeepEqual(serverIDs, ids), nil
+	}
+
+	return wait.PollImmediate(1*time.Second, 5*time.Second, conditionFunc)
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	pluginv1alpha1 "antrea.io/nephe/apis/plugin/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/credentialsource"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+	"antrea.io/nephe/pkg/config"
+	"antrea.io/nephe/pkg/logging"
+)
+
+var pluginLogger = func() logging.Logger {
+	return logging.GetLogger("grpc-plugin")
+}
+
+// pluginCloud implements CloudInterface by forwarding calls to an out-of-tree cloud provider plugin over gRPC.
+type pluginCloud struct {
+	providerType cloudcommon.ProviderType
+	client       pluginv1alpha1.CloudProviderClient
+	timeout      time.Duration
+}
+
+// newPluginCloud creates a new instance of pluginCloud.
+func newPluginCloud(providerType cloudcommon.ProviderType, conn grpc.ClientConnInterface, timeout time.Duration) *pluginCloud {
+	return &pluginCloud{
+		providerType: providerType,
+		client:       pluginv1alpha1.NewCloudProviderClient(conn),
+		timeout:      timeout,
+	}
+}
+
+// Register creates a pluginCloud object for the cloud provider plugin served at address, either a unix domain socket,
+// or a TCP address served with mutual TLS configured by tlsConfig. The connection to the plugin is established
+// lazily, hence the plugin may start after nephe-controller.
+func Register(providerType cloudcommon.ProviderType, address string, tlsConfig *config.CloudProviderPluginTLSConfig,
+	timeout time.Duration) (cloudcommon.CloudInterface, error) {
+	creds, err := clientCredentials(address, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transport of cloud provider plugin %v: %v", providerType, err)
+	}
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect cloud provider plugin %v at %v: %v", providerType, address, err)
+	}
+	return newPluginCloud(providerType, conn, timeout), nil
+}
+
+// call calls method of the plugin with the call timeout, and converts gRPC errors raised by the plugin to plain
+// errors.
+func (c *pluginCloud) call(method string, invoke func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	err := invoke(ctx)
+	if err == nil {
+		return nil
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unknown {
+		return errors.New(s.Message())
+	}
+	return fmt.Errorf("cloud provider plugin %v %v failed: %v", c.providerType, method, err)
+}
+
+// ProviderType returns the cloud provider type served by the plugin.
+func (c *pluginCloud) ProviderType() cloudcommon.ProviderType {
+	return c.providerType
+}
+
+// GetCapabilities returns the network policy features the plugin can realize, or no capabilities if the plugin
+// fails to respond.
+func (c *pluginCloud) GetCapabilities() crdv1alpha1.CloudCapabilities {
+	var resp *pluginv1alpha1.CapabilitiesResponse
+	if err := c.call("GetCapabilities", func(ctx context.Context) (err error) {
+		resp, err = c.client.GetCapabilities(ctx, &pluginv1alpha1.Empty{})
+		return err
+	}); err != nil {
+		pluginLogger().Error(err, "capabilities GET failed", "provider", c.providerType)
+		return crdv1alpha1.CloudCapabilities{}
+	}
+	return convertFromWireCapabilities(resp.GetCapabilities())
+}
+
+// ////////////////////////////////////////////////////////
+//
+//	AccountMgmtInterface Implementation
+//
+// ////////////////////////////////////////////////////////.
+
+// AddProviderAccount resolves account credentials and adds the account to the plugin.
+func (c *pluginCloud) AddProviderAccount(client client.Client, account *crdv1alpha1.CloudProviderAccount) error {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+	req := &pluginv1alpha1.AddProviderAccountRequest{Account: data}
+	if account.Spec.PluginConfig != nil && account.Spec.PluginConfig.SecretRef != nil {
+		credentials, _, err := credentialsource.NewSecretSource(client, account.Spec.PluginConfig.SecretRef).Retrieve()
+		if err != nil {
+			return err
+		}
+		req.Credentials = credentials
+	}
+	return c.call("AddProviderAccount", func(ctx context.Context) error {
+		_, err := c.client.AddProviderAccount(ctx, req)
+		return err
+	})
+}
+
+// RemoveProviderAccount removes the account from the plugin.
+func (c *pluginCloud) RemoveProviderAccount(namespacedName *types.NamespacedName) {
+	req := &pluginv1alpha1.AccountRequest{Account: convertToWireNamespacedName(namespacedName)}
+	if err := c.call("RemoveProviderAccount", func(ctx context.Context) error {
+		_, err := c.client.RemoveProviderAccount(ctx, req)
+		return err
+	}); err != nil {
+		pluginLogger().Error(err, "failed to remove account", "provider", c.providerType, "account", namespacedName)
+	}
+}
+
+// AddAccountResourceSelector adds account specific resource selector.
+func (c *pluginCloud) AddAccountResourceSelector(accNamespacedName *types.NamespacedName,
+	selector *crdv1alpha1.CloudEntitySelector) error {
+	data, err := json.Marshal(selector)
+	if err != nil {
+		return err
+	}
+	req := &pluginv1alpha1.AccountResourceSelectorRequest{Account: convertToWireNamespacedName(accNamespacedName), Selector: data}
+	return c.call("AddAccountResourceSelector", func(ctx context.Context) error {
+		_, err := c.client.AddAccountResourceSelector(ctx, req)
+		return err
+	})
+}
+
+// RemoveAccountResourcesSelector removes account specific resource selector.
+func (c *pluginCloud) RemoveAccountResourcesSelector(accNamespacedName *types.NamespacedName, selector string) {
+	req := &pluginv1alpha1.AccountResourceSelectorRequest{
+		Account:      convertToWireNamespacedName(accNamespacedName),
+		SelectorName: selector,
+	}
+	if err := c.call("RemoveAccountResourcesSelector", func(ctx context.Context) error {
+		_, err := c.client.RemoveAccountResourcesSelector(ctx, req)
+		return err
+	}); err != nil {
+		pluginLogger().Error(err, "failed to remove selector", "provider", c.providerType, "account", accNamespacedName,
+			"selector", selector)
+	}
+}
+
+// GetAccountStatus gets accounts status.
+func (c *pluginCloud) GetAccountStatus(accNamespacedName *types.NamespacedName) (*crdv1alpha1.CloudProviderAccountStatus, error) {
+	var resp *pluginv1alpha1.AccountStatusResponse
+	req := &pluginv1alpha1.AccountRequest{Account: convertToWireNamespacedName(accNamespacedName)}
+	if err := c.call("GetAccountStatus", func(ctx context.Context) (err error) {
+		resp, err = c.client.GetAccountStatus(ctx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return convertFromWireObject[crdv1alpha1.CloudProviderAccountStatus](resp.GetStatus())
+}
+
+// DoInventoryPoll calls cloud API to get cloud resources.
+func (c *pluginCloud) DoInventoryPoll(accountNamespacedName *types.NamespacedName) error {
+	req := &pluginv1alpha1.AccountRequest{Account: convertToWireNamespacedName(accountNamespacedName)}
+	return c.call("DoInventoryPoll", func(ctx context.Context) error {
+		_, err := c.client.DoInventoryPoll(ctx, req)
+		return err
+	})
+}
+
+// DeleteInventoryPollCache resets cloud snapshot to nil.
+func (c *pluginCloud) DeleteInventoryPollCache(accountNamespacedName *types.NamespacedName) error {
+	req := &pluginv1alpha1.AccountRequest{Account: convertToWireNamespacedName(accountNamespacedName)}
+	return c.call("DeleteInventoryPollCache", func(ctx context.Context) error {
+		_, err := c.client.DeleteInventoryPollCache(ctx, req)
+		return err
+	})
+}
+
+// GetVpcInventory gets vpc inventory from internal stored snapshot.
+func (c *pluginCloud) GetVpcInventory(accountNamespacedName *types.NamespacedName) (map[string]*runtimev1alpha1.Vpc, error) {
+	var resp *pluginv1alpha1.VpcInventoryResponse
+	req := &pluginv1alpha1.AccountRequest{Account: convertToWireNamespacedName(accountNamespacedName)}
+	if err := c.call("GetVpcInventory", func(ctx context.Context) (err error) {
+		resp, err = c.client.GetVpcInventory(ctx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return convertFromWireObjects[runtimev1alpha1.Vpc](resp.GetVpcs())
+}
+
+// ////////////////////////////////////////////////////////
+//
+//	ComputeInterface Implementation
+//
+// ////////////////////////////////////////////////////////.
+
+// InstancesGivenProviderAccount returns all VM instances of a given cloud provider account.
+func (c *pluginCloud) InstancesGivenProviderAccount(namespacedName *types.NamespacedName) (
+	map[string]*runtimev1alpha1.VirtualMachine, error) {
+	var resp *pluginv1alpha1.InstancesResponse
+	req := &pluginv1alpha1.AccountRequest{Account: convertToWireNamespacedName(namespacedName)}
+	if err := c.call("InstancesGivenProviderAccount", func(ctx context.Context) (err error) {
+		resp, err = c.client.InstancesGivenProviderAccount(ctx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return convertFromWireObjects[runtimev1alpha1.VirtualMachine](resp.GetVirtualMachines())
+}
+
+// ////////////////////////////////////////////////////////
+//
+//	SecurityInterface Implementation
+//
+// ////////////////////////////////////////////////////////.
+
+// CreateSecurityGroup creates cloud security group corresponding to provided security group.
+func (c *pluginCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) (*string, error) {
+	var resp *pluginv1alpha1.CreateSecurityGroupResponse
+	req := &pluginv1alpha1.SecurityGroupRequest{
+		SecurityGroup:  convertToWireResource(securityGroupIdentifier),
+		MembershipOnly: membershipOnly,
+	}
+	if err := c.call("CreateSecurityGroup", func(ctx context.Context) (err error) {
+		resp, err = c.client.CreateSecurityGroup(ctx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp.CloudSecurityGroupId, nil
+}
+
+// UpdateSecurityGroupRules updates cloud security group corresponding to provided appliedTo group with provided rules.
+func (c *pluginCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygroup.CloudResource, addRules, rmRules,
+	allRules []*securitygroup.CloudRule) error {
+	req := &pluginv1alpha1.UpdateSecurityGroupRulesRequest{
+		AppliedToGroup: convertToWireResource(appliedToGroupIdentifier),
+		AddRules:       convertToWireRules(addRules),
+		RmRules:        convertToWireRules(rmRules),
+		AllRules:       convertToWireRules(allRules),
+	}
+	return c.call("UpdateSecurityGroupRules", func(ctx context.Context) error {
+		_, err := c.client.UpdateSecurityGroupRules(ctx, req)
+		return err
+	})
+}
+
+// UpdateSecurityGroupMembers updates membership of cloud security group corresponding to provided security group.
+func (c *pluginCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securitygroup.CloudResource,
+	computeResourceIdentifier []*securitygroup.CloudResource, membershipOnly bool) error {
+	req := &pluginv1alpha1.UpdateSecurityGroupMembersRequest{
+		SecurityGroup:  convertToWireResource(securityGroupIdentifier),
+		Members:        convertToWireResources(computeResourceIdentifier),
+		MembershipOnly: membershipOnly,
+	}
+	return c.call("UpdateSecurityGroupMembers", func(ctx context.Context) error {
+		_, err := c.client.UpdateSecurityGroupMembers(ctx, req)
+		return err
+	})
+}
+
+// DeleteSecurityGroup deletes the cloud security group corresponding to provided security group.
+func (c *pluginCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) error {
+	req := &pluginv1alpha1.SecurityGroupRequest{
+		SecurityGroup:  convertToWireResource(securityGroupIdentifier),
+		MembershipOnly: membershipOnly,
+	}
+	return c.call("DeleteSecurityGroup", func(ctx context.Context) error {
+		_, err := c.client.DeleteSecurityGroup(ctx, req)
+		return err
+	})
+}
+
+// GetEnforcedSecurity returns the cloud view of enforced security.
+func (c *pluginCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	var resp *pluginv1alpha1.EnforcedSecurityResponse
+	if err := c.call("GetEnforcedSecurity", func(ctx context.Context) (err error) {
+		resp, err = c.client.GetEnforcedSecurity(ctx, &pluginv1alpha1.Empty{})
+		return err
+	}); err != nil {
+		pluginLogger().Error(err, "enforced-security-cloud-view GET failed", "provider", c.providerType)
+		return nil
+	}
+	content, err := convertFromWireContent(resp.GetContent())
+	if err != nil {
+		pluginLogger().Error(err, "invalid enforced-security-cloud-view", "provider", c.providerType)
+		return nil
+	}
+	return content
+}
+
+// GetSecurityGroupLimits returns the limits of the plugin cloud on security groups, or no limits if the plugin
+// fails to respond.
+func (c *pluginCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	var resp *pluginv1alpha1.SecurityGroupLimitsResponse
+	if err := c.call("GetSecurityGroupLimits", func(ctx context.Context) (err error) {
+		resp, err = c.client.GetSecurityGroupLimits(ctx, &pluginv1alpha1.Empty{})
+		return err
+	}); err != nil {
+		pluginLogger().Error(err, "security group limits GET failed", "provider", c.providerType)
+		return securitygroup.SecurityGroupLimits{}
+	}
+	return convertFromWireLimits(resp.GetLimits())
+}
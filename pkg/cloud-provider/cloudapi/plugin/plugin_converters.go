@@ -0,0 +1,415 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	pluginv1alpha1 "antrea.io/nephe/apis/plugin/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// convertToWireNamespacedName converts types.NamespacedName to wire format.
+func convertToWireNamespacedName(namespacedName *types.NamespacedName) *pluginv1alpha1.NamespacedName {
+	return &pluginv1alpha1.NamespacedName{Namespace: namespacedName.Namespace, Name: namespacedName.Name}
+}
+
+// convertFromWireNamespacedName converts wire format namespaced name to types.NamespacedName.
+func convertFromWireNamespacedName(namespacedName *pluginv1alpha1.NamespacedName) *types.NamespacedName {
+	return &types.NamespacedName{Namespace: namespacedName.GetNamespace(), Name: namespacedName.GetName()}
+}
+
+// convertToWireCapabilities converts crdv1alpha1.CloudCapabilities to wire format.
+func convertToWireCapabilities(capabilities crdv1alpha1.CloudCapabilities) *pluginv1alpha1.Capabilities {
+	return &pluginv1alpha1.Capabilities{
+		SecurityGroups: capabilities.SecurityGroups,
+		DenyRules:      capabilities.DenyRules,
+		RuleOrdering:   capabilities.RuleOrdering,
+		Ipv6:           capabilities.IPv6,
+		PortRanges:     capabilities.PortRanges,
+		IcmpTypeCode:   capabilities.ICMPTypeCode,
+		Sctp:           capabilities.SCTP,
+		MaxRules:       int32(capabilities.MaxRules),
+	}
+}
+
+// convertFromWireCapabilities converts wire format capabilities to crdv1alpha1.CloudCapabilities.
+func convertFromWireCapabilities(capabilities *pluginv1alpha1.Capabilities) crdv1alpha1.CloudCapabilities {
+	return crdv1alpha1.CloudCapabilities{
+		SecurityGroups: capabilities.GetSecurityGroups(),
+		DenyRules:      capabilities.GetDenyRules(),
+		RuleOrdering:   capabilities.GetRuleOrdering(),
+		IPv6:           capabilities.GetIpv6(),
+		PortRanges:     capabilities.GetPortRanges(),
+		ICMPTypeCode:   capabilities.GetIcmpTypeCode(),
+		SCTP:           capabilities.GetSctp(),
+		MaxRules:       int(capabilities.GetMaxRules()),
+	}
+}
+
+// convertToWireLimits converts securitygroup.SecurityGroupLimits to wire format.
+func convertToWireLimits(limits securitygroup.SecurityGroupLimits) *pluginv1alpha1.SecurityGroupLimits {
+	return &pluginv1alpha1.SecurityGroupLimits{
+		MaxRules:                      int32(limits.MaxRules),
+		SharedDirections:              limits.SharedDirections,
+		MaxSecurityGroupsPerInterface: int32(limits.MaxSecurityGroupsPerInterface),
+		RulePerPeer:                   limits.RulePerPeer,
+		ExcludeDenyRules:              limits.ExcludeDenyRules,
+	}
+}
+
+// convertFromWireLimits converts wire format limits to securitygroup.SecurityGroupLimits.
+func convertFromWireLimits(limits *pluginv1alpha1.SecurityGroupLimits) securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{
+		MaxRules:                      int(limits.GetMaxRules()),
+		SharedDirections:              limits.GetSharedDirections(),
+		MaxSecurityGroupsPerInterface: int(limits.GetMaxSecurityGroupsPerInterface()),
+		RulePerPeer:                   limits.GetRulePerPeer(),
+		ExcludeDenyRules:              limits.GetExcludeDenyRules(),
+	}
+}
+
+// convertToWireResourceIDs converts securitygroup.CloudResourceID to wire format.
+func convertToWireResourceIDs(ids []*securitygroup.CloudResourceID) []*pluginv1alpha1.CloudResourceID {
+	var wireIDs []*pluginv1alpha1.CloudResourceID
+	for _, id := range ids {
+		wireIDs = append(wireIDs, &pluginv1alpha1.CloudResourceID{Name: id.Name, Vpc: id.Vpc})
+	}
+	return wireIDs
+}
+
+// convertFromWireResourceIDs converts wire format resource IDs to securitygroup.CloudResourceID.
+func convertFromWireResourceIDs(wireIDs []*pluginv1alpha1.CloudResourceID) []*securitygroup.CloudResourceID {
+	var ids []*securitygroup.CloudResourceID
+	for _, wireID := range wireIDs {
+		ids = append(ids, &securitygroup.CloudResourceID{Name: wireID.GetName(), Vpc: wireID.GetVpc()})
+	}
+	return ids
+}
+
+// convertToWireResource converts securitygroup.CloudResource to wire format.
+func convertToWireResource(resource *securitygroup.CloudResource) *pluginv1alpha1.CloudResource {
+	return &pluginv1alpha1.CloudResource{
+		Type:          string(resource.Type),
+		Id:            &pluginv1alpha1.CloudResourceID{Name: resource.Name, Vpc: resource.Vpc},
+		AccountId:     resource.AccountID,
+		CloudProvider: resource.CloudProvider,
+	}
+}
+
+// convertFromWireResource converts wire format resource to securitygroup.CloudResource.
+func convertFromWireResource(resource *pluginv1alpha1.CloudResource) *securitygroup.CloudResource {
+	return &securitygroup.CloudResource{
+		Type:            securitygroup.CloudResourceType(resource.GetType()),
+		CloudResourceID: securitygroup.CloudResourceID{Name: resource.GetId().GetName(), Vpc: resource.GetId().GetVpc()},
+		AccountID:       resource.GetAccountId(),
+		CloudProvider:   resource.GetCloudProvider(),
+	}
+}
+
+// convertToWireResources converts a list of securitygroup.CloudResource to wire format.
+func convertToWireResources(resources []*securitygroup.CloudResource) []*pluginv1alpha1.CloudResource {
+	var wireResources []*pluginv1alpha1.CloudResource
+	for _, resource := range resources {
+		wireResources = append(wireResources, convertToWireResource(resource))
+	}
+	return wireResources
+}
+
+// convertFromWireResources converts a list of wire format resources to securitygroup.CloudResource.
+func convertFromWireResources(wireResources []*pluginv1alpha1.CloudResource) []*securitygroup.CloudResource {
+	var resources []*securitygroup.CloudResource
+	for _, wireResource := range wireResources {
+		resources = append(resources, convertFromWireResource(wireResource))
+	}
+	return resources
+}
+
+// convertToWirePriority converts securitygroup.RulePriority to wire format.
+func convertToWirePriority(priority *securitygroup.RulePriority) *pluginv1alpha1.RulePriority {
+	if priority == nil {
+		return nil
+	}
+	return &pluginv1alpha1.RulePriority{Tier: priority.Tier, Policy: priority.Policy, Rule: priority.Rule}
+}
+
+// convertFromWirePriority converts wire format priority to securitygroup.RulePriority.
+func convertFromWirePriority(priority *pluginv1alpha1.RulePriority) *securitygroup.RulePriority {
+	if priority == nil {
+		return nil
+	}
+	return &securitygroup.RulePriority{Tier: priority.Tier, Policy: priority.Policy, Rule: priority.Rule}
+}
+
+// convertToWireInt converts an optional int to wire format.
+func convertToWireInt(i *int) *int32 {
+	if i == nil {
+		return nil
+	}
+	v := int32(*i)
+	return &v
+}
+
+// convertFromWireInt converts a wire format optional int to int.
+func convertFromWireInt(i *int32) *int {
+	if i == nil {
+		return nil
+	}
+	v := int(*i)
+	return &v
+}
+
+// convertToWireCIDRs converts CIDRs to wire format.
+func convertToWireCIDRs(ipNets []*net.IPNet) []string {
+	var cidrs []string
+	for _, ipNet := range ipNets {
+		cidrs = append(cidrs, ipNet.String())
+	}
+	return cidrs
+}
+
+// convertFromWireCIDRs converts wire format CIDRs to net.IPNet.
+func convertFromWireCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// convertToWireIngressRule converts securitygroup.IngressRule to wire format.
+func convertToWireIngressRule(rule *securitygroup.IngressRule) *pluginv1alpha1.IngressRule {
+	return &pluginv1alpha1.IngressRule{
+		FromPort:           convertToWireInt(rule.FromPort),
+		FromEndPort:        convertToWireInt(rule.FromEndPort),
+		FromSrcIp:          convertToWireCIDRs(rule.FromSrcIP),
+		FromSecurityGroups: convertToWireResourceIDs(rule.FromSecurityGroups),
+		Protocol:           convertToWireInt(rule.Protocol),
+		IcmpType:           convertToWireInt(rule.ICMPType),
+		IcmpCode:           convertToWireInt(rule.ICMPCode),
+		Action:             string(rule.Action),
+		Priority:           convertToWirePriority(rule.Priority),
+	}
+}
+
+// convertFromWireIngressRule converts wire format ingress rule to securitygroup.IngressRule.
+func convertFromWireIngressRule(rule *pluginv1alpha1.IngressRule) (*securitygroup.IngressRule, error) {
+	srcIPs, err := convertFromWireCIDRs(rule.GetFromSrcIp())
+	if err != nil {
+		return nil, err
+	}
+	return &securitygroup.IngressRule{
+		FromPort:           convertFromWireInt(rule.FromPort),
+		FromEndPort:        convertFromWireInt(rule.FromEndPort),
+		FromSrcIP:          srcIPs,
+		FromSecurityGroups: convertFromWireResourceIDs(rule.GetFromSecurityGroups()),
+		Protocol:           convertFromWireInt(rule.Protocol),
+		ICMPType:           convertFromWireInt(rule.IcmpType),
+		ICMPCode:           convertFromWireInt(rule.IcmpCode),
+		Action:             securitygroup.RuleAction(rule.GetAction()),
+		Priority:           convertFromWirePriority(rule.GetPriority()),
+	}, nil
+}
+
+// convertToWireEgressRule converts securitygroup.EgressRule to wire format.
+func convertToWireEgressRule(rule *securitygroup.EgressRule) *pluginv1alpha1.EgressRule {
+	return &pluginv1alpha1.EgressRule{
+		ToPort:           convertToWireInt(rule.ToPort),
+		ToEndPort:        convertToWireInt(rule.ToEndPort),
+		ToDstIp:          convertToWireCIDRs(rule.ToDstIP),
+		ToSecurityGroups: convertToWireResourceIDs(rule.ToSecurityGroups),
+		Protocol:         convertToWireInt(rule.Protocol),
+		IcmpType:         convertToWireInt(rule.ICMPType),
+		IcmpCode:         convertToWireInt(rule.ICMPCode),
+		Action:           string(rule.Action),
+		Priority:         convertToWirePriority(rule.Priority),
+	}
+}
+
+// convertFromWireEgressRule converts wire format egress rule to securitygroup.EgressRule.
+func convertFromWireEgressRule(rule *pluginv1alpha1.EgressRule) (*securitygroup.EgressRule, error) {
+	dstIPs, err := convertFromWireCIDRs(rule.GetToDstIp())
+	if err != nil {
+		return nil, err
+	}
+	return &securitygroup.EgressRule{
+		ToPort:           convertFromWireInt(rule.ToPort),
+		ToEndPort:        convertFromWireInt(rule.ToEndPort),
+		ToDstIP:          dstIPs,
+		ToSecurityGroups: convertFromWireResourceIDs(rule.GetToSecurityGroups()),
+		Protocol:         convertFromWireInt(rule.Protocol),
+		ICMPType:         convertFromWireInt(rule.IcmpType),
+		ICMPCode:         convertFromWireInt(rule.IcmpCode),
+		Action:           securitygroup.RuleAction(rule.GetAction()),
+		Priority:         convertFromWirePriority(rule.GetPriority()),
+	}, nil
+}
+
+// convertToWireRules converts securitygroup.CloudRule to wire format.
+func convertToWireRules(rules []*securitygroup.CloudRule) []*pluginv1alpha1.CloudRule {
+	wireRules := make([]*pluginv1alpha1.CloudRule, 0, len(rules))
+	for _, rule := range rules {
+		wireRule := &pluginv1alpha1.CloudRule{
+			Hash:          rule.Hash,
+			NetworkPolicy: rule.NetworkPolicy,
+			AppliedToGrp:  rule.AppliedToGrp,
+			Shard:         int32(rule.Shard),
+		}
+		switch r := rule.Rule.(type) {
+		case *securitygroup.IngressRule:
+			wireRule.Rule = &pluginv1alpha1.CloudRule_Ingress{Ingress: convertToWireIngressRule(r)}
+		case *securitygroup.EgressRule:
+			wireRule.Rule = &pluginv1alpha1.CloudRule_Egress{Egress: convertToWireEgressRule(r)}
+		}
+		wireRules = append(wireRules, wireRule)
+	}
+	return wireRules
+}
+
+// convertFromWireRules converts wire format rules to securitygroup.CloudRule.
+func convertFromWireRules(wireRules []*pluginv1alpha1.CloudRule) ([]*securitygroup.CloudRule, error) {
+	rules := make([]*securitygroup.CloudRule, 0, len(wireRules))
+	for _, wireRule := range wireRules {
+		rule := &securitygroup.CloudRule{
+			Hash:          wireRule.GetHash(),
+			NetworkPolicy: wireRule.GetNetworkPolicy(),
+			AppliedToGrp:  wireRule.GetAppliedToGrp(),
+			Shard:         int(wireRule.GetShard()),
+		}
+		var err error
+		switch r := wireRule.GetRule().(type) {
+		case *pluginv1alpha1.CloudRule_Ingress:
+			rule.Rule, err = convertFromWireIngressRule(r.Ingress)
+		case *pluginv1alpha1.CloudRule_Egress:
+			rule.Rule, err = convertFromWireEgressRule(r.Egress)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// convertToWireContent converts securitygroup.SynchronizationContent to wire format.
+func convertToWireContent(content []securitygroup.SynchronizationContent) []*pluginv1alpha1.SynchronizationContent {
+	wireContent := make([]*pluginv1alpha1.SynchronizationContent, 0, len(content))
+	for i := range content {
+		c := &content[i]
+		wireSG := &pluginv1alpha1.SynchronizationContent{
+			Resource:       convertToWireResource(&c.Resource),
+			MembershipOnly: c.MembershipOnly,
+			Shard:          int32(c.Shard),
+		}
+		for j := range c.Members {
+			wireSG.Members = append(wireSG.Members, convertToWireResource(&c.Members[j]))
+		}
+		for j := range c.MembersWithOtherSGAttached {
+			wireSG.MembersWithOtherSgAttached = append(wireSG.MembersWithOtherSgAttached,
+				convertToWireResource(&c.MembersWithOtherSGAttached[j]))
+		}
+		for j := range c.IngressRules {
+			wireSG.IngressRules = append(wireSG.IngressRules, convertToWireIngressRule(&c.IngressRules[j]))
+		}
+		for j := range c.EgressRules {
+			wireSG.EgressRules = append(wireSG.EgressRules, convertToWireEgressRule(&c.EgressRules[j]))
+		}
+		wireContent = append(wireContent, wireSG)
+	}
+	return wireContent
+}
+
+// convertFromWireContent converts wire format synchronization content to securitygroup.SynchronizationContent.
+func convertFromWireContent(wireContent []*pluginv1alpha1.SynchronizationContent) ([]securitygroup.SynchronizationContent, error) {
+	content := make([]securitygroup.SynchronizationContent, 0, len(wireContent))
+	for _, wireSG := range wireContent {
+		sg := securitygroup.SynchronizationContent{
+			Resource:       *convertFromWireResource(wireSG.GetResource()),
+			MembershipOnly: wireSG.GetMembershipOnly(),
+			Shard:          int(wireSG.GetShard()),
+		}
+		for _, member := range wireSG.GetMembers() {
+			sg.Members = append(sg.Members, *convertFromWireResource(member))
+		}
+		for _, member := range wireSG.GetMembersWithOtherSgAttached() {
+			sg.MembersWithOtherSGAttached = append(sg.MembersWithOtherSGAttached, *convertFromWireResource(member))
+		}
+		for _, wireRule := range wireSG.GetIngressRules() {
+			rule, err := convertFromWireIngressRule(wireRule)
+			if err != nil {
+				return nil, err
+			}
+			sg.IngressRules = append(sg.IngressRules, *rule)
+		}
+		for _, wireRule := range wireSG.GetEgressRules() {
+			rule, err := convertFromWireEgressRule(wireRule)
+			if err != nil {
+				return nil, err
+			}
+			sg.EgressRules = append(sg.EgressRules, *rule)
+		}
+		content = append(content, sg)
+	}
+	return content, nil
+}
+
+// convertToWireObjects JSON encodes Kubernetes API objects by key.
+func convertToWireObjects[T any](objects map[string]*T) (map[string][]byte, error) {
+	wireObjects := make(map[string][]byte, len(objects))
+	for key, object := range objects {
+		data, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		wireObjects[key] = data
+	}
+	return wireObjects, nil
+}
+
+// convertFromWireObjects decodes JSON encoded Kubernetes API objects by key.
+func convertFromWireObjects[T any](wireObjects map[string][]byte) (map[string]*T, error) {
+	objects := make(map[string]*T, len(wireObjects))
+	for key, data := range wireObjects {
+		object := new(T)
+		if err := json.Unmarshal(data, object); err != nil {
+			return nil, fmt.Errorf("failed to decode %v: %v", key, err)
+		}
+		objects[key] = object
+	}
+	return objects, nil
+}
+
+// convertFromWireObject decodes a JSON encoded Kubernetes API object.
+func convertFromWireObject[T any](data []byte) (*T, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("object is missing")
+	}
+	object := new(T)
+	if err := json.Unmarshal(data, object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	pluginv1alpha1 "antrea.io/nephe/apis/plugin/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+)
+
+// Provider is implemented by out-of-tree cloud provider plugins. It mirrors common.CloudInterface, except that
+// AddProviderAccount receives the account credentials instead of a Kubernetes client.
+type Provider interface {
+	// ProviderType returns the cloud provider type served by the plugin.
+	ProviderType() cloudcommon.ProviderType
+	// GetCapabilities returns the network policy features the plugin can realize.
+	GetCapabilities() crdv1alpha1.CloudCapabilities
+	// AddProviderAccount adds and initializes given account with credentials from the Secret referred by the account.
+	AddProviderAccount(account *crdv1alpha1.CloudProviderAccount, credentials []byte) error
+	// RemoveProviderAccount removes and cleans up any resources of given account.
+	RemoveProviderAccount(namespacedName *types.NamespacedName)
+	// AddAccountResourceSelector adds account specific resource selector.
+	AddAccountResourceSelector(accNamespacedName *types.NamespacedName, selector *crdv1alpha1.CloudEntitySelector) error
+	// RemoveAccountResourcesSelector removes account specific resource selector.
+	RemoveAccountResourcesSelector(accNamespacedName *types.NamespacedName, selector string)
+	// GetAccountStatus gets accounts status.
+	GetAccountStatus(accNamespacedName *types.NamespacedName) (*crdv1alpha1.CloudProviderAccountStatus, error)
+	// DoInventoryPoll calls cloud API to get cloud resources.
+	DoInventoryPoll(accountNamespacedName *types.NamespacedName) error
+	// DeleteInventoryPollCache resets cloud snapshot to nil.
+	DeleteInventoryPollCache(accountNamespacedName *types.NamespacedName) error
+	// GetVpcInventory gets vpc inventory from internal stored snapshot.
+	GetVpcInventory(accountNamespacedName *types.NamespacedName) (map[string]*runtimev1alpha1.Vpc, error)
+
+	cloudcommon.ComputeInterface
+
+	cloudcommon.SecurityInterface
+}
+
+// providerServer serves the CloudProvider gRPC service with a Provider.
+type providerServer struct {
+	pluginv1alpha1.UnimplementedCloudProviderServer
+	provider Provider
+}
+
+// RegisterProviderServer registers the Provider as CloudProvider gRPC service of the server.
+func RegisterProviderServer(s *grpc.Server, p Provider) {
+	pluginv1alpha1.RegisterCloudProviderServer(s, &providerServer{provider: p})
+}
+
+func (s *providerServer) ProviderType(_ context.Context, _ *pluginv1alpha1.Empty) (*pluginv1alpha1.ProviderTypeResponse, error) {
+	return &pluginv1alpha1.ProviderTypeResponse{ProviderType: string(s.provider.ProviderType())}, nil
+}
+
+func (s *providerServer) GetCapabilities(_ context.Context, _ *pluginv1alpha1.Empty) (*pluginv1alpha1.CapabilitiesResponse, error) {
+	return &pluginv1alpha1.CapabilitiesResponse{Capabilities: convertToWireCapabilities(s.provider.GetCapabilities())}, nil
+}
+
+func (s *providerServer) AddProviderAccount(_ context.Context, req *pluginv1alpha1.AddProviderAccountRequest) (
+	*pluginv1alpha1.Empty, error) {
+	account, err := convertFromWireObject[crdv1alpha1.CloudProviderAccount](req.GetAccount())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account: %v", err)
+	}
+	return &pluginv1alpha1.Empty{}, s.provider.AddProviderAccount(account, req.GetCredentials())
+}
+
+func (s *providerServer) RemoveProviderAccount(_ context.Context, req *pluginv1alpha1.AccountRequest) (*pluginv1alpha1.Empty, error) {
+	s.provider.RemoveProviderAccount(convertFromWireNamespacedName(req.GetAccount()))
+	return &pluginv1alpha1.Empty{}, nil
+}
+
+func (s *providerServer) AddAccountResourceSelector(_ context.Context, req *pluginv1alpha1.AccountResourceSelectorRequest) (
+	*pluginv1alpha1.Empty, error) {
+	selector, err := convertFromWireObject[crdv1alpha1.CloudEntitySelector](req.GetSelector())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid selector: %v", err)
+	}
+	return &pluginv1alpha1.Empty{}, s.provider.AddAccountResourceSelector(convertFromWireNamespacedName(req.GetAccount()), selector)
+}
+
+func (s *providerServer) RemoveAccountResourcesSelector(_ context.Context, req *pluginv1alpha1.AccountResourceSelectorRequest) (
+	*pluginv1alpha1.Empty, error) {
+	s.provider.RemoveAccountResourcesSelector(convertFromWireNamespacedName(req.GetAccount()), req.GetSelectorName())
+	return &pluginv1alpha1.Empty{}, nil
+}
+
+func (s *providerServer) GetAccountStatus(_ context.Context, req *pluginv1alpha1.AccountRequest) (
+	*pluginv1alpha1.AccountStatusResponse, error) {
+	accountStatus, err := s.provider.GetAccountStatus(convertFromWireNamespacedName(req.GetAccount()))
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(accountStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1alpha1.AccountStatusResponse{Status: data}, nil
+}
+
+func (s *providerServer) DoInventoryPoll(_ context.Context, req *pluginv1alpha1.AccountRequest) (*pluginv1alpha1.Empty, error) {
+	return &pluginv1alpha1.Empty{}, s.provider.DoInventoryPoll(convertFromWireNamespacedName(req.GetAccount()))
+}
+
+func (s *providerServer) DeleteInventoryPollCache(_ context.Context, req *pluginv1alpha1.AccountRequest) (*pluginv1alpha1.Empty, error) {
+	return &pluginv1alpha1.Empty{}, s.provider.DeleteInventoryPollCache(convertFromWireNamespacedName(req.GetAccount()))
+}
+
+func (s *providerServer) GetVpcInventory(_ context.Context, req *pluginv1alpha1.AccountRequest) (
+	*pluginv1alpha1.VpcInventoryResponse, error) {
+	vpcs, err := s.provider.GetVpcInventory(convertFromWireNamespacedName(req.GetAccount()))
+	if err != nil {
+		return nil, err
+	}
+	wireVpcs, err := convertToWireObjects(vpcs)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1alpha1.VpcInventoryResponse{Vpcs: wireVpcs}, nil
+}
+
+func (s *providerServer) InstancesGivenProviderAccount(_ context.Context, req *pluginv1alpha1.AccountRequest) (
+	*pluginv1alpha1.InstancesResponse, error) {
+	vms, err := s.provider.InstancesGivenProviderAccount(convertFromWireNamespacedName(req.GetAccount()))
+	if err != nil {
+		return nil, err
+	}
+	wireVMs, err := convertToWireObjects(vms)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1alpha1.InstancesResponse{VirtualMachines: wireVMs}, nil
+}
+
+func (s *providerServer) CreateSecurityGroup(_ context.Context, req *pluginv1alpha1.SecurityGroupRequest) (
+	*pluginv1alpha1.CreateSecurityGroupResponse, error) {
+	id, err := s.provider.CreateSecurityGroup(convertFromWireResource(req.GetSecurityGroup()), req.GetMembershipOnly())
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1alpha1.CreateSecurityGroupResponse{CloudSecurityGroupId: id}, nil
+}
+
+func (s *providerServer) UpdateSecurityGroupRules(_ context.Context, req *pluginv1alpha1.UpdateSecurityGroupRulesRequest) (
+	*pluginv1alpha1.Empty, error) {
+	addRules, err := convertFromWireRules(req.GetAddRules())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid rules: %v", err)
+	}
+	rmRules, err := convertFromWireRules(req.GetRmRules())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid rules: %v", err)
+	}
+	allRules, err := convertFromWireRules(req.GetAllRules())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid rules: %v", err)
+	}
+	return &pluginv1alpha1.Empty{}, s.provider.UpdateSecurityGroupRules(convertFromWireResource(req.GetAppliedToGroup()),
+		addRules, rmRules, allRules)
+}
+
+func (s *providerServer) UpdateSecurityGroupMembers(_ context.Context, req *pluginv1alpha1.UpdateSecurityGroupMembersRequest) (
+	*pluginv1alpha1.Empty, error) {
+	return &pluginv1alpha1.Empty{}, s.provider.UpdateSecurityGroupMembers(convertFromWireResource(req.GetSecurityGroup()),
+		convertFromWireResources(req.GetMembers()), req.GetMembershipOnly())
+}
+
+func (s *providerServer) DeleteSecurityGroup(_ context.Context, req *pluginv1alpha1.SecurityGroupRequest) (*pluginv1alpha1.Empty, error) {
+	return &pluginv1alpha1.Empty{}, s.provider.DeleteSecurityGroup(convertFromWireResource(req.GetSecurityGroup()),
+		req.GetMembershipOnly())
+}
+
+func (s *providerServer) GetEnforcedSecurity(_ context.Context, _ *pluginv1alpha1.Empty) (*pluginv1alpha1.EnforcedSecurityResponse,
+	error) {
+	return &pluginv1alpha1.EnforcedSecurityResponse{Content: convertToWireContent(s.provider.GetEnforcedSecurity())}, nil
+}
+
+func (s *providerServer) GetSecurityGroupLimits(_ context.Context, _ *pluginv1alpha1.Empty) (
+	*pluginv1alpha1.SecurityGroupLimitsResponse, error) {
+	return &pluginv1alpha1.SecurityGroupLimitsResponse{Limits: convertToWireLimits(s.provider.GetSecurityGroupLimits())}, nil
+}
@@ -0,0 +1,406 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+	"antrea.io/nephe/pkg/config"
+)
+
+const testProviderType = cloudcommon.ProviderType("Fake")
+
+// fakeProvider is a Provider recording the calls received from nephe-controller.
+type fakeProvider struct {
+	credentials []byte
+	account     *v1alpha1.CloudProviderAccount
+	removed     []types.NamespacedName
+	vpcs        map[string]*runtimev1alpha1.Vpc
+	vms         map[string]*runtimev1alpha1.VirtualMachine
+	addRules    []*securitygroup.CloudRule
+	members     []*securitygroup.CloudResource
+	pollErr     error
+	caps        v1alpha1.CloudCapabilities
+	content     []securitygroup.SynchronizationContent
+	limits      securitygroup.SecurityGroupLimits
+}
+
+func (p *fakeProvider) ProviderType() cloudcommon.ProviderType {
+	return testProviderType
+}
+
+func (p *fakeProvider) GetCapabilities() v1alpha1.CloudCapabilities {
+	return p.caps
+}
+
+func (p *fakeProvider) AddProviderAccount(account *v1alpha1.CloudProviderAccount, credentials []byte) error {
+	p.account = account
+	p.credentials = credentials
+	return nil
+}
+
+func (p *fakeProvider) RemoveProviderAccount(namespacedName *types.NamespacedName) {
+	p.removed = append(p.removed, *namespacedName)
+}
+
+func (p *fakeProvider) AddAccountResourceSelector(_ *types.NamespacedName, _ *v1alpha1.CloudEntitySelector) error {
+	return nil
+}
+
+func (p *fakeProvider) RemoveAccountResourcesSelector(_ *types.NamespacedName, _ string) {}
+
+func (p *fakeProvider) GetAccountStatus(_ *types.NamespacedName) (*v1alpha1.CloudProviderAccountStatus, error) {
+	return &v1alpha1.CloudProviderAccountStatus{}, nil
+}
+
+func (p *fakeProvider) DoInventoryPoll(_ *types.NamespacedName) error {
+	return p.pollErr
+}
+
+func (p *fakeProvider) DeleteInventoryPollCache(_ *types.NamespacedName) error {
+	return nil
+}
+
+func (p *fakeProvider) GetVpcInventory(_ *types.NamespacedName) (map[string]*runtimev1alpha1.Vpc, error) {
+	return p.vpcs, nil
+}
+
+func (p *fakeProvider) InstancesGivenProviderAccount(_ *types.NamespacedName) (map[string]*runtimev1alpha1.VirtualMachine, error) {
+	return p.vms, nil
+}
+
+func (p *fakeProvider) CreateSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, _ bool) (*string, error) {
+	id := securityGroupIdentifier.Name
+	return &id, nil
+}
+
+func (p *fakeProvider) UpdateSecurityGroupRules(_ *securitygroup.CloudResource, addRules, _, _ []*securitygroup.CloudRule) error {
+	p.addRules = addRules
+	return nil
+}
+
+func (p *fakeProvider) UpdateSecurityGroupMembers(_ *securitygroup.CloudResource, members []*securitygroup.CloudResource,
+	_ bool) error {
+	p.members = members
+	return nil
+}
+
+func (p *fakeProvider) DeleteSecurityGroup(_ *securitygroup.CloudResource, _ bool) error {
+	return nil
+}
+
+func (p *fakeProvider) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	return p.content
+}
+
+// writeTestCertificates writes a CA, and server and client certificates of localhost signed by the CA to dir.
+func writeTestCertificates(dir string) {
+	writePEM := func(name, blockType string, der []byte) {
+		data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+		Expect(os.WriteFile(filepath.Join(dir, name), data, 0600)).Should(Succeed())
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).Should(BeNil())
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "nephe-plugin-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	Expect(err).Should(BeNil())
+	caCert, err := x509.ParseCertificate(caDER)
+	Expect(err).Should(BeNil())
+	writePEM("ca.crt", "CERTIFICATE", caDER)
+
+	for i, name := range []string{"server", "client"} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).Should(BeNil())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		Expect(err).Should(BeNil())
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		Expect(err).Should(BeNil())
+		writePEM(name+".crt", "CERTIFICATE", der)
+		writePEM(name+".key", "EC PRIVATE KEY", keyDER)
+	}
+}
+
+func (p *fakeProvider) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return p.limits
+}
+
+var _ = Describe("gRPC cloud provider plugin", func() {
+	var (
+		testAccountNamespacedName = types.NamespacedName{Namespace: "namespace01", Name: "account01"}
+		credentials               = "credentials"
+
+		provider *fakeProvider
+		server   *grpc.Server
+		cloud    cloudcommon.CloudInterface
+		tmpDir   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "plugin")
+		Expect(err).Should(BeNil())
+
+		provider = &fakeProvider{}
+		address := "unix://" + filepath.Join(tmpDir, "plugin.sock")
+		var listener net.Listener
+		server, listener, err = NewServer(address, nil, provider)
+		Expect(err).Should(BeNil())
+		go func() {
+			_ = server.Serve(listener)
+		}()
+
+		cloud, err = Register(testProviderType, address, nil, 10*time.Second)
+		Expect(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Stop()
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	It("Should serve plugin over mutual TLS", func() {
+		writeTestCertificates(tmpDir)
+		tlsServer, listener, err := NewServer("127.0.0.1:0", &ServerTLSConfig{
+			CertFile:     filepath.Join(tmpDir, "server.crt"),
+			KeyFile:      filepath.Join(tmpDir, "server.key"),
+			ClientCAFile: filepath.Join(tmpDir, "ca.crt"),
+		}, provider)
+		Expect(err).Should(BeNil())
+		go func() {
+			_ = tlsServer.Serve(listener)
+		}()
+		defer tlsServer.Stop()
+
+		tlsCloud, err := Register(testProviderType, listener.Addr().String(), &config.CloudProviderPluginTLSConfig{
+			CAFile:     filepath.Join(tmpDir, "ca.crt"),
+			CertFile:   filepath.Join(tmpDir, "client.crt"),
+			KeyFile:    filepath.Join(tmpDir, "client.key"),
+			ServerName: "localhost",
+		}, 10*time.Second)
+		Expect(err).Should(BeNil())
+		tlsCloud.RemoveProviderAccount(&testAccountNamespacedName)
+		Expect(provider.removed).To(Equal([]types.NamespacedName{testAccountNamespacedName}))
+	})
+
+	It("Should reject plugin on TCP without TLS", func() {
+		_, err := Register(testProviderType, "localhost:50051", nil, 10*time.Second)
+		Expect(err).ShouldNot(BeNil())
+		_, _, err = NewServer("localhost:50051", nil, provider)
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("Should pass account credentials to plugin", func() {
+		var pollIntv uint = 1
+		account := &v1alpha1.CloudProviderAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudProviderAccountSpec{
+				PollIntervalInSeconds: &pollIntv,
+				PluginConfig: &v1alpha1.CloudProviderAccountPluginConfig{
+					Provider: string(testProviderType),
+					Region:   "region01",
+					SecretRef: &v1alpha1.SecretReference{
+						Name:      testAccountNamespacedName.Name,
+						Namespace: testAccountNamespacedName.Namespace,
+						Key:       credentials,
+					},
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Data: map[string][]byte{
+				credentials: []byte(`{"token": "secret"}`),
+			},
+		}
+		fakeClient := fake.NewClientBuilder().Build()
+		Expect(fakeClient.Create(context.Background(), secret)).Should(Succeed())
+
+		err := cloud.AddProviderAccount(fakeClient, account)
+		Expect(err).Should(BeNil())
+		Expect(string(provider.credentials)).To(Equal(`{"token": "secret"}`))
+		Expect(provider.account.Spec.PluginConfig.Region).To(Equal("region01"))
+
+		cloud.RemoveProviderAccount(&testAccountNamespacedName)
+		Expect(provider.removed).To(Equal([]types.NamespacedName{testAccountNamespacedName}))
+	})
+
+	It("Should return capabilities from plugin", func() {
+		Expect(cloud.GetCapabilities()).To(Equal(v1alpha1.CloudCapabilities{}))
+
+		provider.caps = v1alpha1.CloudCapabilities{SecurityGroups: true, PortRanges: true, MaxRules: 100}
+		Expect(cloud.GetCapabilities()).To(Equal(provider.caps))
+	})
+
+	It("Should return inventory from plugin", func() {
+		provider.vpcs = map[string]*runtimev1alpha1.Vpc{
+			"vpc01": {Status: runtimev1alpha1.VpcStatus{Id: "vpc01", Cidrs: []string{"10.0.0.0/16"}}},
+		}
+		provider.vms = map[string]*runtimev1alpha1.VirtualMachine{
+			"vm01": {Status: runtimev1alpha1.VirtualMachineStatus{CloudId: "vm01", CloudVpcId: "vpc01"}},
+		}
+
+		vpcs, err := cloud.GetVpcInventory(&testAccountNamespacedName)
+		Expect(err).Should(BeNil())
+		Expect(vpcs).To(Equal(provider.vpcs))
+
+		vms, err := cloud.InstancesGivenProviderAccount(&testAccountNamespacedName)
+		Expect(err).Should(BeNil())
+		Expect(vms).To(Equal(provider.vms))
+
+		provider.vms = nil
+		vms, err = cloud.InstancesGivenProviderAccount(&testAccountNamespacedName)
+		Expect(err).Should(BeNil())
+		Expect(vms).To(BeEmpty())
+	})
+
+	It("Should pass security group rules and members to plugin", func() {
+		sg := &securitygroup.CloudResource{
+			Type:            securitygroup.CloudResourceTypeVM,
+			CloudResourceID: securitygroup.CloudResourceID{Name: "sg01", Vpc: "vpc01"},
+			AccountID:       testAccountNamespacedName.String(),
+			CloudProvider:   string(testProviderType),
+		}
+		id, err := cloud.CreateSecurityGroup(sg, false)
+		Expect(err).Should(BeNil())
+		Expect(*id).To(Equal("sg01"))
+
+		port := 22
+		protocol := 6
+		_, srcIP, _ := net.ParseCIDR("192.168.1.0/24")
+		rules := []*securitygroup.CloudRule{
+			{
+				Rule: &securitygroup.IngressRule{
+					FromPort:  &port,
+					FromSrcIP: []*net.IPNet{srcIP},
+					Protocol:  &protocol,
+				},
+				NetworkPolicy: "ns/anp01",
+				AppliedToGrp:  "sg01",
+			},
+			{
+				Rule: &securitygroup.EgressRule{
+					ToSecurityGroups: []*securitygroup.CloudResourceID{{Name: "sg02", Vpc: "vpc01"}},
+					Protocol:         &protocol,
+				},
+				AppliedToGrp: "sg01",
+			},
+		}
+		err = cloud.UpdateSecurityGroupRules(sg, rules, nil, rules)
+		Expect(err).Should(BeNil())
+		Expect(provider.addRules).To(HaveLen(2))
+		ingressRule, ok := provider.addRules[0].Rule.(*securitygroup.IngressRule)
+		Expect(ok).To(BeTrue())
+		Expect(*ingressRule.FromPort).To(Equal(port))
+		Expect(*ingressRule.Protocol).To(Equal(protocol))
+		Expect(ingressRule.FromSrcIP).To(HaveLen(1))
+		Expect(ingressRule.FromSrcIP[0].String()).To(Equal(srcIP.String()))
+		Expect(provider.addRules[0].NetworkPolicy).To(Equal("ns/anp01"))
+		Expect(provider.addRules[1].Rule).To(Equal(rules[1].Rule))
+
+		members := []*securitygroup.CloudResource{{
+			Type:            securitygroup.CloudResourceTypeVM,
+			CloudResourceID: securitygroup.CloudResourceID{Name: "vm01", Vpc: "vpc01"},
+		}}
+		err = cloud.UpdateSecurityGroupMembers(sg, members, false)
+		Expect(err).Should(BeNil())
+		Expect(provider.members).To(Equal(members))
+	})
+
+	It("Should return enforced security and limits from plugin", func() {
+		port := 80
+		_, dstIP, _ := net.ParseCIDR("10.0.0.0/16")
+		provider.content = []securitygroup.SynchronizationContent{{
+			Resource: securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "sg01", Vpc: "vpc01"},
+			},
+			Members: []securitygroup.CloudResource{{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "vm01", Vpc: "vpc01"},
+			}},
+			EgressRules: []securitygroup.EgressRule{{
+				ToPort:   &port,
+				ToDstIP:  []*net.IPNet{dstIP},
+				Action:   securitygroup.RuleActionDeny,
+				Priority: &securitygroup.RulePriority{Tier: 250, Policy: 1.5, Rule: 2},
+			}},
+			Shard: 1,
+		}}
+		provider.limits = securitygroup.SecurityGroupLimits{MaxRules: 60, MaxSecurityGroupsPerInterface: 5, RulePerPeer: true}
+
+		Expect(cloud.GetEnforcedSecurity()).To(Equal(provider.content))
+		Expect(cloud.GetSecurityGroupLimits()).To(Equal(provider.limits))
+	})
+
+	It("Should propagate plugin errors", func() {
+		provider.pollErr = fmt.Errorf("inventory poll failed")
+		err := cloud.DoInventoryPoll(&testAccountNamespacedName)
+		Expect(err).Should(MatchError("inventory poll failed"))
+	})
+
+	It("Should fail when plugin is not reachable", func() {
+		server.Stop()
+		err := cloud.DoInventoryPoll(&testAccountNamespacedName)
+		Expect(err).ShouldNot(BeNil())
+	})
+})
@@ -0,0 +1,135 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/local"
+
+	"antrea.io/nephe/pkg/config"
+)
+
+// unixScheme is the gRPC target scheme of unix domain sockets. Abstract unix sockets use another scheme, and are
+// not accepted as they are not protected by file permissions.
+const unixScheme = "unix:"
+
+// ServerTLSConfig configures mutual TLS of a plugin server.
+type ServerTLSConfig struct {
+	// CertFile is the certificate of the plugin.
+	CertFile string
+	// KeyFile is the private key of CertFile.
+	KeyFile string
+	// ClientCAFile is the CA bundle verifying the client certificate of nephe-controller.
+	ClientCAFile string
+}
+
+// IsUnixSocketAddress returns true if address is the gRPC target of a unix domain socket, e.g.
+// unix:///var/run/nephe/plugin.sock.
+func IsUnixSocketAddress(address string) bool {
+	return strings.HasPrefix(address, unixScheme)
+}
+
+// clientCredentials returns the transport credentials of nephe-controller to the plugin at address. Account
+// credentials are sent to plugins, hence a plugin is either served on a unix domain socket, or with mutual TLS.
+func clientCredentials(address string, tlsConfig *config.CloudProviderPluginTLSConfig) (credentials.TransportCredentials, error) {
+	if tlsConfig == nil {
+		if !IsUnixSocketAddress(address) {
+			return nil, fmt.Errorf("address %v is not a unix domain socket and TLS is not configured", address)
+		}
+		return local.NewCredentials(), nil
+	}
+	caPool, err := loadCAFile(tlsConfig.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %v", err)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+		ServerName:   tlsConfig.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// NewServer creates the gRPC server of a Provider, and its listener at address. A plugin listening on a TCP
+// address must be served with mutual TLS, verifying the client certificate of nephe-controller.
+func NewServer(address string, tlsConfig *ServerTLSConfig, p Provider) (*grpc.Server, net.Listener, error) {
+	var creds credentials.TransportCredentials
+	if tlsConfig == nil {
+		if !IsUnixSocketAddress(address) {
+			return nil, nil, fmt.Errorf("address %v is not a unix domain socket and TLS is not configured", address)
+		}
+		creds = local.NewCredentials()
+	} else {
+		caPool, err := loadCAFile(tlsConfig.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		cert, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load server certificate: %v", err)
+		}
+		creds = credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    caPool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		})
+	}
+
+	listener, err := listen(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	server := grpc.NewServer(grpc.Creds(creds))
+	RegisterProviderServer(server, p)
+	return server, listener, nil
+}
+
+// listen listens on the unix domain socket or TCP address, removing the stale socket file of a previous run.
+func listen(address string) (net.Listener, error) {
+	if !IsUnixSocketAddress(address) {
+		return net.Listen("tcp", address)
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(address, unixScheme), "//")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket %v: %v", path, err)
+	}
+	return net.Listen("unix", path)
+}
+
+// loadCAFile returns the certificate pool of a PEM encoded CA bundle.
+func loadCAFile(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %v", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in CA file %v", caFile)
+	}
+	return caPool, nil
+}
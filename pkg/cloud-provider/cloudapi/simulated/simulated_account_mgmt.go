@@ -0,0 +1,105 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// simulatedAccountConfig is the account config of a simulated cloud account. The simulated cloud needs no credentials,
+// instead it carries the inventory seeding the simulated cloud and the fault injection knobs.
+type simulatedAccountConfig struct {
+	region    string
+	inventory string
+	faults    simulatedFaults
+}
+
+// setAccountCredentials sets account credentials.
+func setAccountCredentials(client client.Client, _ *types.NamespacedName, credentials interface{}) (interface{}, error) {
+	simulatedProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountSimulatedConfig)
+	if simulatedProviderConfig.ConfigMapRef == nil {
+		return nil, fmt.Errorf("simulated cloud inventory configmap not configured")
+	}
+	inventory, err := extractConfigMap(client, simulatedProviderConfig.ConfigMapRef)
+	if err != nil {
+		return nil, err
+	}
+
+	failedOperations := make(map[string]struct{})
+	for _, operation := range simulatedProviderConfig.FailedOperations {
+		failedOperations[strings.TrimSpace(operation)] = struct{}{}
+	}
+	simulatedConfig := &simulatedAccountConfig{
+		region:    strings.TrimSpace(simulatedProviderConfig.Region),
+		inventory: inventory,
+		faults: simulatedFaults{
+			latency:           time.Duration(simulatedProviderConfig.LatencyInMilliseconds) * time.Millisecond,
+			failurePercentage: simulatedProviderConfig.FailurePercentage,
+			failedOperations:  failedOperations,
+		},
+	}
+	return simulatedConfig, nil
+}
+
+func compareAccountCredentials(accountName string, existing interface{}, new interface{}) bool {
+	existingConfig := existing.(*simulatedAccountConfig)
+	newConfig := new.(*simulatedAccountConfig)
+
+	credsChanged := false
+	if strings.Compare(existingConfig.inventory, newConfig.inventory) != 0 {
+		credsChanged = true
+		simulatedPluginLogger().Info("account inventory updated", "account", accountName)
+	}
+	if !reflect.DeepEqual(existingConfig.faults, newConfig.faults) {
+		credsChanged = true
+		simulatedPluginLogger().Info("account fault injection updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.region, newConfig.region) != 0 {
+		credsChanged = true
+		simulatedPluginLogger().Info("account region updated", "account", accountName)
+	}
+	return credsChanged
+}
+
+// extractConfigMap extracts the simulated cloud inventory from a Kubernetes configmap.
+func extractConfigMap(c client.Client, s *crdv1alpha1.ConfigMapReference) (string, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "ConfigMap",
+		Version: "v1",
+	})
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
+		return "", err
+	}
+
+	data, _ := u.Object["data"].(map[string]interface{})
+	inventory, ok := data[s.Key].(string)
+	if !ok {
+		return "", fmt.Errorf("configmap %v/%v does not have the key %v", s.Namespace, s.Name, s.Key)
+	}
+	return inventory, nil
+}
@@ -0,0 +1,480 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mohae/deepcopy"
+	"gopkg.in/yaml.v2"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// Simulated cloud API calls. Any of them may be listed in failedOperations of the account to always fail.
+const (
+	operationListVpcs                 = "ListVpcs"
+	operationListInstances            = "ListInstances"
+	operationListSecurityGroups       = "ListSecurityGroups"
+	operationCreateSecurityGroup      = "CreateSecurityGroup"
+	operationDeleteSecurityGroup      = "DeleteSecurityGroup"
+	operationUpdateSecurityGroupRules = "UpdateSecurityGroupRules"
+	operationUpdateNetworkInterfaces  = "UpdateNetworkInterfaces"
+)
+
+const (
+	simulatedVpcDefaultSecurityGroupName = "default"
+	simulatedDefaultInstanceState        = "running"
+)
+
+// simulatedInventory is the format of the configmap key seeding a simulated cloud account.
+type simulatedInventory struct {
+	Vpcs      []*simulatedVpc      `yaml:"vpcs"`
+	Instances []*simulatedInstance `yaml:"instances"`
+}
+
+type simulatedVpc struct {
+	ID    string            `yaml:"id"`
+	Name  string            `yaml:"name"`
+	Cidrs []string          `yaml:"cidrs"`
+	Tags  map[string]string `yaml:"tags"`
+}
+
+type simulatedInstance struct {
+	ID                string                       `yaml:"id"`
+	Name              string                       `yaml:"name"`
+	VpcID             string                       `yaml:"vpcId"`
+	State             string                       `yaml:"state"`
+	Tags              map[string]string            `yaml:"tags"`
+	NetworkInterfaces []*simulatedNetworkInterface `yaml:"networkInterfaces"`
+}
+
+type simulatedNetworkInterface struct {
+	ID         string   `yaml:"id"`
+	PrivateIPs []string `yaml:"privateIPs"`
+	PublicIPs  []string `yaml:"publicIPs"`
+	// SecurityGroups are names of security groups, not created by nephe, attached to the network interface. If not
+	// specified, the network interface is attached to the vpc default security group.
+	SecurityGroups []string `yaml:"securityGroups"`
+	// securityGroupIDs are IDs of all security groups currently attached to the network interface.
+	securityGroupIDs map[string]struct{}
+}
+
+type simulatedSecurityGroup struct {
+	id    string
+	name  string
+	vpcID string
+	// rules of the security group, keyed by cloud rule hash.
+	rules map[string]*securitygroup.CloudRule
+}
+
+// simulatedFaults are the latency and failures injected into simulated cloud API calls.
+type simulatedFaults struct {
+	latency           time.Duration
+	failurePercentage uint
+	failedOperations  map[string]struct{}
+}
+
+// simulatedCloudAPI is the in-memory cloud of a simulated account. It keeps vpcs, instances, network interfaces and
+// security groups, and realizes security group rules and memberships like a real cloud would.
+type simulatedCloudAPI struct {
+	mutex               sync.Mutex
+	faults              simulatedFaults
+	vpcs                map[string]*simulatedVpc
+	instances           map[string]*simulatedInstance
+	securityGroups      map[string]*simulatedSecurityGroup
+	lastSecurityGroupID int
+}
+
+// newSimulatedCloudAPI creates the in-memory cloud seeded with the inventory.
+func newSimulatedCloudAPI(inventoryData string, faults simulatedFaults) (*simulatedCloudAPI, error) {
+	inventory := &simulatedInventory{}
+	if err := yaml.Unmarshal([]byte(inventoryData), inventory); err != nil {
+		return nil, fmt.Errorf("invalid simulated cloud inventory: %v", err)
+	}
+
+	api := &simulatedCloudAPI{
+		faults:         faults,
+		vpcs:           make(map[string]*simulatedVpc),
+		instances:      make(map[string]*simulatedInstance),
+		securityGroups: make(map[string]*simulatedSecurityGroup),
+	}
+	// cloud resource IDs are case-insensitive, like the IDs of VirtualMachine and Vpc objects.
+	for _, vpc := range inventory.Vpcs {
+		vpc.ID = strings.ToLower(vpc.ID)
+		if len(vpc.ID) == 0 {
+			return nil, fmt.Errorf("invalid simulated cloud inventory: vpc id cannot be empty")
+		}
+		if _, found := api.vpcs[vpc.ID]; found {
+			return nil, fmt.Errorf("invalid simulated cloud inventory: duplicate vpc %v", vpc.ID)
+		}
+		for _, cidr := range vpc.Cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("invalid simulated cloud inventory: vpc %v: %v", vpc.ID, err)
+			}
+		}
+		if len(vpc.Name) == 0 {
+			vpc.Name = vpc.ID
+		}
+		api.vpcs[vpc.ID] = vpc
+		api.createSecurityGroup(simulatedVpcDefaultSecurityGroupName, vpc.ID)
+	}
+
+	networkInterfaceIDs := make(map[string]struct{})
+	for _, instance := range inventory.Instances {
+		instance.ID = strings.ToLower(instance.ID)
+		instance.VpcID = strings.ToLower(instance.VpcID)
+		if len(instance.ID) == 0 {
+			return nil, fmt.Errorf("invalid simulated cloud inventory: instance id cannot be empty")
+		}
+		if _, found := api.instances[instance.ID]; found {
+			return nil, fmt.Errorf("invalid simulated cloud inventory: duplicate instance %v", instance.ID)
+		}
+		if _, found := api.vpcs[instance.VpcID]; !found {
+			return nil, fmt.Errorf("invalid simulated cloud inventory: instance %v vpc %v not found", instance.ID, instance.VpcID)
+		}
+		if len(instance.Name) == 0 {
+			instance.Name = instance.ID
+		}
+		if len(instance.State) == 0 {
+			instance.State = simulatedDefaultInstanceState
+		}
+		for _, networkInterface := range instance.NetworkInterfaces {
+			networkInterface.ID = strings.ToLower(networkInterface.ID)
+			if len(networkInterface.ID) == 0 {
+				return nil, fmt.Errorf("invalid simulated cloud inventory: instance %v network interface id cannot be empty",
+					instance.ID)
+			}
+			if _, found := networkInterfaceIDs[networkInterface.ID]; found {
+				return nil, fmt.Errorf("invalid simulated cloud inventory: duplicate network interface %v", networkInterface.ID)
+			}
+			networkInterfaceIDs[networkInterface.ID] = struct{}{}
+			for _, ip := range append(networkInterface.PrivateIPs, networkInterface.PublicIPs...) {
+				if net.ParseIP(ip) == nil {
+					return nil, fmt.Errorf("invalid simulated cloud inventory: network interface %v invalid ip %v",
+						networkInterface.ID, ip)
+				}
+			}
+
+			networkInterface.securityGroupIDs = make(map[string]struct{})
+			sgNames := networkInterface.SecurityGroups
+			if len(sgNames) == 0 {
+				sgNames = []string{simulatedVpcDefaultSecurityGroupName}
+			}
+			for _, sgName := range sgNames {
+				sg := api.createSecurityGroup(sgName, instance.VpcID)
+				networkInterface.securityGroupIDs[sg.id] = struct{}{}
+			}
+		}
+		api.instances[instance.ID] = instance
+	}
+	return api, nil
+}
+
+// setFaults updates the latency and failures injected into simulated cloud API calls.
+func (api *simulatedCloudAPI) setFaults(faults simulatedFaults) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	api.faults = faults
+}
+
+// call simulates a cloud API call, applying the injected latency and failures.
+func (api *simulatedCloudAPI) call(operation string) error {
+	api.mutex.Lock()
+	faults := api.faults
+	api.mutex.Unlock()
+
+	time.Sleep(faults.latency)
+	if _, found := faults.failedOperations[operation]; found {
+		return fmt.Errorf("simulated cloud %v failed", operation)
+	}
+	if faults.failurePercentage > 0 && uint(rand.Intn(100)) < faults.failurePercentage {
+		return fmt.Errorf("simulated cloud %v failed at random", operation)
+	}
+	return nil
+}
+
+// listVpcs returns copies of all vpcs.
+func (api *simulatedCloudAPI) listVpcs() ([]*simulatedVpc, error) {
+	if err := api.call(operationListVpcs); err != nil {
+		return nil, err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	vpcs := make([]*simulatedVpc, 0, len(api.vpcs))
+	for _, vpc := range api.vpcs {
+		vpcs = append(vpcs, deepcopy.Copy(vpc).(*simulatedVpc))
+	}
+	return vpcs, nil
+}
+
+// listInstances returns copies of all instances, including the security groups attached to their network interfaces.
+func (api *simulatedCloudAPI) listInstances() ([]*simulatedInstance, error) {
+	if err := api.call(operationListInstances); err != nil {
+		return nil, err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	instances := make([]*simulatedInstance, 0, len(api.instances))
+	for _, instance := range api.instances {
+		instances = append(instances, copyInstance(instance))
+	}
+	return instances, nil
+}
+
+// listSecurityGroups returns copies of all security groups of the vpcs.
+func (api *simulatedCloudAPI) listSecurityGroups(vpcIDs map[string]struct{}) ([]*simulatedSecurityGroup, error) {
+	if err := api.call(operationListSecurityGroups); err != nil {
+		return nil, err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	var sgs []*simulatedSecurityGroup
+	for _, sg := range api.securityGroups {
+		if _, found := vpcIDs[sg.vpcID]; !found {
+			continue
+		}
+		sgCopy := &simulatedSecurityGroup{
+			id:    sg.id,
+			name:  sg.name,
+			vpcID: sg.vpcID,
+			rules: make(map[string]*securitygroup.CloudRule, len(sg.rules)),
+		}
+		for hash, rule := range sg.rules {
+			sgCopy.rules[hash] = copyCloudRule(rule)
+		}
+		sgs = append(sgs, sgCopy)
+	}
+	return sgs, nil
+}
+
+// createOrGetSecurityGroup creates the security group in the vpc, if it does not exist, and returns its ID.
+func (api *simulatedCloudAPI) createOrGetSecurityGroup(sgName string, vpcID string) (string, error) {
+	if err := api.call(operationCreateSecurityGroup); err != nil {
+		return "", err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	vpcID = strings.ToLower(vpcID)
+	if _, found := api.vpcs[vpcID]; !found {
+		return "", fmt.Errorf("vpc %v not found", vpcID)
+	}
+	return api.createSecurityGroup(sgName, vpcID).id, nil
+}
+
+// deleteSecurityGroup detaches the security group from all network interfaces and deletes it.
+func (api *simulatedCloudAPI) deleteSecurityGroup(sgName string, vpcID string) error {
+	if err := api.call(operationDeleteSecurityGroup); err != nil {
+		return err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	sg := api.getSecurityGroup(sgName, vpcID)
+	if sg == nil {
+		return nil
+	}
+	for _, instance := range api.instances {
+		for _, networkInterface := range instance.NetworkInterfaces {
+			if _, found := networkInterface.securityGroupIDs[sg.id]; !found {
+				continue
+			}
+			delete(networkInterface.securityGroupIDs, sg.id)
+			api.attachVpcDefaultSecurityGroupIfNeeded(networkInterface, instance.VpcID)
+		}
+	}
+	delete(api.securityGroups, sg.id)
+	return nil
+}
+
+// updateSecurityGroupRules adds and removes rules of the security group. Security groups referred by the rules must
+// exist in the vpc.
+func (api *simulatedCloudAPI) updateSecurityGroupRules(sgName string, vpcID string, addRules,
+	rmRules []*securitygroup.CloudRule) error {
+	if err := api.call(operationUpdateSecurityGroupRules); err != nil {
+		return err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	sg := api.getSecurityGroup(sgName, vpcID)
+	if sg == nil {
+		return fmt.Errorf("security group %v not found in vpc %v", sgName, vpcID)
+	}
+	for _, rule := range addRules {
+		for _, group := range getRuleSecurityGroups(rule) {
+			if api.getSecurityGroup(group.GetCloudName(true), group.Vpc) == nil {
+				return fmt.Errorf("security group %v not found in vpc %v", group.GetCloudName(true), group.Vpc)
+			}
+		}
+	}
+
+	for _, rule := range rmRules {
+		delete(sg.rules, getCloudRuleHash(rule))
+	}
+	for _, rule := range addRules {
+		sg.rules[getCloudRuleHash(rule)] = copyCloudRule(rule)
+	}
+	return nil
+}
+
+// updateSecurityGroupMembers attaches the security group to network interfaces of members, and detaches it from any
+// other network interfaces in the vpc. Like a real cloud, network interfaces attached to an appliedTo security group
+// are detached from security groups not created by nephe, and network interfaces left without any appliedTo or
+// other security group are attached to the vpc default security group.
+func (api *simulatedCloudAPI) updateSecurityGroupMembers(sgName string, vpcID string, members []*securitygroup.CloudResource,
+	membershipOnly bool) error {
+	if err := api.call(operationUpdateNetworkInterfaces); err != nil {
+		return err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	sg := api.getSecurityGroup(sgName, vpcID)
+	if sg == nil {
+		return fmt.Errorf("security group %v not found in vpc %v", sgName, vpcID)
+	}
+
+	memberInstances, memberNetworkInterfaces := securitygroup.FindResourcesBasedOnKind(members)
+	for _, instance := range api.instances {
+		if !strings.EqualFold(instance.VpcID, vpcID) {
+			continue
+		}
+		_, isInstanceMember := memberInstances[strings.ToLower(instance.ID)]
+		for _, networkInterface := range instance.NetworkInterfaces {
+			_, isNetworkInterfaceMember := memberNetworkInterfaces[strings.ToLower(networkInterface.ID)]
+			if isInstanceMember || isNetworkInterfaceMember {
+				if !membershipOnly {
+					api.detachOtherSecurityGroups(networkInterface)
+				}
+				networkInterface.securityGroupIDs[sg.id] = struct{}{}
+			} else {
+				delete(networkInterface.securityGroupIDs, sg.id)
+			}
+			api.attachVpcDefaultSecurityGroupIfNeeded(networkInterface, instance.VpcID)
+		}
+	}
+	return nil
+}
+
+// createSecurityGroup returns the security group with the name in the vpc, creating it if it does not exist.
+// Caller must hold the mutex.
+func (api *simulatedCloudAPI) createSecurityGroup(sgName string, vpcID string) *simulatedSecurityGroup {
+	if sg := api.getSecurityGroup(sgName, vpcID); sg != nil {
+		return sg
+	}
+	api.lastSecurityGroupID++
+	sg := &simulatedSecurityGroup{
+		id:    fmt.Sprintf("sg-%08d", api.lastSecurityGroupID),
+		name:  sgName,
+		vpcID: vpcID,
+		rules: make(map[string]*securitygroup.CloudRule),
+	}
+	api.securityGroups[sg.id] = sg
+	return sg
+}
+
+// getSecurityGroup returns the security group with the name in the vpc. Caller must hold the mutex.
+func (api *simulatedCloudAPI) getSecurityGroup(sgName string, vpcID string) *simulatedSecurityGroup {
+	for _, sg := range api.securityGroups {
+		if strings.EqualFold(sg.vpcID, vpcID) && strings.EqualFold(sg.name, sgName) {
+			return sg
+		}
+	}
+	return nil
+}
+
+// detachOtherSecurityGroups detaches security groups not created by nephe from the network interface. Caller must hold
+// the mutex.
+func (api *simulatedCloudAPI) detachOtherSecurityGroups(networkInterface *simulatedNetworkInterface) {
+	for sgID := range networkInterface.securityGroupIDs {
+		sg, found := api.securityGroups[sgID]
+		if !found {
+			continue
+		}
+		if _, isAG, isAT := securitygroup.IsNepheControllerCreatedSG(sg.name); !isAG && !isAT {
+			delete(networkInterface.securityGroupIDs, sgID)
+		}
+	}
+}
+
+// attachVpcDefaultSecurityGroupIfNeeded attaches the vpc default security group to the network interface if it is not
+// attached to any appliedTo security group or security group not created by nephe. Caller must hold the mutex.
+func (api *simulatedCloudAPI) attachVpcDefaultSecurityGroupIfNeeded(networkInterface *simulatedNetworkInterface, vpcID string) {
+	for sgID := range networkInterface.securityGroupIDs {
+		sg, found := api.securityGroups[sgID]
+		if !found {
+			continue
+		}
+		if _, isAG, _ := securitygroup.IsNepheControllerCreatedSG(sg.name); !isAG {
+			return
+		}
+	}
+	defaultSg := api.createSecurityGroup(simulatedVpcDefaultSecurityGroupName, vpcID)
+	networkInterface.securityGroupIDs[defaultSg.id] = struct{}{}
+}
+
+// copyInstance returns a deep copy of the instance.
+func copyInstance(instance *simulatedInstance) *simulatedInstance {
+	instanceCopy := deepcopy.Copy(instance).(*simulatedInstance)
+	// unexported fields are not copied by deepcopy.
+	for i, networkInterface := range instance.NetworkInterfaces {
+		sgIDs := make(map[string]struct{}, len(networkInterface.securityGroupIDs))
+		for sgID := range networkInterface.securityGroupIDs {
+			sgIDs[sgID] = struct{}{}
+		}
+		instanceCopy.NetworkInterfaces[i].securityGroupIDs = sgIDs
+	}
+	return instanceCopy
+}
+
+// copyCloudRule returns a deep copy of the cloud rule.
+func copyCloudRule(rule *securitygroup.CloudRule) *securitygroup.CloudRule {
+	return &securitygroup.CloudRule{
+		Hash:          rule.Hash,
+		Rule:          deepcopy.Copy(rule.Rule).(securitygroup.Rule),
+		NetworkPolicy: rule.NetworkPolicy,
+		AppliedToGrp:  rule.AppliedToGrp,
+	}
+}
+
+// getCloudRuleHash returns the hash identifying the cloud rule.
+func getCloudRuleHash(rule *securitygroup.CloudRule) string {
+	if len(rule.Hash) > 0 {
+		return rule.Hash
+	}
+	return rule.GetHash()
+}
+
+// getRuleSecurityGroups returns the address groups referred by the cloud rule.
+func getRuleSecurityGroups(rule *securitygroup.CloudRule) []*securitygroup.CloudResourceID {
+	switch r := rule.Rule.(type) {
+	case *securitygroup.IngressRule:
+		return r.FromSecurityGroups
+	case *securitygroup.EgressRule:
+		return r.ToSecurityGroups
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+type computeServiceConfig struct {
+	accountNamespacedName types.NamespacedName
+	api                   *simulatedCloudAPI
+	resourcesCache        *internal.CloudServiceResourcesCache
+	inventoryStats        *internal.CloudServiceStats
+	// instanceFilters has following possible values
+	// - empty map indicates no selectors configured for this account. NO cloud api call for inventory will be made.
+	// - non-empty map indicates selectors are configured. Cloud api call for inventory will be made.
+	// - key with nil value indicates no filters. Get all instances for account.
+	// - key with non-nil value indicates some filter. Get instances matching those filters only.
+	instanceFilters map[string][]*simulatedInstanceFilter
+	credentials     *simulatedAccountConfig
+}
+
+// computeResourcesCacheSnapshot holds the results from querying for all instances.
+type computeResourcesCacheSnapshot struct {
+	instances map[cloudcommon.InstanceID]*simulatedInstance
+	vpcs      []*simulatedVpc
+	vpcIDs    map[string]struct{}
+}
+
+func newComputeServiceConfig(accountNamespacedName types.NamespacedName, credentials *simulatedAccountConfig) (
+	internal.CloudServiceInterface, error) {
+	// create the in-memory cloud seeded with the account inventory.
+	api, err := newSimulatedCloudAPI(credentials.inventory, credentials.faults)
+	if err != nil {
+		return nil, fmt.Errorf("error creating simulated cloud for account : %v, err: %v", accountNamespacedName.String(), err)
+	}
+
+	config := &computeServiceConfig{
+		api:                   api,
+		accountNamespacedName: accountNamespacedName,
+		resourcesCache:        &internal.CloudServiceResourcesCache{},
+		inventoryStats:        &internal.CloudServiceStats{},
+		instanceFilters:       make(map[string][]*simulatedInstanceFilter),
+		credentials:           credentials,
+	}
+	return config, nil
+}
+
+func (computeCfg *computeServiceConfig) waitForInventoryInit(duration time.Duration) error {
+	operation := func() error {
+		done := computeCfg.inventoryStats.IsInventoryInitialized()
+		if !done {
+			return fmt.Errorf("inventory for account %v not initialized (waited %v duration)", computeCfg.accountNamespacedName, duration)
+		}
+		return nil
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = duration
+
+	return backoff.Retry(operation, b)
+}
+
+// getInstanceResourceFilters returns filters to be applied to listed instances if filters are configured.
+// Otherwise, returns (nil, false). false indicates no selectors configured for the account and hence no cloud api needs
+// to be made for instance inventory.
+func (computeCfg *computeServiceConfig) getInstanceResourceFilters() ([]*simulatedInstanceFilter, bool) {
+	var allFilters []*simulatedInstanceFilter
+
+	if len(computeCfg.instanceFilters) == 0 {
+		return nil, false
+	}
+
+	for _, filters := range computeCfg.instanceFilters {
+		// if any selector found with nil filter, skip all other selectors. As nil indicates all
+		if len(filters) == 0 {
+			return nil, true
+		}
+		allFilters = append(allFilters, filters...)
+	}
+	return allFilters, true
+}
+
+// getCachedInstances returns instances from the cache for the account.
+func (computeCfg *computeServiceConfig) getCachedInstances() []*simulatedInstance {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		simulatedPluginLogger().V(4).Info("cache snapshot nil", "service", simulatedComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []*simulatedInstance{}
+	}
+	instances := snapshot.(*computeResourcesCacheSnapshot).instances
+	instancesToReturn := make([]*simulatedInstance, 0, len(instances))
+	for _, instance := range instances {
+		instancesToReturn = append(instancesToReturn, instance)
+	}
+	simulatedPluginLogger().V(1).Info("cached vm instances", "service", simulatedComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(instancesToReturn))
+	return instancesToReturn
+}
+
+// getManagedVpcIDs returns IDs of vpcs containing managed vms.
+func (computeCfg *computeServiceConfig) getManagedVpcIDs() map[string]struct{} {
+	vpcIDsCopy := make(map[string]struct{})
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		simulatedPluginLogger().V(4).Info("cache snapshot nil", "service", simulatedComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return vpcIDsCopy
+	}
+	for vpcID := range snapshot.(*computeResourcesCacheSnapshot).vpcIDs {
+		vpcIDsCopy[vpcID] = struct{}{}
+	}
+	return vpcIDsCopy
+}
+
+// getCachedVpcs returns vpcs from cached snapshot for the account.
+func (computeCfg *computeServiceConfig) getCachedVpcs() []*simulatedVpc {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		simulatedPluginLogger().V(4).Info("cache snapshot nil", "service", simulatedComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []*simulatedVpc{}
+	}
+	vpcs := snapshot.(*computeResourcesCacheSnapshot).vpcs
+	vpcsToReturn := make([]*simulatedVpc, 0, len(vpcs))
+	vpcsToReturn = append(vpcsToReturn, vpcs...)
+	return vpcsToReturn
+}
+
+// getInstances gets instances from the simulated cloud, applying the configured filters.
+func (computeCfg *computeServiceConfig) getInstances(vpcs map[string]*simulatedVpc) ([]*simulatedInstance, error) {
+	filters, hasFilters := computeCfg.getInstanceResourceFilters()
+	if !hasFilters {
+		simulatedPluginLogger().V(1).Info("fetching vm resources from cloud skipped",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "not-configured")
+		return nil, nil
+	}
+
+	allInstances, err := computeCfg.api.listInstances()
+	if err != nil {
+		return nil, err
+	}
+	if filters == nil {
+		simulatedPluginLogger().V(1).Info("fetching vm resources from cloud",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "all(nil)")
+		return allInstances, nil
+	}
+
+	simulatedPluginLogger().V(1).Info("fetching vm resources from cloud",
+		"account", computeCfg.accountNamespacedName, "resource-filters", "configured")
+	var instances []*simulatedInstance
+	for _, instance := range allInstances {
+		for _, filter := range filters {
+			if filter.matches(instance, vpcs[instance.VpcID]) {
+				instances = append(instances, instance)
+				break
+			}
+		}
+	}
+
+	simulatedPluginLogger().V(1).Info("vm instances from cloud", "service", simulatedComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(instances))
+
+	return instances, nil
+}
+
+// DoResourceInventory gets inventory from cloud for given cloud account.
+func (computeCfg *computeServiceConfig) DoResourceInventory() error {
+	vpcs, err := computeCfg.api.listVpcs()
+	if err != nil {
+		simulatedPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+	vpcsByID := make(map[string]*simulatedVpc)
+	for _, vpc := range vpcs {
+		vpcsByID[vpc.ID] = vpc
+	}
+
+	instances, err := computeCfg.getInstances(vpcsByID)
+	if err != nil {
+		simulatedPluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+
+	exists := struct{}{}
+	vpcIDs := make(map[string]struct{})
+	instanceIDs := make(map[cloudcommon.InstanceID]*simulatedInstance)
+	for _, instance := range instances {
+		instanceIDs[cloudcommon.InstanceID(strings.ToLower(instance.ID))] = instance
+		vpcIDs[instance.VpcID] = exists
+	}
+	computeCfg.resourcesCache.UpdateSnapshot(&computeResourcesCacheSnapshot{instanceIDs, vpcs, vpcIDs})
+	return nil
+}
+
+// SetResourceFilters add/updates instances resource filter for the service.
+func (computeCfg *computeServiceConfig) SetResourceFilters(selector *crdv1alpha1.CloudEntitySelector) {
+	if filters, found := convertSelectorToInstanceFilters(selector); found {
+		computeCfg.instanceFilters[selector.GetName()] = filters
+	} else {
+		if selector != nil {
+			delete(computeCfg.instanceFilters, selector.GetName())
+		}
+		computeCfg.resourcesCache.UpdateSnapshot(nil)
+	}
+}
+
+func (computeCfg *computeServiceConfig) RemoveResourceFilters(selectorName string) {
+	delete(computeCfg.instanceFilters, selectorName)
+}
+
+func (computeCfg *computeServiceConfig) GetInternalResourceObjects(namespace string,
+	account *types.NamespacedName) map[string]*runtimev1alpha1.VirtualMachine {
+	instances := computeCfg.getCachedInstances()
+	vmObjects := map[string]*runtimev1alpha1.VirtualMachine{}
+	for _, instance := range instances {
+		// build runtimev1alpha1 VirtualMachine object.
+		vmObject := instanceToInternalVirtualMachineObject(instance, namespace, account, computeCfg.credentials.region)
+		vmObjects[vmObject.Name] = vmObject
+	}
+
+	simulatedPluginLogger().V(1).Info("Internal resource objects", "Service", simulatedComputeServiceNameCompute,
+		"Account", computeCfg.accountNamespacedName, "VirtualMachine objects", len(vmObjects))
+
+	return vmObjects
+}
+
+func (computeCfg *computeServiceConfig) GetName() internal.CloudServiceName {
+	return simulatedComputeServiceNameCompute
+}
+
+func (computeCfg *computeServiceConfig) GetType() internal.CloudServiceType {
+	return internal.CloudServiceTypeCompute
+}
+
+func (computeCfg *computeServiceConfig) GetInventoryStats() *internal.CloudServiceStats {
+	return computeCfg.inventoryStats
+}
+
+func (computeCfg *computeServiceConfig) ResetCachedState() {
+	computeCfg.SetResourceFilters(nil)
+	computeCfg.inventoryStats.ResetInventoryPollStats()
+}
+
+// UpdateServiceConfig updates the service with the new account config. The simulated cloud, and hence the security
+// groups realized in it, is only re-seeded when the account inventory changes.
+func (computeCfg *computeServiceConfig) UpdateServiceConfig(newConfig internal.CloudServiceInterface) {
+	newComputeServiceConfig := newConfig.(*computeServiceConfig)
+	if computeCfg.credentials.inventory != newComputeServiceConfig.credentials.inventory {
+		computeCfg.api = newComputeServiceConfig.api
+	} else {
+		computeCfg.api.setFaults(newComputeServiceConfig.credentials.faults)
+	}
+	computeCfg.credentials = newComputeServiceConfig.credentials
+}
+
+// GetVpcInventory generates vpc object for the vpcs stored in snapshot(in cloud format) and return a map of vpc runtime objects.
+func (computeCfg *computeServiceConfig) GetVpcInventory() map[string]*runtimev1alpha1.Vpc {
+	vpcs := computeCfg.getCachedVpcs()
+	vpcIDs := computeCfg.getManagedVpcIDs()
+	// Convert to kubernetes object and return a map indexed using vpc ID.
+	vpcMap := map[string]*runtimev1alpha1.Vpc{}
+	for _, vpc := range vpcs {
+		_, managed := vpcIDs[vpc.ID]
+		vpcObj := vpcToInternalVpcObject(vpc, computeCfg.accountNamespacedName.Namespace,
+			computeCfg.accountNamespacedName.Name, strings.ToLower(computeCfg.credentials.region), managed)
+		vpcMap[strings.ToLower(vpc.ID)] = vpcObj
+	}
+
+	simulatedPluginLogger().V(1).Info("cached vpcs", "service", simulatedComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "vpc objects", len(vpcMap))
+
+	return vpcMap
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
+)
+
+var simulatedStateMap = map[string]runtimev1alpha1.VMState{
+	"pending":       runtimev1alpha1.Starting,
+	"starting":      runtimev1alpha1.Starting,
+	"running":       runtimev1alpha1.Running,
+	"stopping":      runtimev1alpha1.Stopping,
+	"stopped":       runtimev1alpha1.Stopped,
+	"shutting-down": runtimev1alpha1.ShuttingDown,
+	"terminated":    runtimev1alpha1.Stopped,
+}
+
+// instanceToInternalVirtualMachineObject converts simulated instance to VirtualMachine runtime object.
+func instanceToInternalVirtualMachineObject(instance *simulatedInstance, namespace string, account *types.NamespacedName,
+	region string) *runtimev1alpha1.VirtualMachine {
+	tags := make(map[string]string)
+	for key, value := range instance.Tags {
+		tags[key] = value
+	}
+
+	// Network interfaces associated with Virtual machine
+	networkInterfaces := make([]runtimev1alpha1.NetworkInterface, 0, len(instance.NetworkInterfaces))
+	for _, nwInf := range instance.NetworkInterfaces {
+		var ipAddressObjs []runtimev1alpha1.IPAddress
+		for _, ip := range nwInf.PrivateIPs {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeInternalIP,
+				Address:     ip,
+			})
+		}
+		for _, ip := range nwInf.PublicIPs {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeExternalIP,
+				Address:     ip,
+			})
+		}
+		networkInterface := runtimev1alpha1.NetworkInterface{
+			Name: strings.ToLower(nwInf.ID),
+			IPs:  ipAddressObjs,
+		}
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+
+	cloudID := strings.ToLower(instance.ID)
+	cloudNetworkID := strings.ToLower(instance.VpcID)
+	state, ok := simulatedStateMap[strings.ToLower(instance.State)]
+	if !ok {
+		state = runtimev1alpha1.Unknown
+	}
+	return utils.GenerateInternalVirtualMachineObject(cloudID, strings.ToLower(instance.Name), cloudID, strings.ToLower(region),
+		namespace, cloudNetworkID, cloudNetworkID, state, tags, networkInterfaces, providerType, account)
+}
+
+// vpcToInternalVpcObject converts simulated vpc to vpc runtime object.
+func vpcToInternalVpcObject(vpc *simulatedVpc, accountNamespace, accountName, region string, managed bool) *runtimev1alpha1.Vpc {
+	cloudID := strings.ToLower(vpc.ID)
+	tags := make(map[string]string)
+	for key, value := range vpc.Tags {
+		tags[key] = value
+	}
+	cidrs := make([]string, 0, len(vpc.Cidrs))
+	cidrs = append(cidrs, vpc.Cidrs...)
+
+	return utils.GenerateInternalVpcObject(cloudID, accountNamespace, accountName, strings.ToLower(vpc.Name),
+		cloudID, tags, runtimev1alpha1.SimulatedCloudProvider, region, cidrs, managed)
+}
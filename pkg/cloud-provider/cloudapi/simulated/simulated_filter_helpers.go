@@ -0,0 +1,83 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"strings"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// simulatedInstanceFilter is a filter for simulated cloud instances. Empty fields of the filter match any value.
+type simulatedInstanceFilter struct {
+	vpcID      string
+	vpcName    string
+	instanceID string
+	name       string
+}
+
+// convertSelectorToInstanceFilters converts vm selector to simulated instance filters.
+func convertSelectorToInstanceFilters(selector *crdv1alpha1.CloudEntitySelector) ([]*simulatedInstanceFilter, bool) {
+	if selector == nil {
+		return nil, false
+	}
+	if selector.Spec.VMSelector == nil {
+		return nil, true
+	}
+
+	return buildInstanceFilters(selector.Spec.VMSelector), true
+}
+
+// buildInstanceFilters builds instance filters for VirtualMachineSelector. Each vmMatch section of a vmSelector
+// generates a filter combined with the vpcMatch of the vmSelector.
+func buildInstanceFilters(vmSelector []crdv1alpha1.VirtualMachineSelector) []*simulatedInstanceFilter {
+	var filters []*simulatedInstanceFilter
+	for _, match := range vmSelector {
+		vpcFilter := simulatedInstanceFilter{}
+		if match.VpcMatch != nil {
+			vpcFilter.vpcID = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchID))
+			vpcFilter.vpcName = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchName))
+		}
+		if len(match.VMMatch) == 0 {
+			filter := vpcFilter
+			filters = append(filters, &filter)
+			continue
+		}
+		for _, vmMatch := range match.VMMatch {
+			filter := vpcFilter
+			filter.instanceID = strings.ToLower(strings.TrimSpace(vmMatch.MatchID))
+			filter.name = strings.ToLower(strings.TrimSpace(vmMatch.MatchName))
+			filters = append(filters, &filter)
+		}
+	}
+	return filters
+}
+
+// matches returns true if the instance, in given vpc, matches the filter.
+func (f *simulatedInstanceFilter) matches(instance *simulatedInstance, vpc *simulatedVpc) bool {
+	if len(f.instanceID) != 0 && f.instanceID != strings.ToLower(instance.ID) {
+		return false
+	}
+	if len(f.name) != 0 && f.name != strings.ToLower(instance.Name) {
+		return false
+	}
+	if len(f.vpcID) != 0 && f.vpcID != strings.ToLower(instance.VpcID) {
+		return false
+	}
+	if len(f.vpcName) != 0 && (vpc == nil || f.vpcName != strings.ToLower(vpc.Name)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+var (
+	mutex sync.Mutex
+)
+
+// getComputeService returns the compute service config of the account managing the cloud resource.
+func (c *simulatedCloud) getComputeService(resource *securitygroup.CloudResource) (*computeServiceConfig, error) {
+	accCfg, found := c.cloudCommon.GetCloudAccountByAccountId(&resource.AccountID)
+	if !found {
+		return nil, fmt.Errorf("simulated account not found managing virtual private cloud [%v]", resource.Vpc)
+	}
+	serviceCfg, err := accCfg.GetServiceConfigByName(simulatedComputeServiceNameCompute)
+	if err != nil {
+		return nil, err
+	}
+	return serviceCfg.(*computeServiceConfig), nil
+}
+
+func (computeCfg *computeServiceConfig) getNepheControllerManagedSecurityGroupsCloudView() []securitygroup.SynchronizationContent {
+	vpcIDs := computeCfg.getManagedVpcIDs()
+	if len(vpcIDs) == 0 {
+		return []securitygroup.SynchronizationContent{}
+	}
+
+	instances, err := computeCfg.api.listInstances()
+	if err != nil {
+		simulatedPluginLogger().Error(err, "failed to get instances of vpcs", "vpc-ids", vpcIDs)
+		return []securitygroup.SynchronizationContent{}
+	}
+	cloudSecurityGroups, err := computeCfg.api.listSecurityGroups(vpcIDs)
+	if err != nil {
+		simulatedPluginLogger().Error(err, "failed to get security groups of vpcs", "vpc-ids", vpcIDs)
+		return []securitygroup.SynchronizationContent{}
+	}
+	managedSgs := make(map[string]*simulatedSecurityGroup)
+	for _, sg := range cloudSecurityGroups {
+		if _, isAG, isAT := securitygroup.IsNepheControllerCreatedSG(sg.name); isAG || isAT {
+			managedSgs[sg.id] = sg
+		}
+	}
+
+	// find all member network interfaces of managed security groups, and whether they are also attached to security
+	// groups not created by nephe.
+	managedSgIDToMembers := make(map[string][]securitygroup.CloudResource)
+	managedSgIDToMembersWithOtherSGAttached := make(map[string][]securitygroup.CloudResource)
+	for _, instance := range instances {
+		if _, found := vpcIDs[instance.VpcID]; !found {
+			continue
+		}
+		for _, networkInterface := range instance.NetworkInterfaces {
+			var memberOf []string
+			isAttachedToOtherSG := false
+			for sgID := range networkInterface.securityGroupIDs {
+				if _, isManagedSg := managedSgs[sgID]; isManagedSg {
+					memberOf = append(memberOf, sgID)
+				} else {
+					isAttachedToOtherSG = true
+				}
+			}
+			cloudResource := securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeNIC,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: networkInterface.ID,
+					Vpc:  instance.VpcID,
+				},
+				AccountID:     computeCfg.accountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.SimulatedCloudProvider),
+			}
+			for _, sgID := range memberOf {
+				managedSgIDToMembers[sgID] = append(managedSgIDToMembers[sgID], cloudResource)
+				if isAttachedToOtherSG {
+					managedSgIDToMembersWithOtherSGAttached[sgID] = append(managedSgIDToMembersWithOtherSGAttached[sgID], cloudResource)
+				}
+			}
+		}
+	}
+
+	// build sync objects for managed security groups
+	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
+	for sgID, sg := range managedSgs {
+		sgName, isAG, _ := securitygroup.IsNepheControllerCreatedSG(sg.name)
+		groupSyncObj := securitygroup.SynchronizationContent{
+			Resource: securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: sgName,
+					Vpc:  sg.vpcID,
+				},
+				AccountID:     computeCfg.accountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.SimulatedCloudProvider),
+			},
+			MembershipOnly:             isAG,
+			Members:                    managedSgIDToMembers[sgID],
+			MembersWithOtherSGAttached: managedSgIDToMembersWithOtherSGAttached[sgID],
+		}
+
+		// rules are listed in hash order, so that the cloud view is stable across polls.
+		hashes := make([]string, 0, len(sg.rules))
+		for hash := range sg.rules {
+			hashes = append(hashes, hash)
+		}
+		sort.Strings(hashes)
+		for _, hash := range hashes {
+			switch rule := sg.rules[hash].Rule.(type) {
+			case *securitygroup.IngressRule:
+				groupSyncObj.IngressRules = append(groupSyncObj.IngressRules, *rule)
+			case *securitygroup.EgressRule:
+				groupSyncObj.EgressRules = append(groupSyncObj.EgressRules, *rule)
+			}
+		}
+		enforcedSecurityCloudView = append(enforcedSecurityCloudView, groupSyncObj)
+	}
+	return enforcedSecurityCloudView
+}
+
+// ////////////////////////////////////////////////////////
+//
+//	SecurityInterface Implementation
+//
+// ////////////////////////////////////////////////////////.
+
+// CreateSecurityGroup creates the security group based on securityGroupIdentifier in the simulated cloud.
+func (c *simulatedCloud) CreateSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) (*string, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	sgID, err := computeService.api.createOrGetSecurityGroup(securityGroupIdentifier.GetCloudName(membershipOnly),
+		securityGroupIdentifier.Vpc)
+	if err != nil {
+		return nil, err
+	}
+	return &sgID, nil
+}
+
+// UpdateSecurityGroupRules updates the security group in the simulated cloud with addRules and rmRules.
+func (c *simulatedCloud) UpdateSecurityGroupRules(appliedToGroupIdentifier *securitygroup.CloudResource,
+	addRules, rmRules, _ []*securitygroup.CloudRule) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(appliedToGroupIdentifier)
+	if err != nil {
+		return err
+	}
+	return computeService.api.updateSecurityGroupRules(appliedToGroupIdentifier.GetCloudName(false), appliedToGroupIdentifier.Vpc,
+		addRules, rmRules)
+}
+
+// UpdateSecurityGroupMembers attaches/detaches nics to/from the security group in the simulated cloud.
+func (c *simulatedCloud) UpdateSecurityGroupMembers(securityGroupIdentifier *securitygroup.CloudResource,
+	cloudResourceIdentifiers []*securitygroup.CloudResource, membershipOnly bool) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return err
+	}
+	return computeService.api.updateSecurityGroupMembers(securityGroupIdentifier.GetCloudName(membershipOnly),
+		securityGroupIdentifier.Vpc, cloudResourceIdentifiers, membershipOnly)
+}
+
+// DeleteSecurityGroup deletes the security group in the simulated cloud. Any attached nic will be moved to default sg.
+func (c *simulatedCloud) DeleteSecurityGroup(securityGroupIdentifier *securitygroup.CloudResource, membershipOnly bool) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	computeService, err := c.getComputeService(securityGroupIdentifier)
+	if err != nil {
+		return err
+	}
+	return computeService.api.deleteSecurityGroup(securityGroupIdentifier.GetCloudName(membershipOnly), securityGroupIdentifier.Vpc)
+}
+
+func (c *simulatedCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	inventoryInitWaitDuration := 30 * time.Second
+
+	var accNamespacedNames []types.NamespacedName
+	accountConfigs := c.cloudCommon.GetCloudAccounts()
+	for _, accCfg := range accountConfigs {
+		accNamespacedNames = append(accNamespacedNames, *accCfg.GetNamespacedName())
+	}
+
+	var enforcedSecurityCloudView []securitygroup.SynchronizationContent
+	var wg sync.WaitGroup
+	ch := make(chan []securitygroup.SynchronizationContent)
+	wg.Add(len(accNamespacedNames))
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	for _, accNamespacedName := range accNamespacedNames {
+		accNamespacedNameCopy := &types.NamespacedName{
+			Namespace: accNamespacedName.Namespace,
+			Name:      accNamespacedName.Name,
+		}
+
+		go func(name *types.NamespacedName, sendCh chan<- []securitygroup.SynchronizationContent) {
+			defer wg.Done()
+
+			accCfg, found := c.cloudCommon.GetCloudAccountByName(name)
+			if !found {
+				simulatedPluginLogger().Info("enforced-security-cloud-view GET for account skipped (account no longer exists)",
+					"account", name)
+				return
+			}
+
+			serviceCfg, err := accCfg.GetServiceConfigByName(simulatedComputeServiceNameCompute)
+			if err != nil {
+				simulatedPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account",
+					accCfg.GetNamespacedName())
+				return
+			}
+			computeService := serviceCfg.(*computeServiceConfig)
+			err = computeService.waitForInventoryInit(inventoryInitWaitDuration)
+			if err != nil {
+				simulatedPluginLogger().Error(err, "enforced-security-cloud-view GET for account skipped", "account",
+					accCfg.GetNamespacedName())
+				return
+			}
+			sendCh <- computeService.getNepheControllerManagedSecurityGroupsCloudView()
+		}(accNamespacedNameCopy, ch)
+	}
+
+	for val := range ch {
+		if val != nil {
+			enforcedSecurityCloudView = append(enforcedSecurityCloudView, val...)
+		}
+	}
+	return enforcedSecurityCloudView
+}
+
+// GetSecurityGroupLimits returns no limits, as the simulated cloud does not limit security groups.
+func (c *simulatedCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{}
+}
@@ -0,0 +1,374 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+const (
+	testInventory = `
+vpcs:
+- id: vpc-01
+  name: vpc01
+  cidrs: ["10.0.0.0/16"]
+- id: vpc-02
+  cidrs: ["10.1.0.0/16"]
+instances:
+- id: i-01
+  name: vm01
+  vpcId: vpc-01
+  tags:
+    app: web
+  networkInterfaces:
+  - id: eni-01
+    privateIPs: ["10.0.0.1"]
+    publicIPs: ["52.0.0.1"]
+- id: i-02
+  vpcId: vpc-01
+  state: stopped
+  networkInterfaces:
+  - id: eni-02
+    privateIPs: ["10.0.0.2"]
+    securityGroups: ["legacy"]
+- id: i-03
+  vpcId: vpc-02
+  networkInterfaces:
+  - id: eni-03
+    privateIPs: ["10.1.0.3"]
+`
+)
+
+var _ = Describe("Simulated cloud", func() {
+	var (
+		testAccountNamespacedName = types.NamespacedName{Namespace: "namespace01", Name: "account01"}
+		inventoryKey              = "inventory"
+
+		account    *v1alpha1.CloudProviderAccount
+		selector   *v1alpha1.CloudEntitySelector
+		configMap  *corev1.ConfigMap
+		fakeClient client.WithWatch
+	)
+
+	BeforeEach(func() {
+		var pollIntv uint = 1
+		account = &v1alpha1.CloudProviderAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudProviderAccountSpec{
+				PollIntervalInSeconds: &pollIntv,
+				SimulatedConfig: &v1alpha1.CloudProviderAccountSimulatedConfig{
+					Region: "sim-region-1",
+					ConfigMapRef: &v1alpha1.ConfigMapReference{
+						Name:      testAccountNamespacedName.Name,
+						Namespace: testAccountNamespacedName.Namespace,
+						Key:       inventoryKey,
+					},
+				},
+			},
+		}
+		selector = &v1alpha1.CloudEntitySelector{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "selector-all",
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudEntitySelectorSpec{
+				AccountName: testAccountNamespacedName.Name,
+				VMSelector:  []v1alpha1.VirtualMachineSelector{},
+			},
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Data: map[string]string{
+				inventoryKey: testInventory,
+			},
+		}
+		fakeClient = fake.NewClientBuilder().Build()
+	})
+
+	addAccount := func() *simulatedCloud {
+		_ = fakeClient.Create(context.Background(), configMap)
+		c := newSimulatedCloud()
+		err := c.AddProviderAccount(fakeClient, account)
+		Expect(err).Should(BeNil())
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+		Expect(found).To(BeTrue())
+		Expect(accCfg).To(Not(BeNil()))
+		return c
+	}
+
+	getComputeService := func(c *simulatedCloud) *computeServiceConfig {
+		accCfg, _ := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+		serviceConfig, err := accCfg.GetServiceConfigByName(simulatedComputeServiceNameCompute)
+		Expect(err).Should(BeNil())
+		return serviceConfig.(*computeServiceConfig)
+	}
+
+	Context("AddProviderAccount", func() {
+		It("Should discover vpcs without any selector", func() {
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(BeEmpty())
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap).Should(HaveLen(2))
+			Expect(vpcMap).Should(HaveKey("vpc-01"))
+			Expect(vpcMap["vpc-02"].Status.Name).Should(Equal("vpc-02"))
+		})
+		It("Should discover all instances with get ALL selector", func() {
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(3))
+			Expect(vms).Should(HaveKey("i-01"))
+			vm := vms["i-01"]
+			Expect(vm.Status.CloudVpcId).Should(Equal("vpc-01"))
+			Expect(vm.Status.Region).Should(Equal("sim-region-1"))
+			Expect(vm.Status.Tags).Should(HaveKeyWithValue("app", "web"))
+			Expect(vm.Status.NetworkInterfaces).Should(HaveLen(1))
+			Expect(vm.Status.NetworkInterfaces[0].IPs).Should(HaveLen(2))
+			Expect(string(vms["i-02"].Status.State)).Should(Equal("stopped"))
+		})
+		It("Should discover instances matching vpc name selector", func() {
+			selector.Spec.VMSelector = []v1alpha1.VirtualMachineSelector{
+				{
+					VpcMatch: &v1alpha1.EntityMatch{MatchName: "vpc01"},
+				},
+			}
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(2))
+			Expect(vms).Should(HaveKey("i-01"))
+			Expect(vms).Should(HaveKey("i-02"))
+		})
+		It("Should fail account add with invalid inventory", func() {
+			configMap.Data[inventoryKey] = "instances:\n- id: i-01\n  vpcId: vpc-unknown\n"
+			_ = fakeClient.Create(context.Background(), configMap)
+			c := newSimulatedCloud()
+			err := c.AddProviderAccount(fakeClient, account)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should fail inventory poll of failed operation", func() {
+			account.Spec.SimulatedConfig.FailedOperations = []string{operationListVpcs}
+
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("SecurityInterface", func() {
+		var (
+			c            *simulatedCloud
+			atIdentifier *securitygroup.CloudResource
+			agIdentifier *securitygroup.CloudResource
+		)
+
+		BeforeEach(func() {
+			c = addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			atIdentifier = &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "at-sg", Vpc: "vpc-01"},
+				AccountID:       testAccountNamespacedName.String(),
+			}
+			agIdentifier = &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: "ag-sg", Vpc: "vpc-01"},
+				AccountID:       testAccountNamespacedName.String(),
+			}
+		})
+
+		It("Should create security groups idempotently", func() {
+			sgID, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			sgIDAgain, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			Expect(*sgIDAgain).Should(Equal(*sgID))
+		})
+		It("Should fail security group creation in unknown vpc", func() {
+			atIdentifier.Vpc = "vpc-unknown"
+			_, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should fail rule referring to missing address group", func() {
+			_, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			rule := &securitygroup.CloudRule{
+				Rule: &securitygroup.EgressRule{
+					ToSecurityGroups: []*securitygroup.CloudResourceID{&agIdentifier.CloudResourceID},
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			rule.Hash = rule.GetHash()
+
+			err = c.UpdateSecurityGroupRules(atIdentifier, []*securitygroup.CloudRule{rule}, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should report enforced security of realized rules and members", func() {
+			_, err := c.CreateSecurityGroup(agIdentifier, true)
+			Expect(err).Should(BeNil())
+			_, err = c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+
+			_, ipNet, _ := net.ParseCIDR("10.0.0.0/24")
+			port := 22
+			protocol := 6
+			ingressRule := &securitygroup.CloudRule{
+				Rule: &securitygroup.IngressRule{
+					FromPort:  &port,
+					FromSrcIP: []*net.IPNet{ipNet},
+					Protocol:  &protocol,
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			ingressRule.Hash = ingressRule.GetHash()
+			egressRule := &securitygroup.CloudRule{
+				Rule: &securitygroup.EgressRule{
+					ToSecurityGroups: []*securitygroup.CloudResourceID{&agIdentifier.CloudResourceID},
+				},
+				NetworkPolicy: "namespace01/anp01",
+				AppliedToGrp:  atIdentifier.CloudResourceID.String(),
+			}
+			egressRule.Hash = egressRule.GetHash()
+			err = c.UpdateSecurityGroupRules(atIdentifier, []*securitygroup.CloudRule{ingressRule, egressRule}, nil, nil)
+			Expect(err).Should(BeNil())
+
+			vmMember := []*securitygroup.CloudResource{
+				{
+					Type:            securitygroup.CloudResourceTypeVM,
+					CloudResourceID: securitygroup.CloudResourceID{Name: "i-01", Vpc: "vpc-01"},
+				},
+			}
+			nicMember := []*securitygroup.CloudResource{
+				{
+					Type:            securitygroup.CloudResourceTypeNIC,
+					CloudResourceID: securitygroup.CloudResourceID{Name: "eni-02", Vpc: "vpc-01"},
+				},
+			}
+			err = c.UpdateSecurityGroupMembers(agIdentifier, nicMember, true)
+			Expect(err).Should(BeNil())
+			err = c.UpdateSecurityGroupMembers(atIdentifier, vmMember, false)
+			Expect(err).Should(BeNil())
+
+			enforced := c.GetEnforcedSecurity()
+			Expect(enforced).Should(HaveLen(2))
+			for _, content := range enforced {
+				Expect(content.Members).Should(HaveLen(1))
+				if content.MembershipOnly {
+					Expect(content.Resource.Name).Should(Equal(agIdentifier.Name))
+					Expect(content.Members[0].Name).Should(Equal("eni-02"))
+					// eni-02 stays attached to its security group not created by nephe.
+					Expect(content.MembersWithOtherSGAttached).Should(HaveLen(1))
+					Expect(content.IngressRules).Should(BeEmpty())
+					Expect(content.EgressRules).Should(BeEmpty())
+				} else {
+					Expect(content.Resource.Name).Should(Equal(atIdentifier.Name))
+					Expect(content.Members[0].Name).Should(Equal("eni-01"))
+					Expect(content.MembersWithOtherSGAttached).Should(BeEmpty())
+					Expect(content.IngressRules).Should(HaveLen(1))
+					Expect(content.IngressRules[0].FromSrcIP[0].String()).Should(Equal("10.0.0.0/24"))
+					Expect(content.EgressRules).Should(HaveLen(1))
+				}
+			}
+
+			err = c.UpdateSecurityGroupRules(atIdentifier, nil, []*securitygroup.CloudRule{ingressRule, egressRule}, nil)
+			Expect(err).Should(BeNil())
+			err = c.DeleteSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			enforced = c.GetEnforcedSecurity()
+			Expect(enforced).Should(HaveLen(1))
+			Expect(enforced[0].MembershipOnly).Should(BeTrue())
+		})
+		It("Should move network interfaces to vpc default security group on delete", func() {
+			_, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			vmMember := []*securitygroup.CloudResource{
+				{
+					Type:            securitygroup.CloudResourceTypeVM,
+					CloudResourceID: securitygroup.CloudResourceID{Name: "i-01", Vpc: "vpc-01"},
+				},
+			}
+			err = c.UpdateSecurityGroupMembers(atIdentifier, vmMember, false)
+			Expect(err).Should(BeNil())
+			err = c.DeleteSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+
+			api := getComputeService(c).api
+			defaultSg := api.getSecurityGroup(simulatedVpcDefaultSecurityGroupName, "vpc-01")
+			Expect(defaultSg).ShouldNot(BeNil())
+			Expect(api.instances["i-01"].NetworkInterfaces[0].securityGroupIDs).Should(HaveKey(defaultSg.id))
+			Expect(api.instances["i-01"].NetworkInterfaces[0].securityGroupIDs).Should(HaveLen(1))
+		})
+		It("Should fail security group operations of failed operation", func() {
+			account.Spec.SimulatedConfig.FailedOperations = []string{operationCreateSecurityGroup}
+			err := c.AddProviderAccount(fakeClient, account)
+			Expect(err).Should(BeNil())
+
+			_, err = c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should keep security groups on fault injection update", func() {
+			_, err := c.CreateSecurityGroup(atIdentifier, false)
+			Expect(err).Should(BeNil())
+			account.Spec.SimulatedConfig.FailurePercentage = 100
+			err = c.AddProviderAccount(fakeClient, account)
+			Expect(err).Should(BeNil())
+
+			err = c.DeleteSecurityGroup(atIdentifier, false)
+			Expect(err).ShouldNot(BeNil())
+			Expect(getComputeService(c).api.getSecurityGroup(atIdentifier.GetCloudName(false), "vpc-01")).ShouldNot(BeNil())
+		})
+	})
+})
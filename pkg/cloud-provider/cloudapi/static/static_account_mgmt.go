@@ -0,0 +1,63 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// staticAccountConfig holds the hosts declared in a static account. Static accounts have no credentials, the declared
+// hosts are handled as the account credentials so that any change of hosts refreshes the account inventory.
+type staticAccountConfig struct {
+	region string
+	hosts  []crdv1alpha1.StaticHost
+}
+
+// setAccountCredentials sets account credentials.
+func setAccountCredentials(_ client.Client, _ *types.NamespacedName, credentials interface{}) (interface{}, error) {
+	staticProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountStaticConfig)
+
+	staticConfig := &staticAccountConfig{
+		region: strings.TrimSpace(staticProviderConfig.Region),
+		hosts:  make([]crdv1alpha1.StaticHost, 0, len(staticProviderConfig.Hosts)),
+	}
+	for i := range staticProviderConfig.Hosts {
+		staticConfig.hosts = append(staticConfig.hosts, *staticProviderConfig.Hosts[i].DeepCopy())
+	}
+
+	return staticConfig, nil
+}
+
+func compareAccountCredentials(accountName string, existing interface{}, new interface{}) bool {
+	existingConfig := existing.(*staticAccountConfig)
+	newConfig := new.(*staticAccountConfig)
+
+	credsChanged := false
+	if strings.Compare(existingConfig.region, newConfig.region) != 0 {
+		credsChanged = true
+		staticPluginLogger().Info("account region updated", "account", accountName)
+	}
+	if !reflect.DeepEqual(existingConfig.hosts, newConfig.hosts) {
+		credsChanged = true
+		staticPluginLogger().Info("account hosts updated", "account", accountName)
+	}
+	return credsChanged
+}
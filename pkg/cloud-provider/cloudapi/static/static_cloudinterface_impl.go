@@ -0,0 +1,122 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+	"antrea.io/nephe/pkg/logging"
+)
+
+var staticPluginLogger = func() logging.Logger {
+	return logging.GetLogger("static-plugin")
+}
+
+const (
+	providerType = cloudcommon.ProviderType(runtimev1alpha1.StaticCloudProvider)
+)
+
+// staticCloud implements CloudInterface for statically declared hosts, e.g. bare-metal hosts.
+type staticCloud struct {
+	cloudCommon internal.CloudCommonInterface
+}
+
+// newStaticCloud creates a new instance of staticCloud.
+func newStaticCloud() *staticCloud {
+	staticCloud := &staticCloud{
+		cloudCommon: internal.NewCloudCommon(staticPluginLogger, &staticCloudCommonHelperImpl{}, nil),
+	}
+	return staticCloud
+}
+
+// Register registers cloud provider type and creates staticCloud object for the provider. Any cloud account added at
+// later point with this cloud provider using CloudInterface API will get added to this staticCloud object.
+func Register() cloudcommon.CloudInterface {
+	return newStaticCloud()
+}
+
+// ProviderType returns the cloud provider type (aws, azure, gce etc).
+func (c *staticCloud) ProviderType() cloudcommon.ProviderType {
+	return providerType
+}
+
+// GetCapabilities returns no capabilities, as security groups are not supported for static hosts.
+func (c *staticCloud) GetCapabilities() crdv1alpha1.CloudCapabilities {
+	return crdv1alpha1.CloudCapabilities{}
+}
+
+// /////////////////////////////////////////////
+//
+//	ComputeInterface Implementation
+//
+// /////////////////////////////////////////////.
+
+// InstancesGivenProviderAccount returns all VM instances of a given cloud provider account, as a map of
+// runtime VirtualMachine objects.
+func (c *staticCloud) InstancesGivenProviderAccount(accountNamespacedName *types.NamespacedName) (
+	map[string]*runtimev1alpha1.VirtualMachine, error) {
+	vmInternalObjectsMap, err := c.cloudCommon.GetCloudAccountComputeInternalResourceObjects(accountNamespacedName)
+	return vmInternalObjectsMap, err
+}
+
+// ////////////////////////////////////////////////////////
+//
+//	AccountMgmtInterface Implementation
+//
+// ////////////////////////////////////////////////////////
+
+// AddProviderAccount adds and initializes given account of a cloud provider.
+func (c *staticCloud) AddProviderAccount(client client.Client, account *crdv1alpha1.CloudProviderAccount) error {
+	return c.cloudCommon.AddCloudAccount(client, account, account.Spec.StaticConfig)
+}
+
+// RemoveProviderAccount removes and cleans up any resources of given account of a cloud provider.
+func (c *staticCloud) RemoveProviderAccount(namespacedName *types.NamespacedName) {
+	c.cloudCommon.RemoveCloudAccount(namespacedName)
+}
+
+// AddAccountResourceSelector adds account specific resource selector.
+func (c *staticCloud) AddAccountResourceSelector(accNamespacedName *types.NamespacedName, selector *crdv1alpha1.CloudEntitySelector) error {
+	return c.cloudCommon.AddSelector(accNamespacedName, selector)
+}
+
+// RemoveAccountResourcesSelector removes account specific resource selector.
+func (c *staticCloud) RemoveAccountResourcesSelector(accNamespacedName *types.NamespacedName, selectorName string) {
+	c.cloudCommon.RemoveSelector(accNamespacedName, selectorName)
+}
+
+func (c *staticCloud) GetAccountStatus(accNamespacedName *types.NamespacedName) (*crdv1alpha1.CloudProviderAccountStatus, error) {
+	return c.cloudCommon.GetStatus(accNamespacedName)
+}
+
+// DoInventoryPoll calls cloud API to get cloud resources.
+func (c *staticCloud) DoInventoryPoll(accountNamespacedName *types.NamespacedName) error {
+	return c.cloudCommon.DoInventoryPoll(accountNamespacedName)
+}
+
+// DeleteInventoryPollCache resets cloud snapshot to nil.
+func (c *staticCloud) DeleteInventoryPollCache(accountNamespacedName *types.NamespacedName) error {
+	return c.cloudCommon.DeleteInventoryPollCache(accountNamespacedName)
+}
+
+// GetVpcInventory pulls cloud vpc inventory from internal snapshot.
+func (c *staticCloud) GetVpcInventory(accountNamespacedName *types.NamespacedName) (map[string]*runtimev1alpha1.Vpc, error) {
+	return c.cloudCommon.GetVpcInventory(accountNamespacedName)
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+type computeServiceConfig struct {
+	accountNamespacedName types.NamespacedName
+	resourcesCache        *internal.CloudServiceResourcesCache
+	inventoryStats        *internal.CloudServiceStats
+	// hostFilters has following possible values
+	// - empty map indicates no selectors configured for this account. NO host will be imported.
+	// - non-empty map indicates selectors are configured. Hosts matching the selectors will be imported.
+	// - key with nil value indicates no filters. Import all hosts of the account.
+	// - key with non-nil value indicates some filter. Import hosts matching those filters only.
+	hostFilters map[string][]*staticHostFilter
+	credentials *staticAccountConfig
+}
+
+// computeResourcesCacheSnapshot holds the imported hosts and all networks of the account.
+type computeResourcesCacheSnapshot struct {
+	hosts    map[cloudcommon.InstanceID]*crdv1alpha1.StaticHost
+	networks []string
+	vpcIDs   map[string]struct{}
+}
+
+func newComputeServiceConfig(accountNamespacedName types.NamespacedName, credentials *staticAccountConfig) (
+	internal.CloudServiceInterface, error) {
+	config := &computeServiceConfig{
+		accountNamespacedName: accountNamespacedName,
+		resourcesCache:        &internal.CloudServiceResourcesCache{},
+		inventoryStats:        &internal.CloudServiceStats{},
+		hostFilters:           make(map[string][]*staticHostFilter),
+		credentials:           credentials,
+	}
+	return config, nil
+}
+
+// getHostResourceFilters returns filters to be applied to declared hosts if filters are configured.
+// Otherwise, returns (nil, false). false indicates no selectors configured for the account and hence no host needs
+// to be imported.
+func (computeCfg *computeServiceConfig) getHostResourceFilters() ([]*staticHostFilter, bool) {
+	var allFilters []*staticHostFilter
+
+	if len(computeCfg.hostFilters) == 0 {
+		return nil, false
+	}
+
+	for _, filters := range computeCfg.hostFilters {
+		// if any selector found with nil filter, skip all other selectors. As nil indicates all
+		if len(filters) == 0 {
+			return nil, true
+		}
+		allFilters = append(allFilters, filters...)
+	}
+	return allFilters, true
+}
+
+// getCachedHosts returns hosts from the cache for the account.
+func (computeCfg *computeServiceConfig) getCachedHosts() []*crdv1alpha1.StaticHost {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		staticPluginLogger().V(4).Info("cache snapshot nil", "service", staticComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []*crdv1alpha1.StaticHost{}
+	}
+	hosts := snapshot.(*computeResourcesCacheSnapshot).hosts
+	hostsToReturn := make([]*crdv1alpha1.StaticHost, 0, len(hosts))
+	for _, host := range hosts {
+		hostsToReturn = append(hostsToReturn, host)
+	}
+	staticPluginLogger().V(1).Info("cached hosts", "service", staticComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "hosts", len(hostsToReturn))
+	return hostsToReturn
+}
+
+// getManagedVpcIDs returns IDs of networks containing imported hosts.
+func (computeCfg *computeServiceConfig) getManagedVpcIDs() map[string]struct{} {
+	vpcIDsCopy := make(map[string]struct{})
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		staticPluginLogger().V(4).Info("cache snapshot nil", "service", staticComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return vpcIDsCopy
+	}
+	for vpcID := range snapshot.(*computeResourcesCacheSnapshot).vpcIDs {
+		vpcIDsCopy[vpcID] = struct{}{}
+	}
+	return vpcIDsCopy
+}
+
+// getCachedNetworks returns networks from cached snapshot for the account.
+func (computeCfg *computeServiceConfig) getCachedNetworks() []string {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		staticPluginLogger().V(4).Info("cache snapshot nil", "service", staticComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []string{}
+	}
+	networks := snapshot.(*computeResourcesCacheSnapshot).networks
+	networksToReturn := make([]string, 0, len(networks))
+	networksToReturn = append(networksToReturn, networks...)
+	return networksToReturn
+}
+
+// filterHosts returns the hosts matching the configured filters.
+func (computeCfg *computeServiceConfig) filterHosts(allHosts []crdv1alpha1.StaticHost) []*crdv1alpha1.StaticHost {
+	filters, hasFilters := computeCfg.getHostResourceFilters()
+	if !hasFilters {
+		staticPluginLogger().V(1).Info("importing hosts skipped",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "not-configured")
+		return nil
+	}
+
+	var hosts []*crdv1alpha1.StaticHost
+	for i := range allHosts {
+		host := &allHosts[i]
+		if filters == nil {
+			hosts = append(hosts, host)
+			continue
+		}
+		for _, filter := range filters {
+			if filter.matches(host) {
+				hosts = append(hosts, host)
+				break
+			}
+		}
+	}
+
+	staticPluginLogger().V(1).Info("hosts to import", "service", staticComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "hosts", len(hosts))
+	return hosts
+}
+
+// DoResourceInventory builds inventory from the hosts declared in the account.
+func (computeCfg *computeServiceConfig) DoResourceInventory() error {
+	allHosts := computeCfg.credentials.hosts
+
+	networkSet := make(map[string]struct{})
+	for i := range allHosts {
+		networkSet[getHostNetwork(&allHosts[i])] = struct{}{}
+	}
+	networks := make([]string, 0, len(networkSet))
+	for network := range networkSet {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	hosts := computeCfg.filterHosts(allHosts)
+
+	exists := struct{}{}
+	vpcIDs := make(map[string]struct{})
+	hostIDs := make(map[cloudcommon.InstanceID]*crdv1alpha1.StaticHost)
+	for _, host := range hosts {
+		hostIDs[cloudcommon.InstanceID(getHostID(host))] = host
+		vpcIDs[getHostNetwork(host)] = exists
+	}
+	computeCfg.resourcesCache.UpdateSnapshot(&computeResourcesCacheSnapshot{hostIDs, networks, vpcIDs})
+	return nil
+}
+
+// SetResourceFilters add/updates host resource filter for the service.
+func (computeCfg *computeServiceConfig) SetResourceFilters(selector *crdv1alpha1.CloudEntitySelector) {
+	if filters, found := convertSelectorToHostFilters(selector); found {
+		computeCfg.hostFilters[selector.GetName()] = filters
+	} else {
+		if selector != nil {
+			delete(computeCfg.hostFilters, selector.GetName())
+		}
+		computeCfg.resourcesCache.UpdateSnapshot(nil)
+	}
+}
+
+func (computeCfg *computeServiceConfig) RemoveResourceFilters(selectorName string) {
+	delete(computeCfg.hostFilters, selectorName)
+}
+
+func (computeCfg *computeServiceConfig) GetInternalResourceObjects(namespace string,
+	account *types.NamespacedName) map[string]*runtimev1alpha1.VirtualMachine {
+	hosts := computeCfg.getCachedHosts()
+	vmObjects := map[string]*runtimev1alpha1.VirtualMachine{}
+	for _, host := range hosts {
+		// build runtimev1alpha1 VirtualMachine object.
+		vmObject := hostToInternalVirtualMachineObject(host, computeCfg.credentials.region, namespace, account)
+		vmObjects[vmObject.Name] = vmObject
+	}
+
+	staticPluginLogger().V(1).Info("Internal resource objects", "Service", staticComputeServiceNameCompute,
+		"Account", computeCfg.accountNamespacedName, "VirtualMachine objects", len(vmObjects))
+
+	return vmObjects
+}
+
+func (computeCfg *computeServiceConfig) GetName() internal.CloudServiceName {
+	return staticComputeServiceNameCompute
+}
+
+func (computeCfg *computeServiceConfig) GetType() internal.CloudServiceType {
+	return internal.CloudServiceTypeCompute
+}
+
+func (computeCfg *computeServiceConfig) GetInventoryStats() *internal.CloudServiceStats {
+	return computeCfg.inventoryStats
+}
+
+func (computeCfg *computeServiceConfig) ResetCachedState() {
+	computeCfg.SetResourceFilters(nil)
+	computeCfg.inventoryStats.ResetInventoryPollStats()
+}
+
+func (computeCfg *computeServiceConfig) UpdateServiceConfig(newConfig internal.CloudServiceInterface) {
+	newComputeServiceConfig := newConfig.(*computeServiceConfig)
+	computeCfg.credentials = newComputeServiceConfig.credentials
+}
+
+// GetVpcInventory generates vpc object for the networks stored in snapshot and return a map of vpc runtime objects.
+func (computeCfg *computeServiceConfig) GetVpcInventory() map[string]*runtimev1alpha1.Vpc {
+	networks := computeCfg.getCachedNetworks()
+	vpcIDs := computeCfg.getManagedVpcIDs()
+	// Convert to kubernetes object and return a map indexed using network.
+	vpcMap := map[string]*runtimev1alpha1.Vpc{}
+	for _, network := range networks {
+		_, managed := vpcIDs[network]
+		vpcObj := networkToInternalVpcObject(network, computeCfg.credentials.region, computeCfg.accountNamespacedName.Namespace,
+			computeCfg.accountNamespacedName.Name, managed)
+		vpcMap[network] = vpcObj
+	}
+
+	staticPluginLogger().V(1).Info("cached vpcs", "service", staticComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "vpc objects", len(vpcMap))
+
+	return vpcMap
+}
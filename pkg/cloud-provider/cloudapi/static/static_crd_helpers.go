@@ -0,0 +1,83 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
+)
+
+// getHostID returns the cloud ID of a static host.
+func getHostID(host *crdv1alpha1.StaticHost) string {
+	return strings.ToLower(strings.TrimSpace(host.ID))
+}
+
+// getHostName returns the cloud name of a static host, its ID if no name is declared.
+func getHostName(host *crdv1alpha1.StaticHost) string {
+	if name := strings.TrimSpace(host.Name); len(name) != 0 {
+		return strings.ToLower(name)
+	}
+	return getHostID(host)
+}
+
+// getHostNetwork returns the network of a static host, which is reported as its vpc.
+func getHostNetwork(host *crdv1alpha1.StaticHost) string {
+	return strings.ToLower(strings.TrimSpace(host.Network))
+}
+
+// hostToInternalVirtualMachineObject converts static host to VirtualMachine runtime object. Static hosts are always
+// reported as running, as their state is not known.
+func hostToInternalVirtualMachineObject(host *crdv1alpha1.StaticHost, region string, namespace string,
+	account *types.NamespacedName) *runtimev1alpha1.VirtualMachine {
+	tags := make(map[string]string)
+	for key, value := range host.Tags {
+		tags[key] = value
+	}
+
+	// Network interfaces associated with the host
+	networkInterfaces := make([]runtimev1alpha1.NetworkInterface, 0, len(host.NetworkInterfaces))
+	for _, nwInf := range host.NetworkInterfaces {
+		var ipAddressObjs []runtimev1alpha1.IPAddress
+		for _, ip := range nwInf.IPs {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeInternalIP,
+				Address:     strings.TrimSpace(ip),
+			})
+		}
+		networkInterface := runtimev1alpha1.NetworkInterface{
+			Name: strings.ToLower(strings.TrimSpace(nwInf.Name)),
+			MAC:  strings.ToLower(strings.TrimSpace(nwInf.MAC)),
+			IPs:  ipAddressObjs,
+		}
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+
+	cloudID := getHostID(host)
+	cloudNetworkID := getHostNetwork(host)
+	return utils.GenerateInternalVirtualMachineObject(cloudID, getHostName(host), cloudID, strings.ToLower(region),
+		namespace, cloudNetworkID, cloudNetworkID, runtimev1alpha1.Running, tags, networkInterfaces, providerType, account)
+}
+
+// networkToInternalVpcObject converts network of static hosts to vpc runtime object.
+func networkToInternalVpcObject(network string, region string, accountNamespace, accountName string,
+	managed bool) *runtimev1alpha1.Vpc {
+	return utils.GenerateInternalVpcObject(network, accountNamespace, accountName, network, network, map[string]string{},
+		runtimev1alpha1.StaticCloudProvider, strings.ToLower(region), nil, managed)
+}
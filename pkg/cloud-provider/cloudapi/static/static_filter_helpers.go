@@ -0,0 +1,81 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"strings"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// staticHostFilter is a filter for static hosts. Empty fields of the filter match any value.
+type staticHostFilter struct {
+	network string
+	hostID  string
+	name    string
+}
+
+// convertSelectorToHostFilters converts vm selector to static host filters.
+func convertSelectorToHostFilters(selector *crdv1alpha1.CloudEntitySelector) ([]*staticHostFilter, bool) {
+	if selector == nil {
+		return nil, false
+	}
+	if selector.Spec.VMSelector == nil {
+		return nil, true
+	}
+
+	return buildHostFilters(selector.Spec.VMSelector), true
+}
+
+// buildHostFilters builds host filters for VirtualMachineSelector. vpcMatch matches the network of a host, by either
+// matchID or matchName as a network has no name other than its ID, and vmMatch matches the ID or name of a host.
+func buildHostFilters(vmSelector []crdv1alpha1.VirtualMachineSelector) []*staticHostFilter {
+	var filters []*staticHostFilter
+	for _, match := range vmSelector {
+		networkFilter := staticHostFilter{}
+		if match.VpcMatch != nil {
+			networkFilter.network = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchID))
+			if len(networkFilter.network) == 0 {
+				networkFilter.network = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchName))
+			}
+		}
+		if len(match.VMMatch) == 0 {
+			filter := networkFilter
+			filters = append(filters, &filter)
+			continue
+		}
+		for _, vmMatch := range match.VMMatch {
+			filter := networkFilter
+			filter.hostID = strings.ToLower(strings.TrimSpace(vmMatch.MatchID))
+			filter.name = strings.ToLower(strings.TrimSpace(vmMatch.MatchName))
+			filters = append(filters, &filter)
+		}
+	}
+	return filters
+}
+
+// matches returns true if the host matches the filter.
+func (f *staticHostFilter) matches(host *crdv1alpha1.StaticHost) bool {
+	if len(f.hostID) != 0 && f.hostID != getHostID(host) {
+		return false
+	}
+	if len(f.name) != 0 && f.name != getHostName(host) {
+		return false
+	}
+	if len(f.network) != 0 && f.network != getHostNetwork(host) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"fmt"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// errSecurityUnsupported is returned for all security group operations. Static hosts are not attached to any cloud
+// network providing security groups, hence network policies are only enforced on agented hosts.
+var errSecurityUnsupported = fmt.Errorf("security groups are unsupported on %v, only agented hosts are supported",
+	providerType)
+
+// ////////////////////////////////////////////////////////
+//
+//	SecurityInterface Implementation
+//
+// ////////////////////////////////////////////////////////.
+
+// CreateSecurityGroup is unsupported for static hosts.
+func (c *staticCloud) CreateSecurityGroup(_ *securitygroup.CloudResource, _ bool) (*string, error) {
+	return nil, errSecurityUnsupported
+}
+
+// UpdateSecurityGroupRules is unsupported for static hosts.
+func (c *staticCloud) UpdateSecurityGroupRules(_ *securitygroup.CloudResource, _, _, _ []*securitygroup.CloudRule) error {
+	return errSecurityUnsupported
+}
+
+// UpdateSecurityGroupMembers is unsupported for static hosts.
+func (c *staticCloud) UpdateSecurityGroupMembers(_ *securitygroup.CloudResource, _ []*securitygroup.CloudResource, _ bool) error {
+	return errSecurityUnsupported
+}
+
+// DeleteSecurityGroup is unsupported for static hosts.
+func (c *staticCloud) DeleteSecurityGroup(_ *securitygroup.CloudResource, _ bool) error {
+	return errSecurityUnsupported
+}
+
+// GetEnforcedSecurity returns no enforced security, as no security group is ever created for static hosts.
+func (c *staticCloud) GetEnforcedSecurity() []securitygroup.SynchronizationContent {
+	return []securitygroup.SynchronizationContent{}
+}
+
+// GetSecurityGroupLimits returns no limits, as security groups are not supported.
+func (c *staticCloud) GetSecurityGroupLimits() securitygroup.SecurityGroupLimits {
+	return securitygroup.SecurityGroupLimits{}
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+var _ = Describe("Static cloud", func() {
+	var (
+		testAccountNamespacedName = types.NamespacedName{Namespace: "namespace01", Name: "account01"}
+
+		account    *v1alpha1.CloudProviderAccount
+		selector   *v1alpha1.CloudEntitySelector
+		fakeClient client.WithWatch
+	)
+
+	BeforeEach(func() {
+		var pollIntv uint = 1
+		account = &v1alpha1.CloudProviderAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudProviderAccountSpec{
+				PollIntervalInSeconds: &pollIntv,
+				StaticConfig: &v1alpha1.CloudProviderAccountStaticConfig{
+					Region: "DC01",
+					Hosts: []v1alpha1.StaticHost{
+						{
+							ID:      "Host01",
+							Name:    "db01",
+							Network: "rack01",
+							Tags:    map[string]string{"role": "db"},
+							NetworkInterfaces: []v1alpha1.StaticHostNetworkInterface{
+								{Name: "eth0", MAC: "0C:C4:7A:00:00:01", IPs: []string{"192.168.10.11"}},
+								{Name: "eth1", IPs: []string{"192.168.20.11"}},
+							},
+						},
+						{
+							ID:      "host02",
+							Network: "rack01",
+							NetworkInterfaces: []v1alpha1.StaticHostNetworkInterface{
+								{Name: "eth0", IPs: []string{"192.168.10.12"}},
+							},
+						},
+						{
+							ID:      "host03",
+							Network: "rack02",
+						},
+					},
+				},
+			},
+		}
+		selector = &v1alpha1.CloudEntitySelector{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "selector-all",
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudEntitySelectorSpec{
+				AccountName: testAccountNamespacedName.Name,
+				VMSelector:  []v1alpha1.VirtualMachineSelector{},
+			},
+		}
+		fakeClient = fake.NewClientBuilder().Build()
+	})
+
+	addAccount := func() *staticCloud {
+		c := newStaticCloud()
+		err := c.AddProviderAccount(fakeClient, account)
+		Expect(err).Should(BeNil())
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+		Expect(found).To(BeTrue())
+		Expect(accCfg).To(Not(BeNil()))
+		return c
+	}
+
+	Context("AddProviderAccount", func() {
+		It("Should discover networks without any selector", func() {
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(BeEmpty())
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap).Should(HaveLen(2))
+			for _, vpc := range vpcMap {
+				Expect(vpc.Status.Provider).Should(Equal(runtimev1alpha1.StaticCloudProvider))
+				Expect(vpc.Status.Region).Should(Equal("dc01"))
+				Expect(vpc.Status.Managed).Should(BeFalse())
+			}
+		})
+		It("Should import all hosts with get ALL selector", func() {
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(3))
+			Expect(vms).Should(HaveKey("host01"))
+			vm := vms["host01"]
+			Expect(vm.Status.Provider).Should(Equal(runtimev1alpha1.StaticCloudProvider))
+			Expect(vm.Status.CloudName).Should(Equal("db01"))
+			Expect(vm.Status.CloudVpcId).Should(Equal("rack01"))
+			Expect(vm.Status.Region).Should(Equal("dc01"))
+			Expect(vm.Status.State).Should(Equal(runtimev1alpha1.Running))
+			Expect(vm.Status.Tags).Should(HaveKeyWithValue("role", "db"))
+			Expect(vm.Status.NetworkInterfaces).Should(HaveLen(2))
+			Expect(vm.Status.NetworkInterfaces[0].Name).Should(Equal("eth0"))
+			Expect(vm.Status.NetworkInterfaces[0].MAC).Should(Equal("0c:c4:7a:00:00:01"))
+			Expect(vm.Status.NetworkInterfaces[0].IPs[0].Address).Should(Equal("192.168.10.11"))
+			Expect(vms["host02"].Status.CloudName).Should(Equal("host02"))
+
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap["rack01"].Status.Managed).Should(BeTrue())
+			Expect(vpcMap["rack02"].Status.Managed).Should(BeTrue())
+		})
+		It("Should import hosts matching network and host name selector", func() {
+			selector.Spec.VMSelector = []v1alpha1.VirtualMachineSelector{
+				{
+					VpcMatch: &v1alpha1.EntityMatch{MatchID: "rack01"},
+					VMMatch:  []v1alpha1.EntityMatch{{MatchName: "DB01"}},
+				},
+			}
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(1))
+			Expect(vms).Should(HaveKey("host01"))
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap["rack01"].Status.Managed).Should(BeTrue())
+			Expect(vpcMap["rack02"].Status.Managed).Should(BeFalse())
+		})
+		It("Should refresh inventory on account hosts update", func() {
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			account.Spec.StaticConfig.Hosts = account.Spec.StaticConfig.Hosts[:1]
+			err = c.AddProviderAccount(fakeClient, account)
+			Expect(err).Should(BeNil())
+			err = c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(1))
+			Expect(vms).Should(HaveKey("host01"))
+		})
+	})
+
+	Context("SecurityInterface", func() {
+		It("Should report security groups unsupported", func() {
+			c := addAccount()
+			sg := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "address-group",
+					Vpc:  "rack01",
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.StaticCloudProvider),
+			}
+			_, err := c.CreateSecurityGroup(sg, true)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			err = c.UpdateSecurityGroupMembers(sg, nil, true)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			err = c.UpdateSecurityGroupRules(sg, nil, nil, nil)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			err = c.DeleteSecurityGroup(sg, true)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			Expect(c.GetEnforcedSecurity()).Should(BeEmpty())
+		})
+	})
+})
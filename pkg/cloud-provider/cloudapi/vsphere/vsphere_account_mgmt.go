@@ -0,0 +1,113 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+type vsphereAccountConfig struct {
+	crdv1alpha1.VSphereAccountCredential
+	endpoint   string
+	datacenter string
+	insecure   bool
+}
+
+// setAccountCredentials sets account credentials.
+func setAccountCredentials(client client.Client, _ *types.NamespacedName, credentials interface{}) (interface{}, error) {
+	vsphereProviderConfig := credentials.(*crdv1alpha1.CloudProviderAccountVSphereConfig)
+	if vsphereProviderConfig.SecretRef == nil {
+		return nil, fmt.Errorf("vCenter credentials secret not configured")
+	}
+	accCred, err := extractSecret(client, vsphereProviderConfig.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	vsphereConfig := &vsphereAccountConfig{
+		VSphereAccountCredential: *accCred,
+		endpoint:                 strings.TrimSpace(vsphereProviderConfig.Endpoint),
+		datacenter:               strings.TrimSpace(vsphereProviderConfig.Datacenter),
+		insecure:                 vsphereProviderConfig.Insecure,
+	}
+	vsphereConfig.Username = strings.TrimSpace(vsphereConfig.Username)
+
+	return vsphereConfig, nil
+}
+
+func compareAccountCredentials(accountName string, existing interface{}, new interface{}) bool {
+	existingConfig := existing.(*vsphereAccountConfig)
+	newConfig := new.(*vsphereAccountConfig)
+
+	credsChanged := false
+	if strings.Compare(existingConfig.Username, newConfig.Username) != 0 {
+		credsChanged = true
+		vspherePluginLogger().Info("account username updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.Password, newConfig.Password) != 0 {
+		credsChanged = true
+		vspherePluginLogger().Info("account password updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.endpoint, newConfig.endpoint) != 0 {
+		credsChanged = true
+		vspherePluginLogger().Info("account endpoint updated", "account", accountName)
+	}
+	if strings.Compare(existingConfig.datacenter, newConfig.datacenter) != 0 {
+		credsChanged = true
+		vspherePluginLogger().Info("account datacenter updated", "account", accountName)
+	}
+	if existingConfig.insecure != newConfig.insecure {
+		credsChanged = true
+		vspherePluginLogger().Info("account insecure flag updated", "account", accountName)
+	}
+	return credsChanged
+}
+
+// extractSecret extracts credentials from a Kubernetes secret.
+func extractSecret(c client.Client, s *crdv1alpha1.SecretReference) (*crdv1alpha1.VSphereAccountCredential, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
+		return nil, err
+	}
+
+	data := u.Object["data"].(map[string]interface{})
+	decode, err := base64.StdEncoding.DecodeString(data[s.Key].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &crdv1alpha1.VSphereAccountCredential{}
+	if err = json.Unmarshal(decode, cred); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	vsphereAPITimeout = 2 * time.Minute
+)
+
+var (
+	vmProperties = []string{"name", "config.instanceUuid", "config.hardware.device", "runtime.powerState", "guest.net",
+		"customValue"}
+	networkProperties = []string{"name"}
+)
+
+// vsphereNetwork is a port group of a vSphere datacenter, which is mapped to a Vpc.
+type vsphereNetwork struct {
+	id         string
+	name       string
+	datacenter string
+}
+
+// vsphereNetworkInterface is a virtual ethernet card of a vSphere virtual machine.
+type vsphereNetworkInterface struct {
+	macAddress string
+	networkID  string
+	ips        []string
+}
+
+// vsphereVirtualMachine is a vSphere virtual machine, which is mapped to a VirtualMachine.
+type vsphereVirtualMachine struct {
+	id                string
+	name              string
+	datacenter        string
+	powerState        string
+	networkID         string
+	tags              map[string]string
+	networkInterfaces []*vsphereNetworkInterface
+}
+
+// vsphereAPIWrapper is layer above govmomi apis to allow for unit-testing.
+type vsphereAPIWrapper interface {
+	// getInventory returns port groups and virtual machines of the configured datacenters.
+	getInventory() ([]*vsphereNetwork, []*vsphereVirtualMachine, error)
+}
+
+type vsphereAPIWrapperImpl struct {
+	url        *url.URL
+	datacenter string
+	insecure   bool
+}
+
+func newVSphereAPIWrapper(credentials *vsphereAccountConfig) (vsphereAPIWrapper, error) {
+	u, err := soap.ParseURL(credentials.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing vCenter endpoint %v: %q", credentials.endpoint, err)
+	}
+	if u == nil {
+		return nil, fmt.Errorf("vCenter endpoint not configured")
+	}
+	u.User = url.UserPassword(credentials.Username, credentials.Password)
+
+	return &vsphereAPIWrapperImpl{
+		url:        u,
+		datacenter: credentials.datacenter,
+		insecure:   credentials.insecure,
+	}, nil
+}
+
+// getInventory logs in to vCenter, collects the inventory of all configured datacenters and logs out. A session is
+// not kept across inventory polls, so that credential or endpoint changes take effect on the next poll.
+func (api *vsphereAPIWrapperImpl) getInventory() ([]*vsphereNetwork, []*vsphereVirtualMachine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vsphereAPITimeout)
+	defer cancel()
+
+	client, err := govmomi.NewClient(ctx, api.url, api.insecure)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error logging in to vCenter %v: %q", api.url.Host, err)
+	}
+	defer func() {
+		_ = client.Logout(ctx)
+	}()
+
+	datacenters, err := api.getDatacenters(ctx, client.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+	fieldNames, err := getCustomFieldNames(ctx, client.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var networks []*vsphereNetwork
+	var vms []*vsphereVirtualMachine
+	for _, dc := range datacenters {
+		dcNetworks, dcVMs, err := getDatacenterInventory(ctx, client.Client, dc, fieldNames)
+		if err != nil {
+			return nil, nil, err
+		}
+		networks = append(networks, dcNetworks...)
+		vms = append(vms, dcVMs...)
+	}
+	return networks, vms, nil
+}
+
+// getDatacenters returns the configured datacenter, or all datacenters when none is configured.
+func (api *vsphereAPIWrapperImpl) getDatacenters(ctx context.Context, c *vim25.Client) ([]*object.Datacenter, error) {
+	finder := find.NewFinder(c, true)
+	if len(api.datacenter) != 0 {
+		dc, err := finder.Datacenter(ctx, api.datacenter)
+		if err != nil {
+			return nil, fmt.Errorf("error finding datacenter %v: %q", api.datacenter, err)
+		}
+		return []*object.Datacenter{dc}, nil
+	}
+	datacenters, err := finder.DatacenterList(ctx, "*")
+	if err != nil {
+		return nil, fmt.Errorf("error listing datacenters: %q", err)
+	}
+	return datacenters, nil
+}
+
+// getCustomFieldNames returns custom attribute names keyed by custom attribute key. Custom attributes are
+// converted to VirtualMachine tags.
+func getCustomFieldNames(ctx context.Context, c *vim25.Client) (map[int32]string, error) {
+	fieldNames := make(map[int32]string)
+	m, err := object.GetCustomFieldsManager(c)
+	if err != nil {
+		// standalone hosts do not support custom attributes.
+		if err == object.ErrNotSupported {
+			return fieldNames, nil
+		}
+		return nil, err
+	}
+	fields, err := m.Field(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing custom attributes: %q", err)
+	}
+	for _, field := range fields {
+		fieldNames[field.Key] = field.Name
+	}
+	return fieldNames, nil
+}
+
+// getDatacenterInventory returns port groups and virtual machines of a datacenter.
+func getDatacenterInventory(ctx context.Context, c *vim25.Client, dc *object.Datacenter,
+	fieldNames map[int32]string) ([]*vsphereNetwork, []*vsphereVirtualMachine, error) {
+	m := view.NewManager(c)
+	v, err := m.CreateContainerView(ctx, dc.Reference(), []string{"Network", "VirtualMachine"}, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating view of datacenter %v: %q", dc.Name(), err)
+	}
+	defer func() {
+		_ = v.Destroy(ctx)
+	}()
+
+	var moNetworks []mo.Network
+	if err = v.Retrieve(ctx, []string{"Network"}, networkProperties, &moNetworks); err != nil {
+		return nil, nil, fmt.Errorf("error listing networks of datacenter %v: %q", dc.Name(), err)
+	}
+	var moVMs []mo.VirtualMachine
+	if err = v.Retrieve(ctx, []string{"VirtualMachine"}, vmProperties, &moVMs); err != nil {
+		return nil, nil, fmt.Errorf("error listing virtual machines of datacenter %v: %q", dc.Name(), err)
+	}
+
+	networks := make([]*vsphereNetwork, 0, len(moNetworks))
+	for _, moNetwork := range moNetworks {
+		networks = append(networks, &vsphereNetwork{
+			id:         moNetwork.Self.Value,
+			name:       moNetwork.Name,
+			datacenter: dc.Name(),
+		})
+	}
+	vms := make([]*vsphereVirtualMachine, 0, len(moVMs))
+	for i := range moVMs {
+		if vm := convertVirtualMachine(&moVMs[i], dc.Name(), fieldNames); vm != nil {
+			vms = append(vms, vm)
+		}
+	}
+	return networks, vms, nil
+}
+
+// convertVirtualMachine converts govmomi virtual machine to vsphereVirtualMachine. Virtual machines without config,
+// e.g. orphaned or inaccessible ones, are skipped.
+func convertVirtualMachine(moVM *mo.VirtualMachine, datacenter string, fieldNames map[int32]string) *vsphereVirtualMachine {
+	if moVM.Config == nil || len(moVM.Config.InstanceUuid) == 0 {
+		return nil
+	}
+	vm := &vsphereVirtualMachine{
+		id:         moVM.Config.InstanceUuid,
+		name:       moVM.Name,
+		datacenter: datacenter,
+		powerState: string(moVM.Runtime.PowerState),
+		tags:       make(map[string]string),
+	}
+
+	for _, customValue := range moVM.CustomValue {
+		stringValue, ok := customValue.(*types.CustomFieldStringValue)
+		if !ok {
+			continue
+		}
+		if name, found := fieldNames[stringValue.Key]; found {
+			vm.tags[name] = stringValue.Value
+		}
+	}
+
+	for _, device := range moVM.Config.Hardware.Device {
+		ethernetCard, ok := device.(types.BaseVirtualEthernetCard)
+		if !ok {
+			continue
+		}
+		card := ethernetCard.GetVirtualEthernetCard()
+		networkInterface := &vsphereNetworkInterface{
+			macAddress: card.MacAddress,
+		}
+		switch backing := card.Backing.(type) {
+		case *types.VirtualEthernetCardNetworkBackingInfo:
+			if backing.Network != nil {
+				networkInterface.networkID = backing.Network.Value
+			}
+		case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+			networkInterface.networkID = backing.Port.PortgroupKey
+		}
+		if moVM.Guest != nil {
+			for _, guestNic := range moVM.Guest.Net {
+				if strings.EqualFold(guestNic.MacAddress, card.MacAddress) {
+					networkInterface.ips = append(networkInterface.ips, guestNic.IpAddress...)
+				}
+			}
+		}
+		// virtual machine is considered to be in the port group of its first network interface.
+		if len(vm.networkID) == 0 {
+			vm.networkID = networkInterface.networkID
+		}
+		vm.networkInterfaces = append(vm.networkInterfaces, networkInterface)
+	}
+	return vm
+}
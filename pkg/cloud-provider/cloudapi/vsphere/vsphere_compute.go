@@ -0,0 +1,264 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+type computeServiceConfig struct {
+	accountNamespacedName types.NamespacedName
+	apiClient             vsphereAPIWrapper
+	resourcesCache        *internal.CloudServiceResourcesCache
+	inventoryStats        *internal.CloudServiceStats
+	// instanceFilters has following possible values
+	// - empty map indicates no selectors configured for this account. NO cloud api call for inventory will be made.
+	// - non-empty map indicates selectors are configured. Cloud api call for inventory will be made.
+	// - key with nil value indicates no filters. Get all instances for account.
+	// - key with non-nil value indicates some filter. Get instances matching those filters only.
+	instanceFilters map[string][]*vsphereInstanceFilter
+	credentials     *vsphereAccountConfig
+}
+
+// computeResourcesCacheSnapshot holds the results from querying for all instances.
+type computeResourcesCacheSnapshot struct {
+	instances map[cloudcommon.InstanceID]*vsphereVirtualMachine
+	vpcs      []*vsphereNetwork
+	vpcIDs    map[string]struct{}
+}
+
+func newComputeServiceConfig(accountNamespacedName types.NamespacedName, credentials *vsphereAccountConfig) (
+	internal.CloudServiceInterface, error) {
+	apiClient, err := newVSphereAPIWrapper(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vSphere api client for account : %v, err: %v", accountNamespacedName.String(), err)
+	}
+
+	config := &computeServiceConfig{
+		apiClient:             apiClient,
+		accountNamespacedName: accountNamespacedName,
+		resourcesCache:        &internal.CloudServiceResourcesCache{},
+		inventoryStats:        &internal.CloudServiceStats{},
+		instanceFilters:       make(map[string][]*vsphereInstanceFilter),
+		credentials:           credentials,
+	}
+	return config, nil
+}
+
+// getInstanceResourceFilters returns filters to be applied to listed instances if filters are configured.
+// Otherwise, returns (nil, false). false indicates no selectors configured for the account and hence no cloud api needs
+// to be made for instance inventory.
+func (computeCfg *computeServiceConfig) getInstanceResourceFilters() ([]*vsphereInstanceFilter, bool) {
+	var allFilters []*vsphereInstanceFilter
+
+	if len(computeCfg.instanceFilters) == 0 {
+		return nil, false
+	}
+
+	for _, filters := range computeCfg.instanceFilters {
+		// if any selector found with nil filter, skip all other selectors. As nil indicates all
+		if len(filters) == 0 {
+			return nil, true
+		}
+		allFilters = append(allFilters, filters...)
+	}
+	return allFilters, true
+}
+
+// getCachedInstances returns instances from the cache for the account.
+func (computeCfg *computeServiceConfig) getCachedInstances() []*vsphereVirtualMachine {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		vspherePluginLogger().V(4).Info("cache snapshot nil", "service", vsphereComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []*vsphereVirtualMachine{}
+	}
+	instances := snapshot.(*computeResourcesCacheSnapshot).instances
+	instancesToReturn := make([]*vsphereVirtualMachine, 0, len(instances))
+	for _, instance := range instances {
+		instancesToReturn = append(instancesToReturn, instance)
+	}
+	vspherePluginLogger().V(1).Info("cached vm instances", "service", vsphereComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(instancesToReturn))
+	return instancesToReturn
+}
+
+// getManagedVpcIDs returns IDs of vpcs containing managed vms.
+func (computeCfg *computeServiceConfig) getManagedVpcIDs() map[string]struct{} {
+	vpcIDsCopy := make(map[string]struct{})
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		vspherePluginLogger().V(4).Info("cache snapshot nil", "service", vsphereComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return vpcIDsCopy
+	}
+	for vpcID := range snapshot.(*computeResourcesCacheSnapshot).vpcIDs {
+		vpcIDsCopy[vpcID] = struct{}{}
+	}
+	return vpcIDsCopy
+}
+
+// getCachedVpcs returns vpcs from cached snapshot for the account.
+func (computeCfg *computeServiceConfig) getCachedVpcs() []*vsphereNetwork {
+	snapshot := computeCfg.resourcesCache.GetSnapshot()
+	if snapshot == nil {
+		vspherePluginLogger().V(4).Info("cache snapshot nil", "service", vsphereComputeServiceNameCompute,
+			"account", computeCfg.accountNamespacedName)
+		return []*vsphereNetwork{}
+	}
+	vpcs := snapshot.(*computeResourcesCacheSnapshot).vpcs
+	vpcsToReturn := make([]*vsphereNetwork, 0, len(vpcs))
+	vpcsToReturn = append(vpcsToReturn, vpcs...)
+	return vpcsToReturn
+}
+
+// filterInstances returns the vms matching the configured filters.
+func (computeCfg *computeServiceConfig) filterInstances(allInstances []*vsphereVirtualMachine,
+	networks map[string]*vsphereNetwork) []*vsphereVirtualMachine {
+	filters, hasFilters := computeCfg.getInstanceResourceFilters()
+	if !hasFilters {
+		vspherePluginLogger().V(1).Info("fetching vm resources from cloud skipped",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "not-configured")
+		return nil
+	}
+	if filters == nil {
+		vspherePluginLogger().V(1).Info("fetching vm resources from cloud",
+			"account", computeCfg.accountNamespacedName, "resource-filters", "all(nil)")
+		return allInstances
+	}
+
+	vspherePluginLogger().V(1).Info("fetching vm resources from cloud",
+		"account", computeCfg.accountNamespacedName, "resource-filters", "configured")
+	var instances []*vsphereVirtualMachine
+	for _, instance := range allInstances {
+		for _, filter := range filters {
+			if filter.matches(instance, networks[instance.networkID]) {
+				instances = append(instances, instance)
+				break
+			}
+		}
+	}
+
+	vspherePluginLogger().V(1).Info("vm instances from cloud", "service", vsphereComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "instances", len(instances))
+	return instances
+}
+
+// DoResourceInventory gets inventory from cloud for given cloud account.
+func (computeCfg *computeServiceConfig) DoResourceInventory() error {
+	networks, allInstances, err := computeCfg.apiClient.getInventory()
+	if err != nil {
+		vspherePluginLogger().Error(err, "failed to fetch cloud resources", "account", computeCfg.accountNamespacedName)
+		return err
+	}
+	networksByID := make(map[string]*vsphereNetwork)
+	for _, network := range networks {
+		networksByID[network.id] = network
+	}
+
+	instances := computeCfg.filterInstances(allInstances, networksByID)
+
+	exists := struct{}{}
+	vpcIDs := make(map[string]struct{})
+	instanceIDs := make(map[cloudcommon.InstanceID]*vsphereVirtualMachine)
+	for _, instance := range instances {
+		instanceIDs[cloudcommon.InstanceID(strings.ToLower(instance.id))] = instance
+		vpcIDs[strings.ToLower(instance.networkID)] = exists
+	}
+	computeCfg.resourcesCache.UpdateSnapshot(&computeResourcesCacheSnapshot{instanceIDs, networks, vpcIDs})
+	return nil
+}
+
+// SetResourceFilters add/updates instances resource filter for the service.
+func (computeCfg *computeServiceConfig) SetResourceFilters(selector *crdv1alpha1.CloudEntitySelector) {
+	if filters, found := convertSelectorToInstanceFilters(selector); found {
+		computeCfg.instanceFilters[selector.GetName()] = filters
+	} else {
+		if selector != nil {
+			delete(computeCfg.instanceFilters, selector.GetName())
+		}
+		computeCfg.resourcesCache.UpdateSnapshot(nil)
+	}
+}
+
+func (computeCfg *computeServiceConfig) RemoveResourceFilters(selectorName string) {
+	delete(computeCfg.instanceFilters, selectorName)
+}
+
+func (computeCfg *computeServiceConfig) GetInternalResourceObjects(namespace string,
+	account *types.NamespacedName) map[string]*runtimev1alpha1.VirtualMachine {
+	instances := computeCfg.getCachedInstances()
+	vmObjects := map[string]*runtimev1alpha1.VirtualMachine{}
+	for _, instance := range instances {
+		// build runtimev1alpha1 VirtualMachine object.
+		vmObject := virtualMachineToInternalVirtualMachineObject(instance, namespace, account)
+		vmObjects[vmObject.Name] = vmObject
+	}
+
+	vspherePluginLogger().V(1).Info("Internal resource objects", "Service", vsphereComputeServiceNameCompute,
+		"Account", computeCfg.accountNamespacedName, "VirtualMachine objects", len(vmObjects))
+
+	return vmObjects
+}
+
+func (computeCfg *computeServiceConfig) GetName() internal.CloudServiceName {
+	return vsphereComputeServiceNameCompute
+}
+
+func (computeCfg *computeServiceConfig) GetType() internal.CloudServiceType {
+	return internal.CloudServiceTypeCompute
+}
+
+func (computeCfg *computeServiceConfig) GetInventoryStats() *internal.CloudServiceStats {
+	return computeCfg.inventoryStats
+}
+
+func (computeCfg *computeServiceConfig) ResetCachedState() {
+	computeCfg.SetResourceFilters(nil)
+	computeCfg.inventoryStats.ResetInventoryPollStats()
+}
+
+func (computeCfg *computeServiceConfig) UpdateServiceConfig(newConfig internal.CloudServiceInterface) {
+	newComputeServiceConfig := newConfig.(*computeServiceConfig)
+	computeCfg.apiClient = newComputeServiceConfig.apiClient
+	computeCfg.credentials = newComputeServiceConfig.credentials
+}
+
+// GetVpcInventory generates vpc object for the vpcs stored in snapshot(in cloud format) and return a map of vpc runtime objects.
+func (computeCfg *computeServiceConfig) GetVpcInventory() map[string]*runtimev1alpha1.Vpc {
+	vpcs := computeCfg.getCachedVpcs()
+	vpcIDs := computeCfg.getManagedVpcIDs()
+	// Convert to kubernetes object and return a map indexed using vpc ID.
+	vpcMap := map[string]*runtimev1alpha1.Vpc{}
+	for _, vpc := range vpcs {
+		_, managed := vpcIDs[strings.ToLower(vpc.id)]
+		vpcObj := networkToInternalVpcObject(vpc, computeCfg.accountNamespacedName.Namespace,
+			computeCfg.accountNamespacedName.Name, managed)
+		vpcMap[strings.ToLower(vpc.id)] = vpcObj
+	}
+
+	vspherePluginLogger().V(1).Info("cached vpcs", "service", vsphereComputeServiceNameCompute,
+		"account", computeCfg.accountNamespacedName, "vpc objects", len(vpcMap))
+
+	return vpcMap
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/utils"
+)
+
+var vsphereStateMap = map[string]runtimev1alpha1.VMState{
+	"poweredon":  runtimev1alpha1.Running,
+	"poweredoff": runtimev1alpha1.Stopped,
+	"suspended":  runtimev1alpha1.Stopped,
+}
+
+// virtualMachineToInternalVirtualMachineObject converts vSphere virtual machine to VirtualMachine runtime object.
+// Network interfaces are named by mac address, and the datacenter of the virtual machine is used as region.
+func virtualMachineToInternalVirtualMachineObject(vm *vsphereVirtualMachine, namespace string,
+	account *types.NamespacedName) *runtimev1alpha1.VirtualMachine {
+	tags := make(map[string]string)
+	for key, value := range vm.tags {
+		tags[key] = value
+	}
+
+	// Network interfaces associated with Virtual machine
+	networkInterfaces := make([]runtimev1alpha1.NetworkInterface, 0, len(vm.networkInterfaces))
+	for _, nwInf := range vm.networkInterfaces {
+		var ipAddressObjs []runtimev1alpha1.IPAddress
+		for _, ip := range nwInf.ips {
+			ipAddressObjs = append(ipAddressObjs, runtimev1alpha1.IPAddress{
+				AddressType: runtimev1alpha1.AddressTypeInternalIP,
+				Address:     ip,
+			})
+		}
+		networkInterface := runtimev1alpha1.NetworkInterface{
+			Name: strings.ToLower(nwInf.macAddress),
+			MAC:  strings.ToLower(nwInf.macAddress),
+			IPs:  ipAddressObjs,
+		}
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+
+	cloudID := strings.ToLower(vm.id)
+	cloudNetworkID := strings.ToLower(vm.networkID)
+	state, ok := vsphereStateMap[strings.ToLower(vm.powerState)]
+	if !ok {
+		state = runtimev1alpha1.Unknown
+	}
+	return utils.GenerateInternalVirtualMachineObject(cloudID, strings.ToLower(vm.name), cloudID, strings.ToLower(vm.datacenter),
+		namespace, cloudNetworkID, cloudNetworkID, state, tags, networkInterfaces, providerType, account)
+}
+
+// networkToInternalVpcObject converts vSphere port group to vpc runtime object.
+func networkToInternalVpcObject(network *vsphereNetwork, accountNamespace, accountName string, managed bool) *runtimev1alpha1.Vpc {
+	cloudID := strings.ToLower(network.id)
+	return utils.GenerateInternalVpcObject(cloudID, accountNamespace, accountName, strings.ToLower(network.name),
+		cloudID, map[string]string{}, runtimev1alpha1.VSphereCloudProvider, strings.ToLower(network.datacenter), nil, managed)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"strings"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+// vsphereInstanceFilter is a filter for vSphere virtual machines. Empty fields of the filter match any value.
+type vsphereInstanceFilter struct {
+	networkID   string
+	networkName string
+	instanceID  string
+	name        string
+}
+
+// convertSelectorToInstanceFilters converts vm selector to vSphere instance filters.
+func convertSelectorToInstanceFilters(selector *crdv1alpha1.CloudEntitySelector) ([]*vsphereInstanceFilter, bool) {
+	if selector == nil {
+		return nil, false
+	}
+	if selector.Spec.VMSelector == nil {
+		return nil, true
+	}
+
+	return buildInstanceFilters(selector.Spec.VMSelector), true
+}
+
+// buildInstanceFilters builds instance filters for VirtualMachineSelector. vpcMatch matches the port group of a
+// virtual machine and vmMatch matches the instance uuid or name of a virtual machine.
+func buildInstanceFilters(vmSelector []crdv1alpha1.VirtualMachineSelector) []*vsphereInstanceFilter {
+	var filters []*vsphereInstanceFilter
+	for _, match := range vmSelector {
+		vpcFilter := vsphereInstanceFilter{}
+		if match.VpcMatch != nil {
+			vpcFilter.networkID = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchID))
+			vpcFilter.networkName = strings.ToLower(strings.TrimSpace(match.VpcMatch.MatchName))
+		}
+		if len(match.VMMatch) == 0 {
+			filter := vpcFilter
+			filters = append(filters, &filter)
+			continue
+		}
+		for _, vmMatch := range match.VMMatch {
+			filter := vpcFilter
+			filter.instanceID = strings.ToLower(strings.TrimSpace(vmMatch.MatchID))
+			filter.name = strings.ToLower(strings.TrimSpace(vmMatch.MatchName))
+			filters = append(filters, &filter)
+		}
+	}
+	return filters
+}
+
+// matches returns true if the virtual machine, in given port group, matches the filter.
+func (f *vsphereInstanceFilter) matches(vm *vsphereVirtualMachine, network *vsphereNetwork) bool {
+	if len(f.instanceID) != 0 && f.instanceID != strings.ToLower(vm.id) {
+		return false
+	}
+	if len(f.name) != 0 && f.name != strings.ToLower(vm.name) {
+		return false
+	}
+	if len(f.networkID) != 0 && f.networkID != strings.ToLower(vm.networkID) {
+		return false
+	}
+	if len(f.networkName) != 0 && (network == nil || f.networkName != strings.ToLower(network.name)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/internal"
+)
+
+const (
+	vsphereComputeServiceNameCompute = internal.CloudServiceName("VSphereCompute")
+)
+
+func newVSphereServiceConfigs(accountNamespacedName *types.NamespacedName, accCredentials interface{}, _ interface{}) (
+	[]internal.CloudServiceInterface, error) {
+	vsphereAccountCredentials := accCredentials.(*vsphereAccountConfig)
+
+	var serviceConfigs []internal.CloudServiceInterface
+
+	computeService, err := newComputeServiceConfig(*accountNamespacedName, vsphereAccountCredentials)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfigs = append(serviceConfigs, computeService)
+
+	return serviceConfigs, nil
+}
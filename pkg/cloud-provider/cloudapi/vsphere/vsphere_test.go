@@ -0,0 +1,226 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/simulator"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+const (
+	testVMName = "DC0_H0_VM0"
+)
+
+var _ = Describe("VSphere cloud", func() {
+	var (
+		testAccountNamespacedName = types.NamespacedName{Namespace: "namespace01", Name: "account01"}
+		credentials               = "credentials"
+
+		model      *simulator.Model
+		server     *simulator.Server
+		account    *v1alpha1.CloudProviderAccount
+		selector   *v1alpha1.CloudEntitySelector
+		secret     *corev1.Secret
+		fakeClient client.WithWatch
+	)
+
+	BeforeEach(func() {
+		// vcsim inventory of one datacenter DC0, with a standalone host and a cluster running virtual machines.
+		model = simulator.VPX()
+		Expect(model.Create()).Should(Succeed())
+		// vcsim accepts any credentials unless a login is configured.
+		model.Service.Listen = &url.URL{User: url.UserPassword("admin", "password")}
+		server = model.Service.NewServer()
+
+		password, _ := server.URL.User.Password()
+		cred, err := json.Marshal(v1alpha1.VSphereAccountCredential{
+			Username: server.URL.User.Username(),
+			Password: password,
+		})
+		Expect(err).Should(BeNil())
+		endpoint := url.URL{Scheme: server.URL.Scheme, Host: server.URL.Host, Path: server.URL.Path}
+
+		var pollIntv uint = 1
+		account = &v1alpha1.CloudProviderAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudProviderAccountSpec{
+				PollIntervalInSeconds: &pollIntv,
+				VSphereConfig: &v1alpha1.CloudProviderAccountVSphereConfig{
+					Endpoint: endpoint.String(),
+					Insecure: true,
+					SecretRef: &v1alpha1.SecretReference{
+						Name:      testAccountNamespacedName.Name,
+						Namespace: testAccountNamespacedName.Namespace,
+						Key:       credentials,
+					},
+				},
+			},
+		}
+		selector = &v1alpha1.CloudEntitySelector{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "selector-all",
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Spec: v1alpha1.CloudEntitySelectorSpec{
+				AccountName: testAccountNamespacedName.Name,
+				VMSelector:  []v1alpha1.VirtualMachineSelector{},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      testAccountNamespacedName.Name,
+				Namespace: testAccountNamespacedName.Namespace,
+			},
+			Data: map[string][]byte{
+				credentials: cred,
+			},
+		}
+		fakeClient = fake.NewClientBuilder().Build()
+	})
+
+	AfterEach(func() {
+		server.Close()
+		model.Remove()
+	})
+
+	addAccount := func() *vsphereCloud {
+		_ = fakeClient.Create(context.Background(), secret)
+		c := newVSphereCloud()
+		err := c.AddProviderAccount(fakeClient, account)
+		Expect(err).Should(BeNil())
+		accCfg, found := c.cloudCommon.GetCloudAccountByName(&testAccountNamespacedName)
+		Expect(found).To(BeTrue())
+		Expect(accCfg).To(Not(BeNil()))
+		return c
+	}
+
+	Context("AddProviderAccount", func() {
+		It("Should discover port groups without any selector", func() {
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(BeEmpty())
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vpcMap).ShouldNot(BeEmpty())
+			for _, vpc := range vpcMap {
+				Expect(vpc.Status.Provider).Should(Equal(runtimev1alpha1.VSphereCloudProvider))
+				Expect(vpc.Status.Region).Should(Equal("dc0"))
+				Expect(vpc.Status.Managed).Should(BeFalse())
+			}
+		})
+		It("Should discover all virtual machines with get ALL selector", func() {
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(model.Count().Machine))
+			vpcMap, err := c.GetVpcInventory(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			for _, vm := range vms {
+				Expect(vm.Status.Provider).Should(Equal(runtimev1alpha1.VSphereCloudProvider))
+				Expect(vm.Status.Region).Should(Equal("dc0"))
+				Expect(vm.Status.State).Should(Equal(runtimev1alpha1.Running))
+				Expect(vm.Status.NetworkInterfaces).ShouldNot(BeEmpty())
+				Expect(vm.Status.NetworkInterfaces[0].Name).Should(Equal(vm.Status.NetworkInterfaces[0].MAC))
+				Expect(vpcMap).Should(HaveKey(vm.Status.CloudVpcId))
+				Expect(vpcMap[vm.Status.CloudVpcId].Status.Managed).Should(BeTrue())
+			}
+		})
+		It("Should discover virtual machine matching vm name selector", func() {
+			selector.Spec.VMSelector = []v1alpha1.VirtualMachineSelector{
+				{
+					VMMatch: []v1alpha1.EntityMatch{{MatchName: testVMName}},
+				},
+			}
+
+			c := addAccount()
+			err := c.AddAccountResourceSelector(&testAccountNamespacedName, selector)
+			Expect(err).Should(BeNil())
+			err = c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+
+			vms, err := c.InstancesGivenProviderAccount(&testAccountNamespacedName)
+			Expect(err).Should(BeNil())
+			Expect(vms).Should(HaveLen(1))
+			for _, vm := range vms {
+				Expect(vm.Status.CloudName).Should(Equal("dc0_h0_vm0"))
+			}
+		})
+		It("Should fail inventory poll of unknown datacenter", func() {
+			account.Spec.VSphereConfig.Datacenter = "unknown"
+
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Should fail inventory poll with invalid credentials", func() {
+			cred, _ := json.Marshal(v1alpha1.VSphereAccountCredential{Username: "invalid", Password: "invalid"})
+			secret.Data[credentials] = cred
+
+			c := addAccount()
+			err := c.DoInventoryPoll(&testAccountNamespacedName)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("SecurityInterface", func() {
+		It("Should report security groups unsupported", func() {
+			c := addAccount()
+			sg := &securitygroup.CloudResource{
+				Type: securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{
+					Name: "address-group",
+					Vpc:  "network-7",
+				},
+				AccountID:     testAccountNamespacedName.String(),
+				CloudProvider: string(runtimev1alpha1.VSphereCloudProvider),
+			}
+			_, err := c.CreateSecurityGroup(sg, true)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			err = c.UpdateSecurityGroupMembers(sg, nil, true)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			err = c.UpdateSecurityGroupRules(sg, nil, nil, nil)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			err = c.DeleteSecurityGroup(sg, true)
+			Expect(err).Should(Equal(errSecurityUnsupported))
+			Expect(c.GetEnforcedSecurity()).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,117 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentialsource
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"antrea.io/nephe/pkg/config"
+)
+
+var (
+	// fileRoot is the directory of credential files, file credential sources are rejected if not set.
+	fileRoot string
+	// vaultAddresses are the Vault servers credential sources may refer to.
+	vaultAddresses = make(map[string]struct{})
+	// vaultRootCAs verifies the certificates of Vault servers, system roots are used if nil.
+	vaultRootCAs *x509.CertPool
+)
+
+// SetConfig sets the files and Vault servers credential sources may refer to.
+func SetConfig(cfg *config.CredentialSourceConfig) error {
+	addresses := make(map[string]struct{}, len(cfg.VaultAddresses))
+	for _, address := range cfg.VaultAddresses {
+		normalized, err := normalizeVaultAddress(address)
+		if err != nil {
+			return err
+		}
+		addresses[normalized] = struct{}{}
+	}
+	var rootCAs *x509.CertPool
+	if len(cfg.VaultCAFile) != 0 {
+		pem, err := os.ReadFile(cfg.VaultCAFile)
+		if err != nil {
+			return fmt.Errorf("unable to read Vault CA file %v: %v", cfg.VaultCAFile, err)
+		}
+		rootCAs = x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in Vault CA file %v", cfg.VaultCAFile)
+		}
+	}
+	fileRoot = ""
+	if len(cfg.FileRoot) != 0 {
+		fileRoot = filepath.Clean(cfg.FileRoot)
+	}
+	vaultAddresses, vaultRootCAs = addresses, rootCAs
+	return nil
+}
+
+// CheckVaultAddress returns an error unless a Vault address is the https address of a configured Vault server.
+func CheckVaultAddress(address string) error {
+	normalized, err := normalizeVaultAddress(address)
+	if err != nil {
+		return err
+	}
+	if _, found := vaultAddresses[normalized]; !found {
+		return fmt.Errorf("vault address %v is not allowed by nephe-controller configuration", address)
+	}
+	return nil
+}
+
+// normalizeVaultAddress returns the scheme and host of a Vault address, which must be an https URL without path.
+func normalizeVaultAddress(address string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(address))
+	if err != nil || u.Scheme != "https" || len(u.Host) == 0 || len(strings.Trim(u.Path, "/")) != 0 ||
+		u.User != nil || len(u.RawQuery) != 0 || len(u.Fragment) != 0 {
+		return "", fmt.Errorf("vault address %v is not an https URL without path", address)
+	}
+	return "https://" + strings.ToLower(u.Host), nil
+}
+
+// CheckFilePath returns an error unless a credential file path of an account in a namespace is in the directory of
+// the namespace in the configured file root.
+func CheckFilePath(namespace, path string) error {
+	if len(fileRoot) == 0 {
+		return fmt.Errorf("credential files are not allowed by nephe-controller configuration")
+	}
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("credential file path %v is not absolute", path)
+	}
+	for _, element := range strings.Split(filepath.ToSlash(path), "/") {
+		if element == ".." {
+			return fmt.Errorf("credential file path %v must not contain ..", path)
+		}
+	}
+	if dir := getFileDir(namespace); !isInDir(dir, filepath.Clean(path)) {
+		return fmt.Errorf("credential file path %v is not in directory %v", path, dir)
+	}
+	return nil
+}
+
+// getFileDir returns the directory of the credential files of accounts in a namespace.
+func getFileDir(namespace string) string {
+	return filepath.Join(fileRoot, namespace)
+}
+
+// isInDir returns true if a cleaned path is in a directory or its subdirectories.
+func isInDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
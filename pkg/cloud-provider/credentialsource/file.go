@@ -0,0 +1,56 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentialsource
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileSource retrieves credentials from a file in the directory of the namespace of the account in the file root,
+// e.g. a projected volume, which is reloaded periodically as the file may be updated in place.
+type fileSource struct {
+	namespace string
+	path      string
+}
+
+// Retrieve returns the content of the file.
+func (s *fileSource) Retrieve() ([]byte, time.Time, error) {
+	if err := CheckFilePath(s.namespace, s.path); err != nil {
+		return nil, time.Time{}, err
+	}
+	// symlinks are resolved, so that a link in the directory cannot expose files outside of it.
+	dir, err := filepath.EvalSymlinks(getFileDir(s.namespace))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to resolve credentials directory of namespace %v: %v", s.namespace, err)
+	}
+	path, err := filepath.EvalSymlinks(s.path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to resolve credentials file %v: %v", s.path, err)
+	}
+	if !isInDir(dir, path) {
+		return nil, time.Time{}, fmt.Errorf("credentials file %v resolves to %v outside of directory %v", s.path, path, dir)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to read credentials file %v: %v", s.path, err)
+	}
+	return data, time.Now().Add(reloadInterval), nil
+}
+
+// Close does nothing, as files are not leased.
+func (s *fileSource) Close() {}
@@ -0,0 +1,192 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentialsource retrieves the credentials of cloud provider accounts from k8s secrets, files and
+// HashiCorp Vault.
+package credentialsource
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+)
+
+const (
+	// reloadInterval is the interval to reload credentials which do not expire, but may be changed in place.
+	reloadInterval = 5 * time.Minute
+	// ExpiryWindow is the time before expiry when credentials are retrieved again.
+	ExpiryWindow = time.Minute
+)
+
+// Source is a source of the credentials of a cloud provider account, in the format of the k8s secret of the cloud
+// provider.
+type Source interface {
+	// Retrieve returns the credentials, and the time they must be retrieved again, which is zero if the credentials
+	// do not change.
+	Retrieve() ([]byte, time.Time, error)
+	// Close revokes the leased credentials of the source.
+	Close()
+}
+
+// newSource returns the source of a credential source of an account in a namespace.
+func newSource(c client.Client, namespace string, source *crdv1alpha1.CloudProviderAccountCredentialSource) (Source, error) {
+	switch {
+	case source.File != nil && source.Vault == nil:
+		return &fileSource{namespace: namespace, path: source.File.Path}, nil
+	case source.Vault != nil && source.File == nil:
+		return newVaultSource(c, source.Vault), nil
+	default:
+		return nil, fmt.Errorf("exactly one of file and vault must be specified in credential source")
+	}
+}
+
+// secretSource retrieves credentials from a key of a k8s secret. The secret cannot be updated while it is referred
+// by an account, hence credentials of the secret do not change.
+type secretSource struct {
+	client    client.Client
+	secretRef *crdv1alpha1.SecretReference
+}
+
+// NewSecretSource returns the source of a key of a k8s secret.
+func NewSecretSource(c client.Client, secretRef *crdv1alpha1.SecretReference) Source {
+	return &secretSource{client: c, secretRef: secretRef}
+}
+
+// Retrieve returns the decoded value of the key of the secret.
+func (s *secretSource) Retrieve() ([]byte, time.Time, error) {
+	data, err := getSecretData(s.client, s.secretRef)
+	return data, time.Time{}, err
+}
+
+// Close does nothing, as secrets are not leased.
+func (s *secretSource) Close() {}
+
+// getSecretData returns the decoded value of a key of a k8s secret.
+func getSecretData(c client.Client, s *crdv1alpha1.SecretReference) ([]byte, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Kind:    "Secret",
+		Version: "v1",
+	})
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, u); err != nil {
+		return nil, err
+	}
+
+	data, _ := u.Object["data"].(map[string]interface{})
+	value, ok := data[s.Key].(string)
+	if !ok {
+		return nil, fmt.Errorf("key %v not found in secret %v/%v", s.Key, s.Namespace, s.Name)
+	}
+	return base64.StdEncoding.DecodeString(value)
+}
+
+// CachedSource caches the credentials of a source, which are retrieved again shortly before they must be.
+// A CachedSource is shared by the clients of all regions of an account, so that dynamic credentials are
+// generated once for the account.
+type CachedSource struct {
+	mutex  sync.Mutex
+	source Source
+	data   []byte
+	expiry time.Time
+	now    func() time.Time
+}
+
+// NewCachedSource returns a CachedSource of a source.
+func NewCachedSource(source Source) *CachedSource {
+	return &CachedSource{source: source, now: time.Now}
+}
+
+// Retrieve returns the cached credentials, and retrieves them from the source if they are about to expire.
+func (s *CachedSource) Retrieve() ([]byte, time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.data != nil && (s.expiry.IsZero() || s.now().Before(s.expiry.Add(-ExpiryWindow))) {
+		return s.data, s.expiry, nil
+	}
+	data, expiry, err := s.source.Retrieve()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	s.data, s.expiry = data, expiry
+	return s.data, s.expiry, nil
+}
+
+// Close closes the source, and clears the cached credentials.
+func (s *CachedSource) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.source.Close()
+	s.data, s.expiry = nil, time.Time{}
+}
+
+// accountSource is the source of the credential source of an account.
+type accountSource struct {
+	spec   *crdv1alpha1.CloudProviderAccountCredentialSource
+	source *CachedSource
+}
+
+var (
+	accountSourcesMutex sync.Mutex
+	// accountSources are the sources of accounts, kept across reconciles of the accounts, so that Vault tokens and
+	// leases are reused rather than created on every reconcile.
+	accountSources = make(map[types.NamespacedName]*accountSource)
+)
+
+// GetAccountSource returns the source of the credential source of an account. The source of the account is reused
+// unless its credential source is changed, in which case the previous source is closed.
+func GetAccountSource(c client.Client, namespacedName *types.NamespacedName,
+	spec *crdv1alpha1.CloudProviderAccountCredentialSource) (*CachedSource, error) {
+	accountSourcesMutex.Lock()
+	defer accountSourcesMutex.Unlock()
+
+	if existing, found := accountSources[*namespacedName]; found {
+		if reflect.DeepEqual(existing.spec, spec) {
+			return existing.source, nil
+		}
+		existing.source.Close()
+		delete(accountSources, *namespacedName)
+	}
+	source, err := newSource(c, namespacedName.Namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	cached := NewCachedSource(source)
+	accountSources[*namespacedName] = &accountSource{spec: spec.DeepCopy(), source: cached}
+	return cached, nil
+}
+
+// ReleaseAccountSource closes the source of an account, when the account is deleted or no longer has a credential
+// source.
+func ReleaseAccountSource(namespacedName *types.NamespacedName) {
+	accountSourcesMutex.Lock()
+	defer accountSourcesMutex.Unlock()
+
+	if existing, found := accountSources[*namespacedName]; found {
+		existing.source.Close()
+		delete(accountSources, *namespacedName)
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentialsource
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/config"
+)
+
+// fakeSource counts the retrievals of credentials.
+type fakeSource struct {
+	retrievals int
+	closed     bool
+	expiry     time.Time
+}
+
+func (s *fakeSource) Retrieve() ([]byte, time.Time, error) {
+	s.retrievals++
+	return []byte(fmt.Sprintf(`{"accessKeyId": "key%d"}`, s.retrievals)), s.expiry, nil
+}
+
+func (s *fakeSource) Close() {
+	s.closed = true
+}
+
+var _ = Describe("Credential source", func() {
+	const (
+		testVaultToken = "test-vault-token"
+		testJWT        = "test-service-account-token"
+		testRole       = "nephe"
+		testNamespace  = "default"
+	)
+
+	var (
+		fakeClient client.Client
+		tmpDir     string
+		tokenRef   = &crdv1alpha1.SecretReference{Name: "vault-token", Namespace: "nephe-system", Key: "token"}
+	)
+
+	BeforeEach(func() {
+		newScheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+		fakeClient = fake.NewClientBuilder().WithScheme(newScheme).Build()
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: tokenRef.Name, Namespace: tokenRef.Namespace},
+			Data:       map[string][]byte{tokenRef.Key: []byte(testVaultToken)},
+		}
+		Expect(fakeClient.Create(context.Background(), secret)).Should(Succeed())
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "credentialsource")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(tmpDir, testNamespace), 0700)).Should(Succeed())
+		Expect(SetConfig(&config.CredentialSourceConfig{FileRoot: tmpDir})).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tmpDir)
+		Expect(SetConfig(&config.CredentialSourceConfig{})).Should(Succeed())
+	})
+
+	Context("Secret and file", func() {
+		It("Should retrieve credentials of secret", func() {
+			data, expiry, err := NewSecretSource(fakeClient, tokenRef).Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal(testVaultToken))
+			Expect(expiry.IsZero()).Should(BeTrue())
+		})
+
+		It("Should reload credentials of file", func() {
+			path := filepath.Join(tmpDir, testNamespace, "credentials")
+			Expect(os.WriteFile(path, []byte(`{"accessKeyId": "key1"}`), 0600)).Should(Succeed())
+			source, err := newSource(fakeClient, testNamespace, &crdv1alpha1.CloudProviderAccountCredentialSource{
+				File: &crdv1alpha1.FileCredentialSource{Path: path},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			data, expiry, err := source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal(`{"accessKeyId": "key1"}`))
+			Expect(expiry.IsZero()).Should(BeFalse())
+
+			Expect(os.WriteFile(path, []byte(`{"accessKeyId": "key2"}`), 0600)).Should(Succeed())
+			data, _, err = source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal(`{"accessKeyId": "key2"}`))
+		})
+
+		It("Should fail file outside of the directory of the namespace", func() {
+			Expect(os.WriteFile(filepath.Join(tmpDir, "credentials"), []byte(`{}`), 0600)).Should(Succeed())
+			for _, path := range []string{
+				filepath.Join(tmpDir, "credentials"),
+				filepath.Join(tmpDir, testNamespace) + "/../credentials",
+				filepath.Join(tmpDir, "kube-system", "credentials"),
+				"credentials",
+			} {
+				source := &fileSource{namespace: testNamespace, path: path}
+				_, _, err := source.Retrieve()
+				Expect(err).Should(HaveOccurred(), path)
+			}
+		})
+
+		It("Should fail symlink to file outside of the directory of the namespace", func() {
+			target := filepath.Join(tmpDir, "credentials")
+			Expect(os.WriteFile(target, []byte(`{}`), 0600)).Should(Succeed())
+			path := filepath.Join(tmpDir, testNamespace, "credentials")
+			Expect(os.Symlink(target, path)).Should(Succeed())
+			source := &fileSource{namespace: testNamespace, path: path}
+			_, _, err := source.Retrieve()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("outside of directory"))
+		})
+
+		It("Should fail file without file root", func() {
+			path := filepath.Join(tmpDir, testNamespace, "credentials")
+			Expect(os.WriteFile(path, []byte(`{}`), 0600)).Should(Succeed())
+			Expect(SetConfig(&config.CredentialSourceConfig{})).Should(Succeed())
+			source := &fileSource{namespace: testNamespace, path: path}
+			_, _, err := source.Retrieve()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not allowed"))
+		})
+
+		It("Should fail credential source with both file and vault", func() {
+			_, err := newSource(fakeClient, testNamespace, &crdv1alpha1.CloudProviderAccountCredentialSource{
+				File:  &crdv1alpha1.FileCredentialSource{Path: "/tmp/credentials"},
+				Vault: &crdv1alpha1.VaultCredentialSource{Address: "https://127.0.0.1:8200", Path: "secret/data/nephe"},
+			})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Account source", func() {
+		var namespacedName = &types.NamespacedName{Namespace: testNamespace, Name: "account"}
+
+		AfterEach(func() {
+			ReleaseAccountSource(namespacedName)
+		})
+
+		It("Should keep one source per account until the credential source is changed", func() {
+			spec := &crdv1alpha1.CloudProviderAccountCredentialSource{
+				File: &crdv1alpha1.FileCredentialSource{Path: filepath.Join(tmpDir, testNamespace, "aws.json")},
+			}
+			source, err := GetAccountSource(fakeClient, namespacedName, spec)
+			Expect(err).ShouldNot(HaveOccurred())
+			fakeSrc := &fakeSource{}
+			source.source = fakeSrc
+			same, err := GetAccountSource(fakeClient, namespacedName, spec.DeepCopy())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(same).Should(BeIdenticalTo(source))
+			Expect(fakeSrc.closed).Should(BeFalse())
+
+			spec.File.Path = filepath.Join(tmpDir, testNamespace, "azure.json")
+			changed, err := GetAccountSource(fakeClient, namespacedName, spec)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(changed).ShouldNot(BeIdenticalTo(source))
+			Expect(fakeSrc.closed).Should(BeTrue())
+
+			fakeSrc = &fakeSource{}
+			changed.source = fakeSrc
+			ReleaseAccountSource(namespacedName)
+			Expect(fakeSrc.closed).Should(BeTrue())
+		})
+	})
+
+	Context("Cached source", func() {
+		It("Should retrieve credentials again before expiry", func() {
+			now := time.Now()
+			source := &fakeSource{expiry: now.Add(10 * time.Minute)}
+			cachedSource := NewCachedSource(source)
+			cachedSource.now = func() time.Time { return now }
+
+			data, _, err := cachedSource.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal(`{"accessKeyId": "key1"}`))
+			_, _, _ = cachedSource.Retrieve()
+			Expect(source.retrievals).Should(Equal(1))
+
+			cachedSource.now = func() time.Time { return now.Add(10*time.Minute - ExpiryWindow/2) }
+			data, _, err = cachedSource.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).Should(Equal(`{"accessKeyId": "key2"}`))
+			Expect(source.retrievals).Should(Equal(2))
+		})
+
+		It("Should not retrieve credentials without expiry again", func() {
+			source := &fakeSource{}
+			cachedSource := NewCachedSource(source)
+			for i := 0; i < 3; i++ {
+				_, _, err := cachedSource.Retrieve()
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			Expect(source.retrievals).Should(Equal(1))
+		})
+	})
+
+	// the Vault server mimics the HTTP API of a Vault dev server.
+	Context("Vault", func() {
+		var (
+			server        *httptest.Server
+			logins        int
+			renewals      int
+			tokenRevoked  bool
+			awsLeases     int
+			revokedLeases []string
+			tokenFile     string
+		)
+
+		BeforeEach(func() {
+			logins, renewals, tokenRevoked, awsLeases, revokedLeases = 0, 0, false, 0, nil
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]string{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				if r.Method != http.MethodPost || body["role"] != testRole || body["jwt"] != testJWT {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+					return
+				}
+				logins++
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"auth": {"client_token": "%s", "lease_duration": 3600, "renewable": true}}`,
+					testVaultToken)))
+			})
+			mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Vault-Token") != testVaultToken {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+					return
+				}
+				switch r.URL.Path {
+				case "/v1/auth/token/renew-self":
+					renewals++
+					_, _ = w.Write([]byte(fmt.Sprintf(`{"auth": {"client_token": "%s", "lease_duration": 3600, "renewable": true}}`,
+						testVaultToken)))
+				case "/v1/auth/token/revoke-self":
+					tokenRevoked = true
+					w.WriteHeader(http.StatusNoContent)
+				case "/v1/sys/leases/revoke":
+					body := map[string]string{}
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					revokedLeases = append(revokedLeases, body["lease_id"])
+					w.WriteHeader(http.StatusNoContent)
+				case "/v1/secret/data/nephe":
+					_, _ = w.Write([]byte(`{"lease_id": "", "lease_duration": 0, "data": {"data": ` +
+						`{"subscriptionId": "SubID", "tenantId": "TenantID"}, "metadata": {"version": 1}}}`))
+				case "/v1/aws/creds/nephe":
+					awsLeases++
+					_, _ = w.Write([]byte(fmt.Sprintf(`{"lease_id": "aws/creds/nephe/%d", "lease_duration": 900, "data": `+
+						`{"access_key": "AKIA", "secret_key": "secret", "security_token": null}}`, awsLeases)))
+				case "/v1/azure/creds/nephe":
+					_, _ = w.Write([]byte(`{"lease_id": "azure/creds/nephe/abc", "lease_duration": 3600, "data": ` +
+						`{"client_id": "ClientID", "client_secret": "ClientKey"}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"errors": []}`))
+				}
+			})
+			server = httptest.NewTLSServer(mux)
+			Expect(SetConfig(&config.CredentialSourceConfig{VaultAddresses: []string{server.URL}})).Should(Succeed())
+			vaultRootCAs = x509.NewCertPool()
+			vaultRootCAs.AddCert(server.Certificate())
+
+			tokenFile = filepath.Join(tmpDir, "token")
+			Expect(os.WriteFile(tokenFile, []byte(testJWT), 0600)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("Should read KV version 2 secret with token", func() {
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address:        server.URL,
+				Path:           "secret/data/nephe",
+				TokenSecretRef: tokenRef,
+			})
+			data, expiry, err := source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			cred := &crdv1alpha1.AzureAccountCredential{}
+			Expect(json.Unmarshal(data, cred)).Should(Succeed())
+			Expect(*cred).Should(Equal(crdv1alpha1.AzureAccountCredential{SubscriptionID: "SubID", TenantID: "TenantID"}))
+			Expect(expiry).Should(BeTemporally("~", time.Now().Add(reloadInterval), time.Minute))
+		})
+
+		It("Should generate AWS access keys with Kubernetes auth", func() {
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address: server.URL,
+				Path:    "aws/creds/nephe",
+				Engine:  crdv1alpha1.VaultSecretEngineAWS,
+				Role:    testRole,
+			})
+			source.tokenFile = tokenFile
+			data, expiry, err := source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(logins).Should(Equal(1))
+			cred := &crdv1alpha1.AwsAccountCredential{}
+			Expect(json.Unmarshal(data, cred)).Should(Succeed())
+			Expect(*cred).Should(Equal(crdv1alpha1.AwsAccountCredential{AccessKeyID: "AKIA", AccessKeySecret: "secret"}))
+			Expect(expiry).Should(BeTemporally("~", time.Now().Add(900*time.Second), time.Minute))
+		})
+
+		It("Should reuse Kubernetes auth token and revoke superseded leases", func() {
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address: server.URL,
+				Path:    "aws/creds/nephe",
+				Engine:  crdv1alpha1.VaultSecretEngineAWS,
+				Role:    testRole,
+			})
+			source.tokenFile = tokenFile
+			for i := 0; i < 2; i++ {
+				_, _, err := source.Retrieve()
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			Expect(logins).Should(Equal(1))
+			Expect(revokedLeases).Should(Equal([]string{"aws/creds/nephe/1"}))
+
+			source.Close()
+			Expect(revokedLeases).Should(Equal([]string{"aws/creds/nephe/1", "aws/creds/nephe/2"}))
+			Expect(tokenRevoked).Should(BeTrue())
+		})
+
+		It("Should renew Kubernetes auth token before expiry", func() {
+			now := time.Now()
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address: server.URL,
+				Path:    "aws/creds/nephe",
+				Engine:  crdv1alpha1.VaultSecretEngineAWS,
+				Role:    testRole,
+			})
+			source.tokenFile = tokenFile
+			source.now = func() time.Time { return now }
+			_, _, err := source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			source.now = func() time.Time { return now.Add(3600*time.Second - ExpiryWindow/2) }
+			_, _, err = source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(logins).Should(Equal(1))
+			Expect(renewals).Should(Equal(1))
+		})
+
+		It("Should generate Azure client secret", func() {
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address:        server.URL,
+				Path:           "azure/creds/nephe",
+				Engine:         crdv1alpha1.VaultSecretEngineAzure,
+				TokenSecretRef: tokenRef,
+			})
+			data, _, err := source.Retrieve()
+			Expect(err).ShouldNot(HaveOccurred())
+			cred := &crdv1alpha1.AzureAccountCredential{}
+			Expect(json.Unmarshal(data, cred)).Should(Succeed())
+			Expect(*cred).Should(Equal(crdv1alpha1.AzureAccountCredential{ClientID: "ClientID", ClientKey: "ClientKey"}))
+		})
+
+		It("Should fail Kubernetes auth with unknown role", func() {
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address: server.URL,
+				Path:    "aws/creds/nephe",
+				Engine:  crdv1alpha1.VaultSecretEngineAWS,
+				Role:    "unknown",
+			})
+			source.tokenFile = tokenFile
+			_, _, err := source.Retrieve()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("permission denied"))
+		})
+
+		It("Should fail Vault server not allowed", func() {
+			for _, address := range []string{"https://vault.example.com:8200", "http://" + server.Listener.Addr().String()} {
+				source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+					Address: address,
+					Path:    "aws/creds/nephe",
+					Engine:  crdv1alpha1.VaultSecretEngineAWS,
+					Role:    testRole,
+				})
+				source.tokenFile = tokenFile
+				_, _, err := source.Retrieve()
+				Expect(err).Should(HaveOccurred(), address)
+			}
+			Expect(logins).Should(Equal(0))
+		})
+
+		It("Should fail missing secret", func() {
+			source := newVaultSource(fakeClient, &crdv1alpha1.VaultCredentialSource{
+				Address:        server.URL,
+				Path:           "secret/data/unknown",
+				TokenSecretRef: tokenRef,
+			})
+			_, _, err := source.Retrieve()
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
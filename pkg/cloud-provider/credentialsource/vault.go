@@ -0,0 +1,295 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentialsource
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	"antrea.io/nephe/pkg/logging"
+)
+
+const (
+	defaultVaultAuthMountPath = "kubernetes"
+	// vaultTokenFile is the service account token of nephe-controller projected with the audience of Vault, so that
+	// the token logging in to Vault is not accepted by the k8s API server or other services.
+	vaultTokenFile      = "/var/run/secrets/nephe/vault/token"
+	vaultRequestTimeout = 30 * time.Second
+)
+
+var credentialSourceLogger = func() logging.Logger {
+	return logging.GetLogger("credential-source")
+}
+
+// vaultSource retrieves credentials from a HashiCorp Vault secret. Dynamic secrets are retrieved again before
+// their lease expires, and KV secrets are reloaded periodically. The token logged in with the Kubernetes auth method
+// is renewed until it expires, the lease of a superseded dynamic secret is revoked, and both are revoked when the
+// source is closed. A vaultSource is not safe for concurrent use, it is wrapped in a CachedSource.
+type vaultSource struct {
+	client     client.Client
+	config     *crdv1alpha1.VaultCredentialSource
+	httpClient *http.Client
+	tokenFile  string
+	now        func() time.Time
+
+	token          string
+	tokenExpiry    time.Time
+	tokenRenewable bool
+	leaseID        string
+}
+
+// vaultResponse is the response of the Vault HTTP API.
+type vaultResponse struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *vaultAuth             `json:"auth"`
+	Errors        []string               `json:"errors"`
+}
+
+// vaultAuth is the token of a Vault login or token renewal.
+type vaultAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+func newVaultSource(c client.Client, config *crdv1alpha1.VaultCredentialSource) *vaultSource {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: vaultRootCAs, MinVersion: tls.VersionTLS12}
+	return &vaultSource{
+		client: c,
+		config: config.DeepCopy(),
+		httpClient: &http.Client{
+			Timeout:   vaultRequestTimeout,
+			Transport: transport,
+			// Vault tokens are only sent to the configured Vault servers.
+			CheckRedirect: func(req *http.Request, _ []*http.Request) error {
+				return CheckVaultAddress(req.URL.Scheme + "://" + req.URL.Host)
+			},
+		},
+		tokenFile: vaultTokenFile,
+		now:       time.Now,
+	}
+}
+
+// Retrieve reads the secret, and converts the secret of a cloud secrets engine to the credentials format.
+func (s *vaultSource) Retrieve() ([]byte, time.Time, error) {
+	if err := CheckVaultAddress(s.config.Address); err != nil {
+		return nil, time.Time{}, err
+	}
+	token, err := s.getToken()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	resp := &vaultResponse{}
+	if err = s.do(http.MethodGet, s.config.Path, token, nil, resp); err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := convertVaultSecret(s.config.Engine, resp.Data)
+	if err != nil {
+		s.revokeLease(token, resp.LeaseID)
+		return nil, time.Time{}, err
+	}
+
+	// the superseded dynamic secret is no longer used once the new one is retrieved.
+	s.revokeLease(token, s.leaseID)
+	s.leaseID = resp.LeaseID
+	expiry := s.now().Add(reloadInterval)
+	if len(resp.LeaseID) != 0 && resp.LeaseDuration > 0 {
+		expiry = s.now().Add(time.Duration(resp.LeaseDuration) * time.Second)
+	}
+	return data, expiry, nil
+}
+
+// Close revokes the lease of the current dynamic secret, and the token logged in with the Kubernetes auth method.
+func (s *vaultSource) Close() {
+	token := s.token
+	if s.config.TokenSecretRef != nil && len(s.leaseID) != 0 {
+		var err error
+		if token, err = s.getToken(); err != nil {
+			credentialSourceLogger().Error(err, "failed to revoke Vault lease", "lease", s.leaseID)
+		}
+	}
+	if len(token) != 0 {
+		s.revokeLease(token, s.leaseID)
+	}
+	if len(s.token) != 0 {
+		if err := s.do(http.MethodPost, "auth/token/revoke-self", s.token, nil, &vaultResponse{}); err != nil {
+			credentialSourceLogger().Error(err, "failed to revoke Vault token", "address", s.config.Address)
+		}
+	}
+	s.token, s.tokenExpiry, s.tokenRenewable, s.leaseID = "", time.Time{}, false, ""
+}
+
+// revokeLease revokes the lease of a dynamic secret. Failures are logged, as the lease still expires at its TTL.
+func (s *vaultSource) revokeLease(token, leaseID string) {
+	if len(leaseID) == 0 {
+		return
+	}
+	body := map[string]string{"lease_id": leaseID}
+	if err := s.do(http.MethodPut, "sys/leases/revoke", token, body, &vaultResponse{}); err != nil {
+		credentialSourceLogger().Error(err, "failed to revoke Vault lease", "lease", leaseID)
+	}
+}
+
+// getToken returns the Vault token of the token secret, or the token logged in using the Kubernetes auth method,
+// which is renewed, or logged in again, shortly before it expires.
+func (s *vaultSource) getToken() (string, error) {
+	if s.config.TokenSecretRef != nil {
+		token, err := getSecretData(s.client, s.config.TokenSecretRef)
+		if err != nil {
+			return "", fmt.Errorf("unable to get Vault token: %v", err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	if len(s.token) != 0 && !s.isTokenExpiring() {
+		return s.token, nil
+	}
+	if len(s.token) != 0 && s.tokenRenewable {
+		resp := &vaultResponse{}
+		err := s.do(http.MethodPost, "auth/token/renew-self", s.token, nil, resp)
+		if err == nil && resp.Auth != nil && len(resp.Auth.ClientToken) != 0 {
+			s.setToken(resp.Auth)
+			// a token reaching its max TTL is not renewed any more.
+			if !s.isTokenExpiring() {
+				return s.token, nil
+			}
+		} else if err != nil {
+			credentialSourceLogger().Info("Failed to renew Vault token, logging in again", "address", s.config.Address,
+				"error", err)
+		}
+	}
+
+	jwt, err := os.ReadFile(s.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read Vault service account token: %v", err)
+	}
+	mountPath := strings.Trim(s.config.AuthMountPath, "/")
+	if len(mountPath) == 0 {
+		mountPath = defaultVaultAuthMountPath
+	}
+	body := map[string]string{
+		"role": s.config.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	}
+	resp := &vaultResponse{}
+	if err = s.do(http.MethodPost, "auth/"+mountPath+"/login", "", body, resp); err != nil {
+		return "", err
+	}
+	if resp.Auth == nil || len(resp.Auth.ClientToken) == 0 {
+		return "", fmt.Errorf("no token returned by Vault login of role %v", s.config.Role)
+	}
+	// the previous token is not revoked, as revoking it also revokes the lease of the current dynamic secret.
+	s.setToken(resp.Auth)
+	return s.token, nil
+}
+
+// setToken sets the token logged in or renewed. A token without lease duration does not expire.
+func (s *vaultSource) setToken(auth *vaultAuth) {
+	s.token, s.tokenRenewable, s.tokenExpiry = auth.ClientToken, auth.Renewable, time.Time{}
+	if auth.LeaseDuration > 0 {
+		s.tokenExpiry = s.now().Add(time.Duration(auth.LeaseDuration) * time.Second)
+	}
+}
+
+// isTokenExpiring returns true if the token expires within ExpiryWindow.
+func (s *vaultSource) isTokenExpiring() bool {
+	return !s.tokenExpiry.IsZero() && !s.now().Before(s.tokenExpiry.Add(-ExpiryWindow))
+}
+
+// do sends a request to the Vault HTTP API.
+func (s *vaultSource) do(method, apiPath, token string, body interface{}, out *vaultResponse) error {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+	url := strings.TrimSuffix(s.config.Address, "/") + "/v1/" + strings.TrimPrefix(apiPath, "/")
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	if len(token) != 0 {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if len(s.config.Namespace) != 0 {
+		req.Header.Set("X-Vault-Namespace", s.config.Namespace)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request %v %v failed: %v", method, apiPath, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(out)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault request %v %v failed with status %v: %v", method, apiPath, resp.StatusCode,
+			strings.Join(out.Errors, ", "))
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("unable to decode Vault response of %v %v: %v", method, apiPath, decodeErr)
+	}
+	return nil
+}
+
+// convertVaultSecret converts the data of a Vault secret to the credentials format of the cloud provider.
+func convertVaultSecret(engine crdv1alpha1.VaultSecretEngine, data map[string]interface{}) ([]byte, error) {
+	var cred interface{}
+	switch engine {
+	case crdv1alpha1.VaultSecretEngineAWS:
+		cred = &crdv1alpha1.AwsAccountCredential{
+			AccessKeyID:     getString(data, "access_key"),
+			AccessKeySecret: getString(data, "secret_key"),
+			SessionToken:    getString(data, "security_token"),
+		}
+	case crdv1alpha1.VaultSecretEngineAzure:
+		cred = &crdv1alpha1.AzureAccountCredential{
+			ClientID:  getString(data, "client_id"),
+			ClientKey: getString(data, "client_secret"),
+		}
+	default:
+		cred = data
+		// the secret of a KV version 2 engine is nested in data, along with its metadata.
+		if nested, ok := data["data"].(map[string]interface{}); ok {
+			if _, found := data["metadata"]; found {
+				cred = nested
+			}
+		}
+	}
+	return json.Marshal(cred)
+}
+
+func getString(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
@@ -17,11 +17,19 @@ package cloudprovider
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/aws"
 	"antrea.io/nephe/pkg/cloud-provider/cloudapi/azure"
 	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/gcp"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/openstack"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/plugin"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/simulated"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/static"
+	"antrea.io/nephe/pkg/cloud-provider/cloudapi/vsphere"
+	"antrea.io/nephe/pkg/config"
 	"antrea.io/nephe/pkg/logging"
 )
 
@@ -38,6 +46,11 @@ var (
 func init() {
 	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.AWSCloudProvider), aws.Register())
 	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.AzureCloudProvider), azure.Register())
+	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.GCPCloudProvider), gcp.Register())
+	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.OpenStackCloudProvider), openstack.Register())
+	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.SimulatedCloudProvider), simulated.Register())
+	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.StaticCloudProvider), static.Register())
+	registerCloudProvider(cloudcommon.ProviderType(runtimev1alpha1.VSphereCloudProvider), vsphere.Register())
 }
 
 // registerCloudProvider registers a crdv1alpha1 provider factory by type. This
@@ -56,6 +69,20 @@ func registerCloudProvider(providerType cloudcommon.ProviderType, cloud cloudcom
 		"type", providerType)
 }
 
+// RegisterCloudProviderPlugins registers out-of-tree cloud provider plugins configured in nephe-controller configuration.
+func RegisterCloudProviderPlugins(plugins []config.CloudProviderPluginConfig) error {
+	for _, pluginConfig := range plugins {
+		providerType := cloudcommon.ProviderType(pluginConfig.ProviderType)
+		cloud, err := plugin.Register(providerType, pluginConfig.Address, pluginConfig.TLS,
+			time.Duration(pluginConfig.TimeoutInSeconds)*time.Second)
+		if err != nil {
+			return err
+		}
+		registerCloudProvider(providerType, cloud)
+	}
+	return nil
+}
+
 // GetCloudInterface returns an instance of the crd.v1alpha1 cloud Provider type, or nil if
 // the type is unknown.  The error return is only used if the named provider
 // was known but failed to initialize.
@@ -16,6 +16,7 @@ package securitygroup
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -42,6 +43,20 @@ func IsNepheControllerCreatedSG(cloudSgName string) (string, bool, bool) {
 	return sgName, isNepheControllerCreatedAddressGroup, isNepheControllerCreatedAppliedToGroup
 }
 
+// GetShardFromCloudName returns the appliedTo group name and the rule shard of a nephe created appliedTo sg name,
+// as returned by IsNepheControllerCreatedSG.
+func GetShardFromCloudName(sgName string) (string, int) {
+	idx := strings.LastIndex(sgName, shardSuffix)
+	if idx < 0 {
+		return sgName, 0
+	}
+	shard, err := strconv.Atoi(sgName[idx+len(shardSuffix):])
+	if err != nil || shard <= 0 {
+		return sgName, 0
+	}
+	return sgName[:idx], shard
+}
+
 func FindResourcesBasedOnKind(cloudResources []*CloudResource) (map[string]struct{}, map[string]struct{}) {
 	virtualMachineIDs := make(map[string]struct{})
 	networkInterfaceIDs := make(map[string]struct{})
@@ -57,16 +72,30 @@ func FindResourcesBasedOnKind(cloudResources []*CloudResource) (map[string]struc
 	return virtualMachineIDs, networkInterfaceIDs
 }
 
-// GenerateCloudDescription generates a CloudRuleDescription object and converts to string.
+// GenerateCloudDescription generates a CloudRuleDescription object and converts to string. namespacedName is
+// either namespace/name of an Antrea NetworkPolicy, or kind/namespace/name of other network policies.
 func GenerateCloudDescription(namespacedName string, appliedToGroup string) (string, error) {
+	return GenerateCloudDescriptionWithPriority(namespacedName, appliedToGroup, nil)
+}
+
+// GenerateCloudDescriptionWithPriority generates a CloudRuleDescription object carrying the priority of a rule
+// and converts to string.
+func GenerateCloudDescriptionWithPriority(namespacedName string, appliedToGroup string, priority *RulePriority) (string, error) {
 	tokens := strings.Split(namespacedName, "/")
-	if len(tokens) != 2 {
+	if len(tokens) != 2 && len(tokens) != 3 {
 		return "", fmt.Errorf("invalid namespacedname %v", namespacedName)
 	}
 	desc := CloudRuleDescription{
-		Name:           tokens[1],
-		Namespace:      tokens[0],
+		Name:           tokens[len(tokens)-1],
+		Namespace:      tokens[len(tokens)-2],
 		AppliedToGroup: appliedToGroup,
+		Priority:       priority,
+	}
+	if len(tokens) == 3 {
+		desc.Kind = tokens[0]
+	}
+	if desc.Name == "" || (desc.Namespace == "" && desc.Kind == "") {
+		return "", fmt.Errorf("invalid namespacedname %v", namespacedName)
 	}
 	return desc.String(), nil
 }
@@ -79,7 +108,8 @@ func ExtractCloudDescription(description *string) (*CloudRuleDescription, bool)
 	numKeyValuePair := 3
 	descMap := map[string]string{}
 	tempSlice := strings.Split(*description, ",")
-	if len(tempSlice) != numKeyValuePair {
+	// description may have an additional kind and an additional priority.
+	if len(tempSlice) < numKeyValuePair || len(tempSlice) > numKeyValuePair+2 {
 		return nil, false
 	}
 	// each key and value are separated by ":"
@@ -90,8 +120,8 @@ func ExtractCloudDescription(description *string) (*CloudRuleDescription, bool)
 		}
 	}
 
-	// check if any of the fields are empty.
-	if descMap[Name] == "" || descMap[Namespace] == "" || descMap[AppliedToGroup] == "" {
+	// check if any of the fields are empty, namespace is empty only for cluster scoped network policies.
+	if descMap[Name] == "" || (descMap[Namespace] == "" && descMap[Kind] == "") || descMap[AppliedToGroup] == "" {
 		return nil, false
 	}
 
@@ -99,6 +129,14 @@ func ExtractCloudDescription(description *string) (*CloudRuleDescription, bool)
 		Name:           descMap[Name],
 		Namespace:      descMap[Namespace],
 		AppliedToGroup: descMap[AppliedToGroup],
+		Kind:           descMap[Kind],
+	}
+	if _, ok := descMap[Priority]; ok {
+		priority, err := ParseRulePriority(descMap[Priority])
+		if err != nil {
+			return nil, false
+		}
+		desc.Priority = priority
 	}
 	return desc, true
 }
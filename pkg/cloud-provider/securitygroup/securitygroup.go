@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"strconv"
 	"strings"
 
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 )
 
@@ -106,6 +108,11 @@ const (
 	Name           = "Name"
 	Namespace      = "Namespace"
 	AppliedToGroup = "AppliedToGroup"
+	Kind           = "Kind"
+	Priority       = "Priority"
+
+	// shardSuffix separates the name of an appliedTo security group from the index of its rule shard.
+	shardSuffix = "-shard"
 )
 
 var (
@@ -174,20 +181,97 @@ func (c *CloudResourceID) GetCloudName(membershipOnly bool) string {
 	return fmt.Sprintf("%v%v", GetControllerAppliedToPrefix(), strings.ToLower(c.Name))
 }
 
+// GetShardCloudName returns the cloud name of a rule shard of an appliedTo security group. Shard 0 is the
+// appliedTo security group itself.
+func (c *CloudResourceID) GetShardCloudName(shard int) string {
+	if shard == 0 {
+		return c.GetCloudName(false)
+	}
+	return fmt.Sprintf("%v%v%v", c.GetCloudName(false), shardSuffix, shard)
+}
+
 func (c *CloudResourceID) String() string {
 	return c.Name + "/" + c.Vpc
 }
 
+// CloudRuleDescription specifies the description of a cloud rule. Kind is only set for network policies other
+// than Antrea NetworkPolicy, Namespace is empty for cluster scoped network policies, and Priority is only set
+// for clouds ordering rules by priority.
 type CloudRuleDescription struct {
 	Name           string
 	Namespace      string
 	AppliedToGroup string
+	Kind           string
+	Priority       *RulePriority
 }
 
 func (r *CloudRuleDescription) String() string {
-	return Name + ":" + r.Name + ", " +
+	desc := Name + ":" + r.Name + ", " +
 		Namespace + ":" + r.Namespace + ", " +
 		AppliedToGroup + ":" + r.AppliedToGroup
+	if r.Kind != "" {
+		desc += ", " + Kind + ":" + r.Kind
+	}
+	if r.Priority != nil {
+		desc += ", " + Priority + ":" + r.Priority.String()
+	}
+	return desc
+}
+
+// RuleAction specifies the action of a cloud rule.
+type RuleAction string
+
+const (
+	// RuleActionAllow allows traffic matching the rule, and is the action of rules without Action set.
+	RuleActionAllow RuleAction = "Allow"
+	// RuleActionDeny denies traffic matching the rule, regardless of any allow rules.
+	RuleActionDeny RuleAction = "Deny"
+)
+
+// RulePriority specifies the order in which a cloud rule is evaluated, the same as Antrea evaluates network policy
+// rules: by the priority of the tier, then by the priority of the network policy within the tier, then by the
+// priority of the rule within the network policy. A lower value takes precedence.
+type RulePriority struct {
+	Tier   int32
+	Policy float64
+	Rule   int32
+}
+
+// Less returns true if a rule of priority p is evaluated before a rule of priority o.
+func (p *RulePriority) Less(o *RulePriority) bool {
+	if p.Tier != o.Tier {
+		return p.Tier < o.Tier
+	}
+	if p.Policy != o.Policy {
+		return p.Policy < o.Policy
+	}
+	return p.Rule < o.Rule
+}
+
+// String returns the priority in the format of tier/policy/rule.
+func (p *RulePriority) String() string {
+	return fmt.Sprintf("%v/%v/%v", p.Tier, strconv.FormatFloat(p.Policy, 'f', -1, 64), p.Rule)
+}
+
+// ParseRulePriority converts a string in the format of tier/policy/rule to a RulePriority.
+func ParseRulePriority(s string) (*RulePriority, error) {
+	tokens := strings.Split(s, "/")
+	if len(tokens) != 3 {
+		return nil, fmt.Errorf("invalid rule priority %v", s)
+	}
+	tier, err := strconv.ParseInt(tokens[0], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tier priority of rule priority %v: %w", s, err)
+	}
+	policy, err := strconv.ParseFloat(tokens[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy priority of rule priority %v: %w", s, err)
+	}
+	rule, err := strconv.ParseInt(tokens[2], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule priority %v: %w", s, err)
+	}
+	return &RulePriority{Tier: int32(tier), Policy: policy, Rule: int32(rule)}, nil
 }
 
 type Rule interface {
@@ -195,30 +279,89 @@ type Rule interface {
 }
 
 // IngressRule specifies one ingress rule of cloud SecurityGroup.
+// FromEndPort is the last port of a port range starting at FromPort, and is nil for a single port. It is
+// omitted from CloudRule hash when nil, so that hashes of single port rules are unchanged.
+// ICMPType and ICMPCode are only applicable to ICMP rules, and nil matches any ICMP type or code.
+// Action is empty for allow rules. Priority orders allow and deny rules, and is only set for clouds ordering rules
+// by priority.
 type IngressRule struct {
 	FromPort           *int
+	FromEndPort        *int `json:",omitempty"`
 	FromSrcIP          []*net.IPNet
 	FromSecurityGroups []*CloudResourceID
 	Protocol           *int
+	ICMPType           *int          `json:",omitempty"`
+	ICMPCode           *int          `json:",omitempty"`
+	Action             RuleAction    `json:",omitempty"`
+	Priority           *RulePriority `json:",omitempty"`
 }
 
 func (i *IngressRule) isRule() {}
 
 // EgressRule specifies one egress rule of cloud SecurityGroup.
+// ToEndPort is the last port of a port range starting at ToPort, and is nil for a single port.
+// ICMPType and ICMPCode are only applicable to ICMP rules, and nil matches any ICMP type or code.
+// Action and Priority are the same as those of IngressRule.
 type EgressRule struct {
 	ToPort           *int
+	ToEndPort        *int `json:",omitempty"`
 	ToDstIP          []*net.IPNet
 	ToSecurityGroups []*CloudResourceID
 	Protocol         *int
+	ICMPType         *int          `json:",omitempty"`
+	ICMPCode         *int          `json:",omitempty"`
+	Action           RuleAction    `json:",omitempty"`
+	Priority         *RulePriority `json:",omitempty"`
 }
 
 func (e *EgressRule) isRule() {}
 
+// CloudRule is a rule of an appliedTo security group. Shard is the rule shard of the appliedTo security group
+// realizing the rule, and is 0 unless rules of the security group exceed the cloud limits.
 type CloudRule struct {
 	Hash          string `json:"-"`
 	Rule          Rule
 	NetworkPolicy string `json:"-"`
 	AppliedToGrp  string
+	Shard         int `json:"-"`
+}
+
+// IsICMPProtocol returns true if the rule protocol is ICMP or ICMPv6.
+func IsICMPProtocol(protocol *int) bool {
+	return protocol != nil && (*protocol == ProtocolNameNumMap["icmp"] || *protocol == ProtocolNameNumMap["icmpv6"])
+}
+
+// IsDeny returns true if the CloudRule denies matching traffic.
+func (c *CloudRule) IsDeny() bool {
+	switch rule := c.Rule.(type) {
+	case *IngressRule:
+		return rule.Action == RuleActionDeny
+	case *EgressRule:
+		return rule.Action == RuleActionDeny
+	}
+	return false
+}
+
+// GetPriority returns the priority of a CloudRule, or nil if the rule is not ordered.
+func (c *CloudRule) GetPriority() *RulePriority {
+	switch rule := c.Rule.(type) {
+	case *IngressRule:
+		return rule.Priority
+	case *EgressRule:
+		return rule.Priority
+	}
+	return nil
+}
+
+// GetPeerCount returns the number of CIDRs and security groups a CloudRule refers to.
+func (c *CloudRule) GetPeerCount() int {
+	switch rule := c.Rule.(type) {
+	case *IngressRule:
+		return len(rule.FromSrcIP) + len(rule.FromSecurityGroups)
+	case *EgressRule:
+		return len(rule.ToDstIP) + len(rule.ToSecurityGroups)
+	}
+	return 0
 }
 
 func (c *CloudRule) GetHash() string {
@@ -229,7 +372,8 @@ func (c *CloudRule) GetHash() string {
 	return hashValue
 }
 
-// SynchronizationContent returns a SecurityGroup content in cloud.
+// SynchronizationContent returns a SecurityGroup content in cloud. Shard is the rule shard of an appliedTo
+// SecurityGroup whose rules are realized in this SecurityGroup, and is 0 for the appliedTo SecurityGroup itself.
 type SynchronizationContent struct {
 	Resource                   CloudResource
 	MembershipOnly             bool
@@ -237,6 +381,38 @@ type SynchronizationContent struct {
 	MembersWithOtherSGAttached []CloudResource
 	IngressRules               []IngressRule
 	EgressRules                []EgressRule
+	Shard                      int `json:",omitempty"`
+}
+
+// SecurityGroupLimits specifies the limits of a cloud on security groups, a zero value means no limit.
+// Rules of an appliedTo group exceeding MaxRules are sharded across multiple security groups, only if
+// MaxSecurityGroupsPerInterface is larger than 1.
+type SecurityGroupLimits struct {
+	// MaxRules is the maximum number of inbound rules, and of outbound rules, of a security group.
+	MaxRules int
+	// SharedDirections is true if MaxRules applies to inbound and outbound rules together.
+	SharedDirections bool
+	// MaxSecurityGroupsPerInterface is the maximum number of security groups attached to a network interface.
+	MaxSecurityGroupsPerInterface int
+	// RulePerPeer is true if each CIDR and each security group a CloudRule refers to is a rule in cloud.
+	RulePerPeer bool
+	// ExcludeDenyRules is true if deny rules are not realized in security groups, e.g. in network acls.
+	ExcludeDenyRules bool
+}
+
+// GetRuleCount returns the number of rules in cloud realizing a CloudRule.
+func (l *SecurityGroupLimits) GetRuleCount(rule *CloudRule) int {
+	if l.ExcludeDenyRules && rule.IsDeny() {
+		return 0
+	}
+	if !l.RulePerPeer {
+		return 1
+	}
+	// a CloudRule without peers matches any IPv4 and IPv6 address.
+	if count := rule.GetPeerCount(); count > 0 {
+		return count
+	}
+	return 2
 }
 
 // CloudSecurityGroupAPI declares interface to program cloud security groups.
@@ -272,4 +448,10 @@ type CloudSecurityGroupAPI interface {
 	// This API ensures cloud plug-in stays stateless.
 	// - Correct SGs accidentally changed by customers via cloud API/console directly.
 	GetSecurityGroupSyncChan() <-chan SynchronizationContent
+
+	// GetSecurityGroupLimits returns the limits of the cloud managing SecurityGroup name.
+	GetSecurityGroupLimits(name *CloudResource) SecurityGroupLimits
+
+	// GetCapabilities returns the network policy features supported by the cloud managing SecurityGroup name.
+	GetCapabilities(name *CloudResource) crdv1alpha1.CloudCapabilities
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sync"
 
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	cloudcommon "antrea.io/nephe/pkg/cloud-provider/cloudapi/common"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
 )
@@ -137,6 +138,28 @@ func (sg *SecurityGroupImpl) DeleteSecurityGroup(securityGroupIdentifier *securi
 	return ch
 }
 
+// GetSecurityGroupLimits returns the limits of the cloud managing the security group, or no limits if the cloud
+// is not known.
+func (sg *SecurityGroupImpl) GetSecurityGroupLimits(
+	securityGroupIdentifier *securitygroup.CloudResource) securitygroup.SecurityGroupLimits {
+	cloudInterface, err := getCloudInterfaceForCloudResource(securityGroupIdentifier)
+	if err != nil {
+		return securitygroup.SecurityGroupLimits{}
+	}
+	return cloudInterface.GetSecurityGroupLimits()
+}
+
+// GetCapabilities returns the network policy features supported by the cloud managing the security group, or no
+// capabilities if the cloud is not known.
+func (sg *SecurityGroupImpl) GetCapabilities(
+	securityGroupIdentifier *securitygroup.CloudResource) crdv1alpha1.CloudCapabilities {
+	cloudInterface, err := getCloudInterfaceForCloudResource(securityGroupIdentifier)
+	if err != nil {
+		return crdv1alpha1.CloudCapabilities{}
+	}
+	return cloudInterface.GetCapabilities()
+}
+
 func (sg *SecurityGroupImpl) GetSecurityGroupSyncChan() <-chan securitygroup.SynchronizationContent {
 	retCh := make(chan securitygroup.SynchronizationContent)
 
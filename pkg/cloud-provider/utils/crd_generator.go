@@ -116,7 +116,9 @@ func GenerateInternalVpcObject(name, namespace, accountName, CloudName,
 // GetCloudResourceCRName gets corresponding cr name from cloud resource id based on cloud type.
 func GetCloudResourceCRName(providerType, name string) string {
 	switch providerType {
-	case string(runtimev1alpha1.AWSCloudProvider):
+	case string(runtimev1alpha1.AWSCloudProvider), string(runtimev1alpha1.GCPCloudProvider),
+		string(runtimev1alpha1.OpenStackCloudProvider), string(runtimev1alpha1.SimulatedCloudProvider),
+		string(runtimev1alpha1.StaticCloudProvider), string(runtimev1alpha1.VSphereCloudProvider):
 		return name
 	case string(runtimev1alpha1.AzureCloudProvider):
 		tokens := strings.Split(name, "/")
@@ -0,0 +1,35 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strings"
+)
+
+// GetAccountRegions returns the distinct regions of a cloud provider account, which are its region followed by its
+// additional regions.
+func GetAccountRegions(region string, regions []string) []string {
+	var accountRegions []string
+	found := make(map[string]struct{})
+	for _, r := range append([]string{region}, regions...) {
+		r = strings.TrimSpace(r)
+		if _, ok := found[r]; ok || len(r) == 0 {
+			continue
+		}
+		found[r] = struct{}{}
+		accountRegions = append(accountRegions, r)
+	}
+	return accountRegions
+}
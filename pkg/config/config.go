@@ -18,9 +18,105 @@ const (
 	DefaultCloudResourcePrefix = "nephe"
 	DefaultCloudSyncInterval   = 300
 	MinimumCloudSyncInterval   = 60
+	DefaultPluginTimeout       = 120
+	DefaultNamedPortTagPrefix  = "nephe.port/"
+
+	// PodAddressTranslationPodIP translates Pod members of AddressGroups to Pod IPs.
+	PodAddressTranslationPodIP = "PodIP"
+	// PodAddressTranslationNodeIP translates Pod members of AddressGroups to IPs of the Nodes hosting them,
+	// for clusters SNATing Pod traffic to Node IPs.
+	PodAddressTranslationNodeIP  = "NodeIP"
+	DefaultPodAddressTranslation = PodAddressTranslationPodIP
+
+	// DefaultCrossVpcAddressType realizes VMs in unpeered VPCs with their public IPs.
+	DefaultCrossVpcAddressType = "ExternalIP"
+	DefaultFQDNRefreshInterval = 30
+
+	// DefaultAWSSecurityGroupRules is the default aws quota of inbound or outbound rules per security group.
+	DefaultAWSSecurityGroupRules = 60
+	// DefaultAWSSecurityGroupsPerInterface is the default aws quota of security groups per network interface, which
+	// can be raised up to MaxAWSSecurityGroupsPerInterface.
+	DefaultAWSSecurityGroupsPerInterface = 5
+	MaxAWSSecurityGroupsPerInterface     = 16
+	// MaxAWSSecurityGroupRulesPerInterface is the aws limit of security group rules times security groups per network
+	// interface.
+	MaxAWSSecurityGroupRulesPerInterface = 1000
+	// DefaultAWSNetworkACLEntries is the default aws quota of inbound or outbound entries per network acl, which can be
+	// raised up to MaxAWSNetworkACLEntries.
+	DefaultAWSNetworkACLEntries = 20
+	MaxAWSNetworkACLEntries     = 40
 )
 
 type ControllerConfig struct {
 	CloudResourcePrefix string `yaml:"cloudResourcePrefix,omitempty"`
 	CloudSyncInterval   int64  `yaml:"cloudSyncInterval,omitempty"`
+	// CloudProviderPlugins are out-of-tree cloud provider plugins served over gRPC.
+	CloudProviderPlugins []CloudProviderPluginConfig `yaml:"cloudProviderPlugins,omitempty"`
+	// NamedPortTagPrefix is the prefix of VM tags advertising named ports, e.g. nephe.port/http=tcp:8080.
+	NamedPortTagPrefix string `yaml:"namedPortTagPrefix,omitempty"`
+	// PodAddressTranslation is how Pod members of AddressGroups are translated to IPs of cloud rules,
+	// either PodIP or NodeIP.
+	PodAddressTranslation string `yaml:"podAddressTranslation,omitempty"`
+	// CrossVpcAddressType is the type of VM IPs, either InternalIP or ExternalIP, realizing AddressGroup members in
+	// rules of VMs in other VPCs, when security groups cannot be referred across the VPCs.
+	CrossVpcAddressType string `yaml:"crossVpcAddressType,omitempty"`
+	// FQDNResolver is the address of the DNS server resolving FQDNs of egress rules, e.g. 10.96.0.10:53.
+	// The nameservers in /etc/resolv.conf are used if not set.
+	FQDNResolver string `yaml:"fqdnResolver,omitempty"`
+	// FQDNRefreshInterval is the interval (in seconds) between refreshes of a resolved FQDN.
+	FQDNRefreshInterval int64 `yaml:"fqdnRefreshInterval,omitempty"`
+	// CredentialSource restricts the files and Vault servers credential sources of CloudProviderAccounts refer to,
+	// as nephe-controller accesses them with its own identity on behalf of the authors of the accounts.
+	CredentialSource CredentialSourceConfig `yaml:"credentialSource,omitempty"`
+	// AWSQuotas are the aws quotas of the accounts managed by nephe-controller, when raised from their defaults.
+	AWSQuotas AWSQuotaConfig `yaml:"awsQuotas,omitempty"`
+}
+
+// AWSQuotaConfig configures the aws quotas cloud rules are realized within. Default aws quotas are used if not set.
+type AWSQuotaConfig struct {
+	// SecurityGroupRules is the quota of inbound or outbound rules per security group.
+	SecurityGroupRules int `yaml:"securityGroupRules,omitempty"`
+	// SecurityGroupsPerInterface is the quota of security groups per network interface.
+	SecurityGroupsPerInterface int `yaml:"securityGroupsPerInterface,omitempty"`
+	// NetworkACLEntries is the quota of inbound or outbound entries per network acl.
+	NetworkACLEntries int `yaml:"networkACLEntries,omitempty"`
+}
+
+// CredentialSourceConfig configures the credential sources of CloudProviderAccounts. Credential sources of a kind
+// are rejected unless it is configured.
+type CredentialSourceConfig struct {
+	// FileRoot is the directory of credential files, e.g. volumes mounted to nephe-controller. The credential files
+	// of an account must be in the subdirectory of its namespace, e.g. /etc/nephe/credentials/<namespace>/aws.json.
+	FileRoot string `yaml:"fileRoot,omitempty"`
+	// VaultAddresses are the https addresses of the Vault servers credential sources may refer to,
+	// e.g. https://vault.example.com:8200.
+	VaultAddresses []string `yaml:"vaultAddresses,omitempty"`
+	// VaultCAFile is the CA bundle verifying the certificates of Vault servers. System roots are used if not set.
+	VaultCAFile string `yaml:"vaultCAFile,omitempty"`
+}
+
+// CloudProviderPluginConfig configures an out-of-tree cloud provider plugin.
+type CloudProviderPluginConfig struct {
+	// ProviderType is the cloud provider type served by the plugin, referred by CloudProviderAccount pluginConfig.
+	ProviderType string `yaml:"providerType"`
+	// Address is the gRPC target of the plugin, either a unix domain socket, e.g. unix:///var/run/nephe/plugin.sock,
+	// or host:port of a plugin serving TLS.
+	Address string `yaml:"address"`
+	// TimeoutInSeconds is the timeout of a single call to the plugin.
+	TimeoutInSeconds int64 `yaml:"timeoutInSeconds,omitempty"`
+	// TLS configures mutual TLS with the plugin, which is required unless the plugin is served on a unix domain
+	// socket, as account credentials are sent to the plugin.
+	TLS *CloudProviderPluginTLSConfig `yaml:"tls,omitempty"`
+}
+
+// CloudProviderPluginTLSConfig configures mutual TLS between nephe-controller and a cloud provider plugin.
+type CloudProviderPluginTLSConfig struct {
+	// CAFile is the CA bundle verifying the certificate of the plugin.
+	CAFile string `yaml:"caFile"`
+	// CertFile is the client certificate of nephe-controller verified by the plugin.
+	CertFile string `yaml:"certFile"`
+	// KeyFile is the private key of CertFile.
+	KeyFile string `yaml:"keyFile"`
+	// ServerName is the name verified in the certificate of the plugin, the host of Address by default.
+	ServerName string `yaml:"serverName,omitempty"`
 }
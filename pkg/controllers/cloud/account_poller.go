@@ -327,9 +327,11 @@ func (p *accountPoller) updateAccountStatus(cloudInterface common.CloudInterface
 	} else if status != nil {
 		discoveredStatus = *status
 	}
+	discoveredStatus.Capabilities = cloudInterface.GetCapabilities()
 
 	if account.Status != discoveredStatus {
 		account.Status.Error = discoveredStatus.Error
+		account.Status.Capabilities = discoveredStatus.Capabilities
 		e = p.Client.Status().Update(context.TODO(), account)
 		if e != nil {
 			p.log.Error(e, "failed to update account status", "account", p.namespacedName)
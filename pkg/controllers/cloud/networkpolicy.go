@@ -19,20 +19,28 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/mohae/deepcopy"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	antreanetworking "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	antreav1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	antreanetcore "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
 	"antrea.io/nephe/pkg/cloud-provider/utils"
+	nepheconfig "antrea.io/nephe/pkg/config"
 	"antrea.io/nephe/pkg/controllers/config"
+	"antrea.io/nephe/pkg/controllers/inventory/common"
 )
 
 // InProgress indicates a securityGroup operation is in progress.
@@ -102,11 +110,15 @@ var (
 		antreanetworking.ProtocolTCP:  6,
 		antreanetworking.ProtocolUDP:  17,
 		antreanetworking.ProtocolSCTP: 132,
+		antreanetworking.ProtocolICMP: 1,
 	}
 )
 
 const (
 	uniqueGroupNameMemberPrefix = "mm_"
+	// baselineTierPriority is the priority of the Antrea Baseline tier. Rules of K8s NetworkPolicies are evaluated
+	// after rules of all other tiers, and before rules of the Baseline tier.
+	baselineTierPriority = 253
 )
 
 func getGroupUniqueName(name string, memberOnly bool) string {
@@ -260,10 +272,137 @@ func vpcsFromGroupMembers(members []antreanetworking.GroupMember, r *NetworkPoli
 		}
 
 		vpcs[ownerVm.Status.CloudVpcId] = append(vpcs[ownerVm.Status.CloudVpcId], &cloudRsc)
+		if len(e.Spec.Ports) > 0 {
+			r.memberNamedPorts[cloudRsc.String()] = e.Spec.Ports
+		} else {
+			delete(r.memberNamedPorts, cloudRsc.String())
+		}
+		var ips []runtimev1alpha1.IPAddress
+		for _, nic := range ownerVm.Status.NetworkInterfaces {
+			ips = append(ips, nic.IPs...)
+		}
+		r.memberIPs[cloudRsc.String()] = ips
 	}
 	return vpcs, notFoundMember, nil
 }
 
+// updateAddrGrpPodIPs updates IPs of Pod members of an AddressGroup, and returns true if Pod members have changed.
+func (r *NetworkPolicyReconciler) updateAddrGrpPodIPs(groupName string, eventType watch.EventType,
+	added, removed []antreanetworking.GroupMember) bool {
+	changed := false
+	pods := r.addrGrpPodIPs[groupName]
+	if eventType == watch.Added && len(pods) > 0 {
+		// Added event carries all members of the group, e.g. when watch restarts.
+		pods = make(map[string][]*net.IPNet)
+		r.addrGrpPodIPs[groupName] = pods
+		changed = true
+	}
+	for _, m := range removed {
+		if m.Pod == nil {
+			continue
+		}
+		delete(pods, types.NamespacedName{Namespace: m.Pod.Namespace, Name: m.Pod.Name}.String())
+		changed = true
+	}
+	for i := range added {
+		m := &added[i]
+		if m.Pod == nil {
+			continue
+		}
+		if pods == nil {
+			pods = make(map[string][]*net.IPNet)
+			r.addrGrpPodIPs[groupName] = pods
+		}
+		pods[types.NamespacedName{Namespace: m.Pod.Namespace, Name: m.Pod.Name}.String()] = r.getPodMemberIPs(m)
+		changed = true
+	}
+	return changed
+}
+
+// getPodMemberIPs returns IPs of a Pod group member, translated to the IP of its Node if PodAddressTranslation
+// is NodeIP.
+func (r *NetworkPolicyReconciler) getPodMemberIPs(m *antreanetworking.GroupMember) []*net.IPNet {
+	var ips []net.IP
+	if r.PodAddressTranslation == nepheconfig.PodAddressTranslationNodeIP {
+		pod := &corev1.Pod{}
+		key := client.ObjectKey{Name: m.Pod.Name, Namespace: m.Pod.Namespace}
+		if err := r.Get(context.TODO(), key, pod); err != nil {
+			r.Log.Error(err, "client get Pod", "key", key)
+			return nil
+		}
+		if ip := net.ParseIP(pod.Status.HostIP); ip != nil {
+			ips = append(ips, ip)
+		}
+	} else {
+		for _, ip := range m.IPs {
+			ips = append(ips, net.IP(ip))
+		}
+	}
+	ipNets := make([]*net.IPNet, 0, len(ips))
+	for _, ip := range ips {
+		ipNets = append(ipNets, convertIPToIPNet(ip))
+	}
+	return ipNets
+}
+
+// getAddrGrpPodIPs returns sorted and unique IPs of Pod members of an AddressGroup, and false if the AddressGroup
+// is not known to have Pod members.
+func (r *NetworkPolicyReconciler) getAddrGrpPodIPs(groupName string) ([]*net.IPNet, bool) {
+	pods, ok := r.addrGrpPodIPs[groupName]
+	if !ok {
+		return nil, false
+	}
+	var ipNets []*net.IPNet
+	for _, podIPNets := range pods {
+		ipNets = append(ipNets, podIPNets...)
+	}
+	return uniqueSortedIPNets(ipNets), true
+}
+
+// getMemberIPs returns sorted and unique IPs of CrossVpcAddressType of VM group members.
+func (r *NetworkPolicyReconciler) getMemberIPs(members []*securitygroup.CloudResource) []*net.IPNet {
+	var ipNets []*net.IPNet
+	for _, member := range members {
+		for _, ip := range r.memberIPs[member.String()] {
+			if ip.AddressType != r.CrossVpcAddressType {
+				continue
+			}
+			if parsed := net.ParseIP(ip.Address); parsed != nil {
+				ipNets = append(ipNets, convertIPToIPNet(parsed))
+			}
+		}
+	}
+	return uniqueSortedIPNets(ipNets)
+}
+
+// isSecurityGroupReferable returns true if rules of an appliedToSecurityGroup can refer to an addrSecurityGroup,
+// i.e. they are in the same VPC, or in peered VPCs of the same cloud account.
+func (r *NetworkPolicyReconciler) isSecurityGroupReferable(appliedTo, addr *securitygroup.CloudResource) bool {
+	if strings.EqualFold(appliedTo.Vpc, addr.Vpc) {
+		return true
+	}
+	if appliedTo.CloudProvider != addr.CloudProvider || appliedTo.AccountID != addr.AccountID {
+		return false
+	}
+	vpcs, err := r.Inventory.GetVpcsFromIndexer(common.VpcIndexerByNameSpacedAccountName, appliedTo.AccountID)
+	if err != nil {
+		r.Log.Error(err, "get vpc indexer", "account", appliedTo.AccountID)
+		return false
+	}
+	for _, i := range vpcs {
+		vpc := i.(*runtimev1alpha1.Vpc)
+		if !strings.EqualFold(vpc.Status.Id, appliedTo.Vpc) {
+			continue
+		}
+		for _, peer := range vpc.Status.Peers {
+			if strings.EqualFold(peer, addr.Vpc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getOwnerVm gets the parent VM object from ExternalEntity.
 func getOwnerVm(e *antreanetcore.ExternalEntity, r *NetworkPolicyReconciler) (*runtimev1alpha1.VirtualMachine, error) {
 	namespace := e.Namespace
@@ -631,6 +770,14 @@ type appliedToSecurityGroup struct {
 	ruleReady     bool
 	hasMembers    bool
 	addrGroupRefs map[string]bool
+	// limits of the cloud on security groups, nil if not yet known.
+	limits *securitygroup.SecurityGroupLimits
+	// capabilities of the cloud, nil if not yet known.
+	capabilities *crdv1alpha1.CloudCapabilities
+	// shards is the number of rule shards realizing rules of the security group.
+	shards int
+	// syncShards are rule shards of the security group in cloud, only set during synchronization with cloud.
+	syncShards []*securitygroup.SynchronizationContent
 }
 
 // newAddrAppliedGroup creates a new addSecurityGroup from Antrea AddressGroup membership.
@@ -729,11 +876,32 @@ func (a *appliedToSecurityGroup) updateANPRules(r *NetworkPolicyReconciler, np *
 		a.clearMembers(r)
 		return
 	}
+	if err = a.checkCapabilities(nps); err != nil {
+		r.Log.Error(err, "check cloud capabilities", "sg", a.id.Name)
+		r.sendRuleRealizationStatus(&np.NetworkPolicy, err)
+		a.status = err
+		_ = a.updateNPTracker(r)
+		return
+	}
+	if err = a.checkRuleOrdering(nps); err != nil {
+		r.Log.Error(err, "check rule ordering", "sg", a.id.Name)
+		r.sendRuleRealizationStatus(&np.NetworkPolicy, err)
+		a.status = err
+		_ = a.updateNPTracker(r)
+		return
+	}
 	// get full set of current rules for this security group.
-	allRules := a.combineRules(nps)
+	allRules := a.combineRules(nps, r)
 
 	// get current rules for given np to compute rule update delta.
-	rules := a.combineRules([]interface{}{np})
+	rules := a.combineRules([]interface{}{np}, r)
+	if err = a.assignShards(r, allRules, rules); err != nil {
+		r.Log.Error(err, "assign rule shards", "sg", a.id.Name)
+		r.sendRuleRealizationStatus(&np.NetworkPolicy, err)
+		a.status = err
+		_ = a.updateNPTracker(r)
+		return
+	}
 	currentRuleMap := make(map[string]*securitygroup.CloudRule)
 	for _, rule := range rules {
 		currentRuleMap[rule.Hash] = rule
@@ -790,27 +958,127 @@ func (a *appliedToSecurityGroup) clearMembers(r *NetworkPolicyReconciler) {
 	a.ruleReady = false
 }
 
+// getCapabilities returns the capabilities of the cloud of appliedToSecurityGroup. Capabilities are cached only once
+// known, as the cloud plug-in may not be ready yet.
+func (a *appliedToSecurityGroup) getCapabilities() *crdv1alpha1.CloudCapabilities {
+	if a.capabilities != nil {
+		return a.capabilities
+	}
+	capabilities := securitygroup.CloudSecurityGroup.GetCapabilities(&a.id)
+	if capabilities != (crdv1alpha1.CloudCapabilities{}) {
+		a.capabilities = &capabilities
+	}
+	return &capabilities
+}
+
+// checkCapabilities returns an error if a rule of the given anps requires a feature missing from the capabilities of
+// the cloud. Network policies are first checked against appliedTo security groups known when they are processed, hence
+// are checked again before their rules are realized in a security group created afterwards.
+func (a *appliedToSecurityGroup) checkCapabilities(nps []interface{}) error {
+	capabilities := a.getCapabilities()
+	// network policies are not realized by cloud providers without security groups.
+	if !capabilities.SecurityGroups {
+		return nil
+	}
+	for _, i := range nps {
+		np := i.(*networkPolicy)
+		if !np.rulesReady {
+			continue
+		}
+		if err := checkRuleCapabilities(np.Rules, a.id.CloudProvider, capabilities); err != nil {
+			return fmt.Errorf("anp %v: %w", np.getNamespacedName(), err)
+		}
+	}
+	return nil
+}
+
+// checkRuleOrdering returns an error if an allow rule of the given anps takes precedence over a deny rule of the same
+// direction, while deny rules take precedence over all allow rules in the cloud.
+func (a *appliedToSecurityGroup) checkRuleOrdering(nps []interface{}) error {
+	if capabilities := a.getCapabilities(); !capabilities.DenyRules || capabilities.RuleOrdering {
+		return nil
+	}
+	type ruleRef struct {
+		np       string
+		priority *securitygroup.RulePriority
+	}
+	// the first allow rule and the last deny rule of each direction.
+	var firstAllow, lastDeny [2]*ruleRef
+	track := func(direction int, np string, action securitygroup.RuleAction, priority *securitygroup.RulePriority) {
+		if priority == nil {
+			return
+		}
+		if action == securitygroup.RuleActionDeny {
+			if lastDeny[direction] == nil || lastDeny[direction].priority.Less(priority) {
+				lastDeny[direction] = &ruleRef{np: np, priority: priority}
+			}
+		} else if firstAllow[direction] == nil || priority.Less(firstAllow[direction].priority) {
+			firstAllow[direction] = &ruleRef{np: np, priority: priority}
+		}
+	}
+	for _, i := range nps {
+		np := i.(*networkPolicy)
+		if !np.rulesReady {
+			continue
+		}
+		npNamespacedName := np.getNamespacedName()
+		for _, rule := range np.getIngressRules(a.id.Name) {
+			track(0, npNamespacedName, rule.Action, rule.Priority)
+		}
+		for _, rule := range np.getEgressRules(a.id.Name) {
+			track(1, npNamespacedName, rule.Action, rule.Priority)
+		}
+	}
+	for direction, name := range []string{"ingress", "egress"} {
+		allow, deny := firstAllow[direction], lastDeny[direction]
+		if allow != nil && deny != nil && allow.priority.Less(deny.priority) {
+			return fmt.Errorf("%v allow rule of %v with priority %v precedes deny rule of %v with priority %v, "+
+				"but deny rules take precedence over all allow rules in cloud provider %v", name, allow.np,
+				allow.priority, deny.np, deny.priority, a.id.CloudProvider)
+		}
+	}
+	return nil
+}
+
 // combineRules converts and combines all rules from given anps to securitygroup.CloudRule.
-func (a *appliedToSecurityGroup) combineRules(nps []interface{}) []*securitygroup.CloudRule {
+// addrSecurityGroups which cannot be referred to by appliedToSecurityGroup are converted to IPs of their members.
+// Rule priorities are only kept if the cloud orders rules by priority.
+// If the cloud has limits on security groups, rules of each anp are compacted and split to fit into the limits.
+func (a *appliedToSecurityGroup) combineRules(nps []interface{}, rr *NetworkPolicyReconciler) []*securitygroup.CloudRule {
 	rules := make([]*securitygroup.CloudRule, 0)
+	ruleOrdering := a.getCapabilities().RuleOrdering
 	for _, i := range nps {
 		np := i.(*networkPolicy)
 		if !np.rulesReady {
 			continue
 		}
 		npNamespacedName := np.getNamespacedName()
-		for _, r := range np.ingressRules {
+		for _, r := range np.getIngressRules(a.id.Name) {
+			ingress := a.resolveIngressRule(r, rr)
+			if ingress == nil {
+				continue
+			}
+			if !ruleOrdering {
+				ingress.Priority = nil
+			}
 			rule := &securitygroup.CloudRule{
-				Rule:          deepcopy.Copy(r).(*securitygroup.IngressRule),
+				Rule:          ingress,
 				NetworkPolicy: npNamespacedName,
 				AppliedToGrp:  a.id.CloudResourceID.String(),
 			}
 			rule.Hash = rule.GetHash()
 			rules = append(rules, rule)
 		}
-		for _, r := range np.egressRules {
+		for _, r := range np.getEgressRules(a.id.Name) {
+			egress := a.resolveEgressRule(r, rr)
+			if egress == nil {
+				continue
+			}
+			if !ruleOrdering {
+				egress.Priority = nil
+			}
 			rule := &securitygroup.CloudRule{
-				Rule:          deepcopy.Copy(r).(*securitygroup.EgressRule),
+				Rule:          egress,
 				NetworkPolicy: npNamespacedName,
 				AppliedToGrp:  a.id.CloudResourceID.String(),
 			}
@@ -818,9 +1086,72 @@ func (a *appliedToSecurityGroup) combineRules(nps []interface{}) []*securitygrou
 			rules = append(rules, rule)
 		}
 	}
+	if limits := a.getLimits(); limits.MaxRules > 0 {
+		rules = splitCloudRules(compactCloudRules(rules), limits)
+	}
 	return rules
 }
 
+// resolveIngressRule returns a copy of ingress rule, whose addrSecurityGroups not referable by
+// appliedToSecurityGroup are converted to IPs of their members. nil is returned if the rule has no source left,
+// as the rule would otherwise match any source.
+func (a *appliedToSecurityGroup) resolveIngressRule(rule *securitygroup.IngressRule,
+	r *NetworkPolicyReconciler) *securitygroup.IngressRule {
+	ingress := deepcopy.Copy(rule).(*securitygroup.IngressRule)
+	if len(ingress.FromSecurityGroups) == 0 {
+		return ingress
+	}
+	ingress.FromSecurityGroups, ingress.FromSrcIP = a.resolveSecurityGroupReferences(ingress.FromSecurityGroups,
+		ingress.FromSrcIP, r)
+	if len(ingress.FromSecurityGroups) == 0 && len(ingress.FromSrcIP) == 0 {
+		return nil
+	}
+	return ingress
+}
+
+// resolveEgressRule returns a copy of egress rule, whose addrSecurityGroups not referable by
+// appliedToSecurityGroup are converted to IPs of their members. nil is returned if the rule has no destination left.
+func (a *appliedToSecurityGroup) resolveEgressRule(rule *securitygroup.EgressRule,
+	r *NetworkPolicyReconciler) *securitygroup.EgressRule {
+	egress := deepcopy.Copy(rule).(*securitygroup.EgressRule)
+	if len(egress.ToSecurityGroups) == 0 {
+		return egress
+	}
+	egress.ToSecurityGroups, egress.ToDstIP = a.resolveSecurityGroupReferences(egress.ToSecurityGroups,
+		egress.ToDstIP, r)
+	if len(egress.ToSecurityGroups) == 0 && len(egress.ToDstIP) == 0 {
+		return nil
+	}
+	return egress
+}
+
+// resolveSecurityGroupReferences returns addrSecurityGroups referable by appliedToSecurityGroup, and ipNets with
+// IPs of members of addrSecurityGroups not referable, e.g. in unpeered VPCs, other accounts or other clouds.
+func (a *appliedToSecurityGroup) resolveSecurityGroupReferences(ids []*securitygroup.CloudResourceID,
+	ipNets []*net.IPNet, r *NetworkPolicyReconciler) ([]*securitygroup.CloudResourceID, []*net.IPNet) {
+	var referable []*securitygroup.CloudResourceID
+	var memberIPs []*net.IPNet
+	resolved := false
+	for _, id := range ids {
+		i, ok, _ := r.addrSGIndexer.GetByKey(id.String())
+		if !ok {
+			referable = append(referable, id)
+			continue
+		}
+		sg := i.(*addrSecurityGroup)
+		if r.isSecurityGroupReferable(&a.id, &sg.id) {
+			referable = append(referable, id)
+			continue
+		}
+		resolved = true
+		memberIPs = append(memberIPs, r.getMemberIPs(sg.getMembers())...)
+	}
+	if !resolved {
+		return ids, ipNets
+	}
+	return referable, uniqueSortedIPNets(append(ipNets, memberIPs...))
+}
+
 // claimUnownedRules claims unowned rules in cloud rule indexer that matches with given np rules.
 func (a *appliedToSecurityGroup) claimUnownedRules(r *NetworkPolicyReconciler, currentRuleMap map[string]*securitygroup.CloudRule,
 	npNamespacedName string) {
@@ -905,27 +1236,13 @@ func (a *appliedToSecurityGroup) checkRealization(r *NetworkPolicyReconciler, np
 		realizedRuleMap[rule.Hash] = rule
 	}
 
-	for _, irule := range np.ingressRules {
-		desiredRule := securitygroup.CloudRule{
-			Rule:         irule,
-			AppliedToGrp: a.id.CloudResourceID.String(),
-		}
-		desiredRule.Hash = desiredRule.GetHash()
+	for _, desiredRule := range a.combineRules([]interface{}{np}, r) {
 		_, found := realizedRuleMap[desiredRule.Hash]
 		if !found {
-			return fmt.Errorf("ingress rule not realized %+v", desiredRule)
-		}
-		delete(realizedRuleMap, desiredRule.Hash)
-	}
-	for _, erule := range np.egressRules {
-		desiredRule := securitygroup.CloudRule{
-			Rule:         erule,
-			AppliedToGrp: a.id.CloudResourceID.String(),
-		}
-		desiredRule.Hash = desiredRule.GetHash()
-		_, found := realizedRuleMap[desiredRule.Hash]
-		if !found {
-			return fmt.Errorf("egress rule not realized %+v", desiredRule)
+			if _, ok := desiredRule.Rule.(*securitygroup.IngressRule); ok {
+				return fmt.Errorf("ingress rule not realized %+v", *desiredRule)
+			}
+			return fmt.Errorf("egress rule not realized %+v", *desiredRule)
 		}
 		delete(realizedRuleMap, desiredRule.Hash)
 	}
@@ -943,7 +1260,7 @@ func (a *appliedToSecurityGroup) updateAddrGroupReference(r *NetworkPolicyReconc
 	if err != nil {
 		return fmt.Errorf("unable to get networkPolicy with key %s from indexer: %w", a.id.Name, err)
 	}
-	rules := a.combineRules(nps)
+	rules := a.combineRules(nps, r)
 
 	// combine rules to get latest addrGroupRefs.
 	currentRefs := make(map[string]bool)
@@ -1147,9 +1464,191 @@ func (a *appliedToSecurityGroup) removeStaleMembers(stales []*types.NamespacedNa
 	a.members = members
 }
 
+// convertIPBlockToIPNet converts an Antrea IPBlock to net.IPNet, preserving the address family of the CIDR.
+func convertIPBlockToIPNet(ipBlock antreanetworking.IPBlock) *net.IPNet {
+	ip := net.IP(ipBlock.CIDR.IP)
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(int(ipBlock.CIDR.PrefixLength), net.IPv4len*8)}
+	}
+	return &net.IPNet{IP: ip.To16(), Mask: net.CIDRMask(int(ipBlock.CIDR.PrefixLength), net.IPv6len*8)}
+}
+
+// convertIPToIPNet converts an IP to a host CIDR, preserving the address family of the IP.
+func convertIPToIPNet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}
+	}
+	return &net.IPNet{IP: ip.To16(), Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}
+}
+
+// uniqueSortedIPNets returns CIDRs in ipNets without duplicates, sorted by their string representation.
+func uniqueSortedIPNets(ipNets []*net.IPNet) []*net.IPNet {
+	ipNetMap := make(map[string]*net.IPNet)
+	for _, ipNet := range ipNets {
+		ipNetMap[ipNet.String()] = ipNet
+	}
+	keys := make([]string, 0, len(ipNetMap))
+	for k := range ipNetMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sorted := make([]*net.IPNet, 0, len(keys))
+	for _, k := range keys {
+		sorted = append(sorted, ipNetMap[k])
+	}
+	return sorted
+}
+
+// convertIPBlockToIPNets converts an Antrea IPBlock to the CIDRs covering its CIDR except the CIDRs in Except,
+// as cloud rules cannot express except. An empty list is returned if Except covers the whole CIDR.
+func convertIPBlockToIPNets(ipBlock antreanetworking.IPBlock) []*net.IPNet {
+	ipNets := []*net.IPNet{convertIPBlockToIPNet(ipBlock)}
+	for _, except := range ipBlock.Except {
+		exceptIPNet := convertIPBlockToIPNet(antreanetworking.IPBlock{CIDR: except})
+		var remaining []*net.IPNet
+		for _, ipNet := range ipNets {
+			remaining = append(remaining, excludeIPNet(ipNet, exceptIPNet)...)
+		}
+		ipNets = remaining
+	}
+	return ipNets
+}
+
+// excludeIPNet returns the CIDRs covering ipNet except the except CIDR. When ipNet contains except, ipNet is split
+// in halves down to the prefix length of except, and the halves not containing except are returned.
+func excludeIPNet(ipNet, except *net.IPNet) []*net.IPNet {
+	ones, bits := ipNet.Mask.Size()
+	exceptOnes, exceptBits := except.Mask.Size()
+	if bits != exceptBits {
+		return []*net.IPNet{ipNet}
+	}
+	if exceptOnes <= ones {
+		if except.Contains(ipNet.IP) {
+			return nil
+		}
+		return []*net.IPNet{ipNet}
+	}
+	if !ipNet.Contains(except.IP) {
+		return []*net.IPNet{ipNet}
+	}
+
+	ipNets := make([]*net.IPNet, 0, exceptOnes-ones)
+	for prefixLength := ones + 1; prefixLength <= exceptOnes; prefixLength++ {
+		mask := net.CIDRMask(prefixLength, bits)
+		// sibling of the half containing except, which differs in the last bit of the prefix.
+		sibling := except.IP.Mask(mask)
+		sibling[(prefixLength-1)/8] ^= 1 << (7 - uint((prefixLength-1)%8))
+		ipNets = append(ipNets, &net.IPNet{IP: sibling, Mask: mask})
+	}
+	return ipNets
+}
+
+// hasNamedPort returns true if any service of an Antrea networkPolicy rule uses a named port.
+func hasNamedPort(rule *antreanetworking.NetworkPolicyRule) bool {
+	for _, s := range rule.Services {
+		if s.Port != nil && s.Port.Type == intstr.String {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveNamedPortServices replaces named ports in services with port numbers advertised by members of the
+// security groups of given groups. A named port resolved to no port number is removed.
+func resolveNamedPortServices(services []antreanetworking.Service, indexer cache.Indexer, groups []string,
+	r *NetworkPolicyReconciler) []antreanetworking.Service {
+	resolved := make([]antreanetworking.Service, 0, len(services))
+	for _, s := range services {
+		if s.Port == nil || s.Port.Type != intstr.String {
+			resolved = append(resolved, s)
+			continue
+		}
+		protocol := antreanetworking.ProtocolTCP
+		if s.Protocol != nil {
+			protocol = *s.Protocol
+		}
+		ports := make(map[int32]struct{})
+		for _, group := range groups {
+			sgs, err := indexer.ByIndex(addrAppliedToIndexerByGroupID, group)
+			if err != nil {
+				r.Log.Error(err, "indexer error for", "Group", group)
+				continue
+			}
+			for _, i := range sgs {
+				for _, member := range i.(cloudSecurityGroup).getMembers() {
+					for _, namedPort := range r.memberNamedPorts[member.String()] {
+						if namedPort.Name == s.Port.StrVal && string(namedPort.Protocol) == string(protocol) {
+							ports[namedPort.Port] = struct{}{}
+						}
+					}
+				}
+			}
+		}
+		portList := make([]int, 0, len(ports))
+		for port := range ports {
+			portList = append(portList, int(port))
+		}
+		sort.Ints(portList)
+		for _, port := range portList {
+			service := *s.DeepCopy()
+			portNumber := intstr.FromInt(port)
+			service.Port = &portNumber
+			service.EndPort = nil
+			resolved = append(resolved, service)
+		}
+	}
+	return resolved
+}
+
+// getServiceEndPort returns the end port of an Antrea service port range, or nil if the service has a single port.
+func getServiceEndPort(s antreanetworking.Service) *int {
+	if s.EndPort == nil || int(*s.EndPort) <= int(s.Port.IntVal) {
+		return nil
+	}
+	endPort := int(*s.EndPort)
+	return &endPort
+}
+
+// getServiceICMPTypeCode returns ICMP type and code of an Antrea ICMP service, nil matches any type or code.
+func getServiceICMPTypeCode(s antreanetworking.Service) (*int, *int) {
+	if s.Protocol == nil || *s.Protocol != antreanetworking.ProtocolICMP {
+		return nil, nil
+	}
+	var icmpType, icmpCode *int
+	if s.ICMPType != nil {
+		t := int(*s.ICMPType)
+		icmpType = &t
+	}
+	if s.ICMPCode != nil {
+		c := int(*s.ICMPCode)
+		icmpCode = &c
+	}
+	return icmpType, icmpCode
+}
+
 // networkPolicyRule describe an Antrea networkPolicy rule.
 type networkPolicyRule struct {
-	rule *antreanetworking.NetworkPolicyRule
+	rule           *antreanetworking.NetworkPolicyRule
+	tierPriority   *int32
+	policyPriority *float64
+}
+
+// getRuleActionAndPriority returns the cloud rule action and priority of an Antrea networkPolicy rule. Drop and
+// Reject rules are realized as deny rules. The priority of allow and deny rules is derived from the tier priority,
+// the policy priority and the rule priority. K8s NetworkPolicies have no tier priority, and their rules are placed
+// ahead of rules of the Baseline tier, whose policy priorities are at least 1.
+func (r *networkPolicyRule) getRuleActionAndPriority() (securitygroup.RuleAction, *securitygroup.RulePriority) {
+	priority := &securitygroup.RulePriority{Tier: baselineTierPriority, Rule: r.rule.Priority}
+	if r.tierPriority != nil {
+		priority.Tier = *r.tierPriority
+	}
+	if r.policyPriority != nil {
+		priority.Policy = *r.policyPriority
+	}
+	if r.rule.Action == nil || *r.rule.Action == antreav1alpha1.RuleActionAllow {
+		return "", priority
+	}
+	return securitygroup.RuleActionDeny, priority
 }
 
 // rules generate cloud plug-in ingressRule and/or egressRule from an networkPolicyRule.
@@ -1157,13 +1656,15 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 	egressList []*securitygroup.EgressRule, ready bool) {
 	ready = true
 	rule := r.rule
+	action, priority := r.getRuleActionAndPriority()
 	if rule.Direction == antreanetworking.DirectionIn {
 		iRules := make([]*securitygroup.IngressRule, 0)
 		for _, ip := range rule.From.IPBlocks {
-			ingress := &securitygroup.IngressRule{}
-			ipNet := net.IPNet{IP: net.IP(ip.CIDR.IP), Mask: net.CIDRMask(int(ip.CIDR.PrefixLength), 32)}
-			ingress.FromSrcIP = append(ingress.FromSrcIP, &ipNet)
-			iRules = append(iRules, ingress)
+			for _, ipNet := range convertIPBlockToIPNets(ip) {
+				ingress := &securitygroup.IngressRule{Action: action, Priority: priority}
+				ingress.FromSrcIP = append(ingress.FromSrcIP, ipNet)
+				iRules = append(iRules, ingress)
+			}
 		}
 		for _, ag := range rule.From.AddressGroups {
 			sgs, err := rr.addrSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, ag)
@@ -1171,16 +1672,22 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 				rr.Log.Error(err, "get AddrSecurityGroup indexer", "Name", ag)
 				continue
 			}
-			if len(sgs) == 0 {
+			podIPs, hasPods := rr.getAddrGrpPodIPs(ag)
+			if len(sgs) == 0 && !hasPods {
 				rr.Log.V(1).Info("Ingress rule cannot be computed with unknown AddressGroup", "AddressGroup", ag)
 				ready = false
 				return
 			}
+			if len(podIPs) > 0 {
+				ingress := &securitygroup.IngressRule{Action: action, Priority: priority}
+				ingress.FromSrcIP = podIPs
+				iRules = append(iRules, ingress)
+			}
 			for _, i := range sgs {
 				sg := i.(*addrSecurityGroup)
 				id := sg.getID()
 				if len(id.Vpc) > 0 {
-					ingress := &securitygroup.IngressRule{}
+					ingress := &securitygroup.IngressRule{Action: action, Priority: priority}
 					ingress.FromSecurityGroups = append(ingress.FromSecurityGroups, &id)
 					iRules = append(iRules, ingress)
 				}
@@ -1196,6 +1703,7 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 		for _, s := range rule.Services {
 			var protocol *int
 			var fromPort *int
+			var fromEndPort *int
 			if s.Protocol != nil {
 				if p, ok := AntreaProtocolMap[*s.Protocol]; ok {
 					protocol = &p
@@ -1204,11 +1712,16 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 			if s.Port != nil {
 				port := int(s.Port.IntVal)
 				fromPort = &port
+				fromEndPort = getServiceEndPort(s)
 			}
+			icmpType, icmpCode := getServiceICMPTypeCode(s)
 			for _, ingress := range iRules {
 				i := deepcopy.Copy(ingress).(*securitygroup.IngressRule)
 				i.FromPort = fromPort
+				i.FromEndPort = fromEndPort
 				i.Protocol = protocol
+				i.ICMPType = icmpType
+				i.ICMPCode = icmpCode
 				ingressList = append(ingressList, i)
 			}
 		}
@@ -1216,9 +1729,21 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 	}
 	eRules := make([]*securitygroup.EgressRule, 0)
 	for _, ip := range rule.To.IPBlocks {
-		egress := &securitygroup.EgressRule{}
-		ipNet := net.IPNet{IP: net.IP(ip.CIDR.IP), Mask: net.CIDRMask(int(ip.CIDR.PrefixLength), 32)}
-		egress.ToDstIP = append(egress.ToDstIP, &ipNet)
+		for _, ipNet := range convertIPBlockToIPNets(ip) {
+			egress := &securitygroup.EgressRule{Action: action, Priority: priority}
+			egress.ToDstIP = append(egress.ToDstIP, ipNet)
+			eRules = append(eRules, egress)
+		}
+	}
+	// unresolved FQDNs are reported in networkPolicy status.
+	for _, fqdn := range rule.To.FQDNs {
+		ipNets, err := rr.fqdnResolver.resolve(fqdn)
+		if err != nil || len(ipNets) == 0 {
+			rr.Log.V(1).Info("Egress rule skips unresolved FQDN", "FQDN", fqdn, "err", err)
+			continue
+		}
+		egress := &securitygroup.EgressRule{Action: action, Priority: priority}
+		egress.ToDstIP = ipNets
 		eRules = append(eRules, egress)
 	}
 	for _, ag := range rule.To.AddressGroups {
@@ -1227,16 +1752,22 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 			rr.Log.Error(err, "get AddrSecurityGroup indexer", "Name", ag)
 			continue
 		}
-		if len(sgs) == 0 {
+		podIPs, hasPods := rr.getAddrGrpPodIPs(ag)
+		if len(sgs) == 0 && !hasPods {
 			rr.Log.V(1).Info("Egress rule cannot be computed with unknown AddressGroup", "AddressGroup", ag)
 			ready = false
 			return
 		}
+		if len(podIPs) > 0 {
+			egress := &securitygroup.EgressRule{Action: action, Priority: priority}
+			egress.ToDstIP = podIPs
+			eRules = append(eRules, egress)
+		}
 		for _, i := range sgs {
 			sg := i.(*addrSecurityGroup)
 			id := sg.getID()
 			if len(id.Vpc) > 0 {
-				egress := &securitygroup.EgressRule{}
+				egress := &securitygroup.EgressRule{Action: action, Priority: priority}
 				egress.ToSecurityGroups = append(egress.ToSecurityGroups, &id)
 				eRules = append(eRules, egress)
 			}
@@ -1252,6 +1783,7 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 	for _, s := range rule.Services {
 		var protocol *int
 		var fromPort *int
+		var fromEndPort *int
 		if s.Protocol != nil {
 			if p, ok := AntreaProtocolMap[*s.Protocol]; ok {
 				protocol = &p
@@ -1260,11 +1792,16 @@ func (r *networkPolicyRule) rules(rr *NetworkPolicyReconciler) (ingressList []*s
 		if s.Port != nil {
 			port := int(s.Port.IntVal)
 			fromPort = &port
+			fromEndPort = getServiceEndPort(s)
 		}
+		icmpType, icmpCode := getServiceICMPTypeCode(s)
 		for _, egress := range eRules {
 			e := deepcopy.Copy(egress).(*securitygroup.EgressRule)
 			e.ToPort = fromPort
+			e.ToEndPort = fromEndPort
 			e.Protocol = protocol
+			e.ICMPType = icmpType
+			e.ICMPCode = icmpCode
 			egressList = append(egressList, e)
 		}
 	}
@@ -1276,11 +1813,150 @@ type networkPolicy struct {
 	antreanetworking.NetworkPolicy
 	ingressRules []*securitygroup.IngressRule
 	egressRules  []*securitygroup.EgressRule
-	rulesReady   bool
+	// ingressRuleAppliedTo and egressRuleAppliedTo are the rule level appliedToGroups of ingressRules and
+	// egressRules at the same index, they are nil for rules applied to all appliedToGroups of networkPolicy.
+	ingressRuleAppliedTo [][]string
+	egressRuleAppliedTo  [][]string
+	rulesReady           bool
 }
 
 func (n *networkPolicy) getNamespacedName() string {
-	return types.NamespacedName{Name: n.Name, Namespace: n.Namespace}.String()
+	return getNetworkPolicyNamespacedName(&n.NetworkPolicy)
+}
+
+// getStatusName returns the name of networkPolicy in VirtualMachinePolicy status. Name of a network policy other
+// than Antrea NetworkPolicy is prefixed with its source type, as it may be the same as an Antrea NetworkPolicy name.
+func (n *networkPolicy) getStatusName() string {
+	if n.SourceRef != nil && n.SourceRef.Type != antreanetworking.AntreaNetworkPolicy {
+		return string(n.SourceRef.Type) + ":" + n.Name
+	}
+	return n.Name
+}
+
+// getAppliedToGroups returns all appliedToGroups of networkPolicy, including the rule level appliedToGroups.
+func (n *networkPolicy) getAppliedToGroups() []string {
+	appliedToGrps := make([]string, 0, len(n.AppliedToGroups))
+	found := make(map[string]struct{})
+	add := func(grps []string) {
+		for _, grp := range grps {
+			if _, ok := found[grp]; !ok {
+				found[grp] = struct{}{}
+				appliedToGrps = append(appliedToGrps, grp)
+			}
+		}
+	}
+	add(n.AppliedToGroups)
+	for _, rule := range n.Rules {
+		add(rule.AppliedToGroups)
+	}
+	return appliedToGrps
+}
+
+// isRuleAppliedTo returns true if a rule with given rule level appliedToGroups applies to appliedToGroup.
+func isRuleAppliedTo(ruleAppliedTo []string, appliedToGroup string) bool {
+	if len(ruleAppliedTo) == 0 {
+		return true
+	}
+	for _, grp := range ruleAppliedTo {
+		if grp == appliedToGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNamedPorts returns true if any rule of networkPolicy uses named ports.
+func (n *networkPolicy) hasNamedPorts() bool {
+	for i := range n.Rules {
+		if hasNamedPort(&n.Rules[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasUnreferableAddrGrp returns true if any appliedToSecurityGroup of networkPolicy cannot refer to some
+// addrSecurityGroup of an AddressGroup, whose members are hence realized as IPs in rules.
+func (n *networkPolicy) hasUnreferableAddrGrp(addrGrp string, r *NetworkPolicyReconciler) bool {
+	addrSGs, err := r.addrSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, addrGrp)
+	if err != nil || len(addrSGs) == 0 {
+		return false
+	}
+	for _, gname := range n.getAppliedToGroups() {
+		appliedToSGs, err := r.appliedToSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, gname)
+		if err != nil {
+			continue
+		}
+		for _, i := range appliedToSGs {
+			appliedToSG := i.(*appliedToSecurityGroup)
+			for _, j := range addrSGs {
+				if !r.isSecurityGroupReferable(&appliedToSG.id, &j.(*addrSecurityGroup).id) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// resolveNamedPorts returns rules of an Antrea networkPolicy rule with named ports resolved to port numbers. As
+// cloud rules apply to all VMs of a security group, a named port resolves to port numbers advertised by any VM.
+// Named ports of an ingress rule are resolved with VMs of each of its appliedToGroups, and named ports of an
+// egress rule are resolved with VMs of its AddressGroups.
+func (n *networkPolicy) resolveNamedPorts(rule *antreanetworking.NetworkPolicyRule,
+	r *NetworkPolicyReconciler) []*antreanetworking.NetworkPolicyRule {
+	if !hasNamedPort(rule) {
+		return []*antreanetworking.NetworkPolicyRule{rule}
+	}
+	if rule.Direction == antreanetworking.DirectionOut {
+		resolved := rule.DeepCopy()
+		resolved.Services = resolveNamedPortServices(rule.Services, r.addrSGIndexer, rule.To.AddressGroups, r)
+		return []*antreanetworking.NetworkPolicyRule{resolved}
+	}
+	appliedToGroups := rule.AppliedToGroups
+	if len(appliedToGroups) == 0 {
+		appliedToGroups = n.AppliedToGroups
+	}
+	rules := make([]*antreanetworking.NetworkPolicyRule, 0, len(appliedToGroups))
+	for _, group := range appliedToGroups {
+		resolved := rule.DeepCopy()
+		resolved.AppliedToGroups = []string{group}
+		resolved.Services = resolveNamedPortServices(rule.Services, r.appliedToSGIndexer, []string{group}, r)
+		rules = append(rules, resolved)
+	}
+	return rules
+}
+
+// getIngressRules returns ingress rules of networkPolicy applied to appliedToGroup.
+func (n *networkPolicy) getIngressRules(appliedToGroup string) []*securitygroup.IngressRule {
+	rules := make([]*securitygroup.IngressRule, 0, len(n.ingressRules))
+	for i, rule := range n.ingressRules {
+		if isRuleAppliedTo(n.ingressRuleAppliedTo[i], appliedToGroup) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// getEgressRules returns egress rules of networkPolicy applied to appliedToGroup.
+func (n *networkPolicy) getEgressRules(appliedToGroup string) []*securitygroup.EgressRule {
+	rules := make([]*securitygroup.EgressRule, 0, len(n.egressRules))
+	for i, rule := range n.egressRules {
+		if isRuleAppliedTo(n.egressRuleAppliedTo[i], appliedToGroup) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// getNetworkPolicyNamespacedName returns namespace/name of an Antrea NetworkPolicy, and kind/namespace/name of
+// other network policies. Namespace is empty for cluster scoped network policies.
+func getNetworkPolicyNamespacedName(anp *antreanetworking.NetworkPolicy) string {
+	namespacedName := types.NamespacedName{Name: anp.Name, Namespace: anp.Namespace}.String()
+	if anp.SourceRef != nil && anp.SourceRef.Type != antreanetworking.AntreaNetworkPolicy {
+		return string(anp.SourceRef.Type) + "/" + namespacedName
+	}
+	return namespacedName
 }
 
 // update an networkPolicy from Antrea controller.
@@ -1301,8 +1977,9 @@ func (n *networkPolicy) update(anp *antreanetworking.NetworkPolicy, recompute bo
 		if ok := n.computeRules(r); !ok {
 			return
 		}
-		modifiedAppliedTo = n.AppliedToGroups
+		modifiedAppliedTo = n.getAppliedToGroups()
 	} else {
+		prevAppliedTo := n.getAppliedToGroups()
 		if !reflect.DeepEqual(anp.Rules, n.Rules) {
 			// Indexer does not work with in-place update. Do delete->update->add.
 			if err := r.networkPolicyIndexer.Delete(n); err != nil {
@@ -1315,7 +1992,7 @@ func (n *networkPolicy) update(anp *antreanetworking.NetworkPolicy, recompute bo
 				r.Log.Error(err, "add networkPolicy indexer", "Name", n.Name)
 			}
 			if ok := n.computeRules(r); ok {
-				modifiedAppliedTo = n.AppliedToGroups
+				modifiedAppliedTo = prevAppliedTo
 			}
 		}
 		if !reflect.DeepEqual(anp.AppliedToGroups, n.AppliedToGroups) {
@@ -1323,13 +2000,14 @@ func (n *networkPolicy) update(anp *antreanetworking.NetworkPolicy, recompute bo
 			if err := r.networkPolicyIndexer.Delete(n); err != nil {
 				r.Log.Error(err, "delete networkPolicy indexer", "Name", n.Name)
 			}
-			addedAppliedTo, removedAppliedTo = diffAppliedToGrp(n.AppliedToGroups, anp.AppliedToGroups)
 			n.AppliedToGroups = anp.AppliedToGroups
 			n.Generation = anp.Generation
 			if err := r.networkPolicyIndexer.Add(n); err != nil {
 				r.Log.Error(err, "add networkPolicy indexer", "Name", n.Name)
 			}
 		}
+		// appliedToGroups may also change with rule level appliedToGroups.
+		addedAppliedTo, removedAppliedTo = diffAppliedToGrp(prevAppliedTo, n.getAppliedToGroups())
 	}
 
 	// process addressGroup need updates in this networkPolicy.
@@ -1366,11 +2044,13 @@ func (n *networkPolicy) update(anp *antreanetworking.NetworkPolicy, recompute bo
 func (n *networkPolicy) delete(r *NetworkPolicyReconciler) error {
 	n.ingressRules = nil
 	n.egressRules = nil
+	n.ingressRuleAppliedTo = nil
+	n.egressRuleAppliedTo = nil
 	n.markDirty(r)
 	if err := r.networkPolicyIndexer.Delete(n); err != nil {
 		r.Log.Error(err, "delete from networkPolicy indexer", "Name", n.Name, "Namespace", n.Namespace)
 	}
-	for _, gname := range n.AppliedToGroups {
+	for _, gname := range n.getAppliedToGroups() {
 		sgs, err := r.appliedToSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, gname)
 		if err != nil {
 			return fmt.Errorf("unable to get appliedToSGs %s from indexer: %w", gname, err)
@@ -1421,6 +2101,9 @@ func (n *networkPolicy) computeRulesReady(withStatus bool, r *NetworkPolicyRecon
 				return err
 			}
 			if len(sgs) == 0 {
+				if _, hasPods := r.getAddrGrpPodIPs(name); hasPods {
+					continue
+				}
 				err := fmt.Errorf("internal error")
 				r.Log.Error(err, "skip computing rules in networkPolicy because AddrSecurityGroup unknown",
 					"networkPolicy", n.Name, "AddressGroup", name)
@@ -1455,7 +2138,7 @@ func (n *networkPolicy) notifyAddrGrpChanges(r *NetworkPolicyReconciler) error {
 		return nil
 	}
 
-	for _, gname := range n.AppliedToGroups {
+	for _, gname := range n.getAppliedToGroups() {
 		sgs, err := r.appliedToSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, gname)
 		if err != nil {
 			return fmt.Errorf("unable to get appliedToSGs %s from indexer: %w", gname, err)
@@ -1475,19 +2158,27 @@ func (n *networkPolicy) computeRules(rr *NetworkPolicyReconciler) bool {
 	rr.Log.V(1).Info("Compute rules", "networkPolicy", n.Name)
 	n.ingressRules = nil
 	n.egressRules = nil
+	n.ingressRuleAppliedTo = nil
+	n.egressRuleAppliedTo = nil
 	n.rulesReady = false
-	for _, r := range n.Rules {
-		ing, eg, ready := (&networkPolicyRule{rule: &r}).rules(rr)
-		if !ready {
-			n.ingressRules = nil
-			n.egressRules = nil
-			return false
-		}
-		if ing != nil {
-			n.ingressRules = append(n.ingressRules, ing...)
-		}
-		if eg != nil {
-			n.egressRules = append(n.egressRules, eg...)
+	for idx := range n.Rules {
+		for _, r := range n.resolveNamedPorts(&n.Rules[idx], rr) {
+			ing, eg, ready := (&networkPolicyRule{rule: r, tierPriority: n.TierPriority, policyPriority: n.Priority}).rules(rr)
+			if !ready {
+				n.ingressRules = nil
+				n.egressRules = nil
+				n.ingressRuleAppliedTo = nil
+				n.egressRuleAppliedTo = nil
+				return false
+			}
+			for _, i := range ing {
+				n.ingressRules = append(n.ingressRules, i)
+				n.ingressRuleAppliedTo = append(n.ingressRuleAppliedTo, r.AppliedToGroups)
+			}
+			for _, e := range eg {
+				n.egressRules = append(n.egressRules, e)
+				n.egressRuleAppliedTo = append(n.egressRuleAppliedTo, r.AppliedToGroups)
+			}
 		}
 	}
 	_ = n.computeRulesReady(false, rr)
@@ -1496,7 +2187,7 @@ func (n *networkPolicy) computeRules(rr *NetworkPolicyReconciler) bool {
 
 // markDirty marks all cloud resources this NetworkPolicy applied to dirty.
 func (n *networkPolicy) markDirty(r *NetworkPolicyReconciler) {
-	for _, key := range n.AppliedToGroups {
+	for _, key := range n.getAppliedToGroups() {
 		sgs, err := r.appliedToSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, key)
 		if err != nil {
 			r.Log.Error(err, "get appliedToSecurityGroup indexer", "Key", key)
@@ -1509,10 +2200,26 @@ func (n *networkPolicy) markDirty(r *NetworkPolicyReconciler) {
 	}
 }
 
+// getFQDNStatus returns an error listing FQDNs of egress rules not resolved.
+func (n *networkPolicy) getFQDNStatus(r *NetworkPolicyReconciler) error {
+	var unresolved []string
+	for _, rule := range n.Rules {
+		for _, fqdn := range rule.To.FQDNs {
+			if _, err := r.fqdnResolver.resolve(fqdn); err != nil {
+				unresolved = append(unresolved, fqdn)
+			}
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unresolved FQDNs %v", unresolved)
+}
+
 // getStatus returns status of networkPolicy.
 func (n *networkPolicy) getStatus(r *NetworkPolicyReconciler) error {
 	if n.rulesReady {
-		return nil
+		return n.getFQDNStatus(r)
 	}
 	if err := n.computeRulesReady(true, r); err != nil {
 		return err
@@ -233,20 +233,20 @@ func (c *cloudResourceNPTracker) computeNPStatus(r *NetworkPolicyReconciler) map
 		// networkPolicy rules are ready to be sent, and
 		// appliedToSG of this cloud resource is ready.
 		if status := np.getStatus(r); status != nil {
-			npList[np.Name] = status.Error()
+			npList[np.getStatusName()] = status.Error()
 			continue
 		}
 		i, found, _ := r.appliedToSGIndexer.GetByKey(asgName)
 		if !found {
-			npList[np.Name] = asgName + "=Internal Error "
+			npList[np.getStatusName()] = asgName + "=Internal Error "
 			continue
 		}
 		asg := i.(*appliedToSecurityGroup)
 		if status := asg.getStatus(); status != nil {
-			npList[np.Name] = asgName + "=" + status.Error()
+			npList[np.getStatusName()] = asgName + "=" + status.Error()
 			continue
 		}
-		npList[np.Name] = asgName + "=" + NetworkPolicyStatusApplied
+		npList[np.getStatusName()] = asgName + "=" + NetworkPolicyStatusApplied
 	}
 
 	newPrevSgs := make(map[string]*appliedToSecurityGroup)
@@ -273,7 +273,7 @@ func (c *cloudResourceNPTracker) computeNPStatus(r *NetworkPolicyReconciler) map
 				npList = make(map[string]string)
 				ret[np.Namespace] = npList
 			}
-			npList[np.Name] = errMsg
+			npList[np.getStatusName()] = errMsg
 		}
 		if len(nps) == 0 {
 			// handle dangling appliedToGroups with no namespaces.
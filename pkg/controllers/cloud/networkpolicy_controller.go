@@ -17,6 +17,7 @@ package cloud
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"time"
 
@@ -35,6 +36,8 @@ import (
 	antreav1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	antreav1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	antreanetworkingclient "antrea.io/antrea/pkg/client/clientset/versioned/typed/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
+	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
 	"antrea.io/nephe/pkg/controllers/config"
 	"antrea.io/nephe/pkg/controllers/inventory"
@@ -46,6 +49,7 @@ const (
 	appliedToIndexerByAddrGroupRef              = "AddressGrp"
 	networkPolicyIndexerByAddrGrp               = "AddressGrp"
 	networkPolicyIndexerByAppliedToGrp          = "AppliedToGrp"
+	networkPolicyIndexerByFQDN                  = "FQDN"
 	cloudResourceNPTrackerIndexerByAppliedToGrp = "AppliedToGrp"
 	cloudRuleIndexerByAppliedToGrp              = "AppliedToGrp"
 
@@ -58,6 +62,7 @@ const (
 	npSyncReadyBookMarkCnt = 3
 )
 
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.antrea.io,resources=networkpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.antrea.io,resources=addressgroups,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.antrea.io,resources=appliedtogroups,verbs=get;list;watch
@@ -90,6 +95,15 @@ type NetworkPolicyReconciler struct {
 	virtualMachinePolicyIndexer   cache.Indexer
 	cloudRuleIndexer              cache.Indexer
 
+	// memberNamedPorts keeps named ports advertised by group members, keyed by cloud resource.
+	memberNamedPorts map[string][]antreav1alpha2.NamedPort
+	// addrGrpPodIPs keeps IPs of Pod members of AddressGroups, keyed by AddressGroup name and Pod namespaced name.
+	addrGrpPodIPs map[string]map[string][]*net.IPNet
+	// memberIPs keeps IPs of VM group members, keyed by cloud resource.
+	memberIPs map[string][]runtimev1alpha1.IPAddress
+	// fqdnResolver resolves FQDNs of egress rules.
+	fqdnResolver *fqdnResolver
+
 	Inventory inventory.Interface
 
 	// pendingDeleteGroups keep tracks of deleting AddressGroup or AppliedToGroup.
@@ -105,6 +119,17 @@ type NetworkPolicyReconciler struct {
 	// CloudSyncInterval specifies the interval (in seconds) to be used for syncing cloud resources with controller.
 	CloudSyncInterval int64
 
+	// PodAddressTranslation specifies how Pod members of AddressGroups are translated to IPs of cloud rules.
+	PodAddressTranslation string
+
+	// CrossVpcAddressType specifies the type of VM IPs realizing AddressGroup members in rules of VMs in unpeered VPCs.
+	CrossVpcAddressType runtimev1alpha1.AddressType
+
+	// FQDNResolver specifies the DNS server resolving FQDNs of egress rules.
+	FQDNResolver string
+	// FQDNRefreshInterval specifies the interval (in seconds) between refreshes of a resolved FQDN.
+	FQDNRefreshInterval int64
+
 	// Bookmark events received prior to sync with the cloud.
 	bookmarkCnt int
 
@@ -117,13 +142,15 @@ func (r *NetworkPolicyReconciler) isNetworkPolicySupported(anp *antreanetworking
 	if anp.SourceRef == nil {
 		return fmt.Errorf("source reference not set in network policy")
 	}
-	if anp.SourceRef.Type != antreanetworking.AntreaNetworkPolicy {
-		return fmt.Errorf("only antrea network policy is supported")
+	switch anp.SourceRef.Type {
+	case antreanetworking.AntreaNetworkPolicy, antreanetworking.AntreaClusterNetworkPolicy, antreanetworking.K8sNetworkPolicy:
+	default:
+		return fmt.Errorf("unsupported network policy type %v", anp.SourceRef.Type)
 	}
-	// Check for support actions.
+	// Check for support actions, Drop and Reject rules are realized as cloud deny rules.
 	for _, rule := range anp.Rules {
-		if rule.Action != nil && *rule.Action != antreav1alpha1.RuleActionAllow {
-			return fmt.Errorf("only Allow action is supported in antrea network policy")
+		if rule.Action != nil && *rule.Action == antreav1alpha1.RuleActionPass {
+			return fmt.Errorf("only Allow, Drop and Reject actions are supported in antrea network policy")
 		}
 		// check for supported protocol.
 		for _, s := range rule.Services {
@@ -133,6 +160,103 @@ func (r *NetworkPolicyReconciler) isNetworkPolicySupported(anp *antreanetworking
 			}
 		}
 	}
+	return r.checkNetworkPolicyCapabilities(anp)
+}
+
+// checkNetworkPolicyCapabilities checks rules of a network policy against the capabilities of the cloud providers
+// of VMs the network policy applies to. Only appliedTo security groups already known are checked; the others check
+// the rules before realizing them.
+func (r *NetworkPolicyReconciler) checkNetworkPolicyCapabilities(anp *antreanetworking.NetworkPolicy) error {
+	if securitygroup.CloudSecurityGroup == nil {
+		return nil
+	}
+	appliedToGroups := append([]string{}, anp.AppliedToGroups...)
+	for _, rule := range anp.Rules {
+		appliedToGroups = append(appliedToGroups, rule.AppliedToGroups...)
+	}
+	providers := make(map[string]bool)
+	for _, group := range appliedToGroups {
+		sgs, err := r.appliedToSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, getNormalizedName(group))
+		if err != nil {
+			return fmt.Errorf("get appliedToSG indexer with index %v: %w", group, err)
+		}
+		for _, obj := range sgs {
+			sg := obj.(*appliedToSecurityGroup)
+			if providers[sg.id.CloudProvider] {
+				continue
+			}
+			providers[sg.id.CloudProvider] = true
+			capabilities := securitygroup.CloudSecurityGroup.GetCapabilities(&sg.id)
+			// network policies are not realized by cloud providers without security groups.
+			if !capabilities.SecurityGroups {
+				continue
+			}
+			if err := checkRuleCapabilities(anp.Rules, sg.id.CloudProvider, &capabilities); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRuleCapabilities returns an error if any rule requires a feature missing from the capabilities of the cloud
+// provider.
+func checkRuleCapabilities(rules []antreanetworking.NetworkPolicyRule, provider string,
+	capabilities *crdv1alpha1.CloudCapabilities) error {
+	for _, rule := range rules {
+		if rule.Action != nil && *rule.Action != antreav1alpha1.RuleActionAllow && !capabilities.DenyRules {
+			return fmt.Errorf("%v action is not supported by cloud provider %v, only Allow action is supported",
+				*rule.Action, provider)
+		}
+		for _, peer := range []antreanetworking.NetworkPolicyPeer{rule.From, rule.To} {
+			for _, ipBlock := range peer.IPBlocks {
+				if ip := net.IP(ipBlock.CIDR.IP); ip.To4() == nil && !capabilities.IPv6 {
+					return fmt.Errorf("IPv6 address %v/%v is not supported by cloud provider %v", ip,
+						ipBlock.CIDR.PrefixLength, provider)
+				}
+			}
+		}
+		for _, s := range rule.Services {
+			if s.EndPort != nil && !capabilities.PortRanges {
+				return fmt.Errorf("port range %v-%v is not supported by cloud provider %v", s.Port, *s.EndPort, provider)
+			}
+			if (s.ICMPType != nil || s.ICMPCode != nil) && !capabilities.ICMPTypeCode {
+				return fmt.Errorf("ICMP type and code are not supported by cloud provider %v", provider)
+			}
+			if s.Protocol != nil && *s.Protocol == antreanetworking.ProtocolSCTP && !capabilities.SCTP {
+				return fmt.Errorf("protocol %v is not supported by cloud provider %v", *s.Protocol, provider)
+			}
+		}
+	}
+	if capabilities.DenyRules && !capabilities.RuleOrdering {
+		return checkRuleOrdering(rules, provider)
+	}
+	return nil
+}
+
+// checkRuleOrdering returns an error if an allow rule takes precedence over a deny rule of the same direction, as
+// deny rules take precedence over all allow rules in the cloud provider.
+func checkRuleOrdering(rules []antreanetworking.NetworkPolicyRule, provider string) error {
+	for _, direction := range []antreanetworking.Direction{antreanetworking.DirectionIn, antreanetworking.DirectionOut} {
+		var firstAllow, lastDeny *antreanetworking.NetworkPolicyRule
+		for i := range rules {
+			rule := &rules[i]
+			if rule.Direction != direction {
+				continue
+			}
+			if rule.Action == nil || *rule.Action == antreav1alpha1.RuleActionAllow {
+				if firstAllow == nil || rule.Priority < firstAllow.Priority {
+					firstAllow = rule
+				}
+			} else if lastDeny == nil || rule.Priority > lastDeny.Priority {
+				lastDeny = rule
+			}
+		}
+		if firstAllow != nil && lastDeny != nil && firstAllow.Priority < lastDeny.Priority {
+			return fmt.Errorf("allow rule %v precedes %v rule %v, but deny rules take precedence over all allow rules "+
+				"in cloud provider %v", firstAllow.Priority, *lastDeny.Action, lastDeny.Priority, provider)
+		}
+	}
 	return nil
 }
 
@@ -144,7 +268,7 @@ func (r *NetworkPolicyReconciler) updateRuleRealizationStatus(currentSgID string
 	}
 
 	// current sg update rule success, check other sg rule realization status.
-	for _, at := range np.AppliedToGroups {
+	for _, at := range np.getAppliedToGroups() {
 		sgs, e := r.appliedToSGIndexer.ByIndex(addrAppliedToIndexerByGroupID, at)
 		if e != nil {
 			r.Log.Error(e, "get appliedToSG indexer", "sg", at)
@@ -162,7 +286,7 @@ func (r *NetworkPolicyReconciler) updateRuleRealizationStatus(currentSgID string
 			}
 		}
 	}
-	r.sendRuleRealizationStatus(&np.NetworkPolicy, nil)
+	r.sendRuleRealizationStatus(&np.NetworkPolicy, np.getFQDNStatus(r))
 }
 
 // sendRuleRealizationStatus sends anp realization status to antrea controller.
@@ -200,6 +324,9 @@ func (r *NetworkPolicyReconciler) normalizedANPObject(anp *antreanetworking.Netw
 		anp.AppliedToGroups[i] = getNormalizedName(appliedTo)
 	}
 	for i, rule := range anp.Rules {
+		for j, appliedTo := range rule.AppliedToGroups {
+			anp.Rules[i].AppliedToGroups[j] = getNormalizedName(appliedTo)
+		}
 		for j, addrGroup := range rule.From.AddressGroups {
 			anp.Rules[i].From.AddressGroups[j] = getNormalizedName(addrGroup)
 		}
@@ -282,6 +409,9 @@ func (r *NetworkPolicyReconciler) processGroup(groupName string, eventType watch
 			}
 		}
 	} else if eventType == watch.Deleted {
+		if isAddrGrp {
+			delete(r.addrGrpPodIPs, groupName)
+		}
 		sgs, err := indexer.ByIndex(addrAppliedToIndexerByGroupID, groupName)
 		if err != nil {
 			return err
@@ -307,6 +437,8 @@ func (r *NetworkPolicyReconciler) processGroup(groupName string, eventType watch
 			sg = i.(cloudSecurityGroup)
 			if compareCloudResources(members, sg.getMembers()) {
 				r.Log.V(1).Info("Unchanged SecurityGroup, ignoring add.", "key", key)
+				// members re-added with changed named ports are also in removed members.
+				delete(removedMembers, vpc)
 				continue
 			}
 			removed, ok := removedMembers[vpc]
@@ -349,9 +481,51 @@ func (r *NetworkPolicyReconciler) processGroup(groupName string, eventType watch
 			continue
 		}
 	}
+	if isAddrGrp && r.updateAddrGrpPodIPs(groupName, eventType, added, removed) {
+		// Pod members are realized as IPs in rules, all networkPolicies referring to the group are recomputed.
+		r.notifyGroupMemberChanges(groupName, isAddrGrp, nil)
+	} else if hasNamedPortMembers(added) || hasNamedPortMembers(removed) {
+		r.notifyGroupMemberChanges(groupName, isAddrGrp, (*networkPolicy).hasNamedPorts)
+	} else if isAddrGrp {
+		// Members of addrSecurityGroups not referable by appliedToSecurityGroups are realized as IPs in rules.
+		r.notifyGroupMemberChanges(groupName, isAddrGrp, func(np *networkPolicy) bool {
+			return np.hasUnreferableAddrGrp(groupName, r)
+		})
+	}
 	return nil
 }
 
+// hasNamedPortMembers returns true if any group member advertises named ports.
+func hasNamedPortMembers(members []antreanetworking.GroupMember) bool {
+	for _, m := range members {
+		if len(m.Ports) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyGroupMemberChanges recomputes rules of networkPolicies referring to an AddressGroup or AppliedToGroup, as
+// members of the group have changed. If filter is provided, only networkPolicies matching filter are recomputed.
+func (r *NetworkPolicyReconciler) notifyGroupMemberChanges(groupName string, isAddrGrp bool,
+	filter func(*networkPolicy) bool) {
+	index := networkPolicyIndexerByAppliedToGrp
+	if isAddrGrp {
+		index = networkPolicyIndexerByAddrGrp
+	}
+	nps, err := r.networkPolicyIndexer.ByIndex(index, groupName)
+	if err != nil {
+		r.Log.Error(err, "get networkPolicy indexer", "index", index, "key", groupName)
+		return
+	}
+	for _, i := range nps {
+		np := i.(*networkPolicy)
+		if filter == nil || filter(np) {
+			np.update(nil, true, r)
+		}
+	}
+}
+
 // processAddressGroup processes AddressGroup updates from Antrea controller.
 func (r *NetworkPolicyReconciler) processAddressGroup(event watch.Event) error {
 	accessor, _ := meta.Accessor(event.Object)
@@ -418,7 +592,7 @@ func (r *NetworkPolicyReconciler) processNetworkPolicy(event watch.Event) error
 
 	var np *networkPolicy
 	isCreate := false
-	npKey := types.NamespacedName{Name: anp.Name, Namespace: anp.Namespace}.String()
+	npKey := getNetworkPolicyNamespacedName(anp)
 	if i, ok, _ := r.networkPolicyIndexer.GetByKey(npKey); !ok {
 		np = &networkPolicy{}
 		anp.DeepCopyInto(&np.NetworkPolicy)
@@ -491,6 +665,7 @@ func (r *NetworkPolicyReconciler) Start(stop context.Context) error {
 		r.Log.Error(err, "Start watchers")
 	}
 
+	r.fqdnResolver.start(stop)
 	r.Log.Info("Re-sync finished, listening to new events")
 	lastSyncTime := time.Now().Unix()
 	ticker := time.NewTicker(time.Second)
@@ -536,6 +711,8 @@ func (r *NetworkPolicyReconciler) Start(stop context.Context) error {
 				return nil
 			}
 			err = r.processLocalEvent(event)
+		case result := <-r.fqdnResolver.results:
+			err = r.processFQDNResult(result)
 		case <-ticker.C:
 			r.backgroupProcess()
 			r.retryQueue.CheckToRun()
@@ -600,6 +777,30 @@ func (r *NetworkPolicyReconciler) updatePendingSyncCountAndStatus() {
 // backgroundProcess runs background processes.
 func (r *NetworkPolicyReconciler) backgroupProcess() {
 	r.processCloudResourceNPTrackers()
+	r.refreshFQDNs()
+}
+
+// refreshFQDNs requests expired FQDNs of egress rules to be resolved again.
+func (r *NetworkPolicyReconciler) refreshFQDNs() {
+	r.fqdnResolver.refresh(r.networkPolicyIndexer.ListIndexFuncValues(networkPolicyIndexerByFQDN), time.Now())
+}
+
+// processFQDNResult applies the result of resolving an FQDN, and recomputes rules of networkPolicies whose FQDN is
+// resolved to different IPs.
+func (r *NetworkPolicyReconciler) processFQDNResult(result *fqdnResult) error {
+	if !r.fqdnResolver.apply(result, time.Now()) {
+		return nil
+	}
+	r.Log.V(1).Info("FQDN resolution changed", "FQDN", result.fqdn)
+	nps, err := r.networkPolicyIndexer.ByIndex(networkPolicyIndexerByFQDN, result.fqdn)
+	if err != nil {
+		return fmt.Errorf("unable to get networkPolicies of FQDN %s: %w", result.fqdn, err)
+	}
+	for _, i := range nps {
+		np := i.(*networkPolicy)
+		np.update(nil, true, r)
+	}
+	return nil
 }
 
 // SetupWithManager sets up NetworkPolicyReconciler with manager.
@@ -642,7 +843,7 @@ func (r *NetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.networkPolicyIndexer = cache.NewIndexer(
 		func(obj interface{}) (string, error) {
 			np := obj.(*networkPolicy)
-			return types.NamespacedName{Name: np.Name, Namespace: np.Namespace}.String(), nil
+			return np.getNamespacedName(), nil
 		},
 		cache.Indexers{
 			// networkPolicy indexed by Antrea AddrGroup ID.
@@ -658,7 +859,16 @@ func (r *NetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			// networkPolicy indexed by Antrea AppliedTo ID.
 			networkPolicyIndexerByAppliedToGrp: func(obj interface{}) ([]string, error) {
 				np := obj.(*networkPolicy)
-				return np.AppliedToGroups, nil
+				return np.getAppliedToGroups(), nil
+			},
+			// networkPolicy indexed by FQDNs of egress rules.
+			networkPolicyIndexerByFQDN: func(obj interface{}) ([]string, error) {
+				np := obj.(*networkPolicy)
+				fqdns := make([]string, 0)
+				for _, rule := range np.Rules {
+					fqdns = append(fqdns, rule.To.FQDNs...)
+				}
+				return fqdns, nil
 			},
 		})
 	r.cloudResourceNPTrackerIndexer = cache.NewIndexer(
@@ -708,6 +918,10 @@ func (r *NetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return []string{npStatus.Namespace}, nil
 			},
 		})
+	r.memberNamedPorts = make(map[string][]antreav1alpha2.NamedPort)
+	r.addrGrpPodIPs = make(map[string]map[string][]*net.IPNet)
+	r.memberIPs = make(map[string][]runtimev1alpha1.IPAddress)
+	r.fqdnResolver = newFQDNResolver(r.FQDNResolver, time.Duration(r.FQDNRefreshInterval)*time.Second)
 	r.localRequest = make(chan watch.Event)
 	r.cloudResponse = make(chan *securityGroupStatus, cloudResponseChBuffer)
 	r.pendingDeleteGroups = NewPendingItemQueue(r, nil)
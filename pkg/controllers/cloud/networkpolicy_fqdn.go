@@ -0,0 +1,196 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// fqdnLookupTimeout is the timeout of resolving an FQDN, including all its DNS queries.
+	fqdnLookupTimeout = 10 * time.Second
+	// fqdnRetryInterval is the interval to retry resolving an FQDN failed to be resolved.
+	fqdnRetryInterval = 30 * time.Second
+	// fqdnLookupWorkers is the number of FQDNs resolved concurrently.
+	fqdnLookupWorkers = 4
+	// fqdnLookupQueueSize is the number of FQDNs waiting to be resolved. FQDNs not queued are retried on refresh.
+	fqdnLookupQueueSize = 1024
+
+	dnsPort = "53"
+)
+
+// fqdnLookupFunc resolves an FQDN to its IPs.
+type fqdnLookupFunc func(ctx context.Context, fqdn string) ([]net.IP, error)
+
+// fqdnEntry is the resolution result of an FQDN.
+type fqdnEntry struct {
+	ipNets []*net.IPNet
+	err    error
+	// expiry is the time FQDN shall be resolved again.
+	expiry time.Time
+	// resolving is true while FQDN is queued or being resolved.
+	resolving bool
+}
+
+// fqdnResult is the result of resolving an FQDN by a lookup worker.
+type fqdnResult struct {
+	fqdn string
+	ips  []net.IP
+	err  error
+}
+
+// fqdnResolver resolves FQDNs of egress rules to IPs, and refreshes them periodically. FQDNs are resolved by lookup
+// workers, so that slow DNS servers do not block the reconciler. Results are sent back on results, and applied to
+// entries by the reconciler, which alone accesses entries.
+type fqdnResolver struct {
+	lookup fqdnLookupFunc
+	// interval is the interval between refreshes of a resolved FQDN.
+	interval time.Duration
+	entries  map[string]*fqdnEntry
+	requests chan string
+	results  chan *fqdnResult
+}
+
+// newFQDNResolver returns an fqdnResolver querying DNS server. The nameservers, search domains and options in
+// /etc/resolv.conf are used if server is empty.
+func newFQDNResolver(server string, interval time.Duration) *fqdnResolver {
+	resolver := &net.Resolver{PreferGo: true}
+	if len(server) != 0 {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, dnsPort)
+		}
+		dialer := &net.Dialer{}
+		// DNS queries are sent to server, over UDP, or over TCP for truncated responses.
+		resolver.Dial = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, server)
+		}
+	}
+	return &fqdnResolver{
+		lookup: func(ctx context.Context, fqdn string) ([]net.IP, error) {
+			return resolver.LookupIP(ctx, "ip", fqdn)
+		},
+		interval: interval,
+		entries:  make(map[string]*fqdnEntry),
+		requests: make(chan string, fqdnLookupQueueSize),
+		results:  make(chan *fqdnResult, fqdnLookupQueueSize),
+	}
+}
+
+// start starts the lookup workers, which run until stop is done.
+func (f *fqdnResolver) start(stop context.Context) {
+	for i := 0; i < fqdnLookupWorkers; i++ {
+		go f.runLookupWorker(stop)
+	}
+}
+
+// runLookupWorker resolves requested FQDNs, and sends back their results.
+func (f *fqdnResolver) runLookupWorker(stop context.Context) {
+	for {
+		select {
+		case fqdn := <-f.requests:
+			ctx, cancel := context.WithTimeout(stop, fqdnLookupTimeout)
+			ips, err := f.lookup(ctx, fqdn)
+			cancel()
+			select {
+			case f.results <- &fqdnResult{fqdn: fqdn, ips: ips, err: err}:
+			case <-stop.Done():
+				return
+			}
+		case <-stop.Done():
+			return
+		}
+	}
+}
+
+// resolve returns IPs of an FQDN. An FQDN not known is requested to be resolved, and has no IPs until its result
+// is applied.
+func (f *fqdnResolver) resolve(fqdn string) ([]*net.IPNet, error) {
+	entry, ok := f.entries[fqdn]
+	if !ok {
+		entry = &fqdnEntry{}
+		f.entries[fqdn] = entry
+		if strings.Contains(fqdn, "*") {
+			entry.err = fmt.Errorf("wildcard FQDN %s cannot be resolved", fqdn)
+		} else {
+			entry.err = fmt.Errorf("FQDN %s is being resolved", fqdn)
+			f.request(fqdn, entry)
+		}
+	}
+	return entry.ipNets, entry.err
+}
+
+// request queues an FQDN to be resolved. An FQDN not queued is requested again on refresh.
+func (f *fqdnResolver) request(fqdn string, entry *fqdnEntry) {
+	select {
+	case f.requests <- fqdn:
+		entry.resolving = true
+	default:
+	}
+}
+
+// refresh requests expired FQDNs in fqdns to be resolved again, and forgets FQDNs not in fqdns.
+func (f *fqdnResolver) refresh(fqdns []string, now time.Time) {
+	inUse := make(map[string]struct{}, len(fqdns))
+	for _, fqdn := range fqdns {
+		inUse[fqdn] = struct{}{}
+		entry, ok := f.entries[fqdn]
+		if !ok || entry.resolving || now.Before(entry.expiry) || strings.Contains(fqdn, "*") {
+			continue
+		}
+		f.request(fqdn, entry)
+	}
+	for fqdn := range f.entries {
+		if _, ok := inUse[fqdn]; !ok {
+			delete(f.entries, fqdn)
+		}
+	}
+}
+
+// apply applies the result of resolving an FQDN, and returns true if its IPs or resolution error have changed.
+func (f *fqdnResolver) apply(result *fqdnResult, now time.Time) bool {
+	entry, ok := f.entries[result.fqdn]
+	if !ok {
+		// FQDN is forgotten while being resolved.
+		return false
+	}
+	updated := &fqdnEntry{err: result.err, expiry: now.Add(fqdnRetryInterval)}
+	if result.err == nil {
+		ipNets := make([]*net.IPNet, 0, len(result.ips))
+		for _, ip := range result.ips {
+			ipNets = append(ipNets, convertIPToIPNet(ip))
+		}
+		updated.ipNets = uniqueSortedIPNets(ipNets)
+		updated.expiry = now.Add(f.interval)
+	}
+	f.entries[result.fqdn] = updated
+	return !isSameIPNets(entry.ipNets, updated.ipNets) || (entry.err == nil) != (updated.err == nil)
+}
+
+// isSameIPNets returns true if sorted CIDRs s1 and s2 are the same.
+func isSameIPNets(s1, s2 []*net.IPNet) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i].String() != s2[i].String() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,454 @@
+// Copyright 2023 Antrea Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/mohae/deepcopy"
+
+	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+)
+
+// getLimits returns the limits of the cloud on appliedToSecurityGroup. Limits are cached only once known, as the
+// cloud plug-in may not be ready yet.
+func (a *appliedToSecurityGroup) getLimits() *securitygroup.SecurityGroupLimits {
+	if a.limits != nil {
+		return a.limits
+	}
+	limits := securitygroup.CloudSecurityGroup.GetSecurityGroupLimits(&a.id)
+	if limits != (securitygroup.SecurityGroupLimits{}) {
+		a.limits = &limits
+	}
+	return &limits
+}
+
+// getMaxShards returns the maximum number of rule shards of appliedToSecurityGroup. Each member network interface
+// reserves one security group for addrSecurityGroups, and security groups for the rule shards of other
+// appliedToSecurityGroups it is a member of.
+func (a *appliedToSecurityGroup) getMaxShards(limits *securitygroup.SecurityGroupLimits, r *NetworkPolicyReconciler) int {
+	if limits.MaxSecurityGroupsPerInterface <= 1 {
+		return 1
+	}
+	used := 0
+	for _, rsc := range a.members {
+		tracker := r.getCloudResourceNPTracker(rsc, false)
+		if tracker == nil {
+			continue
+		}
+		count := 0
+		for _, sg := range tracker.appliedToSGs {
+			if sg == a {
+				continue
+			}
+			if sg.shards > 1 {
+				count += sg.shards
+			} else {
+				count++
+			}
+		}
+		if count > used {
+			used = count
+		}
+	}
+	if maxShards := limits.MaxSecurityGroupsPerInterface - 1 - used; maxShards > 1 {
+		return maxShards
+	}
+	return 1
+}
+
+// assignShards assigns rules of appliedToSecurityGroup to its rule shards. allRules are rules of all anps, and rules
+// are rules of the anp being updated, which get the same shards as in allRules.
+func (a *appliedToSecurityGroup) assignShards(r *NetworkPolicyReconciler, allRules, rules []*securitygroup.CloudRule) error {
+	limits := a.getLimits()
+	if limits.MaxRules == 0 {
+		return nil
+	}
+	realizedRules, err := r.cloudRuleIndexer.ByIndex(cloudRuleIndexerByAppliedToGrp, a.id.CloudResourceID.String())
+	if err != nil {
+		return err
+	}
+	realized := make(map[string]int)
+	for _, obj := range realizedRules {
+		rule := obj.(*securitygroup.CloudRule)
+		realized[rule.Hash] = rule.Shard
+	}
+	shards, err := assignRuleShards(allRules, realized, limits, a.getMaxShards(limits, r))
+	if err != nil {
+		return fmt.Errorf("unable to realize rules of sg %s: %w", a.id.Name, err)
+	}
+	a.shards = shards
+
+	shardOfRule := make(map[string]int)
+	for _, rule := range allRules {
+		shardOfRule[rule.Hash] = rule.Shard
+	}
+	for _, rule := range rules {
+		rule.Shard = shardOfRule[rule.Hash]
+	}
+	return nil
+}
+
+// assignRuleShards assigns rules to at most maxShards rule shards, each within the rule limits. Rules already
+// realized stay in their shards, and other rules are assigned to the first shard with enough capacity. It returns
+// the number of shards used.
+func assignRuleShards(rules []*securitygroup.CloudRule, realized map[string]int,
+	limits *securitygroup.SecurityGroupLimits, maxShards int) (int, error) {
+	counts := make([]map[bool]int, maxShards)
+	for i := range counts {
+		counts[i] = make(map[bool]int)
+	}
+	isIngress := func(rule *securitygroup.CloudRule) bool {
+		if limits.SharedDirections {
+			return true
+		}
+		_, ok := rule.Rule.(*securitygroup.IngressRule)
+		return ok
+	}
+
+	shards := 1
+	pending := make([]*securitygroup.CloudRule, 0)
+	for _, rule := range rules {
+		shard, ok := realized[rule.Hash]
+		if !ok || shard >= maxShards {
+			pending = append(pending, rule)
+			continue
+		}
+		rule.Shard = shard
+		counts[shard][isIngress(rule)] += limits.GetRuleCount(rule)
+		if shard >= shards {
+			shards = shard + 1
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Hash < pending[j].Hash
+	})
+	for _, rule := range pending {
+		count := limits.GetRuleCount(rule)
+		assigned := false
+		for shard := 0; shard < maxShards && !assigned; shard++ {
+			if counts[shard][isIngress(rule)]+count > limits.MaxRules {
+				continue
+			}
+			rule.Shard = shard
+			counts[shard][isIngress(rule)] += count
+			assigned = true
+			if shard >= shards {
+				shards = shard + 1
+			}
+		}
+		if !assigned {
+			if maxShards == 1 {
+				return 0, fmt.Errorf("rules exceed the cloud limit of %v rules per security group", limits.MaxRules)
+			}
+			return 0, fmt.Errorf("rules exceed %v security groups of %v rules, the cloud limit of security groups "+
+				"per network interface", maxShards, limits.MaxRules)
+		}
+	}
+	return shards, nil
+}
+
+// compactCloudRules returns rules with port ranges of rules differing only in ports merged, and then peers of rules
+// differing only in peers merged. Rules of different anps are never merged, and rules without peers or ports are
+// kept as is, as they match any peer or port.
+func compactCloudRules(rules []*securitygroup.CloudRule) []*securitygroup.CloudRule {
+	return mergeRulePeers(mergeRulePorts(rules))
+}
+
+// splitCloudRules splits rules with more peers than the rule limits into multiple rules, if each peer of a rule is
+// a rule in cloud.
+func splitCloudRules(rules []*securitygroup.CloudRule, limits *securitygroup.SecurityGroupLimits) []*securitygroup.CloudRule {
+	if !limits.RulePerPeer || limits.MaxRules == 0 {
+		return rules
+	}
+	split := make([]*securitygroup.CloudRule, 0, len(rules))
+	for _, rule := range rules {
+		if limits.GetRuleCount(rule) <= limits.MaxRules {
+			split = append(split, rule)
+			continue
+		}
+		ipNets, sgs := getRulePeers(rule.Rule)
+		for start := 0; start < len(sgs)+len(ipNets); start += limits.MaxRules {
+			end := start + limits.MaxRules
+			var chunkSGs []*securitygroup.CloudResourceID
+			var chunkIPNets []*net.IPNet
+			for i := start; i < end && i < len(sgs)+len(ipNets); i++ {
+				if i < len(sgs) {
+					chunkSGs = append(chunkSGs, sgs[i])
+				} else {
+					chunkIPNets = append(chunkIPNets, ipNets[i-len(sgs)])
+				}
+			}
+			split = append(split, newCloudRuleWithPeers(rule, chunkIPNets, chunkSGs))
+		}
+	}
+	return split
+}
+
+// mergeRulePorts merges contiguous or overlapping port ranges of rules differing only in ports.
+func mergeRulePorts(rules []*securitygroup.CloudRule) []*securitygroup.CloudRule {
+	merged := make([]*securitygroup.CloudRule, 0, len(rules))
+	groups := make(map[string][]*securitygroup.CloudRule)
+	keys := make([]string, 0)
+	for _, rule := range rules {
+		port, _, protocol := getRulePorts(rule.Rule)
+		if port == nil || securitygroup.IsICMPProtocol(protocol) {
+			merged = append(merged, rule)
+			continue
+		}
+		key := getCloudRuleKey(rule, false, true)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], rule)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) == 1 {
+			merged = append(merged, group[0])
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			iStart, _ := getRulePortRange(group[i].Rule)
+			jStart, _ := getRulePortRange(group[j].Rule)
+			return iStart < jStart
+		})
+		first := group[0]
+		start, end := getRulePortRange(first.Rule)
+		for _, rule := range group[1:] {
+			nextStart, nextEnd := getRulePortRange(rule.Rule)
+			if nextStart <= end+1 {
+				if nextEnd > end {
+					end = nextEnd
+				}
+				continue
+			}
+			merged = append(merged, newCloudRuleWithPorts(first, start, end))
+			first = rule
+			start, end = nextStart, nextEnd
+		}
+		merged = append(merged, newCloudRuleWithPorts(first, start, end))
+	}
+	return merged
+}
+
+// mergeRulePeers merges peers of rules differing only in peers, and compacts CIDRs of the merged rules.
+func mergeRulePeers(rules []*securitygroup.CloudRule) []*securitygroup.CloudRule {
+	merged := make([]*securitygroup.CloudRule, 0, len(rules))
+	groups := make(map[string][]*securitygroup.CloudRule)
+	keys := make([]string, 0)
+	for _, rule := range rules {
+		if rule.GetPeerCount() == 0 {
+			merged = append(merged, rule)
+			continue
+		}
+		key := getCloudRuleKey(rule, true, false)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], rule)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		var ipNets []*net.IPNet
+		var sgs []*securitygroup.CloudResourceID
+		sgSet := make(map[string]struct{})
+		for _, rule := range group {
+			ruleIPNets, ruleSGs := getRulePeers(rule.Rule)
+			ipNets = append(ipNets, ruleIPNets...)
+			for _, sg := range ruleSGs {
+				if _, ok := sgSet[sg.String()]; !ok {
+					sgSet[sg.String()] = struct{}{}
+					sgs = append(sgs, sg)
+				}
+			}
+		}
+		compacted := compactIPNets(ipNets)
+		if len(group) == 1 && len(compacted) == len(ipNets) {
+			merged = append(merged, group[0])
+			continue
+		}
+		sort.Slice(sgs, func(i, j int) bool {
+			return sgs[i].String() < sgs[j].String()
+		})
+		merged = append(merged, newCloudRuleWithPeers(group[0], compacted, sgs))
+	}
+	return merged
+}
+
+// compactIPNets returns the smallest sorted list of CIDRs covering ipNets, by removing CIDRs contained in other
+// CIDRs and merging adjacent CIDRs of the same prefix length into their parent CIDR.
+func compactIPNets(ipNets []*net.IPNet) []*net.IPNet {
+	var ipv4Nets, ipv6Nets []*net.IPNet
+	for _, ipNet := range ipNets {
+		ones, bits := ipNet.Mask.Size()
+		if ip := ipNet.IP.To4(); ip != nil {
+			if bits == 8*net.IPv6len {
+				ones -= 8 * (net.IPv6len - net.IPv4len)
+			}
+			mask := net.CIDRMask(ones, 8*net.IPv4len)
+			ipv4Nets = append(ipv4Nets, &net.IPNet{IP: ip.Mask(mask), Mask: mask})
+			continue
+		}
+		mask := net.CIDRMask(ones, 8*net.IPv6len)
+		ipv6Nets = append(ipv6Nets, &net.IPNet{IP: ipNet.IP.To16().Mask(mask), Mask: mask})
+	}
+	return append(compactIPNetsOfFamily(ipv4Nets), compactIPNetsOfFamily(ipv6Nets)...)
+}
+
+// compactIPNetsOfFamily compacts CIDRs of the same IP family.
+func compactIPNetsOfFamily(ipNets []*net.IPNet) []*net.IPNet {
+	sort.Slice(ipNets, func(i, j int) bool {
+		if c := bytes.Compare(ipNets[i].IP, ipNets[j].IP); c != 0 {
+			return c < 0
+		}
+		iOnes, _ := ipNets[i].Mask.Size()
+		jOnes, _ := ipNets[j].Mask.Size()
+		return iOnes < jOnes
+	})
+	compacted := make([]*net.IPNet, 0, len(ipNets))
+	for _, ipNet := range ipNets {
+		// as CIDRs are sorted, only the last compacted CIDR may contain ipNet.
+		if n := len(compacted); n > 0 && compacted[n-1].Contains(ipNet.IP) {
+			continue
+		}
+		compacted = append(compacted, ipNet)
+		for n := len(compacted); n > 1; n = len(compacted) {
+			prev, last := compacted[n-2], compacted[n-1]
+			prevOnes, bits := prev.Mask.Size()
+			lastOnes, _ := last.Mask.Size()
+			if prevOnes != lastOnes || prevOnes == 0 {
+				break
+			}
+			mask := net.CIDRMask(prevOnes-1, bits)
+			parent := &net.IPNet{IP: prev.IP.Mask(mask), Mask: mask}
+			if !parent.IP.Equal(prev.IP) || !parent.Contains(last.IP) {
+				break
+			}
+			compacted = append(compacted[:n-2], parent)
+		}
+	}
+	return compacted
+}
+
+// getCloudRuleKey returns a key identifying a rule and its anp, excluding peers or ports of the rule.
+func getCloudRuleKey(rule *securitygroup.CloudRule, excludePeers, excludePorts bool) string {
+	ruleCopy := deepcopy.Copy(rule.Rule).(securitygroup.Rule)
+	if excludePeers {
+		setRulePeers(ruleCopy, nil, nil)
+	}
+	if excludePorts {
+		setRulePorts(ruleCopy, nil, nil)
+	}
+	ruleBytes, _ := json.Marshal(ruleCopy)
+	return fmt.Sprintf("%s/%T/%s", rule.NetworkPolicy, ruleCopy, ruleBytes)
+}
+
+// newCloudRuleWithPeers returns a copy of rule with the given peers.
+func newCloudRuleWithPeers(rule *securitygroup.CloudRule, ipNets []*net.IPNet,
+	sgs []*securitygroup.CloudResourceID) *securitygroup.CloudRule {
+	if len(ipNets) == 0 {
+		ipNets = nil
+	}
+	if len(sgs) == 0 {
+		sgs = nil
+	}
+	newRule := &securitygroup.CloudRule{
+		Rule:          deepcopy.Copy(rule.Rule).(securitygroup.Rule),
+		NetworkPolicy: rule.NetworkPolicy,
+		AppliedToGrp:  rule.AppliedToGrp,
+	}
+	setRulePeers(newRule.Rule, ipNets, sgs)
+	newRule.Hash = newRule.GetHash()
+	return newRule
+}
+
+// newCloudRuleWithPorts returns a copy of rule with the given port range, or a copy of rule if the port range is
+// the same as the rule.
+func newCloudRuleWithPorts(rule *securitygroup.CloudRule, start, end int) *securitygroup.CloudRule {
+	if ruleStart, ruleEnd := getRulePortRange(rule.Rule); ruleStart == start && ruleEnd == end {
+		return rule
+	}
+	newRule := &securitygroup.CloudRule{
+		Rule:          deepcopy.Copy(rule.Rule).(securitygroup.Rule),
+		NetworkPolicy: rule.NetworkPolicy,
+		AppliedToGrp:  rule.AppliedToGrp,
+	}
+	var endPort *int
+	if end != start {
+		endPort = &end
+	}
+	setRulePorts(newRule.Rule, &start, endPort)
+	newRule.Hash = newRule.GetHash()
+	return newRule
+}
+
+// getRulePeers returns CIDRs and security groups of an ingress or egress rule.
+func getRulePeers(rule securitygroup.Rule) ([]*net.IPNet, []*securitygroup.CloudResourceID) {
+	switch r := rule.(type) {
+	case *securitygroup.IngressRule:
+		return r.FromSrcIP, r.FromSecurityGroups
+	case *securitygroup.EgressRule:
+		return r.ToDstIP, r.ToSecurityGroups
+	}
+	return nil, nil
+}
+
+// setRulePeers sets CIDRs and security groups of an ingress or egress rule.
+func setRulePeers(rule securitygroup.Rule, ipNets []*net.IPNet, sgs []*securitygroup.CloudResourceID) {
+	switch r := rule.(type) {
+	case *securitygroup.IngressRule:
+		r.FromSrcIP, r.FromSecurityGroups = ipNets, sgs
+	case *securitygroup.EgressRule:
+		r.ToDstIP, r.ToSecurityGroups = ipNets, sgs
+	}
+}
+
+// getRulePorts returns port, end port and protocol of an ingress or egress rule.
+func getRulePorts(rule securitygroup.Rule) (*int, *int, *int) {
+	switch r := rule.(type) {
+	case *securitygroup.IngressRule:
+		return r.FromPort, r.FromEndPort, r.Protocol
+	case *securitygroup.EgressRule:
+		return r.ToPort, r.ToEndPort, r.Protocol
+	}
+	return nil, nil, nil
+}
+
+// getRulePortRange returns the first and last port of an ingress or egress rule with port.
+func getRulePortRange(rule securitygroup.Rule) (int, int) {
+	port, endPort, _ := getRulePorts(rule)
+	if endPort == nil {
+		return *port, *port
+	}
+	return *port, *endPort
+}
+
+// setRulePorts sets port and end port of an ingress or egress rule.
+func setRulePorts(rule securitygroup.Rule, port, endPort *int) {
+	switch r := rule.(type) {
+	case *securitygroup.IngressRule:
+		r.FromPort, r.FromEndPort = port, endPort
+	case *securitygroup.EgressRule:
+		r.ToPort, r.ToEndPort = port, endPort
+	}
+}
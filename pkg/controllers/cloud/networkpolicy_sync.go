@@ -102,11 +102,21 @@ func (a *appliedToSecurityGroup) sync(syncContent *securitygroup.Synchronization
 				log.V(1).Info("np not ready", "Name", np.Name, "Namespace", np.Namespace)
 			}
 		}
-		for _, iRule := range np.ingressRules {
-			countIngressRuleItems(iRule, items, false)
+	}
+	// count rules as realized in cloud, i.e. resolved, compacted and split. If the cloud realizes deny rules outside of
+	// security groups, they are not reported in syncContent, and are compared with cloudRuleIndexer instead.
+	excludeDenyRules := a.getLimits().ExcludeDenyRules
+	denyRules := make(map[string]struct{})
+	for _, rule := range a.combineRules(nps, r) {
+		if excludeDenyRules && rule.IsDeny() {
+			denyRules[rule.Hash] = struct{}{}
+			continue
 		}
-		for _, eRule := range np.egressRules {
-			countEgressRuleItems(eRule, items, false)
+		switch rule := rule.Rule.(type) {
+		case *securitygroup.IngressRule:
+			countIngressRuleItems(rule, items, false)
+		case *securitygroup.EgressRule:
+			countEgressRuleItems(rule, items, false)
 		}
 	}
 
@@ -124,23 +134,40 @@ func (a *appliedToSecurityGroup) sync(syncContent *securitygroup.Synchronization
 	cloudRuleMap := make(map[string]*securitygroup.CloudRule)
 	for _, obj := range rules {
 		rule := obj.(*securitygroup.CloudRule)
+		if excludeDenyRules && rule.IsDeny() {
+			if _, found := denyRules[rule.Hash]; !found {
+				indexerUpdate = true
+			}
+			delete(denyRules, rule.Hash)
+			continue
+		}
 		cloudRuleMap[rule.Hash] = rule
 	}
+	if len(denyRules) != 0 {
+		indexerUpdate = true
+	}
 
-	// roughly count and compare rules in syncContent against nps.
+	// roughly count and compare rules in syncContent and its rule shards against nps.
 	// also updates cloudRuleIndexer in the process.
-	for _, iRule := range syncContent.IngressRules {
-		countIngressRuleItems(&iRule, items, true)
-		if updated := a.checkAndUpdateIndexer(r, &iRule, cloudRuleMap); updated {
-			indexerUpdate = true
+	shards := 1
+	for _, content := range append([]*securitygroup.SynchronizationContent{syncContent}, a.syncShards...) {
+		for _, iRule := range content.IngressRules {
+			countIngressRuleItems(&iRule, items, true)
+			if updated := a.checkAndUpdateIndexer(r, &iRule, content.Shard, cloudRuleMap); updated {
+				indexerUpdate = true
+			}
 		}
-	}
-	for _, eRule := range syncContent.EgressRules {
-		countEgressRuleItems(&eRule, items, true)
-		if updated := a.checkAndUpdateIndexer(r, &eRule, cloudRuleMap); updated {
-			indexerUpdate = true
+		for _, eRule := range content.EgressRules {
+			countEgressRuleItems(&eRule, items, true)
+			if updated := a.checkAndUpdateIndexer(r, &eRule, content.Shard, cloudRuleMap); updated {
+				indexerUpdate = true
+			}
+		}
+		if content.Shard >= shards {
+			shards = content.Shard + 1
 		}
 	}
+	a.shards = shards
 	// remove rules no longer exist in cloud from indexer.
 	for _, rule := range cloudRuleMap {
 		indexerUpdate = true
@@ -182,6 +209,7 @@ func (r *NetworkPolicyReconciler) syncWithCloud() {
 	ch := securitygroup.CloudSecurityGroup.GetSecurityGroupSyncChan()
 	cloudAddrSGs := make(map[securitygroup.CloudResourceID]*securitygroup.SynchronizationContent)
 	cloudAppliedToSGs := make(map[securitygroup.CloudResourceID]*securitygroup.SynchronizationContent)
+	cloudAppliedToShards := make(map[securitygroup.CloudResourceID][]*securitygroup.SynchronizationContent)
 	rscWithUnknownSGs := make(map[securitygroup.CloudResource]struct{})
 	for content := range ch {
 		log.V(1).Info("Sync from cloud", "SecurityGroup", content)
@@ -203,6 +231,9 @@ func (r *NetworkPolicyReconciler) syncWithCloud() {
 		cc := content
 		if content.MembershipOnly {
 			cloudAddrSGs[content.Resource.CloudResourceID] = &cc
+		} else if content.Shard > 0 {
+			cloudAppliedToShards[content.Resource.CloudResourceID] = append(
+				cloudAppliedToShards[content.Resource.CloudResourceID], &cc)
 		} else {
 			cloudAppliedToSGs[content.Resource.CloudResourceID] = &cc
 			for _, rsc := range content.MembersWithOtherSGAttached {
@@ -217,7 +248,9 @@ func (r *NetworkPolicyReconciler) syncWithCloud() {
 	}
 	for _, i := range r.appliedToSGIndexer.List() {
 		sg := i.(*appliedToSecurityGroup)
+		sg.syncShards = cloudAppliedToShards[sg.getID()]
 		sg.sync(cloudAppliedToSGs[sg.getID()], r)
+		sg.syncShards = nil
 	}
 	// For cloud resource with any non nephe created SG, tricking plug-in to remove them by explicitly
 	// updating a single instance of associated security group.
@@ -279,8 +312,9 @@ func (r *NetworkPolicyReconciler) getNICsOfCloudResources(resources []*securityg
 }
 
 // checkAndUpdateIndexer checks if rule is present in indexer and updates the indexer if not present.
+// The rule shard realizing a rule is updated in indexer without being considered an indexer update.
 // Returns true if indexer is updated.
-func (a *appliedToSecurityGroup) checkAndUpdateIndexer(r *NetworkPolicyReconciler, rule securitygroup.Rule,
+func (a *appliedToSecurityGroup) checkAndUpdateIndexer(r *NetworkPolicyReconciler, rule securitygroup.Rule, shard int,
 	existingRuleMap map[string]*securitygroup.CloudRule) bool {
 	indexerUpdate := false
 
@@ -289,14 +323,19 @@ func (a *appliedToSecurityGroup) checkAndUpdateIndexer(r *NetworkPolicyReconcile
 	cr := &securitygroup.CloudRule{
 		Rule:         ruleCopy,
 		AppliedToGrp: a.id.CloudResourceID.String(),
+		Shard:        shard,
 	}
 	cr.Hash = cr.GetHash()
 
 	// update rule if not found in indexer, otherwise remove from map to indicate a matching rule is found.
-	if _, found := existingRuleMap[cr.Hash]; !found {
+	if existing, found := existingRuleMap[cr.Hash]; !found {
 		indexerUpdate = true
 		_ = r.cloudRuleIndexer.Update(cr)
 	} else {
+		if existing.Shard != shard {
+			existing.Shard = shard
+			_ = r.cloudRuleIndexer.Update(existing)
+		}
 		delete(existingRuleMap, cr.Hash)
 	}
 
@@ -316,8 +355,15 @@ func countIngressRuleItems(iRule *securitygroup.IngressRule, items map[string]in
 	}
 	if proto > 0 || port > 0 {
 		portStr := fmt.Sprintf("protocol=%v,port=%v", proto, port)
+		if iRule.FromEndPort != nil {
+			portStr = fmt.Sprintf("%v-%v", portStr, *iRule.FromEndPort)
+		}
 		updateCountForItem(portStr, items, subtract)
 	}
+	if iRule.ICMPType != nil || iRule.ICMPCode != nil {
+		updateCountForItem(fmt.Sprintf("protocol=%v,icmpType=%v,icmpCode=%v", proto,
+			getIntValueOrNil(iRule.ICMPType), getIntValueOrNil(iRule.ICMPCode)), items, subtract)
+	}
 	for _, ip := range iRule.FromSrcIP {
 		updateCountForItem(ip.String(), items, subtract)
 	}
@@ -338,8 +384,15 @@ func countEgressRuleItems(eRule *securitygroup.EgressRule, items map[string]int,
 	}
 	if proto > 0 || port > 0 {
 		portStr := fmt.Sprintf("protocol=%v,port=%v", proto, port)
+		if eRule.ToEndPort != nil {
+			portStr = fmt.Sprintf("%v-%v", portStr, *eRule.ToEndPort)
+		}
 		updateCountForItem(portStr, items, subtract)
 	}
+	if eRule.ICMPType != nil || eRule.ICMPCode != nil {
+		updateCountForItem(fmt.Sprintf("protocol=%v,icmpType=%v,icmpCode=%v", proto,
+			getIntValueOrNil(eRule.ICMPType), getIntValueOrNil(eRule.ICMPCode)), items, subtract)
+	}
 	for _, ip := range eRule.ToDstIP {
 		updateCountForItem(ip.String(), items, subtract)
 	}
@@ -348,6 +401,14 @@ func countEgressRuleItems(eRule *securitygroup.EgressRule, items map[string]int,
 	}
 }
 
+// getIntValueOrNil returns the value of an int pointer, or nil.
+func getIntValueOrNil(v *int) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
 // updateCountForItem adds or subtracts the item count in the items map.
 func updateCountForItem(item string, items map[string]int, subtract bool) {
 	if subtract {
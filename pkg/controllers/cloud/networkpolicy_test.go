@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -41,8 +42,10 @@ import (
 	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	antreatypes "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	antreafakeclientset "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdv1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 	"antrea.io/nephe/pkg/cloud-provider/securitygroup"
+	nepheconfig "antrea.io/nephe/pkg/config"
 	"antrea.io/nephe/pkg/controllers/config"
 	"antrea.io/nephe/pkg/converter/target"
 	cloudtest "antrea.io/nephe/pkg/testing/cloudsecurity"
@@ -100,6 +103,8 @@ var _ = Describe("NetworkPolicy", func() {
 		mockInventory = inventory.NewMockInterface(mockCtrl)
 		mockCloudSecurityAPI = cloudtest.NewMockCloudSecurityGroupAPI(mockCtrl)
 		securitygroup.CloudSecurityGroup = mockCloudSecurityAPI
+		mockCloudSecurityAPI.EXPECT().GetSecurityGroupLimits(mock.Any()).Return(securitygroup.SecurityGroupLimits{}).AnyTimes()
+		mockCloudSecurityAPI.EXPECT().GetCapabilities(mock.Any()).Return(crdv1alpha1.CloudCapabilities{}).AnyTimes()
 		reconciler = &NetworkPolicyReconciler{
 			Log:             logf.Log,
 			Client:          mockClient,
@@ -890,7 +895,7 @@ var _ = Describe("NetworkPolicy", func() {
 			if hasPolicy && !hasError {
 				Expect(found).To(BeTrue())
 				npStatus := obj.(*NetworkPolicyStatus)
-				status, ok := npStatus.NPStatus[anp.Name]
+				status, ok := npStatus.NPStatus[(&networkPolicy{NetworkPolicy: *anp}).getStatusName()]
 				Expect(ok).To(BeTrue())
 				Expect(status).To(ContainSubstring(NetworkPolicyStatusApplied))
 			} else if !hasPolicy && hasError {
@@ -949,19 +954,526 @@ var _ = Describe("NetworkPolicy", func() {
 		deleteAndVerifyNP(true)
 	})
 
-	It("Verify unsupported networkPolicy drop action", func() {
+	It("Verify unsupported networkPolicy pass action", func() {
 		anpTemp := anp
-		ruleAction := v1alpha1.RuleActionDrop
+		ruleAction := v1alpha1.RuleActionPass
 		anpTemp.Rules[0].Action = &ruleAction
 		event := watch.Event{Type: watch.Added, Object: anpTemp}
 		err := reconciler.processNetworkPolicy(event)
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("Verify drop and reject actions are converted to deny cloud rules", func() {
+		_, ipBlock, _ := net.ParseCIDR("6.6.6.0/24")
+		dropAction := v1alpha1.RuleActionDrop
+		rejectAction := v1alpha1.RuleActionReject
+		tierPriority := int32(250)
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn, Action: &dropAction, Priority: 2}
+		inRule.From.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipBlock.IP), PrefixLength: 24}}}
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut, Action: &rejectAction, Priority: 3}
+		eRule.To.IPBlocks = inRule.From.IPBlocks
+		anpTemp := anp.DeepCopy()
+		anpTemp.Rules = append(anpTemp.Rules, inRule, eRule)
+		Expect(reconciler.isNetworkPolicySupported(anpTemp)).ToNot(HaveOccurred())
+
+		policyPriority := 5.5
+		npRule := &networkPolicyRule{rule: &inRule, tierPriority: &tierPriority, policyPriority: &policyPriority}
+		iRules, _, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(iRules).To(HaveLen(1))
+		Expect(iRules[0].Action).To(Equal(securitygroup.RuleActionDeny))
+		Expect(*iRules[0].Priority).To(Equal(securitygroup.RulePriority{Tier: 250, Policy: 5.5, Rule: 2}))
+
+		npRule = &networkPolicyRule{rule: &eRule, tierPriority: &tierPriority, policyPriority: &policyPriority}
+		_, eRules, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(eRules).To(HaveLen(1))
+		Expect(eRules[0].Action).To(Equal(securitygroup.RuleActionDeny))
+		Expect(*eRules[0].Priority).To(Equal(securitygroup.RulePriority{Tier: 250, Policy: 5.5, Rule: 3}))
+
+		// allow rules are ordered the same as deny rules.
+		allowAction := v1alpha1.RuleActionAllow
+		eRule.Action = &allowAction
+		_, eRules, _ = npRule.rules(reconciler)
+		Expect(eRules[0].Action).To(BeEmpty())
+		Expect(*eRules[0].Priority).To(Equal(securitygroup.RulePriority{Tier: 250, Policy: 5.5, Rule: 3}))
+		Expect((&securitygroup.CloudRule{Rule: eRules[0]}).IsDeny()).To(BeFalse())
+
+		// rules of K8s NetworkPolicies precede rules of the Baseline tier.
+		npRule = &networkPolicyRule{rule: &eRule}
+		_, eRules, _ = npRule.rules(reconciler)
+		baselinePriority := securitygroup.RulePriority{Tier: baselineTierPriority, Policy: 1}
+		Expect(eRules[0].Priority.Less(&baselinePriority)).To(BeTrue())
+		Expect((&securitygroup.RulePriority{Tier: 252, Policy: 10000}).Less(eRules[0].Priority)).To(BeTrue())
+	})
+
+	It("Verify rule level appliedTo groups", func() {
+		_, ipBlock, _ := net.ParseCIDR("6.6.6.0/24")
+		ipBlocks := []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipBlock.IP), PrefixLength: 24}}}
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn,
+			AppliedToGroups: []string{appliedToGrpsNames[0]}}
+		inRule.From.IPBlocks = ipBlocks
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut,
+			AppliedToGroups: []string{appliedToGrpsNames[1]}}
+		eRule.To.IPBlocks = ipBlocks
+		np := &networkPolicy{}
+		anp.DeepCopyInto(&np.NetworkPolicy)
+		np.AppliedToGroups = nil
+		np.Rules = []antreanetworking.NetworkPolicyRule{inRule, eRule}
+		Expect(np.computeRules(reconciler)).To(BeTrue())
+		Expect(np.getAppliedToGroups()).To(Equal(appliedToGrpsNames))
+		Expect(reconciler.networkPolicyIndexer.Add(np)).ToNot(HaveOccurred())
+
+		for i, name := range appliedToGrpsNames {
+			nps, err := reconciler.networkPolicyIndexer.ByIndex(networkPolicyIndexerByAppliedToGrp, name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nps).To(HaveLen(1))
+			asg := &appliedToSecurityGroup{}
+			asg.id = securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: name, Vpc: vpc},
+			}
+			rules := asg.combineRules(nps, reconciler)
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].AppliedToGrp).To(Equal(asg.id.CloudResourceID.String()))
+			if i == 0 {
+				Expect(rules[0].Rule).To(BeAssignableToTypeOf(&securitygroup.IngressRule{}))
+			} else {
+				Expect(rules[0].Rule).To(BeAssignableToTypeOf(&securitygroup.EgressRule{}))
+			}
+		}
+	})
+
+	It("Verify named ports are resolved with ports advertised by group members", func() {
+		member := &securitygroup.CloudResource{
+			Type:            securitygroup.CloudResourceTypeVM,
+			CloudResourceID: securitygroup.CloudResourceID{Name: "named-port-vm", Vpc: vpc},
+		}
+		for i, name := range appliedToGrpsNames {
+			id := &securitygroup.CloudResource{
+				Type:            securitygroup.CloudResourceTypeVM,
+				CloudResourceID: securitygroup.CloudResourceID{Name: name, Vpc: vpc},
+			}
+			members := make([]*securitygroup.CloudResource, 0)
+			if i == 0 {
+				members = append(members, member)
+			}
+			Expect(reconciler.appliedToSGIndexer.Add(newAppliedToSecurityGroup(id, members, nil))).ToNot(HaveOccurred())
+		}
+		reconciler.memberNamedPorts[member.String()] = []antreatypes.NamedPort{
+			{Name: "http", Protocol: "TCP", Port: 8080},
+			{Name: "http", Protocol: "UDP", Port: 8081},
+		}
+
+		_, ipBlock, _ := net.ParseCIDR("6.6.6.0/24")
+		namedPort := intstr.FromString("http")
+		protocol := antreanetworking.ProtocolTCP
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn,
+			Services: []antreanetworking.Service{{Protocol: &protocol, Port: &namedPort}}}
+		inRule.From.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipBlock.IP), PrefixLength: 24}}}
+		np := &networkPolicy{}
+		anp.DeepCopyInto(&np.NetworkPolicy)
+		np.Rules = []antreanetworking.NetworkPolicyRule{inRule}
+		Expect(np.hasNamedPorts()).To(BeTrue())
+		Expect(np.computeRules(reconciler)).To(BeTrue())
+
+		iRules := np.getIngressRules(appliedToGrpsNames[0])
+		Expect(iRules).To(HaveLen(1))
+		Expect(*iRules[0].FromPort).To(Equal(8080))
+		Expect(iRules[0].FromSrcIP).To(HaveLen(1))
+		// named port is not advertised by members of the other appliedToGroup.
+		Expect(np.getIngressRules(appliedToGrpsNames[1])).To(BeEmpty())
+	})
+
+	It("Verify AddressGroup members in unpeered VPCs are realized as IPs", func() {
+		otherVpc := "other-vpc"
+		accountID := types.NamespacedName{Namespace: namespace, Name: "account"}.String()
+		member := &securitygroup.CloudResource{
+			Type:            securitygroup.CloudResourceTypeVM,
+			CloudResourceID: securitygroup.CloudResourceID{Name: "other-vpc-vm", Vpc: otherVpc},
+			AccountID:       accountID,
+		}
+		reconciler.memberIPs[member.String()] = []runtimev1alpha1.IPAddress{
+			{AddressType: runtimev1alpha1.AddressTypeInternalIP, Address: "10.1.1.5"},
+			{AddressType: runtimev1alpha1.AddressTypeExternalIP, Address: "34.1.1.5"},
+		}
+		reconciler.CrossVpcAddressType = runtimev1alpha1.AddressTypeExternalIP
+		addrID := &securitygroup.CloudResource{
+			Type:            securitygroup.CloudResourceTypeVM,
+			CloudResourceID: securitygroup.CloudResourceID{Name: "cross-vpc-addr-grp", Vpc: otherVpc},
+			AccountID:       accountID,
+		}
+		addrSG := newAddrSecurityGroup(addrID, []*securitygroup.CloudResource{member}, nil)
+		Expect(reconciler.addrSGIndexer.Add(addrSG)).ToNot(HaveOccurred())
+
+		asg := &appliedToSecurityGroup{}
+		asg.id = securitygroup.CloudResource{
+			Type:            securitygroup.CloudResourceTypeVM,
+			CloudResourceID: securitygroup.CloudResourceID{Name: appliedToGrpsNames[0], Vpc: vpc},
+			AccountID:       accountID,
+		}
+		ingress := &securitygroup.IngressRule{FromSecurityGroups: []*securitygroup.CloudResourceID{&addrID.CloudResourceID}}
+
+		// VPCs are not peered.
+		vpcObj := &runtimev1alpha1.Vpc{Status: runtimev1alpha1.VpcStatus{Id: vpc}}
+		mockInventory.EXPECT().GetVpcsFromIndexer(mock.Any(), accountID).Return([]interface{}{vpcObj}, nil).Times(1)
+		_, memberIPNet, _ := net.ParseCIDR("34.1.1.5/32")
+		resolved := asg.resolveIngressRule(ingress, reconciler)
+		Expect(resolved.FromSecurityGroups).To(BeEmpty())
+		Expect(resolved.FromSrcIP).To(Equal([]*net.IPNet{memberIPNet}))
+
+		// VPCs are peered.
+		vpcObj.Status.Peers = []string{otherVpc}
+		mockInventory.EXPECT().GetVpcsFromIndexer(mock.Any(), accountID).Return([]interface{}{vpcObj}, nil).Times(1)
+		resolved = asg.resolveIngressRule(ingress, reconciler)
+		Expect(resolved.FromSecurityGroups).To(Equal(ingress.FromSecurityGroups))
+		Expect(resolved.FromSrcIP).To(BeEmpty())
+
+		// VPCs of other accounts are never peered, rule without member IPs is dropped.
+		asg.id.AccountID = types.NamespacedName{Namespace: namespace, Name: "other-account"}.String()
+		reconciler.CrossVpcAddressType = runtimev1alpha1.AddressTypeInternalIP
+		reconciler.memberIPs[member.String()] = nil
+		Expect(asg.resolveIngressRule(ingress, reconciler)).To(BeNil())
+	})
+
+	It("Verify Pod members of AddressGroups are translated to IPs", func() {
+		podGrp := "pod-addr-grp"
+		pod := antreanetworking.GroupMember{
+			Pod: &antreanetworking.PodReference{Name: "pod-1", Namespace: namespace},
+			IPs: []antreanetworking.IPAddress{antreanetworking.IPAddress(net.ParseIP("10.10.1.5").To4())},
+		}
+		Expect(reconciler.updateAddrGrpPodIPs(podGrp, watch.Added, []antreanetworking.GroupMember{pod}, nil)).To(BeTrue())
+
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn}
+		inRule.From.AddressGroups = []string{podGrp}
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut}
+		eRule.To.AddressGroups = []string{podGrp}
+		_, podIPNet, _ := net.ParseCIDR("10.10.1.5/32")
+
+		iRules, _, ready := (&networkPolicyRule{rule: &inRule}).rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(iRules).To(HaveLen(1))
+		Expect(iRules[0].FromSrcIP).To(Equal([]*net.IPNet{podIPNet}))
+		_, eRules, ready := (&networkPolicyRule{rule: &eRule}).rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(eRules).To(HaveLen(1))
+		Expect(eRules[0].ToDstIP).To(Equal([]*net.IPNet{podIPNet}))
+
+		// Pod IPs are translated to the IP of its Node.
+		reconciler.PodAddressTranslation = nepheconfig.PodAddressTranslationNodeIP
+		key := client.ObjectKey{Name: "pod-1", Namespace: namespace}
+		mockClient.EXPECT().Get(mock.Any(), key, mock.Any()).Return(nil).Times(1).
+			Do(func(_ context.Context, _ client.ObjectKey, out *corev1.Pod) {
+				out.Status.HostIP = "192.168.1.10"
+			})
+		Expect(reconciler.updateAddrGrpPodIPs(podGrp, watch.Modified, []antreanetworking.GroupMember{pod}, nil)).To(BeTrue())
+		_, nodeIPNet, _ := net.ParseCIDR("192.168.1.10/32")
+		iRules, _, ready = (&networkPolicyRule{rule: &inRule}).rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(iRules).To(HaveLen(1))
+		Expect(iRules[0].FromSrcIP).To(Equal([]*net.IPNet{nodeIPNet}))
+
+		// AddressGroup without Pod members left has no rules, but remains ready.
+		Expect(reconciler.updateAddrGrpPodIPs(podGrp, watch.Modified, nil, []antreanetworking.GroupMember{pod})).To(BeTrue())
+		iRules, _, ready = (&networkPolicyRule{rule: &inRule}).rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(iRules).To(BeEmpty())
+	})
+
+	It("Verify FQDNs of egress rules are resolved to IPs", func() {
+		fqdn := "www.example.com"
+		unknownFQDN := "unknown.example.com"
+		fqdnIPs := []net.IP{net.ParseIP("93.184.216.34"), net.ParseIP("2606:2800:220:1::1")}
+		reconciler.fqdnResolver.lookup = func(_ context.Context, name string) ([]net.IP, error) {
+			if name != fqdn {
+				return nil, fmt.Errorf("no such host %s", name)
+			}
+			return fqdnIPs, nil
+		}
+		reconciler.fqdnResolver.interval = time.Minute
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		reconciler.fqdnResolver.start(ctx)
+		// applyResults applies results of requested FQDNs, and returns FQDNs whose resolution changed.
+		applyResults := func(count int, now time.Time) []string {
+			var changed []string
+			for i := 0; i < count; i++ {
+				var result *fqdnResult
+				Eventually(reconciler.fqdnResolver.results).Should(Receive(&result))
+				if reconciler.fqdnResolver.apply(result, now) {
+					changed = append(changed, result.fqdn)
+				}
+			}
+			Consistently(reconciler.fqdnResolver.results, "100ms").ShouldNot(Receive())
+			sort.Strings(changed)
+			return changed
+		}
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut}
+		eRule.To.FQDNs = []string{fqdn, unknownFQDN, "*.example.com"}
+		_, fqdnIPNet, _ := net.ParseCIDR("93.184.216.34/32")
+		_, fqdnIPv6Net, _ := net.ParseCIDR("2606:2800:220:1::1/128")
+
+		// FQDNs are resolved in background, and have no IPs until resolved.
+		_, eRules, ready := (&networkPolicyRule{rule: &eRule}).rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(eRules).To(BeEmpty())
+		Expect(applyResults(2, time.Now())).To(Equal([]string{fqdn, unknownFQDN}))
+		_, eRules, _ = (&networkPolicyRule{rule: &eRule}).rules(reconciler)
+		Expect(eRules).To(HaveLen(1))
+		Expect(eRules[0].ToDstIP).To(Equal([]*net.IPNet{fqdnIPNet, fqdnIPv6Net}))
+
+		// Unresolved FQDNs are reported in status.
+		np := &networkPolicy{}
+		np.Rules = []antreanetworking.NetworkPolicyRule{eRule}
+		np.rulesReady = true
+		err := np.getStatus(reconciler)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(fmt.Sprintf("unresolved FQDNs [%s *.example.com]", unknownFQDN)))
+
+		// FQDNs are resolved again after the refresh interval, and changed only if their IPs change.
+		fqdnIPs = []net.IP{net.ParseIP("93.184.216.35")}
+		reconciler.fqdnResolver.refresh(eRule.To.FQDNs, time.Now())
+		Expect(applyResults(0, time.Now())).To(BeEmpty())
+		reconciler.fqdnResolver.refresh(eRule.To.FQDNs, time.Now().Add(time.Hour))
+		Expect(applyResults(2, time.Now())).To(Equal([]string{fqdn}))
+		_, fqdnIPNet, _ = net.ParseCIDR("93.184.216.35/32")
+		_, eRules, _ = (&networkPolicyRule{rule: &eRule}).rules(reconciler)
+		Expect(eRules).To(HaveLen(1))
+		Expect(eRules[0].ToDstIP).To(Equal([]*net.IPNet{fqdnIPNet}))
+
+		// FQDNs no longer in use are forgotten.
+		reconciler.fqdnResolver.refresh(nil, time.Now())
+		Expect(reconciler.fqdnResolver.entries).To(BeEmpty())
+	})
+
+	It("Verify IPv6 IPBlocks are preserved in cloud rules", func() {
+		_, ingressIPv6Block, _ := net.ParseCIDR("2001:db8:1::/64")
+		_, egressIPv6Block, _ := net.ParseCIDR("2001:db8:2::/48")
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn}
+		inRule.From.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ingressIPv6Block.IP), PrefixLength: 64}}}
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut}
+		eRule.To.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(egressIPv6Block.IP), PrefixLength: 48}}}
+
+		npRule := &networkPolicyRule{rule: &inRule}
+		iRules, _, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(iRules).To(HaveLen(1))
+		Expect(iRules[0].FromSrcIP).To(Equal([]*net.IPNet{ingressIPv6Block}))
+
+		npRule = &networkPolicyRule{rule: &eRule}
+		_, eRules, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(eRules).To(HaveLen(1))
+		Expect(eRules[0].ToDstIP).To(Equal([]*net.IPNet{egressIPv6Block}))
+	})
+
+	It("Verify IPBlock except is realized as CIDR complement", func() {
+		_, ipBlock, _ := net.ParseCIDR("10.0.0.0/8")
+		_, exceptBlock, _ := net.ParseCIDR("10.1.0.0/16")
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn}
+		inRule.From.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR:   antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipBlock.IP), PrefixLength: 8},
+			Except: []antreanetworking.IPNet{{IP: antreanetworking.IPAddress(exceptBlock.IP), PrefixLength: 16}}}}
+
+		npRule := &networkPolicyRule{rule: &inRule}
+		iRules, _, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		var cidrs []string
+		for _, iRule := range iRules {
+			Expect(iRule.FromSrcIP).To(HaveLen(1))
+			cidrs = append(cidrs, iRule.FromSrcIP[0].String())
+		}
+		Expect(cidrs).To(Equal([]string{"10.128.0.0/9", "10.64.0.0/10", "10.32.0.0/11", "10.16.0.0/12",
+			"10.8.0.0/13", "10.4.0.0/14", "10.2.0.0/15", "10.0.0.0/16"}))
+
+		_, exceptBlock, _ = net.ParseCIDR("10.0.0.0/16")
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut}
+		eRule.To.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR:   antreanetworking.IPNet{IP: antreanetworking.IPAddress(exceptBlock.IP), PrefixLength: 24},
+			Except: []antreanetworking.IPNet{{IP: antreanetworking.IPAddress(exceptBlock.IP), PrefixLength: 16}}}}
+		npRule = &networkPolicyRule{rule: &eRule}
+		_, eRules, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(eRules).To(BeEmpty())
+	})
+
+	It("Verify port ranges are preserved in cloud rules", func() {
+		_, ipBlock, _ := net.ParseCIDR("5.5.5.0/24")
+		protocol := antreanetworking.ProtocolTCP
+		endPort := int32(32767)
+		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn}
+		inRule.From.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipBlock.IP), PrefixLength: 24}}}
+		inRule.Services = []antreanetworking.Service{
+			{Port: &intstr.IntOrString{IntVal: 30000}, EndPort: &endPort, Protocol: &protocol},
+			{Port: &intstr.IntOrString{IntVal: 443}, Protocol: &protocol},
+		}
+
+		npRule := &networkPolicyRule{rule: &inRule}
+		iRules, _, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(iRules).To(HaveLen(2))
+		Expect(*iRules[0].FromPort).To(Equal(30000))
+		Expect(*iRules[0].FromEndPort).To(Equal(32767))
+		Expect(*iRules[1].FromPort).To(Equal(443))
+		Expect(iRules[1].FromEndPort).To(BeNil())
+
+		// Hash of a port range rule differs from that of its start port.
+		rangeRule := &securitygroup.CloudRule{Rule: iRules[0]}
+		singleRule := &securitygroup.CloudRule{Rule: deepcopy.Copy(iRules[0]).(*securitygroup.IngressRule)}
+		singleRule.Rule.(*securitygroup.IngressRule).FromEndPort = nil
+		Expect(rangeRule.GetHash()).ToNot(Equal(singleRule.GetHash()))
+	})
+
+	It("Verify ICMP type and code are preserved in cloud rules", func() {
+		_, ipBlock, _ := net.ParseCIDR("5.5.5.0/24")
+		protocol := antreanetworking.ProtocolICMP
+		icmpType := int32(8)
+		eRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionOut}
+		eRule.To.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipBlock.IP), PrefixLength: 24}}}
+		eRule.Services = []antreanetworking.Service{{Protocol: &protocol, ICMPType: &icmpType}}
+		anpTemp := anp.DeepCopy()
+		anpTemp.Rules = append(anpTemp.Rules, eRule)
+		Expect(reconciler.isNetworkPolicySupported(anpTemp)).ToNot(HaveOccurred())
+
+		npRule := &networkPolicyRule{rule: &eRule}
+		_, eRules, ready := npRule.rules(reconciler)
+		Expect(ready).To(BeTrue())
+		Expect(eRules).To(HaveLen(1))
+		Expect(*eRules[0].Protocol).To(Equal(1))
+		Expect(*eRules[0].ICMPType).To(Equal(8))
+		Expect(eRules[0].ICMPCode).To(BeNil())
+		Expect(eRules[0].ToPort).To(BeNil())
+	})
+
+	It("Verify cloud rules are compacted", func() {
+		var ipNets []*net.IPNet
+		for _, cidr := range []string{"10.0.1.0/24", "10.0.0.0/24", "10.0.2.0/23", "10.0.2.128/25", "2001:db8::/33",
+			"2001:db8:8000::/33", "10.1.0.0/24"} {
+			_, ipNet, _ := net.ParseCIDR(cidr)
+			ipNets = append(ipNets, ipNet)
+		}
+		var cidrs []string
+		for _, ipNet := range compactIPNets(ipNets) {
+			cidrs = append(cidrs, ipNet.String())
+		}
+		Expect(cidrs).To(Equal([]string{"10.0.0.0/22", "10.1.0.0/24", "2001:db8::/32"}))
+
+		protocol := 6
+		newRule := func(np string, port, endPort *int, cidrs ...string) *securitygroup.CloudRule {
+			rule := &securitygroup.IngressRule{FromPort: port, FromEndPort: endPort, Protocol: &protocol}
+			for _, cidr := range cidrs {
+				_, ipNet, _ := net.ParseCIDR(cidr)
+				rule.FromSrcIP = append(rule.FromSrcIP, ipNet)
+			}
+			cloudRule := &securitygroup.CloudRule{Rule: rule, NetworkPolicy: np}
+			cloudRule.Hash = cloudRule.GetHash()
+			return cloudRule
+		}
+		port80, port81, port82, port90 := 80, 81, 82, 90
+		rules := compactCloudRules([]*securitygroup.CloudRule{
+			newRule("ns/np1", &port80, nil, "10.0.0.0/24"),
+			newRule("ns/np1", &port81, &port82, "10.0.0.0/24"),
+			newRule("ns/np1", &port90, nil, "10.0.1.0/24"),
+			newRule("ns/np1", &port90, nil, "10.0.0.0/24"),
+			newRule("ns/np2", &port80, nil, "10.0.1.0/24"),
+			newRule("ns/np1", nil, nil, "10.0.2.0/24"),
+		})
+		Expect(rules).To(HaveLen(4))
+		Expect(rules[0]).To(Equal(newRule("ns/np1", nil, nil, "10.0.2.0/24")))
+		Expect(rules[1]).To(Equal(newRule("ns/np1", &port80, &port82, "10.0.0.0/24")))
+		Expect(rules[2]).To(Equal(newRule("ns/np1", &port90, nil, "10.0.0.0/23")))
+		Expect(rules[3]).To(Equal(newRule("ns/np2", &port80, nil, "10.0.1.0/24")))
+	})
+
+	It("Verify cloud rules exceeding security group limits are sharded", func() {
+		limits := &securitygroup.SecurityGroupLimits{MaxRules: 4, RulePerPeer: true, ExcludeDenyRules: true}
+		newRule := func(ingress bool, cidrs ...string) *securitygroup.CloudRule {
+			var ipNets []*net.IPNet
+			for _, cidr := range cidrs {
+				_, ipNet, _ := net.ParseCIDR(cidr)
+				ipNets = append(ipNets, ipNet)
+			}
+			cloudRule := &securitygroup.CloudRule{Rule: &securitygroup.EgressRule{ToDstIP: ipNets}}
+			if ingress {
+				cloudRule.Rule = &securitygroup.IngressRule{FromSrcIP: ipNets}
+			}
+			cloudRule.Hash = cloudRule.GetHash()
+			return cloudRule
+		}
+
+		// rules with more peers than the limit are split.
+		rules := splitCloudRules([]*securitygroup.CloudRule{
+			newRule(true, "1.1.1.0/24", "2.2.2.0/24", "3.3.3.0/24", "4.4.4.0/24", "5.5.5.0/24"),
+		}, limits)
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[0].Rule.(*securitygroup.IngressRule).FromSrcIP).To(HaveLen(4))
+		Expect(rules[1].Rule.(*securitygroup.IngressRule).FromSrcIP).To(HaveLen(1))
+
+		// realized rules stay in their shards, and new rules are assigned to shards with capacity.
+		rules = append(rules, newRule(true, "6.6.6.0/24", "7.7.7.0/24"), newRule(false, "8.8.8.0/24"))
+		realized := map[string]int{rules[0].Hash: 1}
+		shards, err := assignRuleShards(rules, realized, limits, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(shards).To(Equal(2))
+		Expect(rules[0].Shard).To(Equal(1))
+		Expect(rules[1].Shard).To(Equal(0))
+		Expect(rules[2].Shard).To(Equal(0))
+		Expect(rules[3].Shard).To(Equal(0))
+
+		// rules exceeding the limits of all shards are rejected.
+		rules = append(rules, newRule(true, "9.9.9.0/24", "10.10.10.0/24"))
+		_, err = assignRuleShards(rules, realized, limits, 2)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Verify rule priorities are realized according to cloud capabilities", func() {
+		atID := &securitygroup.CloudResource{CloudResourceID: securitygroup.CloudResourceID{Name: "at", Vpc: vpc}}
+		a := newAppliedToSecurityGroup(atID, []*securitygroup.CloudResource{}, nil).(*appliedToSecurityGroup)
+		_, ipNet, _ := net.ParseCIDR("6.6.6.0/24")
+		newNP := func(name string, action securitygroup.RuleAction, priority *securitygroup.RulePriority) *networkPolicy {
+			np := &networkPolicy{rulesReady: true}
+			np.Name, np.Namespace = name, "default"
+			np.ingressRules = []*securitygroup.IngressRule{{FromSrcIP: []*net.IPNet{ipNet}, Action: action, Priority: priority}}
+			np.ingressRuleAppliedTo = [][]string{nil}
+			return np
+		}
+		allowNP := newNP("allow", "", &securitygroup.RulePriority{Tier: 250, Policy: 1})
+		denyNP := newNP("deny", securitygroup.RuleActionDeny, &securitygroup.RulePriority{Tier: 250, Policy: 2})
+		nps := []interface{}{allowNP, denyNP}
+
+		// rule priorities are not realized if deny rules take precedence over all allow rules.
+		a.capabilities = &crdv1alpha1.CloudCapabilities{SecurityGroups: true, DenyRules: true}
+		Expect(a.checkRuleOrdering(nps)).To(MatchError(ContainSubstring("deny rules take precedence")))
+		rules := a.combineRules(nps, reconciler)
+		Expect(rules).To(HaveLen(2))
+		for _, rule := range rules {
+			Expect(rule.GetPriority()).To(BeNil())
+		}
+		denyNP.ingressRules[0].Priority = &securitygroup.RulePriority{Tier: 100, Policy: 2}
+		Expect(a.checkRuleOrdering(nps)).ToNot(HaveOccurred())
+
+		// rule priorities are realized if the cloud orders rules by priority.
+		denyNP.ingressRules[0].Priority = &securitygroup.RulePriority{Tier: 250, Policy: 2}
+		a.capabilities.RuleOrdering = true
+		Expect(a.checkRuleOrdering(nps)).ToNot(HaveOccurred())
+		rules = a.combineRules(nps, reconciler)
+		Expect(rules).To(HaveLen(2))
+		for _, rule := range rules {
+			Expect(rule.GetPriority()).ToNot(BeNil())
+		}
+	})
+
 	It("Verify unsupported networkPolicy protocol", func() {
 		anpTemp := anp
 		inRule := antreanetworking.NetworkPolicyRule{Direction: antreanetworking.DirectionIn}
-		protocol := antreanetworking.ProtocolICMP
+		protocol := antreanetworking.Protocol("GRE")
 		inRule.Services = []antreanetworking.Service{
 			{Protocol: &protocol},
 		}
@@ -1090,6 +1602,141 @@ var _ = Describe("NetworkPolicy", func() {
 		verifyVmp(0)
 	})
 
+	It("Tracking cluster networkPolicy", func() {
+		anp.Namespace = ""
+		anp.SourceRef = &antreanetworking.NetworkPolicyReference{
+			Type: antreanetworking.AntreaClusterNetworkPolicy,
+			Name: anp.Name,
+		}
+		trackedVMs := make(map[string]*runtimev1alpha1.VirtualMachine)
+		createAndVerifyNP(false)
+		Expect(reconciler.networkPolicyIndexer.ListKeys()).To(ConsistOf("AntreaClusterNetworkPolicy//" + anp.Name))
+		verifyNPTracker(trackedVMs, true, false)
+		verifyNPStatus(trackedVMs, true, false)
+		verifyVmp(len(trackedVMs))
+	})
+
+	It("Verify network policies are checked against cloud capabilities", func() {
+		capabilities := &crdv1alpha1.CloudCapabilities{SecurityGroups: true}
+		Expect(checkRuleCapabilities(anp.Rules, "GCP", capabilities)).ToNot(HaveOccurred())
+
+		dropAction := v1alpha1.RuleActionDrop
+		rules := deepcopy.Copy(anp.Rules).([]antreanetworking.NetworkPolicyRule)
+		rules[0].Action = &dropAction
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).To(MatchError(ContainSubstring("Drop action")))
+		capabilities.DenyRules = true
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).ToNot(HaveOccurred())
+
+		rules = deepcopy.Copy(anp.Rules).([]antreanetworking.NetworkPolicyRule)
+		_, ipv6Block, _ := net.ParseCIDR("2001:db8::/64")
+		rules[1].To.IPBlocks = []antreanetworking.IPBlock{{
+			CIDR: antreanetworking.IPNet{IP: antreanetworking.IPAddress(ipv6Block.IP), PrefixLength: 64},
+		}}
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).To(MatchError(ContainSubstring("IPv6 address 2001:db8::/64")))
+		capabilities.IPv6 = true
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).ToNot(HaveOccurred())
+
+		rules = deepcopy.Copy(anp.Rules).([]antreanetworking.NetworkPolicyRule)
+		endPort := int32(500)
+		rules[0].Services[0].EndPort = &endPort
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).To(MatchError(ContainSubstring("port range 443-500")))
+		capabilities.PortRanges = true
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).ToNot(HaveOccurred())
+
+		rules = deepcopy.Copy(anp.Rules).([]antreanetworking.NetworkPolicyRule)
+		icmp := antreanetworking.ProtocolICMP
+		icmpType := int32(8)
+		rules[0].Services = []antreanetworking.Service{{Protocol: &icmp, ICMPType: &icmpType}}
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).To(MatchError(ContainSubstring("ICMP type and code")))
+		capabilities.ICMPTypeCode = true
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).ToNot(HaveOccurred())
+
+		rules = deepcopy.Copy(anp.Rules).([]antreanetworking.NetworkPolicyRule)
+		sctp := antreanetworking.ProtocolSCTP
+		rules[1].Services[0].Protocol = &sctp
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).To(MatchError(ContainSubstring("protocol SCTP")))
+		capabilities.SCTP = true
+		Expect(checkRuleCapabilities(rules, "GCP", capabilities)).ToNot(HaveOccurred())
+
+		// allow rules cannot precede deny rules of the same direction without rule ordering.
+		rules = deepcopy.Copy(anp.Rules).([]antreanetworking.NetworkPolicyRule)
+		denyRule := *rules[0].DeepCopy()
+		denyRule.Action = &dropAction
+		denyRule.Priority = rules[0].Priority + 1
+		rules = append(rules, denyRule)
+		Expect(checkRuleCapabilities(rules, "AWS", capabilities)).To(MatchError(ContainSubstring("deny rules take precedence")))
+		capabilities.RuleOrdering = true
+		Expect(checkRuleCapabilities(rules, "Azure", capabilities)).ToNot(HaveOccurred())
+		capabilities.RuleOrdering = false
+		rules[0].Priority, rules[2].Priority = rules[2].Priority, rules[0].Priority
+		Expect(checkRuleCapabilities(rules, "AWS", capabilities)).ToNot(HaveOccurred())
+	})
+
+	It("Verify rules are checked against cloud capabilities of appliedTo security group", func() {
+		atID := &securitygroup.CloudResource{CloudResourceID: securitygroup.CloudResourceID{Name: "at", Vpc: vpc}, CloudProvider: "GCP"}
+		a := newAppliedToSecurityGroup(atID, []*securitygroup.CloudResource{}, nil).(*appliedToSecurityGroup)
+		np := &networkPolicy{rulesReady: true}
+		anp.DeepCopyInto(&np.NetworkPolicy)
+		dropAction := v1alpha1.RuleActionDrop
+		np.Rules[0].Action = &dropAction
+		nps := []interface{}{np}
+
+		// rules are not checked until the cloud is known to support security groups.
+		a.capabilities = &crdv1alpha1.CloudCapabilities{}
+		Expect(a.checkCapabilities(nps)).ToNot(HaveOccurred())
+		a.capabilities.SecurityGroups = true
+		Expect(a.checkCapabilities(nps)).To(MatchError(ContainSubstring(np.getNamespacedName())))
+		Expect(a.checkCapabilities(nps)).To(MatchError(ContainSubstring("Drop action is not supported by cloud provider GCP")))
+		np.rulesReady = false
+		Expect(a.checkCapabilities(nps)).ToNot(HaveOccurred())
+		np.rulesReady = true
+		a.capabilities.DenyRules = true
+		Expect(a.checkCapabilities(nps)).ToNot(HaveOccurred())
+	})
+
+	It("Verify network policy sources and cloud rule descriptions", func() {
+		for _, t := range []antreanetworking.NetworkPolicyType{antreanetworking.AntreaNetworkPolicy,
+			antreanetworking.AntreaClusterNetworkPolicy, antreanetworking.K8sNetworkPolicy} {
+			anpTemp := anp.DeepCopy()
+			anpTemp.SourceRef.Type = t
+			Expect(reconciler.isNetworkPolicySupported(anpTemp)).ToNot(HaveOccurred())
+		}
+		anpTemp := anp.DeepCopy()
+		anpTemp.SourceRef.Type = "Unknown"
+		Expect(reconciler.isNetworkPolicySupported(anpTemp)).To(HaveOccurred())
+
+		// Antrea NetworkPolicy and K8s NetworkPolicy with the same name are different network policies.
+		np := &networkPolicy{}
+		anp.DeepCopyInto(&np.NetworkPolicy)
+		k8sNp := &networkPolicy{}
+		anp.DeepCopyInto(&k8sNp.NetworkPolicy)
+		k8sNp.SourceRef.Type = antreanetworking.K8sNetworkPolicy
+		Expect(reconciler.networkPolicyIndexer.Add(np)).ToNot(HaveOccurred())
+		Expect(reconciler.networkPolicyIndexer.Add(k8sNp)).ToNot(HaveOccurred())
+		Expect(reconciler.networkPolicyIndexer.ListKeys()).To(HaveLen(2))
+		Expect(np.getStatusName()).To(Equal(anp.Name))
+		Expect(k8sNp.getStatusName()).To(Equal("K8sNetworkPolicy:" + anp.Name))
+
+		acnp := &networkPolicy{}
+		anp.DeepCopyInto(&acnp.NetworkPolicy)
+		acnp.Namespace = ""
+		acnp.SourceRef.Type = antreanetworking.AntreaClusterNetworkPolicy
+		for _, n := range []*networkPolicy{np, k8sNp, acnp} {
+			description, err := securitygroup.GenerateCloudDescription(n.getNamespacedName(), "nephe-at-test")
+			Expect(err).ToNot(HaveOccurred())
+			desc, ok := securitygroup.ExtractCloudDescription(&description)
+			Expect(ok).To(BeTrue())
+			Expect(desc.Name).To(Equal(n.Name))
+			Expect(desc.Namespace).To(Equal(n.Namespace))
+			Expect(desc.AppliedToGroup).To(Equal("nephe-at-test"))
+		}
+		description, _ := securitygroup.GenerateCloudDescription(acnp.getNamespacedName(), "nephe-at-test")
+		desc, _ := securitygroup.ExtractCloudDescription(&description)
+		Expect(desc.Kind).To(Equal(string(antreanetworking.AntreaClusterNetworkPolicy)))
+		_, err := securitygroup.GenerateCloudDescription("/"+anp.Name, "nephe-at-test")
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("Create NetworkPolicy groups after security group garbage collection", func() {
 		createAndVerifyNP(false)
 		sgConfig.sgDeletePending = true
@@ -21,7 +21,8 @@ import (
 	runtimev1alpha1 "antrea.io/nephe/apis/runtime/v1alpha1"
 )
 
-var ErrorMsgUnknownCloudProvider = "missing cloud provider config. Please add AWS or Azure Config"
+var ErrorMsgUnknownCloudProvider = "missing cloud provider config. " +
+	"Please add AWS, Azure, GCP, OpenStack, Simulated, Static or VSphere Config"
 
 // GetVMIPAddresses returns IP addresses of all network interfaces attached to the vm.
 func GetVMIPAddresses(vm *runtimev1alpha1.VirtualMachine) []runtimev1alpha1.IPAddress {
@@ -42,6 +43,18 @@ func GetAccountProviderType(account *crdv1alpha1.CloudProviderAccount) (runtimev
 		return runtimev1alpha1.AWSCloudProvider, nil
 	} else if account.Spec.AzureConfig != nil {
 		return runtimev1alpha1.AzureCloudProvider, nil
+	} else if account.Spec.GCPConfig != nil {
+		return runtimev1alpha1.GCPCloudProvider, nil
+	} else if account.Spec.OpenStackConfig != nil {
+		return runtimev1alpha1.OpenStackCloudProvider, nil
+	} else if account.Spec.SimulatedConfig != nil {
+		return runtimev1alpha1.SimulatedCloudProvider, nil
+	} else if account.Spec.StaticConfig != nil {
+		return runtimev1alpha1.StaticCloudProvider, nil
+	} else if account.Spec.VSphereConfig != nil {
+		return runtimev1alpha1.VSphereCloudProvider, nil
+	} else if account.Spec.PluginConfig != nil {
+		return runtimev1alpha1.CloudProvider(account.Spec.PluginConfig.Provider), nil
 	} else {
 		return "", fmt.Errorf("%s", ErrorMsgUnknownCloudProvider)
 	}
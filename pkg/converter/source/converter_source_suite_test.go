@@ -38,7 +38,7 @@ var (
 	mockCtrl                         *mock.Controller
 	mockClient                       *controllerruntimeclient.MockClient
 	scheme                           = runtime.NewScheme()
-	networkInterfaceIPAddresses      = []string{"1.1.1.1", "2.2.2.2"}
+	networkInterfaceIPAddresses      = []string{"1.1.1.1", "2.2.2.2", "2001:db8::1"}
 	networkInterfaceIPAddressesPatch = []string{"3.3.3.3"}
 	// Used by ExternalNode.
 	networkInterfaceNames      = []string{"nic0"}
@@ -15,27 +15,47 @@
 package source
 
 import (
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	antreatypes "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	"antrea.io/nephe/apis/runtime/v1alpha1"
+	nepheconfig "antrea.io/nephe/pkg/config"
 	"antrea.io/nephe/pkg/controllers/config"
 	"antrea.io/nephe/pkg/controllers/utils"
 	"antrea.io/nephe/pkg/converter/target"
 )
 
+// namedPortTagPrefix is the prefix of VM tags advertising named ports.
+var namedPortTagPrefix = nepheconfig.DefaultNamedPortTagPrefix
+
+// SetNamedPortTagPrefix sets the prefix of VM tags advertising named ports.
+func SetNamedPortTagPrefix(prefix string) {
+	namedPortTagPrefix = prefix
+}
+
 // VirtualMachineSource says VirtualMachine is a source of converter targets.
 type VirtualMachineSource struct {
 	v1alpha1.VirtualMachine
 	watch.EventType
 }
 
-// GetEndPointAddresses returns VirtualMachine's IP addresses.
+// GetEndPointAddresses returns VirtualMachine's IPv4 and IPv6 addresses.
+// Valid addresses are returned in canonical form, so that IPv6 addresses reported in different
+// notations by a cloud do not result in ExternalEntity updates.
 func (v *VirtualMachineSource) GetEndPointAddresses() ([]string, error) {
 	ipAddrs := utils.GetVMIPAddresses(&v.VirtualMachine)
 	ip := make([]string, 0, len(ipAddrs))
 	for _, ipAddr := range ipAddrs {
+		if parsedIP := net.ParseIP(ipAddr.Address); parsedIP != nil {
+			ip = append(ip, parsedIP.String())
+			continue
+		}
 		ip = append(ip, ipAddr.Address)
 	}
 	return ip, nil
@@ -46,9 +66,46 @@ func (v *VirtualMachineSource) GetNetworkInterfaces() ([]v1alpha1.NetworkInterfa
 	return v.Status.NetworkInterfaces, nil
 }
 
-// GetEndPointPort returns nil as VirtualMachine has no associated port.
+// GetEndPointPort returns named ports advertised by VirtualMachine tags. A tag <prefix><name>=<protocol>:<port>,
+// e.g. nephe.port/http=tcp:8080, advertises a named port, protocol is optional and defaults to TCP. '-' is also
+// accepted as separator for clouds not allowing ':' in tag values. Invalid tags are ignored.
 func (v *VirtualMachineSource) GetEndPointPort(_ client.Client) []antreatypes.NamedPort {
-	return nil
+	var ports []antreatypes.NamedPort
+	for key, value := range v.Status.Tags {
+		name := strings.TrimPrefix(key, namedPortTagPrefix)
+		if len(name) == len(key) || len(name) == 0 {
+			continue
+		}
+		if port, ok := parseNamedPort(name, value); ok {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// parseNamedPort parses a named port from value of a named port tag.
+func parseNamedPort(name, value string) (antreatypes.NamedPort, bool) {
+	protocol := corev1.ProtocolTCP
+	tokens := strings.FieldsFunc(value, func(c rune) bool {
+		return c == ':' || c == '-'
+	})
+	if len(tokens) == 2 {
+		switch p := corev1.Protocol(strings.ToUpper(tokens[0])); p {
+		case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+			protocol = p
+		default:
+			return antreatypes.NamedPort{}, false
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) != 1 {
+		return antreatypes.NamedPort{}, false
+	}
+	port, err := strconv.ParseInt(tokens[0], 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		return antreatypes.NamedPort{}, false
+	}
+	return antreatypes.NamedPort{Name: name, Protocol: protocol, Port: int32(port)}, true
 }
 
 // GetTags returns tags of VirtualMachine.
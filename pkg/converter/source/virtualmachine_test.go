@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -680,3 +681,25 @@ var _ = Describe("VirtualMachineConverter", func() {
 		})
 	})
 })
+
+var _ = Describe("VirtualMachineSource", func() {
+	table.DescribeTable("GetEndPointPort",
+		func(tags map[string]string, expected []antreav1alpha2.NamedPort) {
+			vm := &source.VirtualMachineSource{}
+			vm.Status.Tags = tags
+			Expect(vm.GetEndPointPort(nil)).To(ConsistOf(expected))
+		},
+		table.Entry("Named port with protocol",
+			map[string]string{"nephe.port/http": "tcp:8080", "Name": "vm"},
+			[]antreav1alpha2.NamedPort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080}}),
+		table.Entry("Named port with '-' separator",
+			map[string]string{"nephe.port/dns": "udp-53"},
+			[]antreav1alpha2.NamedPort{{Name: "dns", Protocol: corev1.ProtocolUDP, Port: 53}}),
+		table.Entry("Named port without protocol",
+			map[string]string{"nephe.port/https": "8443"},
+			[]antreav1alpha2.NamedPort{{Name: "https", Protocol: corev1.ProtocolTCP, Port: 8443}}),
+		table.Entry("Invalid named ports",
+			map[string]string{"nephe.port/ping": "icmp:1", "nephe.port/big": "70000", "nephe.port/": "80"},
+			[]antreav1alpha2.NamedPort{}),
+	)
+})
@@ -43,7 +43,7 @@ var _ = Describe("Externalentity", func() {
 		namespace = "test-externalentity-sources-namespace"
 
 		// Test tunable
-		networkInterfaceIPAddresses = []string{"1.1.1.1", "2.2.2.2"}
+		networkInterfaceIPAddresses = []string{"1.1.1.1", "2.2.2.2", "2001:db8::1"}
 		namedports                  = []antreav1alpha2.NamedPort{
 			{Name: "http", Protocol: v1.ProtocolTCP, Port: 80},
 			{Name: "https", Protocol: v1.ProtocolTCP, Port: 443},
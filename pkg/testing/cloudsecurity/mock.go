@@ -22,6 +22,7 @@ package cloudsecurity
 import (
 	reflect "reflect"
 
+	v1alpha1 "antrea.io/nephe/apis/crd/v1alpha1"
 	securitygroup "antrea.io/nephe/pkg/cloud-provider/securitygroup"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -77,6 +78,34 @@ func (mr *MockCloudSecurityGroupAPIMockRecorder) DeleteSecurityGroup(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockCloudSecurityGroupAPI)(nil).DeleteSecurityGroup), arg0, arg1)
 }
 
+// GetCapabilities mocks base method.
+func (m *MockCloudSecurityGroupAPI) GetCapabilities(arg0 *securitygroup.CloudResource) v1alpha1.CloudCapabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapabilities", arg0)
+	ret0, _ := ret[0].(v1alpha1.CloudCapabilities)
+	return ret0
+}
+
+// GetCapabilities indicates an expected call of GetCapabilities.
+func (mr *MockCloudSecurityGroupAPIMockRecorder) GetCapabilities(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapabilities", reflect.TypeOf((*MockCloudSecurityGroupAPI)(nil).GetCapabilities), arg0)
+}
+
+// GetSecurityGroupLimits mocks base method.
+func (m *MockCloudSecurityGroupAPI) GetSecurityGroupLimits(arg0 *securitygroup.CloudResource) securitygroup.SecurityGroupLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityGroupLimits", arg0)
+	ret0, _ := ret[0].(securitygroup.SecurityGroupLimits)
+	return ret0
+}
+
+// GetSecurityGroupLimits indicates an expected call of GetSecurityGroupLimits.
+func (mr *MockCloudSecurityGroupAPIMockRecorder) GetSecurityGroupLimits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupLimits", reflect.TypeOf((*MockCloudSecurityGroupAPI)(nil).GetSecurityGroupLimits), arg0)
+}
+
 // GetSecurityGroupSyncChan mocks base method.
 func (m *MockCloudSecurityGroupAPI) GetSecurityGroupSyncChan() <-chan securitygroup.SynchronizationContent {
 	m.ctrl.T.Helper()